@@ -409,6 +409,9 @@ func (r *BlockchainReactor) demux(events <-chan Event) {
 			switch event := event.(type) {
 			case pcBlockProcessed:
 				r.setSyncHeight(event.height)
+				if err := r.reporter.Report(behaviour.UsefulMessage(event.peerID, "block processed")); err != nil {
+					r.logger.Debug("Error reporting peer", "err", err)
+				}
 				if r.syncHeight%100 == 0 {
 					lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
 					r.logger.Info("Fast Sync Rate", "height", r.syncHeight,
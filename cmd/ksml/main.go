@@ -0,0 +1,242 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// ksml checks and dry-runs KSML scripts used by dual nodes watchers.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/cmd/flags"
+	dualMsg "github.com/kardiachain/go-kardia/dualnode/message"
+	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/ksml"
+	message "github.com/kardiachain/go-kardia/ksml/proto"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+
+	app *cli.App
+
+	scriptFlag = cli.StringFlag{
+		Name:  "script",
+		Usage: "Path to the KSML script, a JSON list of patterns",
+	}
+	abiFlag = cli.StringFlag{
+		Name:  "abi",
+		Usage: "Path to the ABI json of the contract smc built-ins are called on",
+	}
+	messageFlag = cli.StringFlag{
+		Name:  "message",
+		Usage: "Path to the EventMessage json the script is executed against",
+	}
+	chainDataFlag = cli.StringFlag{
+		Name:  "chaindata",
+		Usage: "Path to a snapshot of the node chaindata, the node must not be running on it",
+	}
+	contractFlag = cli.StringFlag{
+		Name:  "contract",
+		Usage: "Address of the contract smc built-ins are called on",
+	}
+	keyFlag = cli.StringFlag{
+		Name:  "key",
+		Usage: "Hex private key used to sign transactions created by smc:trigger (default = random key)",
+	}
+	proxyFlag = cli.StringFlag{
+		Name:  "proxy",
+		Usage: "Name of the proxy the script is executed by",
+		Value: "KARDIA",
+	}
+
+	checkCommand = cli.Command{
+		Name:   "check",
+		Usage:  "Statically check a KSML script",
+		Action: flags.MigrateFlags(check),
+		Flags:  []cli.Flag{scriptFlag, abiFlag},
+	}
+	runCommand = cli.Command{
+		Name:   "run",
+		Usage:  "Execute a KSML script against an event message without sending anything",
+		Action: flags.MigrateFlags(run),
+		Flags:  []cli.Flag{scriptFlag, messageFlag, chainDataFlag, contractFlag, keyFlag, proxyFlag},
+	}
+)
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "kardia KSML checker and dry-run tool")
+	app.Flags = []cli.Flag{scriptFlag, abiFlag, messageFlag, chainDataFlag, contractFlag, keyFlag, proxyFlag}
+	app.Commands = []cli.Command{checkCommand, runCommand}
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+// readScript reads a JSON list of patterns
+func readScript(c *cli.Context) []string {
+	path := c.GlobalString(scriptFlag.Name)
+	if path == "" {
+		flags.Fatalf("No script specified (--script)")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		flags.Fatalf("Failed to read script: %v", err)
+	}
+	var patterns []string
+	if err := json.Unmarshal(data, &patterns); err != nil {
+		flags.Fatalf("Failed to decode script, expect a JSON list of patterns: %v", err)
+	}
+	return patterns
+}
+
+func check(c *cli.Context) error {
+	patterns := readScript(c)
+	var contractAbi *abi.ABI
+	if path := c.GlobalString(abiFlag.Name); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			flags.Fatalf("Failed to read ABI: %v", err)
+		}
+		a, err := abi.JSON(strings.NewReader(strings.Replace(string(data), "'", "\"", -1)))
+		if err != nil {
+			flags.Fatalf("Failed to decode ABI: %v", err)
+		}
+		contractAbi = &a
+	}
+	errs := ksml.NewChecker(contractAbi).Check(patterns)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("found %v issue(s)", len(errs))
+	}
+	fmt.Println("OK")
+	return nil
+}
+
+// runResult is printed by run command
+type runResult struct {
+	Params       []string                 `json:"params"`
+	Transactions []map[string]interface{} `json:"transactions"`
+	Published    []dualMsg.TriggerMessage `json:"published"`
+}
+
+func run(c *cli.Context) error {
+	patterns := readScript(c)
+
+	if c.GlobalString(messageFlag.Name) == "" {
+		flags.Fatalf("No event message specified (--message)")
+	}
+	data, err := ioutil.ReadFile(c.GlobalString(messageFlag.Name))
+	if err != nil {
+		flags.Fatalf("Failed to read event message: %v", err)
+	}
+	var msg message.EventMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		flags.Fatalf("Failed to decode event message: %v", err)
+	}
+
+	if c.GlobalString(chainDataFlag.Name) == "" {
+		flags.Fatalf("No chaindata specified (--chaindata)")
+	}
+	if !common.IsHexAddress(c.GlobalString(contractFlag.Name)) {
+		flags.Fatalf("Invalid contract address (--contract)")
+	}
+	contractAddress := common.HexToAddress(c.GlobalString(contractFlag.Name))
+
+	db, err := storage.NewLevelDbInfo(c.GlobalString(chainDataFlag.Name), 16, 32).Start()
+	if err != nil {
+		flags.Fatalf("Failed to open chaindata: %v", err)
+	}
+	defer db.DB().Close()
+	chainConfig := db.ReadChainConfig(db.ReadCanonicalHash(0))
+	if chainConfig == nil {
+		flags.Fatalf("Chain config is not found in chaindata")
+	}
+	logger := log.New()
+	bc, err := blockchain.NewBlockChain(logger, db, chainConfig)
+	if err != nil {
+		flags.Fatalf("Failed to load blockchain: %v", err)
+	}
+	txPool := tx_pool.NewTxPool(tx_pool.DefaultTxPoolConfig, chainConfig, bc)
+	defer txPool.Stop()
+
+	key, err := crypto.GenerateKey()
+	if hexKey := c.GlobalString(keyFlag.Name); hexKey != "" {
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	}
+	if err != nil {
+		flags.Fatalf("Invalid key: %v", err)
+	}
+
+	result := runResult{
+		Params:       make([]string, 0),
+		Transactions: make([]map[string]interface{}, 0),
+		Published:    make([]dualMsg.TriggerMessage, 0),
+	}
+	publish := func(endpoint string, topic string, msg dualMsg.TriggerMessage) error {
+		result.Published = append(result.Published, msg)
+		return nil
+	}
+	parser := ksml.NewParser(c.GlobalString(proxyFlag.Name), "", publish, bc, txPool, &contractAddress, patterns, &msg, true)
+	parser.DryRun = true
+	parser.SenderKey = key
+	if err := parser.ParseParams(); err != nil {
+		return err
+	}
+
+	if result.Params, err = parser.GetParams(); err != nil {
+		return err
+	}
+	for _, tx := range parser.Transactions {
+		result.Transactions = append(result.Transactions, map[string]interface{}{
+			"hash":  tx.Hash().Hex(),
+			"from":  crypto.PubkeyToAddress(key.PublicKey).Hex(),
+			"to":    tx.To().Hex(),
+			"nonce": tx.Nonce(),
+			"gas":   tx.Gas(),
+			"input": common.Encode(tx.Data()),
+		})
+	}
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
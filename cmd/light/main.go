@@ -0,0 +1,215 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// light is a light client which serves a local RPC endpoint verifying the
+// responses of a full node.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kardiachain/go-kardia/cmd/flags"
+	"github.com/kardiachain/go-kardia/kai/kaidb/leveldb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	kmath "github.com/kardiachain/go-kardia/lib/math"
+	"github.com/kardiachain/go-kardia/light"
+	"github.com/kardiachain/go-kardia/light/provider"
+	lighthttp "github.com/kardiachain/go-kardia/light/provider/http"
+	"github.com/kardiachain/go-kardia/light/proxy"
+	dbs "github.com/kardiachain/go-kardia/light/store/db"
+	"github.com/kardiachain/go-kardia/rpc"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+
+	app *cli.App
+
+	chainIDFlag = cli.StringFlag{
+		Name:  "chain-id",
+		Usage: "Chain ID of the network",
+	}
+	primaryFlag = cli.StringFlag{
+		Name:  "primary",
+		Usage: "RPC endpoint of the full node serving the light blocks and the forwarded requests",
+	}
+	witnessesFlag = cli.StringFlag{
+		Name:  "witnesses",
+		Usage: "Comma separated RPC endpoints of the full nodes cross-checking the primary",
+	}
+	heightFlag = cli.Uint64Flag{
+		Name:  "height",
+		Usage: "Trusted block height",
+	}
+	hashFlag = cli.StringFlag{
+		Name:  "hash",
+		Usage: "Trusted block hash",
+	}
+	trustingPeriodFlag = cli.DurationFlag{
+		Name:  "trusting-period",
+		Usage: "Trusting period, should be significantly less than the unbonding period",
+		Value: 168 * time.Hour,
+	}
+	trustLevelFlag = cli.StringFlag{
+		Name:  "trust-level",
+		Usage: "Trust level for skipping verification, between 1/3 and 1",
+		Value: light.DefaultTrustLevel.String(),
+	}
+	sequentialFlag = cli.BoolFlag{
+		Name:  "sequential",
+		Usage: "Verify all the headers sequentially instead of skipping",
+	}
+	listenAddrFlag = cli.StringFlag{
+		Name:  "laddr",
+		Usage: "Listening address of the local RPC server",
+		Value: "127.0.0.1:8545",
+	}
+	dirFlag = cli.StringFlag{
+		Name:  "dir",
+		Usage: "Directory storing the trusted light blocks",
+		Value: filepath.Join(os.Getenv("HOME"), ".kardia-light"),
+	}
+	unverifiedFlag = cli.BoolFlag{
+		Name:  "unverified",
+		Usage: "Forward receipt, account and call requests to the primary without verification",
+	}
+)
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "kardia light client")
+	app.Flags = []cli.Flag{
+		chainIDFlag,
+		primaryFlag,
+		witnessesFlag,
+		heightFlag,
+		hashFlag,
+		trustingPeriodFlag,
+		trustLevelFlag,
+		sequentialFlag,
+		listenAddrFlag,
+		dirFlag,
+		unverifiedFlag,
+	}
+	app.Action = flags.MigrateFlags(runProxy)
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+func runProxy(c *cli.Context) error {
+	chainID := c.GlobalString(chainIDFlag.Name)
+	if chainID == "" {
+		flags.Fatalf("No chain ID specified (--chain-id)")
+	}
+	primaryAddr := c.GlobalString(primaryFlag.Name)
+	if primaryAddr == "" {
+		flags.Fatalf("No primary specified (--primary)")
+	}
+	logger := log.Root()
+
+	primary, err := lighthttp.New(chainID, primaryAddr)
+	if err != nil {
+		return fmt.Errorf("primary %s: %w", primaryAddr, err)
+	}
+	var witnesses []provider.Provider
+	for _, addr := range strings.Split(c.GlobalString(witnessesFlag.Name), ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		witness, err := lighthttp.New(chainID, addr)
+		if err != nil {
+			return fmt.Errorf("witness %s: %w", addr, err)
+		}
+		witnesses = append(witnesses, witness)
+	}
+
+	verification := light.SequentialVerification()
+	if !c.GlobalBool(sequentialFlag.Name) {
+		trustLevel, err := kmath.ParseFraction(c.GlobalString(trustLevelFlag.Name))
+		if err != nil {
+			flags.Fatalf("Invalid trust level: %v", err)
+		}
+		verification = light.SkippingVerification(trustLevel)
+	}
+
+	db, err := leveldb.New(filepath.Join(c.GlobalString(dirFlag.Name), "light"), 16, 16)
+	if err != nil {
+		return fmt.Errorf("open light block store: %w", err)
+	}
+	defer db.Close()
+
+	client, err := light.NewClient(
+		context.Background(),
+		chainID,
+		light.TrustOptions{
+			Period: c.GlobalDuration(trustingPeriodFlag.Name),
+			Height: c.GlobalUint64(heightFlag.Name),
+			Hash:   common.HexToHash(c.GlobalString(hashFlag.Name)),
+		},
+		primary,
+		witnesses,
+		dbs.New(db, chainID),
+		verification,
+		light.Logger(logger),
+	)
+	if err != nil {
+		return err
+	}
+
+	remote, err := rpc.Dial(primaryAddr)
+	if err != nil {
+		return fmt.Errorf("dial primary %s: %w", primaryAddr, err)
+	}
+	p, err := proxy.NewProxy(client, remote, c.GlobalBool(unverifiedFlag.Name), logger)
+	if err != nil {
+		return err
+	}
+	defer p.Stop()
+
+	server := &http.Server{Addr: c.GlobalString(listenAddrFlag.Name), Handler: p}
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		<-sigs
+		server.Close()
+	}()
+	logger.Info("Starting light client proxy", "addr", server.Addr, "primary", primaryAddr, "witnesses", len(witnesses))
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -46,6 +47,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/metrics"
 	"github.com/kardiachain/go-kardia/lib/sysutils"
 	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/mainchain/gasprice"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
 	"github.com/kardiachain/go-kardia/node"
@@ -74,7 +76,18 @@ func (c *Config) getP2PConfig() (*configs.P2PConfig, error) {
 	p2pConfig.ListenAddress = c.P2P.ListenAddress
 	p2pConfig.RootDir = c.DataDir
 	p2pConfig.AddrBook = filepath.Join(c.DataDir, "addrbook.json")
+	p2pConfig.BanList = filepath.Join(c.DataDir, "banlist.json")
+	p2pConfig.BannedPeers = strings.Join(peer.BannedPeers, ",")
 	p2pConfig.PrivateKey = privKey
+	if peer.MinTrustScore != nil {
+		p2pConfig.MinTrustScore = *peer.MinTrustScore
+	}
+	if peer.MinTrustEvents != nil {
+		p2pConfig.MinTrustEvents = *peer.MinTrustEvents
+	}
+	if peer.AutoBanThreshold != nil {
+		p2pConfig.AutoBanThreshold = *peer.AutoBanThreshold
+	}
 	return p2pConfig, nil
 }
 
@@ -110,6 +123,25 @@ func (c *Config) getTxPoolConfig() tx_pool.TxPoolConfig {
 	}
 }
 
+// getGasPriceOracleConfig gets the gas price oracle config of the mainchain, unset values keep their default
+func (c *Config) getGasPriceOracleConfig() gasprice.Config {
+	gpo := gasprice.DefaultConfig
+	oracle := c.MainChain.GasPriceOracle
+	if oracle == nil {
+		return gpo
+	}
+	if oracle.Blocks > 0 {
+		gpo.Blocks = oracle.Blocks
+	}
+	if oracle.Percentile > 0 {
+		gpo.Percentile = oracle.Percentile
+	}
+	if maxPrice, ok := new(big.Int).SetString(oracle.MaxPrice, 10); ok {
+		gpo.MaxPrice = maxPrice
+	}
+	return gpo
+}
+
 // getGenesisConfig gets node data from config
 func (c *Config) getGenesisConfig(isDual bool) (*genesis.Genesis, error) {
 	var (
@@ -137,7 +169,7 @@ func (c *Config) getGenesisConfig(isDual bool) (*genesis.Genesis, error) {
 
 		for key, contract := range g.Contracts {
 			configs.LoadGenesisContract(key, contract.Address, contract.ByteCode, contract.ABI)
-			if key != configs.StakingContractKey && contract.Address != "" {
+			if key != configs.StakingContractKey && key != configs.GovernanceContractKey && contract.Address != "" {
 				genesisContracts[contract.Address] = contract.ByteCode
 			}
 		}
@@ -173,6 +205,7 @@ func (c *Config) getMainChainConfig() (*node.MainChainConfig, error) {
 		DBInfo:      dbInfo,
 		Genesis:     genesisData,
 		TxPool:      c.getTxPoolConfig(),
+		GPO:         c.getGasPriceOracleConfig(),
 		AcceptTxs:   chain.AcceptTxs,
 		NetworkId:   chain.NetworkID,
 		ChainId:     chain.ChainID,
@@ -233,6 +266,8 @@ func (c *Config) getNodeConfig() (*node.Config, error) {
 		HTTPModules:      n.HTTPModules,
 		WSHost:           n.WSHost,
 		WSPort:           n.WSPort,
+		RPCAuth:          c.getRPCAuthConfig(),
+		RPCLimits:        c.getRPCLimitsConfig(),
 		MainChainConfig:  node.MainChainConfig{},
 		DualChainConfig:  node.DualChainConfig{},
 		Metrics:          n.Metrics,
@@ -256,6 +291,48 @@ func (c *Config) getNodeConfig() (*node.Config, error) {
 	return &nodeConfig, nil
 }
 
+// getRPCAuthConfig gets the RPC authentication config, nil if it's disabled
+func (c *Config) getRPCAuthConfig() *node.RPCAuthConfig {
+	if c.RPCAuth == nil {
+		return nil
+	}
+	auth := &node.RPCAuthConfig{
+		JWTSecretFile: c.RPCAuth.JWTSecretFile,
+		JWTNamespaces: c.RPCAuth.JWTNamespaces,
+		JWTMethods:    c.RPCAuth.JWTMethods,
+	}
+	for _, key := range c.RPCAuth.APIKeys {
+		auth.APIKeys = append(auth.APIKeys, node.RPCAPIKey{
+			Name:       key.Name,
+			Key:        key.Key,
+			Namespaces: key.Namespaces,
+			Methods:    key.Methods,
+		})
+	}
+	return auth
+}
+
+// getRPCLimitsConfig gets the RPC limits config, batch and response size
+// limits are the default ones unless set
+func (c *Config) getRPCLimitsConfig() node.RPCLimitsConfig {
+	limits := node.DefaultRPCLimits
+	if c.RPCLimits == nil {
+		return limits
+	}
+	limits.IPRate = c.RPCLimits.IPRate
+	limits.IPBurst = c.RPCLimits.IPBurst
+	limits.KeyRate = c.RPCLimits.KeyRate
+	limits.KeyBurst = c.RPCLimits.KeyBurst
+	limits.LogsBlockRange = c.RPCLimits.LogsBlockRange
+	if c.RPCLimits.BatchLimit != nil {
+		limits.BatchLimit = *c.RPCLimits.BatchLimit
+	}
+	if c.RPCLimits.ResponseSizeLimit != nil {
+		limits.ResponseSizeLimit = *c.RPCLimits.ResponseSizeLimit
+	}
+	return limits
+}
+
 func (c *Config) getFastSyncConfig() *configs.FastSyncConfig {
 	if c.FastSync == nil {
 		return configs.DefaultFastSyncConfig()
@@ -458,6 +535,7 @@ func (c *Config) StartDual(n *node.Node) error {
 		// submit tx to either internal or external blockchain.
 		bcManager := blockchain.NewDualBlockChainManager(kardiaProxy, dualProxy)
 		dualService.SetDualBlockChainManager(bcManager)
+		dualService.SetKardiaDB(kardiaService.DB())
 
 		// Register the 'other' blockchain to each internal/external blockchain. This is needed
 		// for generate Tx to submit to the other blockchain.
@@ -570,5 +648,12 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	if flag.Arg(0) == "rollback" {
+		if err := config.Rollback(flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, "rollback failed:", err)
+			os.Exit(1)
+		}
+		return
+	}
 	config.Start()
 }
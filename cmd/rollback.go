@@ -0,0 +1,71 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+)
+
+// Rollback runs the rollback command, reverting the chain data of the stopped
+// node to the height given in args.
+//
+//	kardia --network devnet rollback --height N [--force]
+func (c *Config) Rollback(args []string) error {
+	var (
+		fs     = flag.NewFlagSet("rollback", flag.ExitOnError)
+		height = fs.Uint64("height", 0, "Height to roll the node back to, the blocks above it are executed again on the next start")
+		force  = fs.Bool("force", false, "Roll back even if the node validator signed blocks above the height")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *height == 0 {
+		return errors.New("rollback height is required (--height)")
+	}
+
+	logger := c.newLog()
+	nodeConfig, err := c.getNodeConfig()
+	if err != nil {
+		return err
+	}
+	if nodeConfig.DataDir == "" {
+		return errors.New("no data directory to roll back")
+	}
+	genesisCfg, err := c.getGenesisConfig(false)
+	if err != nil {
+		return err
+	}
+
+	// the database can't be opened while the node is running
+	database := c.MainChain.Database
+	db, err := storage.NewLevelDBDatabase(nodeConfig.ResolvePath("chaindata"), database.Caches, database.Handles, "chaindata")
+	if err != nil {
+		return err
+	}
+	defer db.DB().Close()
+
+	validator := crypto.PubkeyToAddress(nodeConfig.NodeKey().PublicKey)
+	_, err = kai.Rollback(logger, db, genesisCfg, *height, validator, *force)
+	return err
+}
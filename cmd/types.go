@@ -32,22 +32,49 @@ type (
 	}
 	Node struct {
 		P2P struct {
-			ListenAddress string `yaml:"ListenAddress"`
-			PrivateKey    string `yaml:"PrivateKey"`
+			ListenAddress    string   `yaml:"ListenAddress"`
+			PrivateKey       string   `yaml:"PrivateKey"`
+			MinTrustScore    *int     `yaml:"MinTrustScore"`
+			MinTrustEvents   *int     `yaml:"MinTrustEvents"`
+			BannedPeers      []string `yaml:"BannedPeers"`
+			AutoBanThreshold *int     `yaml:"AutoBanThreshold"`
 		} `yaml:"P2P"`
-		LogLevel         string    `yaml:"LogLevel"`
-		Name             string    `yaml:"Name"`
-		DataDir          string    `yaml:"DataDir"`
-		HTTPHost         string    `yaml:"HTTPHost"`
-		HTTPPort         int       `yaml:"HTTPPort"`
-		HTTPModules      []string  `yaml:"HTTPModules"`
-		HTTPVirtualHosts []string  `yaml:"HTTPVirtualHosts"`
-		HTTPCors         []string  `yaml:"HTTPCors"`
-		WSHost           string    `yaml:"WSHost"`
-		WSPort           int       `yaml:"WSPort"`
-		Metrics          bool      `yaml:"Metrics"`
-		FastSync         *FastSync `yaml:"FastSync"`
-		Genesis          *Genesis  `yaml:"Genesis,omitempty"`
+		LogLevel         string     `yaml:"LogLevel"`
+		Name             string     `yaml:"Name"`
+		DataDir          string     `yaml:"DataDir"`
+		HTTPHost         string     `yaml:"HTTPHost"`
+		HTTPPort         int        `yaml:"HTTPPort"`
+		HTTPModules      []string   `yaml:"HTTPModules"`
+		HTTPVirtualHosts []string   `yaml:"HTTPVirtualHosts"`
+		HTTPCors         []string   `yaml:"HTTPCors"`
+		WSHost           string     `yaml:"WSHost"`
+		WSPort           int        `yaml:"WSPort"`
+		RPCAuth          *RPCAuth   `yaml:"RPCAuth,omitempty"`
+		RPCLimits        *RPCLimits `yaml:"RPCLimits,omitempty"`
+		Metrics          bool       `yaml:"Metrics"`
+		FastSync         *FastSync  `yaml:"FastSync"`
+		Genesis          *Genesis   `yaml:"Genesis,omitempty"`
+	}
+	RPCAuth struct {
+		JWTSecretFile string      `yaml:"JWTSecretFile"`
+		JWTNamespaces []string    `yaml:"JWTNamespaces"`
+		JWTMethods    []string    `yaml:"JWTMethods"`
+		APIKeys       []RPCAPIKey `yaml:"APIKeys"`
+	}
+	RPCAPIKey struct {
+		Name       string   `yaml:"Name"`
+		Key        string   `yaml:"Key"`
+		Namespaces []string `yaml:"Namespaces"`
+		Methods    []string `yaml:"Methods"`
+	}
+	RPCLimits struct {
+		IPRate            float64 `yaml:"IPRate"`
+		IPBurst           int     `yaml:"IPBurst"`
+		KeyRate           float64 `yaml:"KeyRate"`
+		KeyBurst          int     `yaml:"KeyBurst"`
+		BatchLimit        *int    `yaml:"BatchLimit"`
+		ResponseSizeLimit *int    `yaml:"ResponseSizeLimit"`
+		LogsBlockRange    uint64  `yaml:"LogsBlockRange"`
 	}
 	FastSync struct {
 		ServiceName   string `yaml:"ServiceName"`
@@ -58,20 +85,21 @@ type (
 		MinRecvRate   int64  `yaml:"MinRecvRate"`
 	}
 	Chain struct {
-		ServiceName        string     `yaml:"ServiceName"`
-		Protocol           *string    `yaml:"Protocol,omitempty"`
-		ChainID            uint64     `yaml:"ChainID"`
-		NetworkID          uint64     `yaml:"NetworkID"`
-		AcceptTxs          uint32     `yaml:"AcceptTxs"`
-		IsDual             uint       `yaml:"IsDual"`
-		Genesis            *Genesis   `yaml:"Genesis,omitempty"`
-		EventPool          *Pool      `yaml:"EventPool,omitempty"`
-		Database           *Database  `yaml:"Database,omitempty"`
-		Seeds              []string   `yaml:"Seeds"`
-		Events             []Event    `yaml:"Events"`
-		PublishedEndpoint  *string    `yaml:"PublishedEndpoint,omitempty"`
-		SubscribedEndpoint *string    `yaml:"SubscribedEndpoint,omitempty"`
-		Consensus          *Consensus `yaml:"Consensus"`
+		ServiceName        string          `yaml:"ServiceName"`
+		Protocol           *string         `yaml:"Protocol,omitempty"`
+		ChainID            uint64          `yaml:"ChainID"`
+		NetworkID          uint64          `yaml:"NetworkID"`
+		AcceptTxs          uint32          `yaml:"AcceptTxs"`
+		IsDual             uint            `yaml:"IsDual"`
+		Genesis            *Genesis        `yaml:"Genesis,omitempty"`
+		EventPool          *Pool           `yaml:"EventPool,omitempty"`
+		Database           *Database       `yaml:"Database,omitempty"`
+		Seeds              []string        `yaml:"Seeds"`
+		Events             []Event         `yaml:"Events"`
+		PublishedEndpoint  *string         `yaml:"PublishedEndpoint,omitempty"`
+		SubscribedEndpoint *string         `yaml:"SubscribedEndpoint,omitempty"`
+		Consensus          *Consensus      `yaml:"Consensus"`
+		GasPriceOracle     *GasPriceOracle `yaml:"GasPriceOracle,omitempty"`
 	}
 	Genesis struct {
 		Accounts        []Account                   `yaml:"Accounts"`
@@ -101,6 +129,11 @@ type (
 		Broadcast     bool   `yaml:"Broadcast"`
 		MaxBatchBytes int    `yaml:"MaxBatchBytes"`
 	}
+	GasPriceOracle struct {
+		Blocks     int    `yaml:"Blocks"`
+		Percentile int    `yaml:"Percentile"`
+		MaxPrice   string `yaml:"MaxPrice"` // in HYDRO
+	}
 	Database struct {
 		Type    uint   `yaml:"Type"`
 		Dir     string `yaml:"Dir"`
@@ -0,0 +1,225 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// wal inspects and repairs the consensus write-ahead log of a stopped node.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/kardiachain/go-kardia/cmd/flags"
+	"github.com/kardiachain/go-kardia/consensus"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+
+	app *cli.App
+
+	walFlag = cli.StringFlag{
+		Name:  "wal",
+		Usage: "Path to the head file of the WAL, usually <datadir>/cs.wal/wal. The node must not be running on it",
+	}
+	fromFlag = cli.Int64Flag{
+		Name:  "from",
+		Usage: "First height to dump (default = first height in the WAL)",
+	}
+	toFlag = cli.Int64Flag{
+		Name:  "to",
+		Usage: "Last height to dump (default = last height in the WAL)",
+	}
+	inFlag = cli.StringFlag{
+		Name:  "in",
+		Usage: "Path to the JSON file to convert, as written by dump",
+	}
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Path of the file the conversion is written to, it must not exist",
+	}
+
+	dumpCommand = cli.Command{
+		Name:   "dump",
+		Usage:  "Print the messages of a height range as JSON, one per line",
+		Action: flags.MigrateFlags(dump),
+		Flags:  []cli.Flag{walFlag, fromFlag, toFlag},
+	}
+	validateCommand = cli.Command{
+		Name:   "validate",
+		Usage:  "Check the integrity of the WAL",
+		Action: flags.MigrateFlags(validate),
+		Flags:  []cli.Flag{walFlag},
+	}
+	truncateCommand = cli.Command{
+		Name:   "truncate",
+		Usage:  "Repair a corrupted WAL by cutting it after the last good EndHeightMessage",
+		Action: flags.MigrateFlags(truncate),
+		Flags:  []cli.Flag{walFlag},
+	}
+	convertCommand = cli.Command{
+		Name:  "convert",
+		Usage: "Convert between the binary and JSON forms of the WAL",
+		Subcommands: []cli.Command{
+			{
+				Name:   "tojson",
+				Usage:  "Convert the binary WAL at --wal to JSON written to --out",
+				Action: flags.MigrateFlags(toJSON),
+				Flags:  []cli.Flag{walFlag, outFlag},
+			},
+			{
+				Name:   "fromjson",
+				Usage:  "Convert the JSON at --in to a binary WAL written to --out",
+				Action: flags.MigrateFlags(fromJSON),
+				Flags:  []cli.Flag{inFlag, outFlag},
+			},
+		},
+	}
+)
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "kardia consensus WAL inspection and repair tool")
+	app.Flags = []cli.Flag{walFlag, fromFlag, toFlag, inFlag, outFlag}
+	app.Commands = []cli.Command{dumpCommand, validateCommand, truncateCommand, convertCommand}
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+func walFile(c *cli.Context) string {
+	path := c.GlobalString(walFlag.Name)
+	if path == "" {
+		flags.Fatalf("No WAL specified (--wal)")
+	}
+	return path
+}
+
+func outFile(c *cli.Context) *os.File {
+	path := c.GlobalString(outFlag.Name)
+	if path == "" {
+		flags.Fatalf("No output specified (--out)")
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		flags.Fatalf("Failed to create output: %v", err)
+	}
+	return f
+}
+
+func printReport(report *consensus.WALReport) {
+	fmt.Fprintf(os.Stderr, "files: %d, messages: %d, last end height: %d\n",
+		report.Files, report.Messages, report.LastEndHeight)
+	if report.Corruption != nil {
+		fmt.Fprintf(os.Stderr, "corrupted at file index %d, offset %d: %v\n",
+			report.CorruptIndex, report.CorruptOffset, report.Corruption)
+	}
+}
+
+func dump(c *cli.Context) error {
+	w := bufio.NewWriter(os.Stdout)
+	report, err := consensus.DumpWAL(walFile(c), c.GlobalInt64(fromFlag.Name), c.GlobalInt64(toFlag.Name), w)
+	if err != nil {
+		flags.Fatalf("Failed to dump WAL: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if report.Corruption != nil {
+		printReport(report)
+		os.Exit(1)
+	}
+	return nil
+}
+
+func validate(c *cli.Context) error {
+	report, err := consensus.ValidateWAL(walFile(c))
+	if err != nil {
+		flags.Fatalf("Failed to read WAL: %v", err)
+	}
+	printReport(report)
+	if report.Corruption != nil {
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, "WAL is valid")
+	return nil
+}
+
+func truncate(c *cli.Context) error {
+	report, err := consensus.TruncateWAL(walFile(c))
+	if err != nil {
+		flags.Fatalf("Failed to truncate WAL: %v", err)
+	}
+	printReport(report)
+	if report.Truncated {
+		fmt.Fprintf(os.Stderr, "WAL truncated after the end of height %d\n", report.LastEndHeight)
+	} else {
+		fmt.Fprintln(os.Stderr, "WAL is valid, nothing to truncate")
+	}
+	return nil
+}
+
+func toJSON(c *cli.Context) error {
+	path := walFile(c)
+	f := outFile(c)
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	report, err := consensus.DumpWAL(path, 0, 0, w)
+	if err != nil {
+		flags.Fatalf("Failed to convert WAL: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	printReport(report)
+	if report.Corruption != nil {
+		flags.Fatalf("WAL converted up to the corruption only")
+	}
+	return nil
+}
+
+func fromJSON(c *cli.Context) error {
+	in := c.GlobalString(inFlag.Name)
+	if in == "" {
+		flags.Fatalf("No input specified (--in)")
+	}
+	out := c.GlobalString(outFlag.Name)
+	if out == "" {
+		flags.Fatalf("No output specified (--out)")
+	}
+	f, err := os.Open(in)
+	if err != nil {
+		flags.Fatalf("Failed to open input: %v", err)
+	}
+	defer f.Close()
+
+	n, err := consensus.JSONToWAL(bufio.NewReader(f), out)
+	if err != nil {
+		flags.Fatalf("Failed to convert JSON: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "%d messages written to %s\n", n, out)
+	return nil
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
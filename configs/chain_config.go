@@ -30,6 +30,13 @@ import (
 type ChainConfig struct {
 	// Various consensus engines
 	Kaicon *KaiconConfig `json:"kaicon,omitempty" yaml:"KaiconConfig"`
+
+	// PrecompilesV1Height is the height from which the KVM runs the BLAKE2F,
+	// BLS12-381 and secp256r1 precompiled contracts, nil keeps them disabled.
+	PrecompilesV1Height *uint64 `json:"precompilesV1Height,omitempty" yaml:"PrecompilesV1Height"`
+	// StakingPrecompileHeight is the height from which the KVM runs the native
+	// staking precompiled contract, nil keeps it disabled.
+	StakingPrecompileHeight *uint64 `json:"stakingPrecompileHeight,omitempty" yaml:"StakingPrecompileHeight"`
 }
 
 // KaiconConfig is the consensus engine configs for Kardia BFT DPoS.
@@ -44,6 +51,18 @@ func (c *KaiconConfig) String() string {
 	return "kaicon"
 }
 
+// IsPrecompilesV1 returns whether the v1 precompiled contracts are active at
+// the given height. A nil config keeps them disabled.
+func (c *ChainConfig) IsPrecompilesV1(height uint64) bool {
+	return c != nil && c.PrecompilesV1Height != nil && height >= *c.PrecompilesV1Height
+}
+
+// IsStakingPrecompile returns whether the native staking precompiled contract
+// is active at the given height. A nil config keeps it disabled.
+func (c *ChainConfig) IsStakingPrecompile(height uint64) bool {
+	return c != nil && c.StakingPrecompileHeight != nil && height >= *c.StakingPrecompileHeight
+}
+
 // String implements the fmt.Stringer interface.
 func (c *ChainConfig) String() string {
 	var engine interface{}
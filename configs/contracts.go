@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	DefaultStakingContractAddress = "0x0000000000000000000000000000000000001337"
+	DefaultStakingContractAddress    = "0x0000000000000000000000000000000000001337"
+	DefaultGovernanceContractAddress = "0x0000000000000000000000000000000000001338"
 
 	StakingContractKey           = "Staking"
 	ValidatorContractKey         = "Validator"
@@ -19,6 +20,7 @@ var (
 	PermissionContractKey        = "Permission"
 	CandidateDBContractKey       = "CandidateDB"
 	CandidateExchangeContractKey = "CandidateExchange"
+	GovernanceContractKey        = "Governance"
 
 	StakingContract = Contract{
 		Address:  "0x0000000000000000000000000000000000001337",
@@ -185,19 +187,9 @@ var (
         "name": "params",
         "outputs": [
           {
-            "internalType": "uint256",
-            "name": "baseProposerReward",
-            "type": "uint256"
-          },
-          {
-            "internalType": "uint256",
-            "name": "bonusProposerReward",
-            "type": "uint256"
-          },
-          {
-            "internalType": "uint256",
-            "name": "maxValidator",
-            "type": "uint256"
+            "internalType": "address",
+            "name": "",
+            "type": "address"
           }
         ],
         "payable": false,
@@ -228,6 +220,21 @@ var (
         "stateMutability": "view",
         "type": "function"
       },
+      {
+        "constant": true,
+        "inputs": [],
+        "name": "treasury",
+        "outputs": [
+          {
+            "internalType": "address",
+            "name": "",
+            "type": "address"
+          }
+        ],
+        "payable": false,
+        "stateMutability": "view",
+        "type": "function"
+      },
       {
         "constant": true,
         "inputs": [],
@@ -1901,6 +1908,514 @@ var (
 		"stateMutability": "view",
 		"type": "function"
 	}
+]`,
+	}
+
+	// GovernanceContract is built from kvm/smc/dpos/Governance.sol and deployed at genesis, governance is
+	// disabled if it is not in the genesis contracts.
+	GovernanceContract = Contract{
+		Address:  DefaultGovernanceContractAddress,
+		ByteCode: "0x608060405234801561001057600080fd5b50611e15806100206000396000f3fe60806040526004361061012a5760003560e01c80635bdcd008116100ab578063a80db6fc1161006f578063a80db6fc14610320578063bc3da53514610342578063bc3f931f1461036f578063c2ee3a081461039e578063c7f758a8146103ba578063cff0ab96146103ed57600080fd5b80635bdcd0081461025d5780638635447a1461029857806387f31108146102ae578063943e8216146102eb578063a03526231461030b57600080fd5b806341b3d185116100f257806341b3d185146101b257806342623360146101d057806342cde4e8146101f05780634bb278f31461020c5780634cf088d91461022f57600080fd5b80630892a56e1461012f5780631703a01814610157578063327d71b814610173578063346193b6146101865780633ccfd60b1461019b575b600080fd5b34801561013b57600080fd5b50610144601481565b6040519081526020015b60405180910390f35b34801561016357600080fd5b506101446704a29b3afafb000081565b6101446101813660046118d9565b610402565b34801561019257600080fd5b50600054610144565b3480156101a757600080fd5b506101b06107f0565b005b3480156101be57600080fd5b506101446969e10de76676d080000081565b3480156101dc57600080fd5b506101446101eb366004611965565b610882565b3480156101fc57600080fd5b506101446706f05b59d3b2000081565b34801561021857600080fd5b506102216109af565b60405161014e9291906119c4565b34801561023b57600080fd5b5061024561133781565b6040516001600160a01b03909116815260200161014e565b34801561026957600080fd5b5061027d6102783660046119f2565b610d3d565b6040805193845260208401929092529082015260600161014e565b3480156102a457600080fd5b5061014461438081565b3480156102ba57600080fd5b506102ce6102c93660046119f2565b610da5565b60408051931515845260208401929092529082015260600161014e565b3480156102f757600080fd5b506101b0610306366004611a0b565b611056565b34801561031757600080fd5b50610144601381565b34801561032c57600080fd5b50610335611260565b60405161014e9190611a3f565b34801561034e57600080fd5b5061014461035d366004611965565b60036020526000908152604090205481565b34801561037b57600080fd5b5061038f61038a366004611a52565b6112b8565b60405161014e93929190611aab565b3480156103aa57600080fd5b50610144670de0b6b3a764000081565b3480156103c657600080fd5b506103da6103d53660046119f2565b61131d565b60405161014e9796959493929190611ace565b3480156103f957600080fd5b50610245611461565b6000841580159061041257508483145b8015610429575061042560136001611b4a565b8511155b61046b5760405162461bcd60e51b815260206004820152600e60248201526d696e76616c696420706172616d7360901b60448201526064015b60405180910390fd5b6969e10de76676d08000003410156104b35760405162461bcd60e51b815260206004820152600b60248201526a1b5a5b8819195c1bdcda5d60aa1b6044820152606401610462565b6104bf61438043611b4a565b82101561050e5760405162461bcd60e51b815260206004820152601a60248201527f7461726765742068656967687420697320746f6f206561726c790000000000006044820152606401610462565b6001546014116105605760405162461bcd60e51b815260206004820152601a60248201527f746f6f206d616e792070656e64696e672070726f706f73616c730000000000006044820152606401610462565b600061056b33610882565b116105b15760405162461bcd60e51b81526020600482015260166024820152751c1c9bdc1bdcd95c881a5cc81b9bdd08189bdb99195960521b6044820152606401610462565b60005b858110156106d75760008060006105e28a8a868181106105d6576105d6611b63565b90506020020135610da5565b925092509250826106355760405162461bcd60e51b815260206004820152601760248201527f706172616d2063616e6e6f74206265206368616e6765640000000000000000006044820152606401610462565b8188888681811061064857610648611b63565b905060200201351015801561067557508088888681811061066b5761066b611b63565b9050602002013511155b6106c15760405162461bcd60e51b815260206004820152601960248201527f706172616d2076616c7565206f7574206f6620626f756e6473000000000000006044820152606401610462565b50505080806106cf90611b79565b9150506105b4565b5060008054600181018255908052600a81027f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563810180546001600160a01b031916331781559061074a907f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e56401898961182d565b5061075960028201878761182d565b50346003820155436004820155600581018490556001805480820182556000919091527fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf60182905560405182907f5f549dbc1a23009be6825a21f75e77f1787daf0c6f177ee03a77a4d8cb926f79906107dd9033908c908c908c908c908c90611bc4565b60405180910390a2509695505050505050565b33600090815260036020526040902054806108435760405162461bcd60e51b81526020600482015260136024820152726e6f7468696e6720746f20776974686472617760681b6044820152606401610462565b336000818152600360205260408082208290555183156108fc0291849190818181858888f1935050505015801561087e573d6000803e3d6000fd5b5050565b60405163195d8add60e11b81526001600160a01b03821660048201526000908190611337906332bb15ba90602401600060405180830381865afa1580156108cd573d6000803e3d6000fd5b505050506040513d6000823e601f3d908101601f191682016040526108f59190810190611c36565b905060005b81518110156109a85781818151811061091557610915611b63565b60209081029190910101516040516314b0d1cf60e31b81526001600160a01b0386811660048301529091169063a5868e7890602401602060405180830381865afa925050508015610983575060408051601f3d908101601f1916820190925261098091810190611cfb565b60015b15610996576109928185611b4a565b9350505b806109a081611b79565b9150506108fa565b5050919050565b606080333014610a015760405162461bcd60e51b815260206004820152601760248201527f63616c6c6572206973206e6f742074686520636861696e0000000000000000006044820152606401610462565b60015460009067ffffffffffffffff811115610a1f57610a1f611c10565b604051908082528060200260200182016040528015610a48578160200160208202803683370190505b50905060008060005b600154811015610b9e57600060018281548110610a7057610a70611b63565b906000526020600020015490504360008281548110610a9157610a91611b63565b90600052602060002090600a0201600501541115610abc5781610ab381611b79565b92505050610a51565b60018054610acb908290611d14565b81548110610adb57610adb611b63565b906000526020600020015460018381548110610af957610af9611b63565b6000918252602090912001556001805480610b1657610b16611d27565b60019003818190600052602060002001600090559055610b35816114cc565b15610b9857808585610b4681611b79565b965081518110610b5857610b58611b63565b60200260200101818152505060008181548110610b7757610b77611b63565b600091825260209091206001600a909202010154610b959084611b4a565b92505b50610a51565b8167ffffffffffffffff811115610bb757610bb7611c10565b604051908082528060200260200182016040528015610be0578160200160208202803683370190505b5095508167ffffffffffffffff811115610bfc57610bfc611c10565b604051908082528060200260200182016040528015610c25578160200160208202803683370190505b50945060009150600090505b82811015610d3557600080858381518110610c4e57610c4e611b63565b602002602001015181548110610c6657610c66611b63565b90600052602060002090600a0201905060005b6001820154811015610d2057816001018181548110610c9a57610c9a611b63565b9060005260206000200154888581518110610cb757610cb7611b63565b602002602001018181525050816002018181548110610cd857610cd8611b63565b9060005260206000200154878581518110610cf557610cf5611b63565b602090810291909101015283610d0a81611b79565b9450508080610d1890611b79565b915050610c79565b50508080610d2d90611b79565b915050610c31565b505050509091565b60008054819081908410610d635760405162461bcd60e51b815260040161046290611d3d565b6000808581548110610d7757610d77611b63565b90600052602060002090600a02019050806007015481600801548260090154935093509350505b9193909250565b600080806013841115610dc057506000915081905080610d9e565b6000846013811115610dd457610dd4611a77565b90506000816013811115610dea57610dea611a77565b1480610e0757506001816013811115610e0557610e05611a77565b145b80610e2357506004816013811115610e2157610e21611a77565b145b80610e3f57506006816013811115610e3d57610e3d611a77565b145b80610e5b57506008816013811115610e5957610e59611a77565b145b80610e775750600d816013811115610e7557610e75611a77565b145b80610e9357506010816013811115610e9157610e91611a77565b145b80610eaf57506011816013811115610ead57610ead611a77565b145b15610ecd5760016000670de0b6b3a764000093509350935050610d9e565b600e816013811115610ee157610ee1611a77565b03610efe57600180670de0b6b3a764000093509350935050610d9e565b6002816013811115610f1257610f12611a77565b03610f2857600180606493509350935050610d9e565b6003816013811115610f3c57610f3c611a77565b03610f55576001603c62278d0093509350935050610d9e565b6005816013811115610f6957610f69611a77565b03610f85576001620151806301e1338093509350935050610d9e565b6009816013811115610f9957610f99611a77565b1480610fb65750600a816013811115610fb457610fb4611a77565b145b80610fd25750600b816013811115610fd057610fd0611a77565b145b80610fee5750600c816013811115610fec57610fec611a77565b145b15611017576001670de0b6b3a76400006b033b2e3c9fd0803ce800000093509350935050610d9e565b600f81601381111561102b5761102b611a77565b03611047576001620186a06305f5e10093509350935050610d9e565b50600094859450849350915050565b60005482106110775760405162461bcd60e51b815260040161046290611d3d565b600080838154811061108b5761108b611b63565b600091825260208220600a909102019150600682015460ff1660028111156110b5576110b5611a77565b146110f65760405162461bcd60e51b81526020600482015260116024820152701a5b9858dd1a5d99481c1c9bdc1bdcd85b607a1b6044820152606401610462565b8060050154431061113c5760405162461bcd60e51b815260206004820152601060248201526f1d9bdd1a5b99c81a5cc818db1bdcd95960821b6044820152606401610462565b600061114733610882565b90506000811161118f5760405162461bcd60e51b81526020600482015260136024820152721d9bdd195c881a5cc81b9bdd08189bdb991959606a1b6044820152606401610462565b60008481526002602090815260408083203384529091529020805460ff16156111d4576111d4838260000160019054906101000a900460ff168360010154600061175d565b8054600160ff198216811783558591839161ffff199091161761010083600281111561120257611202611a77565b021790555081816001018190555061121d838584600161175d565b847f2635c84c3a8027a303ca6d6e90b1c4a9604754040372414d244aadfa6471751833868560405161125193929190611d69565b60405180910390a25050505050565b606060018054806020026020016040519081016040528092919081815260200182805480156112ae57602002820191906000526020600020905b81548152602001906001019080831161129a575b5050505050905090565b600080548190819085106112de5760405162461bcd60e51b815260040161046290611d3d565b50505060009182526002602090815260408084206001600160a01b039390931684529190529020805460019091015460ff808316936101009093041691565b6000606080600080600080600080549050881061134c5760405162461bcd60e51b815260040161046290611d3d565b600080898154811061136057611360611b63565b6000918252602091829020600a909102018054600382015460048301546005840154600685015460018601805460408051828b0281018b019091528181529799506001600160a01b0390961697909660028a019660ff9093169290918891908301828280156113ee57602002820191906000526020600020905b8154815260200190600101908083116113da575b505050505095508480548060200260200160405190810160405280929190818152602001828054801561144057602002820191906000526020600020905b81548152602001906001019080831161142c575b50505050509450975097509750975097509750975050919395979092949650565b60006113376001600160a01b031663cff0ab966040518163ffffffff1660e01b8152600401602060405180830381865afa1580156114a3573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906114c79190611d82565b905090565b600080600083815481106114e2576114e2611b63565b90600052602060002090600a02019050600081600901548260080154836007015461150d9190611b4a565b6115179190611b4a565b90506113376001600160a01b03166344d96e956040518163ffffffff1660e01b8152600401602060405180830381865afa925050508015611575575060408051601f3d908101601f1916820190925261157291810190611cfb565b60015b6115825760009250611600565b6000821180156115b4575061159f6704a29b3afafb000082611d9f565b6115b1670de0b6b3a764000084611d9f565b10155b80156115fc57506706f05b59d3b20000836008015484600701546115d89190611b4a565b6115e29190611d9f565b670de0b6b3a764000084600701546115fa9190611d9f565b115b9350505b81546001600160a01b031683156116255760068301805460ff19166001179055611696565b60068301805460ff19166002179055604080516361d027b360e01b81529051611337916361d027b39160048083019260209291908290030181865afa92505050801561168e575060408051601f3d908101601f1916820190925261168b91810190611d82565b60015b156116965790505b60038301546040516001600160a01b0383169180156108fc02916000818181858888f1935050505061170157826003015460036000836001600160a01b03166001600160a01b0316815260200190815260200160002060008282546116fb9190611b4a565b90915550505b600683015460078401546008850154600986015460405189947f40b1a7d92c98f0b4f80e67cfd329d30a358dfe3bfe8d4be0bb45bc41a8ccd1ae9461174d9460ff909216939092611db6565b60405180910390a2505050919050565b600183600281111561177157611771611a77565b036117aa57806117905781846007015461178b9190611d14565b6117a0565b8184600701546117a09190611b4a565b6007850155611827565b60028360028111156117be576117be611a77565b036117f757806117dd578184600801546117d89190611d14565b6117ed565b8184600801546117ed9190611b4a565b6008850155611827565b806118115781846009015461180c9190611d14565b611821565b8184600901546118219190611b4a565b60098501555b50505050565b828054828255906000526020600020908101928215611868579160200282015b8281111561186857823582559160200191906001019061184d565b50611874929150611878565b5090565b5b808211156118745760008155600101611879565b60008083601f84011261189f57600080fd5b50813567ffffffffffffffff8111156118b757600080fd5b6020830191508360208260051b85010111156118d257600080fd5b9250929050565b6000806000806000606086880312156118f157600080fd5b853567ffffffffffffffff8082111561190957600080fd5b61191589838a0161188d565b9097509550602088013591508082111561192e57600080fd5b5061193b8882890161188d565b96999598509660400135949350505050565b6001600160a01b038116811461196257600080fd5b50565b60006020828403121561197757600080fd5b81356119828161194d565b9392505050565b600081518084526020808501945080840160005b838110156119b95781518752958201959082019060010161199d565b509495945050505050565b6040815260006119d76040830185611989565b82810360208401526119e98185611989565b95945050505050565b600060208284031215611a0457600080fd5b5035919050565b60008060408385031215611a1e57600080fd5b82359150602083013560038110611a3457600080fd5b809150509250929050565b6020815260006119826020830184611989565b60008060408385031215611a6557600080fd5b823591506020830135611a348161194d565b634e487b7160e01b600052602160045260246000fd5b6003811061196257634e487b7160e01b600052602160045260246000fd5b831515815260608101611abd84611a8d565b602082019390935260400152919050565b6001600160a01b038816815260e060208201819052600090611af290830189611989565b8281036040840152611b048189611989565b9150508560608301528460808301528360a0830152611b2283611a8d565b8260c083015298975050505050505050565b634e487b7160e01b600052601160045260246000fd5b80820180821115611b5d57611b5d611b34565b92915050565b634e487b7160e01b600052603260045260246000fd5b600060018201611b8b57611b8b611b34565b5060010190565b81835260006001600160fb1b03831115611bab57600080fd5b8260051b80836020870137939093016020019392505050565b6001600160a01b0387168152608060208201819052600090611be99083018789611b92565b8281036040840152611bfc818688611b92565b915050826060830152979650505050505050565b634e487b7160e01b600052604160045260246000fd5b8051611c318161194d565b919050565b60006020808385031215611c4957600080fd5b825167ffffffffffffffff80821115611c6157600080fd5b818501915085601f830112611c7557600080fd5b815181811115611c8757611c87611c10565b8060051b604051601f19603f83011681018181108582111715611cac57611cac611c10565b604052918252848201925083810185019188831115611cca57600080fd5b938501935b82851015611cef57611ce085611c26565b84529385019392850192611ccf565b98975050505050505050565b600060208284031215611d0d57600080fd5b5051919050565b81810381811115611b5d57611b5d611b34565b634e487b7160e01b600052603160045260246000fd5b6020808252601290820152711c1c9bdc1bdcd85b081b9bdd08199bdd5b9960721b604082015260600190565b6001600160a01b038416815260608101611abd84611a8d565b600060208284031215611d9457600080fd5b81516119828161194d565b8082028115828204841417611b5d57611b5d611b34565b60808101611dc386611a8d565b948152602081019390935260408301919091526060909101529056fea2646970667358221220fa52228bfd8d73df889794317ad77a347559b48c5a93dcfd8b0f46ab277da4b664736f6c63430008150033",
+		ABI: `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "address",
+				"name": "proposer",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256[]",
+				"name": "keys",
+				"type": "uint256[]"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256[]",
+				"name": "values",
+				"type": "uint256[]"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "targetHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "ProposalCreated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "enum Governance.ProposalStatus",
+				"name": "status",
+				"type": "uint8"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "yes",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "no",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "abstain",
+				"type": "uint256"
+			}
+		],
+		"name": "ProposalExecuted",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "address",
+				"name": "voter",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "enum Governance.VoteOption",
+				"name": "option",
+				"type": "uint8"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "weight",
+				"type": "uint256"
+			}
+		],
+		"name": "Voted",
+		"type": "event"
+	},
+	{
+		"inputs": [],
+		"name": "MAX_PARAM_KEY",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "ONE",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "allProposals",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "finalize",
+		"outputs": [
+			{
+				"internalType": "uint256[]",
+				"name": "keys",
+				"type": "uint256[]"
+			},
+			{
+				"internalType": "uint256[]",
+				"name": "values",
+				"type": "uint256[]"
+			}
+		],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getPendingProposals",
+		"outputs": [
+			{
+				"internalType": "uint256[]",
+				"name": "",
+				"type": "uint256[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			}
+		],
+		"name": "getProposal",
+		"outputs": [
+			{
+				"internalType": "address",
+				"name": "proposer",
+				"type": "address"
+			},
+			{
+				"internalType": "uint256[]",
+				"name": "keys",
+				"type": "uint256[]"
+			},
+			{
+				"internalType": "uint256[]",
+				"name": "values",
+				"type": "uint256[]"
+			},
+			{
+				"internalType": "uint256",
+				"name": "deposit",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "startHeight",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "targetHeight",
+				"type": "uint256"
+			},
+			{
+				"internalType": "enum Governance.ProposalStatus",
+				"name": "status",
+				"type": "uint8"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			}
+		],
+		"name": "getProposalResults",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "yes",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "no",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "abstain",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			},
+			{
+				"internalType": "address",
+				"name": "voter",
+				"type": "address"
+			}
+		],
+		"name": "getVote",
+		"outputs": [
+			{
+				"internalType": "bool",
+				"name": "voted",
+				"type": "bool"
+			},
+			{
+				"internalType": "enum Governance.VoteOption",
+				"name": "option",
+				"type": "uint8"
+			},
+			{
+				"internalType": "uint256",
+				"name": "weight",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "maxPendingProposals",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "minDeposit",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "minVotingPeriod",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "key",
+				"type": "uint256"
+			}
+		],
+		"name": "paramBounds",
+		"outputs": [
+			{
+				"internalType": "bool",
+				"name": "allowed",
+				"type": "bool"
+			},
+			{
+				"internalType": "uint256",
+				"name": "min",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "max",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "pure",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "params",
+		"outputs": [
+			{
+				"internalType": "address",
+				"name": "",
+				"type": "address"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256[]",
+				"name": "keys",
+				"type": "uint256[]"
+			},
+			{
+				"internalType": "uint256[]",
+				"name": "values",
+				"type": "uint256[]"
+			},
+			{
+				"internalType": "uint256",
+				"name": "targetHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "propose",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "quorum",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "",
+				"type": "address"
+			}
+		],
+		"name": "refunds",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "voter",
+				"type": "address"
+			}
+		],
+		"name": "stakeOf",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "stake",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "staking",
+		"outputs": [
+			{
+				"internalType": "contract IGovernanceStaking",
+				"name": "",
+				"type": "address"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "threshold",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			},
+			{
+				"internalType": "enum Governance.VoteOption",
+				"name": "option",
+				"type": "uint8"
+			}
+		],
+		"name": "vote",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "withdraw",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
 ]`,
 	}
 )
@@ -1915,6 +2430,7 @@ func AddDefaultContract() {
 	contracts[CandidateDBContractKey] = CandidateDBContract
 	contracts[CandidateExchangeContractKey] = CandidateExchangeContract
 	contracts[ValidatorContractKey] = ValidatorContract
+	contracts[GovernanceContractKey] = GovernanceContract
 }
 
 func AddDefaultStakingContractAddress() {
@@ -22,6 +22,7 @@ const (
 
 var (
 	defaultAddrBookName = "addrbook.json"
+	defaultBanListName  = "banlist.json"
 )
 
 //-----------------------------------------------------------------------------
@@ -96,6 +97,26 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Peers whose trust score (0-100) drops below this value are disconnected
+	// and banned for a while. Set to 0 to only track trust scores.
+	MinTrustScore int `mapstructure:"min_trust_score"`
+
+	// Number of good and bad events a peer must have in its trust metric
+	// before its trust score can get it disconnected, so a single fault of a
+	// new peer doesn't ban it.
+	MinTrustEvents int `mapstructure:"min_trust_events"`
+
+	// Path to the list of banned node IDs and IPs
+	BanList string `mapstructure:"ban_list_file"`
+
+	// Comma separated list of node IDs, IPs and CIDR ranges banned for ever
+	BannedPeers string `mapstructure:"banned_peers"`
+
+	// Number of protocol violations within an hour after which a peer is
+	// banned for AutoBanDuration. Set to 0 to disable automatic bans.
+	AutoBanThreshold int           `mapstructure:"auto_ban_threshold"`
+	AutoBanDuration  time.Duration `mapstructure:"auto_ban_duration"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
@@ -126,6 +147,11 @@ func DefaultP2PConfig() *P2PConfig {
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
+		MinTrustScore:                10,
+		MinTrustEvents:               20,
+		BanList:                      filepath.Join(DefaultDataDir(), defaultBanListName),
+		AutoBanThreshold:             3,
+		AutoBanDuration:              24 * time.Hour,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
 		TestDialFail:                 false,
@@ -139,6 +165,11 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// BanListFile returns the full path to the ban list
+func (cfg *P2PConfig) BanListFile() string {
+	return rootify(cfg.BanList, cfg.RootDir)
+}
+
 // Address return the main address
 func (cfg *P2PConfig) Address() *common.Address {
 	if address := crypto.PubkeyToAddress(cfg.PrivateKey.PublicKey); !address.Equal(common.Address{}) {
@@ -295,7 +295,7 @@ func GetBlockchain() (*blockchain.BlockChain, *configs.ChainConfig, error) {
 	genesisContracts := make(map[string]string)
 	for key, contract := range configs.GetContracts() {
 		configs.LoadGenesisContract(key, contract.Address, contract.ByteCode, contract.ABI)
-		if key != configs.StakingContractKey {
+		if key != configs.StakingContractKey && key != configs.GovernanceContractKey {
 			genesisContracts[contract.Address] = contract.ByteCode
 		}
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/kardiachain/go-kardia/configs"
 	cstypes "github.com/kardiachain/go-kardia/consensus/types"
 	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/behaviour"
 	cmn "github.com/kardiachain/go-kardia/lib/common"
 	kevents "github.com/kardiachain/go-kardia/lib/events"
 	"github.com/kardiachain/go-kardia/lib/log"
@@ -60,6 +61,7 @@ type ConsensusManager struct {
 	targetPending   int
 	mtx             sync.RWMutex
 	eventBus        *types.EventBus
+	reporter        behaviour.Reporter
 }
 
 // NewConsensusManager returns a new ConsensusManager with the given
@@ -104,6 +106,8 @@ func (conR *ConsensusManager) Validators() []*types.Validator {
 
 func (conR *ConsensusManager) OnStart() error {
 	conR.Logger.Info("Consensus manager ", "waitSync", conR.WaitSync())
+	conR.reporter = behaviour.NewSwitchReporter(conR.Switch)
+	go conR.peerStatsRoutine()
 	conR.subscribeToBroadcastEvents()
 	if !conR.WaitSync() {
 		err := conR.conS.Start()
@@ -246,13 +250,13 @@ func (conR *ConsensusManager) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		conR.Switch.StopPeerForError(src, err)
+		_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		conR.Switch.StopPeerForError(src, err)
+		_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
@@ -284,7 +288,7 @@ func (conR *ConsensusManager) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 			// Peer claims to have a maj23 for some BlockID at H,R,S,
 			err := votes.SetPeerMaj23(msg.Round, msg.Type, ps.peer.ID(), msg.BlockID)
 			if err != nil {
-				conR.Switch.StopPeerForError(src, err)
+				_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 				return
 			}
 			// Respond with a VoteSetBitsMessage showing which votes we have.
@@ -370,6 +374,21 @@ func (conR *ConsensusManager) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	}
 }
 
+// peerStatsRoutine reports the behaviour of peers observed by the consensus
+// state, so added votes and block parts raise their trust.
+func (conR *ConsensusManager) peerStatsRoutine() {
+	for {
+		select {
+		case b := <-conR.conS.statsMsgQueue:
+			if err := conR.reporter.Report(b); err != nil {
+				conR.Logger.Debug("Error reporting peer behaviour", "err", err)
+			}
+		case <-conR.Quit():
+			return
+		}
+	}
+}
+
 // subscribeToBroadcastEvents subscribes for new round steps, votes and
 // proposal heartbeats using internal pubsub defined on state to broadcast
 // them to peers upon receiving.
@@ -7,6 +7,9 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/types"
 )
 
@@ -158,3 +161,116 @@ LOOP:
 	cs.Logger.Info("Replay: Done")
 	return nil
 }
+
+//---------------------------------------------------
+// 2. Recover from failure while applying the block.
+// (by comparing the block store, state and app heights on startup,
+// and replaying the blocks missing from the state and the app)
+//---------------------------------------------------
+
+// HandshakeBlockOperations is the view of the block store and of the
+// application the Handshaker reconciles the state with.
+type HandshakeBlockOperations interface {
+	// Height returns the height of the last saved block.
+	Height() uint64
+	LoadBlock(height uint64) *types.Block
+	LoadBlockMeta(height uint64) *types.BlockMeta
+	// AppHeight returns the height of the last block committed by the application.
+	AppHeight() uint64
+	// LoadAppHash returns the app hash committed for the block at height.
+	LoadAppHash(height uint64) common.Hash
+	// RewindApp moves the application back to its state after the block at height.
+	RewindApp(height uint64) error
+}
+
+// Handshaker replays the blocks saved in the block store but missing from the
+// state or the application, which happens when the node stops between
+// SaveBlock and the end of ApplyBlock.
+type Handshaker struct {
+	logger       log.Logger
+	initialState cstate.LastestBlockState
+	blockOps     HandshakeBlockOperations
+	blockExec    *cstate.BlockExecutor
+
+	nBlocks int // number of blocks applied to the state
+}
+
+// NewHandshaker returns a Handshaker reconciling the given state with the
+// block store and the application.
+func NewHandshaker(logger log.Logger, state cstate.LastestBlockState,
+	blockOps HandshakeBlockOperations, blockExec *cstate.BlockExecutor) *Handshaker {
+	return &Handshaker{
+		logger:       logger,
+		initialState: state,
+		blockOps:     blockOps,
+		blockExec:    blockExec,
+	}
+}
+
+// NBlocks returns the number of blocks replayed by the last Handshake.
+func (h *Handshaker) NBlocks() int {
+	return h.nBlocks
+}
+
+// Handshake compares the heights of the block store, the state and the
+// application, and replays the saved blocks the state is missing through
+// ApplyBlock. Blocks the application already committed are executed again
+// from its state at the state height and must produce the same app hash.
+// It returns the reconciled state, which ApplyBlock also persists.
+func (h *Handshaker) Handshake() (cstate.LastestBlockState, error) {
+	var (
+		state       = h.initialState
+		storeHeight = h.blockOps.Height()
+		stateHeight = state.LastBlockHeight
+		appHeight   = h.blockOps.AppHeight()
+	)
+	h.logger.Info("Handshake", "storeHeight", storeHeight, "stateHeight", stateHeight, "appHeight", appHeight)
+
+	switch {
+	case storeHeight < stateHeight:
+		return state, fmt.Errorf("state height %d is higher than block store height %d", stateHeight, storeHeight)
+	case storeHeight < appHeight:
+		return state, fmt.Errorf("app block height %d is higher than block store height %d", appHeight, storeHeight)
+	case appHeight < stateHeight:
+		return state, fmt.Errorf("app block height %d is lower than state height %d, the app state is lost", appHeight, stateHeight)
+	case storeHeight == stateHeight:
+		h.logger.Info("Handshake: state and app are up to date", "height", stateHeight)
+		return state, nil
+	}
+
+	// Remember the app hashes of the blocks the app committed ahead of the
+	// state, they are executed again to recover their validator updates.
+	appHashes := make(map[uint64]common.Hash)
+	if appHeight > stateHeight {
+		for height := stateHeight + 1; height <= appHeight; height++ {
+			appHashes[height] = h.blockOps.LoadAppHash(height)
+		}
+		if err := h.blockOps.RewindApp(stateHeight); err != nil {
+			return state, fmt.Errorf("failed to rewind app to height %d: %w", stateHeight, err)
+		}
+	}
+
+	h.nBlocks = 0
+	for height := stateHeight + 1; height <= storeHeight; height++ {
+		block := h.blockOps.LoadBlock(height)
+		blockMeta := h.blockOps.LoadBlockMeta(height)
+		if block == nil || blockMeta == nil {
+			return state, fmt.Errorf("block %d is missing from the block store", height)
+		}
+
+		h.logger.Info("Handshake: replaying block", "height", height, "hash", block.Hash())
+		var err error
+		state, _, err = h.blockExec.ApplyBlock(state, blockMeta.BlockID, block)
+		if err != nil {
+			return state, fmt.Errorf("failed to replay block %d: %w", height, err)
+		}
+		h.nBlocks++
+
+		if appHash, ok := appHashes[height]; ok && appHash != state.AppHash {
+			return state, fmt.Errorf("app hash mismatch replaying block %d: got %v, expected %v", height, state.AppHash.Hex(), appHash.Hex())
+		}
+	}
+
+	h.logger.Info("Handshake: replayed blocks", "count", h.nBlocks, "height", state.LastBlockHeight, "appHash", state.AppHash)
+	return state, nil
+}
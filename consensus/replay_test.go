@@ -3,6 +3,18 @@ package consensus
 import (
 	"fmt"
 	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	stypes "github.com/kardiachain/go-kardia/mainchain/staking/types"
+	"github.com/kardiachain/go-kardia/types"
 )
 
 func tempWALWithData(data []byte) string {
@@ -19,3 +31,159 @@ func tempWALWithData(data []byte) string {
 	}
 	return walFile.Name()
 }
+
+// mockHandshakeBlockOperations is a block store and application whose blocks
+// commit to a fixed app hash per height.
+type mockHandshakeBlockOperations struct {
+	blocks    map[uint64]*types.Block
+	appHashes map[uint64]common.Hash
+	appHeight uint64
+	rewoundTo *uint64
+}
+
+func (m *mockHandshakeBlockOperations) Height() uint64 {
+	return uint64(len(m.blocks))
+}
+
+func (m *mockHandshakeBlockOperations) LoadBlock(height uint64) *types.Block {
+	return m.blocks[height]
+}
+
+func (m *mockHandshakeBlockOperations) LoadBlockMeta(height uint64) *types.BlockMeta {
+	block := m.blocks[height]
+	if block == nil {
+		return nil
+	}
+	return types.NewBlockMeta(block, block.MakePartSet(types.BlockPartSizeBytes))
+}
+
+func (m *mockHandshakeBlockOperations) AppHeight() uint64 {
+	return m.appHeight
+}
+
+func (m *mockHandshakeBlockOperations) LoadAppHash(height uint64) common.Hash {
+	return m.appHashes[height]
+}
+
+func (m *mockHandshakeBlockOperations) RewindApp(height uint64) error {
+	m.appHeight = height
+	m.rewoundTo = &height
+	return nil
+}
+
+func (m *mockHandshakeBlockOperations) CommitAndValidateBlockTxs(block *types.Block, _ stypes.LastCommitInfo,
+	_ []stypes.Evidence) ([]*types.Validator, common.Hash, error) {
+	if block.Height() != m.appHeight+1 {
+		return nil, common.Hash{}, fmt.Errorf("app at height %d cannot commit block %d", m.appHeight, block.Height())
+	}
+	m.appHeight = block.Height()
+	return nil, common.BytesToHash([]byte{byte(block.Height())}), nil
+}
+
+type nopEvidencePool struct{}
+
+func (nopEvidencePool) Update(cstate.LastestBlockState, types.EvidenceList) {}
+func (nopEvidencePool) CheckEvidence(types.EvidenceList) error              { return nil }
+
+// makeHandshakeState returns a genesis state with a single validator and the
+// first block built on top of it.
+func makeHandshakeState() (cstate.LastestBlockState, *types.Block) {
+	valSet, _ := types.RandValidatorSet(1, 10)
+	state := cstate.LastestBlockState{
+		ChainID:        "kaicon",
+		InitialHeight:  1,
+		LastBlockID:    types.NewZeroBlockID(),
+		LastBlockTime:  time.Now(),
+		Validators:     valSet,
+		LastValidators: valSet,
+		NextValidators: valSet.CopyIncrementProposerPriority(1),
+	}
+	header := &types.Header{
+		Height:             1,
+		Time:               state.LastBlockTime,
+		LastBlockID:        state.LastBlockID,
+		ProposerAddress:    valSet.Validators[0].Address,
+		ValidatorsHash:     state.Validators.Hash(),
+		NextValidatorsHash: state.NextValidators.Hash(),
+		AppHash:            state.AppHash,
+	}
+	return state, types.NewBlock(header, nil, &types.Commit{}, nil)
+}
+
+func newHandshaker(t *testing.T, state cstate.LastestBlockState, blockOps *mockHandshakeBlockOperations) *Handshaker {
+	logger := log.TestingLogger()
+	blockExec := cstate.NewBlockExecutor(cstate.NewStore(memorydb.New()), logger, nopEvidencePool{}, blockOps)
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+	blockExec.SetEventBus(eventBus)
+	return NewHandshaker(logger, state, blockOps, blockExec)
+}
+
+func TestHandshakeReplayBlocks(t *testing.T) {
+	state, block := makeHandshakeState()
+	appHash := common.BytesToHash([]byte{1})
+
+	testCases := []struct {
+		name      string
+		appHeight uint64
+		appHash   common.Hash
+		replayed  int
+		rewound   bool
+		err       bool
+	}{
+		// the node stopped after SaveBlock, before the app committed the block
+		{"store ahead of app and state", 0, common.Hash{}, 1, false, false},
+		// the node stopped after the app committed the block, before the state was saved
+		{"app ahead of state", 1, appHash, 1, true, false},
+		// the block committed before the crash does not execute the same way again
+		{"app hash mismatch", 1, common.BytesToHash([]byte{2}), 1, true, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			blockOps := &mockHandshakeBlockOperations{
+				blocks:    map[uint64]*types.Block{1: block},
+				appHashes: map[uint64]common.Hash{1: tc.appHash},
+				appHeight: tc.appHeight,
+			}
+			h := newHandshaker(t, state, blockOps)
+			newState, err := h.Handshake()
+			assert.Equal(t, tc.replayed, h.NBlocks())
+			assert.Equal(t, tc.rewound, blockOps.rewoundTo != nil)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), newState.LastBlockHeight)
+			assert.Equal(t, block.Hash(), newState.LastBlockID.Hash)
+			assert.Equal(t, appHash, newState.AppHash)
+			assert.Equal(t, uint64(1), blockOps.appHeight)
+		})
+	}
+}
+
+func TestHandshakeHeights(t *testing.T) {
+	state, block := makeHandshakeState()
+
+	// nothing to replay
+	blockOps := &mockHandshakeBlockOperations{blocks: map[uint64]*types.Block{}}
+	h := newHandshaker(t, state, blockOps)
+	newState, err := h.Handshake()
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.NBlocks())
+	assert.Equal(t, state.LastBlockHeight, newState.LastBlockHeight)
+
+	// the app is ahead of the block store
+	blockOps = &mockHandshakeBlockOperations{blocks: map[uint64]*types.Block{}, appHeight: 1}
+	_, err = newHandshaker(t, state, blockOps).Handshake()
+	assert.Error(t, err)
+
+	// the state is ahead of the app
+	stateAhead := state.Copy()
+	stateAhead.LastBlockHeight = 1
+	blockOps = &mockHandshakeBlockOperations{blocks: map[uint64]*types.Block{1: block}}
+	_, err = newHandshaker(t, stateAhead, blockOps).Handshake()
+	assert.Error(t, err)
+}
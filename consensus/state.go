@@ -34,6 +34,7 @@ import (
 	cfg "github.com/kardiachain/go-kardia/configs"
 	cstypes "github.com/kardiachain/go-kardia/consensus/types"
 	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/behaviour"
 	cmn "github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/crypto"
 	kevents "github.com/kardiachain/go-kardia/lib/events"
@@ -110,6 +111,9 @@ type ConsensusState struct {
 	peerMsgQueue     chan msgInfo
 	internalMsgQueue chan msgInfo
 
+	// behaviour of peers observed while handling their msgs, reported by the manager
+	statsMsgQueue chan behaviour.PeerBehaviour
+
 	// we use eventBus to trigger msg broadcasts in the manager,
 	// and to notify external subscribers, eg. through a websocket
 	eventBus *types.EventBus
@@ -146,6 +150,7 @@ func NewConsensusState(
 		blockOperations:  blockOperations,
 		peerMsgQueue:     make(chan msgInfo, msgQueueSize),
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
+		statsMsgQueue:    make(chan behaviour.PeerBehaviour, msgQueueSize),
 		timeoutTicker:    NewTimeoutTicker(),
 		done:             make(chan struct{}),
 		evpool:           evpool,
@@ -1666,7 +1671,10 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 		err = cs.setProposal(msg.Proposal)
 	case *BlockPartMessage:
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
-		_, err := cs.addProposalBlockPart(msg, peerID)
+		added, err := cs.addProposalBlockPart(msg, peerID)
+		if added && peerID != "" {
+			cs.reportPeer(behaviour.BlockPart(peerID, "added block part"))
+		}
 		if err != nil && msg.Round != cs.Round {
 			cs.Logger.Debug(
 				"Received block part from wrong round",
@@ -1683,10 +1691,14 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
 		cs.Logger.Trace("handling AddVote", "VoteMessage", msg.Vote)
-		_, err := cs.tryAddVote(msg.Vote, peerID)
+		added, err := cs.tryAddVote(msg.Vote, peerID)
+		if added && peerID != "" {
+			cs.reportPeer(behaviour.ConsensusVote(peerID, "added vote"))
+		}
+		// late, duplicate or unexpected votes are common among honest peers,
+		// they are not reported as misbehaviour
 		if err == ErrAddingVote {
 			cs.Logger.Trace("trying to add vote failed", "err", err)
-			cs.Logger.Warn("TODO - punish peer.")
 		}
 
 	default:
@@ -1697,6 +1709,15 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 	}
 }
 
+// reportPeer queues the behaviour of a peer for the manager to report,
+// dropping it rather than blocking when the queue is full.
+func (cs *ConsensusState) reportPeer(b behaviour.PeerBehaviour) {
+	select {
+	case cs.statsMsgQueue <- b:
+	default:
+	}
+}
+
 func (cs *ConsensusState) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	cs.Logger.Debug("Received tock", "timeout", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
 
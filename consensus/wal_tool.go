@@ -0,0 +1,244 @@
+package consensus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	auto "github.com/kardiachain/go-kardia/lib/autofile"
+	kcons "github.com/kardiachain/go-kardia/proto/kardiachain/consensus"
+)
+
+// WALEntry is a message read back from a WAL group together with its
+// position in the group.
+type WALEntry struct {
+	// Height the message belongs to. An EndHeightMessage belongs to the height
+	// it ends, messages preceding the first EndHeightMessage of a pruned WAL
+	// have an unknown height of -1.
+	Height int64
+	// Index of the group file the message starts in.
+	Index int
+	// Offset of the message within that file.
+	Offset int64
+	Msg    *TimedWALMessage
+}
+
+// WALReport summarises a pass over a WAL group.
+type WALReport struct {
+	Files    int
+	Messages int
+	// LastEndHeight is the height of the last good EndHeightMessage, -1 if
+	// there is none.
+	LastEndHeight int64
+	// Corruption is the first DataCorruptionError found, nil if the WAL is
+	// intact. CorruptIndex and CorruptOffset locate it.
+	Corruption    error
+	CorruptIndex  int
+	CorruptOffset int64
+	// Truncated is set by TruncateWAL once the WAL has been repaired.
+	Truncated bool
+
+	// position right after the last good EndHeightMessage
+	endIndex  int
+	endOffset int64
+}
+
+// ValidateWAL reads the whole WAL group at walFile and reports its content
+// and the first corruption found, if any.
+func ValidateWAL(walFile string) (*WALReport, error) {
+	return iterateWAL(walFile, func(*WALEntry) error { return nil })
+}
+
+// DumpWAL writes the messages of the WAL group at walFile belonging to the
+// heights [from, to] to w, one JSON encoded TimedWALMessage per line. A zero
+// bound leaves that side of the range open. Dumping stops at the first
+// corruption, which is returned in the report.
+func DumpWAL(walFile string, from, to int64, w io.Writer) (*WALReport, error) {
+	m := jsonpb.Marshaler{}
+	return iterateWAL(walFile, func(e *WALEntry) error {
+		if (from > 0 && e.Height < from) || (to > 0 && e.Height > to) {
+			return nil
+		}
+		pbMsg, err := WALToProto(e.Msg.Msg)
+		if err != nil {
+			return err
+		}
+		if err := m.Marshal(w, &kcons.TimedWALMessage{Time: e.Msg.Time, Msg: pbMsg}); err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "\n")
+		return err
+	})
+}
+
+// TruncateWAL repairs a corrupted WAL group by cutting it right after the last
+// good EndHeightMessage, so the node replays from a height boundary. Files
+// rotated after that point are removed and the file holding it becomes the
+// head. An intact WAL is left untouched.
+func TruncateWAL(walFile string) (*WALReport, error) {
+	report, err := ValidateWAL(walFile)
+	if err != nil || report.Corruption == nil {
+		return report, err
+	}
+	if report.LastEndHeight < 0 {
+		return report, errors.New("no EndHeightMessage found before the corruption, cannot truncate")
+	}
+
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return report, err
+	}
+	maxIndex := group.MaxIndex()
+	paths := make([]string, maxIndex+1)
+	for i := report.endIndex; i <= maxIndex; i++ {
+		paths[i] = group.FilePath(i)
+	}
+	group.Close()
+
+	for i := report.endIndex + 1; i <= maxIndex; i++ {
+		if err := os.Remove(paths[i]); err != nil {
+			return report, err
+		}
+	}
+	if err := os.Truncate(paths[report.endIndex], report.endOffset); err != nil {
+		return report, err
+	}
+	if report.endIndex != maxIndex {
+		if err := os.Rename(paths[report.endIndex], walFile); err != nil {
+			return report, err
+		}
+	}
+	report.Truncated = true
+	return report, nil
+}
+
+// JSONToWAL reads JSON encoded TimedWALMessages, as written by DumpWAL, from r
+// and writes them to a new binary WAL file at walFile. It returns the number
+// of messages written.
+func JSONToWAL(r io.Reader, walFile string) (int, error) {
+	f, err := os.OpenFile(walFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var (
+		dec = json.NewDecoder(r)
+		enc = NewWALEncoder(f)
+		n   int
+	)
+	for dec.More() {
+		var pbMsg kcons.TimedWALMessage
+		if err := jsonpb.UnmarshalNext(dec, &pbMsg); err != nil {
+			return n, fmt.Errorf("failed to decode message %d: %w", n, err)
+		}
+		msg, err := WALFromProto(pbMsg.Msg)
+		if err != nil {
+			return n, fmt.Errorf("failed to convert message %d: %w", n, err)
+		}
+		if err := enc.Encode(&TimedWALMessage{Time: pbMsg.Time, Msg: msg}); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, f.Sync()
+}
+
+// iterateWAL decodes every message of the WAL group at walFile in order and
+// passes it to fn. Decoding stops at the first corruption, which is recorded
+// in the report rather than returned as an error.
+func iterateWAL(walFile string, fn func(*WALEntry) error) (*WALReport, error) {
+	if _, err := os.Stat(walFile); err != nil {
+		return nil, err
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+	defer group.Close()
+
+	minIndex, maxIndex := group.MinIndex(), group.MaxIndex()
+	sizes := make([]int64, 0, maxIndex-minIndex+1)
+	for i := minIndex; i <= maxIndex; i++ {
+		info, err := os.Stat(group.FilePath(i))
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, info.Size())
+	}
+	// position maps an offset in the concatenated group to a file and an
+	// offset within it.
+	position := func(off int64) (int, int64) {
+		for i, size := range sizes {
+			if off < size || i == len(sizes)-1 {
+				return minIndex + i, off
+			}
+			off -= size
+		}
+		return maxIndex, off
+	}
+
+	gr, err := group.NewReader(minIndex)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	var (
+		cr     = &countingReader{r: gr}
+		dec    = NewWALDecoder(cr)
+		height = int64(-1)
+		report = &WALReport{
+			Files:         len(sizes),
+			LastEndHeight: -1,
+			endIndex:      minIndex,
+		}
+	)
+	for {
+		start := cr.n
+		msg, err := dec.Decode()
+		if err == io.EOF && cr.n > start {
+			err = DataCorruptionError{fmt.Errorf("truncated message: %v", err)}
+		}
+		if err == io.EOF {
+			return report, nil
+		}
+		if IsDataCorruptionError(err) {
+			report.Corruption = err
+			report.CorruptIndex, report.CorruptOffset = position(start)
+			return report, nil
+		}
+		if err != nil {
+			return report, err
+		}
+
+		entry := &WALEntry{Height: height, Msg: msg}
+		entry.Index, entry.Offset = position(start)
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			entry.Height = m.Height
+			height = m.Height + 1
+			report.LastEndHeight = m.Height
+			report.endIndex, report.endOffset = position(cr.n)
+		}
+		report.Messages++
+		if err := fn(entry); err != nil {
+			return report, err
+		}
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
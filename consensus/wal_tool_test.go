@@ -0,0 +1,128 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kcons "github.com/kardiachain/go-kardia/proto/kardiachain/consensus"
+)
+
+// writeWALGroup splits data over a group of three files rooted at walFile,
+// cutting through messages like a rotation of the head would.
+func writeWALGroup(t *testing.T, walFile string, data []byte) {
+	third := len(data) / 3
+	require.NoError(t, ioutil.WriteFile(fmt.Sprintf("%s.%03d", walFile, 0), data[:third], 0600))
+	require.NoError(t, ioutil.WriteFile(fmt.Sprintf("%s.%03d", walFile, 1), data[third:2*third], 0600))
+	require.NoError(t, ioutil.WriteFile(walFile, data[2*third:], 0600))
+}
+
+func TestValidateAndDumpWAL(t *testing.T) {
+	walDir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	data, err := WALWithNBlocks(t, 5)
+	require.NoError(t, err)
+	writeWALGroup(t, walFile, data)
+
+	report, err := ValidateWAL(walFile)
+	require.NoError(t, err)
+	assert.Nil(t, report.Corruption)
+	assert.Equal(t, 3, report.Files)
+	assert.Equal(t, int64(4), report.LastEndHeight)
+
+	// dump a height range
+	buf := new(bytes.Buffer)
+	_, err = DumpWAL(walFile, 2, 3, buf)
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(make([]byte, maxMsgSizeBytes), 4*maxMsgSizeBytes)
+	var lines, ends int
+	for scanner.Scan() {
+		var pbMsg kcons.TimedWALMessage
+		require.NoError(t, jsonpb.UnmarshalString(scanner.Text(), &pbMsg))
+		msg, err := WALFromProto(pbMsg.Msg)
+		require.NoError(t, err)
+		if m, ok := msg.(EndHeightMessage); ok {
+			assert.Contains(t, []int64{2, 3}, m.Height)
+			ends++
+		}
+		lines++
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, 2, ends)
+	assert.True(t, lines > ends && lines < report.Messages)
+
+	// convert to JSON and back
+	buf.Reset()
+	_, err = DumpWAL(walFile, 0, 0, buf)
+	require.NoError(t, err)
+	binFile := filepath.Join(walDir, "converted")
+	n, err := JSONToWAL(buf, binFile)
+	require.NoError(t, err)
+	assert.Equal(t, report.Messages, n)
+	converted, err := ioutil.ReadFile(binFile)
+	require.NoError(t, err)
+	assert.Equal(t, data, converted)
+}
+
+func TestTruncateWAL(t *testing.T) {
+	walDir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	// the generator stops once height 5 starts, the last EndHeightMessage is 4
+	data, err := WALWithNBlocks(t, 5)
+	require.NoError(t, err)
+
+	// an intact WAL is left untouched
+	writeWALGroup(t, walFile, data)
+	report, err := TruncateWAL(walFile)
+	require.NoError(t, err)
+	assert.False(t, report.Truncated)
+
+	// corrupt the middle file
+	middle := fmt.Sprintf("%s.%03d", walFile, 1)
+	content, err := ioutil.ReadFile(middle)
+	require.NoError(t, err)
+	content[len(content)/2] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(middle, content, 0600))
+
+	report, err = ValidateWAL(walFile)
+	require.NoError(t, err)
+	require.NotNil(t, report.Corruption)
+	assert.True(t, IsDataCorruptionError(report.Corruption))
+	lastGood := report.LastEndHeight
+	assert.True(t, lastGood >= 0 && lastGood < 4)
+
+	report, err = TruncateWAL(walFile)
+	require.NoError(t, err)
+	assert.True(t, report.Truncated)
+
+	report, err = ValidateWAL(walFile)
+	require.NoError(t, err)
+	assert.Nil(t, report.Corruption)
+	assert.Equal(t, lastGood, report.LastEndHeight)
+	_, err = os.Stat(walFile)
+	assert.NoError(t, err, "the head must remain")
+
+	// the repaired WAL ends with the last good EndHeightMessage
+	var last *WALEntry
+	_, err = iterateWAL(walFile, func(e *WALEntry) error {
+		last = e
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, EndHeightMessage{lastGood}, last.Msg.Msg)
+}
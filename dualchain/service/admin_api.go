@@ -0,0 +1,299 @@
+/*
+ *  Copyright 2019 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/ksml"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	// DualChain selects the watchers of the dual chain, read by the dual proxy.
+	DualChain = "dual"
+	// KardiaChain selects the watchers of the Kardia chain, read by the Kardia proxy.
+	KardiaChain = "kardia"
+)
+
+// WatcherArgs represents the arguments to add or update a watcher action of a watched smart contract.
+// Chain is either "dual", the default, or "kardia".
+type WatcherArgs struct {
+	Chain           string   `json:"chain"`
+	ContractAddress string   `json:"contractAddress"`
+	MasterContract  string   `json:"masterContract"`
+	Method          string   `json:"method"`
+	DualActions     []string `json:"dualActions"`
+	WatcherActions  []string `json:"watcherActions"`
+}
+
+// WatcherJSON represents a watcher action in JSON format
+type WatcherJSON struct {
+	Method         string   `json:"method"`
+	DualActions    []string `json:"dualActions"`
+	WatcherActions []string `json:"watcherActions"`
+	Disabled       bool     `json:"disabled"`
+}
+
+// WatchedContractJSON represents a watched smart contract with its abi and watcher actions in JSON format
+type WatchedContractJSON struct {
+	ContractAddress string         `json:"contractAddress"`
+	MasterContract  string         `json:"masterContract"`
+	ABI             string         `json:"abi"`
+	Version         uint64         `json:"version"`
+	Watchers        []*WatcherJSON `json:"watchers"`
+}
+
+// PrivateDualAdminAPI provides APIs to manage watcher actions, dual actions and contract abi at runtime.
+// It is not public, therefore it is only served over IPC unless "dualadmin" is explicitly listed in the RPC modules
+// of an endpoint listening on a loopback interface.
+// Every method applies to the dual chain unless the optional chain argument is "kardia", in which case the
+// watchers of the Kardia chain are updated, they are read by the Kardia proxy on every new block.
+type PrivateDualAdminAPI struct {
+	dualService *DualService
+	mtx         sync.Mutex
+}
+
+// NewPrivateDualAdminAPI creates a new Dual admin API.
+func NewPrivateDualAdminAPI(dualService *DualService) *PrivateDualAdminAPI {
+	return &PrivateDualAdminAPI{dualService: dualService}
+}
+
+// db returns the database holding the watchers of chain.
+func (s *PrivateDualAdminAPI) db(chain string) (types.StoreDB, error) {
+	switch chain {
+	case "", DualChain:
+		return s.dualService.groupDb, nil
+	case KardiaChain:
+		if s.dualService.kardiaDb == nil {
+			return nil, fmt.Errorf("kardia chain is not attached to the dual service")
+		}
+		return s.dualService.kardiaDb, nil
+	default:
+		return nil, fmt.Errorf("unknown chain %v", chain)
+	}
+}
+
+// chainArg returns the value of an optional chain argument.
+func chainArg(chain *string) string {
+	if chain == nil {
+		return ""
+	}
+	return *chain
+}
+
+// validateWatcher checks watcher args, including its KSML watcher actions, before they are stored.
+func (s *PrivateDualAdminAPI) validateWatcher(db types.StoreDB, args WatcherArgs) error {
+	if args.ContractAddress == "" {
+		return fmt.Errorf("contractAddress is required")
+	}
+	if args.Method == "" {
+		return fmt.Errorf("method is required")
+	}
+	a := db.ReadSmartContractAbi(args.ContractAddress)
+	if a != nil {
+		if _, ok := a.Methods[args.Method]; !ok {
+			if _, ok := a.Events[args.Method]; !ok {
+				return fmt.Errorf("method %v is not found in abi of %v", args.Method, args.ContractAddress)
+			}
+		}
+	}
+	if len(args.WatcherActions) > 0 {
+		if err := ksml.ValidatePatterns(args.WatcherActions, a); err != nil {
+			return fmt.Errorf("invalid watcher actions: %v", err)
+		}
+	}
+	return nil
+}
+
+// isWatched checks if method of contract has a watcher action, either enabled or disabled.
+func (s *PrivateDualAdminAPI) isWatched(db types.StoreDB, address string, method string) bool {
+	if db.ReadEvent(address, method) != nil {
+		return true
+	}
+	for _, watcher := range db.ReadDisabledWatchers(address) {
+		if watcher.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PrivateDualAdminAPI) writeWatcher(db types.StoreDB, args WatcherArgs) uint64 {
+	db.WriteWatcher(args.ContractAddress, args.MasterContract, &types.Watcher{
+		Method:         args.Method,
+		DualActions:    args.DualActions,
+		WatcherActions: args.WatcherActions,
+	})
+	return db.IncreaseWatcherVersion(args.ContractAddress)
+}
+
+// AddWatcher adds a new watcher action to a smart contract and returns the new version of its watchers.
+func (s *PrivateDualAdminAPI) AddWatcher(args WatcherArgs) (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	db, err := s.db(args.Chain)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.validateWatcher(db, args); err != nil {
+		return 0, err
+	}
+	if s.isWatched(db, args.ContractAddress, args.Method) {
+		return 0, fmt.Errorf("watcher %v of %v already exists", args.Method, args.ContractAddress)
+	}
+	return s.writeWatcher(db, args), nil
+}
+
+// UpdateWatcher replaces an existing watcher action of a smart contract and returns the new version of its watchers.
+// A disabled watcher action is enabled again.
+func (s *PrivateDualAdminAPI) UpdateWatcher(args WatcherArgs) (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	db, err := s.db(args.Chain)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.validateWatcher(db, args); err != nil {
+		return 0, err
+	}
+	if !s.isWatched(db, args.ContractAddress, args.Method) {
+		return 0, fmt.Errorf("watcher %v of %v is not found", args.Method, args.ContractAddress)
+	}
+	return s.writeWatcher(db, args), nil
+}
+
+// DisableWatcher disables a watcher action of a smart contract and returns the new version of its watchers.
+func (s *PrivateDualAdminAPI) DisableWatcher(contractAddress string, method string, chain *string) (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	db, err := s.db(chainArg(chain))
+	if err != nil {
+		return 0, err
+	}
+	if !db.DisableWatcher(contractAddress, method) {
+		return 0, fmt.Errorf("watcher %v of %v is not found", method, contractAddress)
+	}
+	return db.IncreaseWatcherVersion(contractAddress), nil
+}
+
+// Watchers returns abi, version and all watcher actions of a smart contract.
+func (s *PrivateDualAdminAPI) Watchers(contractAddress string, chain *string) (*WatchedContractJSON, error) {
+	db, err := s.db(chainArg(chain))
+	if err != nil {
+		return nil, err
+	}
+	masterSmc, watchers := db.ReadEvents(contractAddress)
+	result := &WatchedContractJSON{
+		ContractAddress: contractAddress,
+		MasterContract:  masterSmc,
+		ABI:             db.ReadRawSmartContractAbi(contractAddress),
+		Version:         db.ReadWatcherVersion(contractAddress),
+		Watchers:        make([]*WatcherJSON, 0),
+	}
+	for _, watcher := range watchers {
+		result.Watchers = append(result.Watchers, newWatcherJSON(watcher, false))
+	}
+	for _, watcher := range db.ReadDisabledWatchers(contractAddress) {
+		result.Watchers = append(result.Watchers, newWatcherJSON(watcher, true))
+	}
+	return result, nil
+}
+
+func newWatcherJSON(watcher *types.Watcher, disabled bool) *WatcherJSON {
+	return &WatcherJSON{
+		Method:         watcher.Method,
+		DualActions:    watcher.DualActions,
+		WatcherActions: watcher.WatcherActions,
+		Disabled:       disabled,
+	}
+}
+
+// SetABI adds or updates abi of a smart contract and returns the new version of its watchers.
+func (s *PrivateDualAdminAPI) SetABI(contractAddress string, contractAbi string, chain *string) (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if contractAddress == "" {
+		return 0, fmt.Errorf("contractAddress is required")
+	}
+	if contractAbi != "" {
+		abiStr := strings.Replace(contractAbi, "'", "\"", -1)
+		if _, err := abi.JSON(strings.NewReader(abiStr)); err != nil {
+			return 0, fmt.Errorf("invalid abi: %v", err)
+		}
+	}
+	db, err := s.db(chainArg(chain))
+	if err != nil {
+		return 0, err
+	}
+	db.WriteSmartContractAbi(contractAddress, contractAbi)
+	return db.IncreaseWatcherVersion(contractAddress), nil
+}
+
+// SetDualAction maps a dual action to the smart contract it is executed on and returns the new version of its watchers.
+func (s *PrivateDualAdminAPI) SetDualAction(action string, contractAddress string, chain *string) (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if action == "" {
+		return 0, fmt.Errorf("action is required")
+	}
+	if contractAddress == "" {
+		return 0, fmt.Errorf("contractAddress is required")
+	}
+	db, err := s.db(chainArg(chain))
+	if err != nil {
+		return 0, err
+	}
+	db.WriteDualAction(action, contractAddress)
+	return db.IncreaseWatcherVersion(contractAddress), nil
+}
+
+// RemoveDualAction removes a dual action and returns the new version of the watchers of its smart contract.
+func (s *PrivateDualAdminAPI) RemoveDualAction(action string, chain *string) (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	db, err := s.db(chainArg(chain))
+	if err != nil {
+		return 0, err
+	}
+	contractAddress, ok := db.ReadDualActions()[action]
+	if !ok {
+		return 0, fmt.Errorf("dual action %v is not found", action)
+	}
+	db.DeleteDualAction(action)
+	return db.IncreaseWatcherVersion(contractAddress), nil
+}
+
+// DualActions returns all dual actions with the address of the smart contract they are executed on.
+func (s *PrivateDualAdminAPI) DualActions(chain *string) (map[string]string, error) {
+	db, err := s.db(chainArg(chain))
+	if err != nil {
+		return nil, err
+	}
+	return db.ReadDualActions(), nil
+}
@@ -0,0 +1,67 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/kai/storage"
+)
+
+func TestDualAdminAPIChains(t *testing.T) {
+	dualService := &DualService{groupDb: storage.NewMemoryDatabase()}
+	api := NewPrivateDualAdminAPI(dualService)
+	kardia := KardiaChain
+	args := WatcherArgs{
+		Chain:           KardiaChain,
+		ContractAddress: "0x00000000000000000000000000000000736D6332",
+		MasterContract:  "0x00000000000000000000000000000000736D6331",
+		Method:          "deposit",
+		DualActions:     []string{"depositAction"},
+	}
+
+	// the Kardia chain must be attached first
+	_, err := api.AddWatcher(args)
+	assert.Error(t, err)
+
+	dualService.SetKardiaDB(storage.NewMemoryDatabase())
+	_, err = api.AddWatcher(args)
+	require.NoError(t, err)
+
+	// the watcher is only added to the Kardia chain, where the Kardia proxy reads it
+	assert.NotNil(t, dualService.kardiaDb.ReadEvent(args.ContractAddress, args.Method))
+	assert.Nil(t, dualService.groupDb.ReadEvent(args.ContractAddress, args.Method))
+	watchers, err := api.Watchers(args.ContractAddress, &kardia)
+	require.NoError(t, err)
+	assert.Len(t, watchers.Watchers, 1)
+	watchers, err = api.Watchers(args.ContractAddress, nil)
+	require.NoError(t, err)
+	assert.Len(t, watchers.Watchers, 0)
+
+	_, err = api.DisableWatcher(args.ContractAddress, args.Method, &kardia)
+	require.NoError(t, err)
+	assert.Nil(t, dualService.kardiaDb.ReadEvent(args.ContractAddress, args.Method))
+
+	unknown := "neo"
+	_, err = api.DualActions(&unknown)
+	assert.Error(t, err)
+}
@@ -52,6 +52,8 @@ type DualService struct {
 	// DB interfaces
 	groupDb types.StoreDB // Local key-value store endpoint. Each use types should use wrapper layer with unique prefixes.
 
+	kardiaDb types.StoreDB // Database of the Kardia chain, holding the watchers read by the Kardia proxy
+
 	// Handlers
 	eventPool           *event_pool.Pool
 	blockchain          *blockchain.DualBlockChain
@@ -160,6 +162,11 @@ func (s *DualService) SetDualBlockChainManager(bcManager *blockchain.DualBlockCh
 	s.dualBlockOperations.SetDualBlockChainManager(bcManager)
 }
 
+// SetKardiaDB sets the database of the Kardia chain, allowing its watchers to be managed at runtime.
+func (s *DualService) SetKardiaDB(db types.StoreDB) {
+	s.kardiaDb = db
+}
+
 func (s *DualService) IsListening() bool  { return true } // Always listening
 func (s *DualService) NetVersion() uint64 { return s.networkID }
 func (s *DualService) DB() types.StoreDB  { return s.groupDb }
@@ -195,6 +202,12 @@ func (s *DualService) APIs() []rpc.API {
 			Service:   NewPublicDualAPI(s),
 			Public:    true,
 		},
+		{
+			Namespace: "dualadmin",
+			Version:   "1.0",
+			Service:   NewPrivateDualAdminAPI(s),
+			Public:    false,
+		},
 	}
 }
 
@@ -26,6 +26,7 @@ import (
 	"strings"
 
 	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/mainchain/gasprice"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
 	"github.com/kardiachain/go-kardia/node"
 )
@@ -74,6 +75,7 @@ var DefaultConfig = node.Config{
 		NetworkId: privateNetworkId,
 		DBInfo:    storage.NewLevelDbInfo(MainChainDataDir, DefaultDbCache, DefaultDbHandles),
 		AcceptTxs: 1, // 1 is to allow new transactions, 0 is not
+		GPO:       gasprice.DefaultConfig,
 		// todo: uncomment and update config when we support dual node
 		//Genesis:   genesis.DefaultTestnetFullGenesisBlock(configs.GenesisAccounts, configs.GenesisContracts),
 	},
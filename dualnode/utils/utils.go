@@ -19,6 +19,7 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -94,7 +95,8 @@ func ExecuteKardiaSmartContract(txPool *tx_pool.TxPool, bc base.BaseBlockChain,
 		sender := bc.P2P().Address()
 		currentHeader := bc.CurrentHeader()
 		stateDb := txPool.State()
-		gasUsed, err := ksml.EstimateGas(*sender, common.HexToAddress(contractAddress), currentHeader, bc, stateDb, input)
+		gasUsed, err := ksml.EstimateGas(context.Background(), *sender, common.HexToAddress(contractAddress), currentHeader, bc, stateDb, input,
+			uint64(ksml.MaximumGasToCallFunction))
 		if err != nil {
 			return nil, err
 		}
@@ -155,39 +155,45 @@ func updateState(logger log.Logger, state LastestBlockState, blockID types.Block
 }
 
 func getBeginBlockValidatorInfo(b *types.Block, store Store) stypes.LastCommitInfo {
-	lastCommit := b.LastCommit()
-	voteInfos := make([]stypes.VoteInfo, lastCommit.Size())
 	// block.Height=1 -> LastCommitInfo.Votes are empty.
 	// Remember that the first LastCommit is intentionally empty, so it makes
 	// sense for LastCommitInfo.Votes to also be empty.
-	if b.Height() > 1 {
-		lastValSet, err := store.LoadValidators(b.Height() - 1)
-		if err != nil {
-			panic(err)
-		}
+	if b.Height() <= 1 {
+		return stypes.LastCommitInfo{Votes: make([]stypes.VoteInfo, b.LastCommit().Size())}
+	}
+	lastValSet, err := store.LoadValidators(b.Height() - 1)
+	if err != nil {
+		panic(err)
+	}
+	return LastCommitInfo(b.Height(), b.LastCommit(), lastValSet)
+}
+
+// LastCommitInfo returns the votes of the validators of the previous height
+// in the last commit of the block at the given height, as passed to the
+// staking FinalizeCommit.
+func LastCommitInfo(height uint64, lastCommit *types.Commit, lastValSet *types.ValidatorSet) stypes.LastCommitInfo {
+	// Sanity check that commit size matches validator set size - only applies
+	// after first block.
+	var (
+		commitSize = lastCommit.Size()
+		valSetLen  = len(lastValSet.Validators)
+	)
+	if commitSize != valSetLen {
+		panic(fmt.Sprintf("commit size (%d) doesn't match valset length (%d) at height %d\n\n%v\n\n%v",
+			commitSize, valSetLen, height, lastCommit.Signatures, lastValSet.Validators))
+	}
 
-		// Sanity check that commit size matches validator set size - only applies
-		// after first block.
-		var (
-			commitSize = lastCommit.Size()
-			valSetLen  = len(lastValSet.Validators)
-		)
-		if commitSize != valSetLen {
-			panic(fmt.Sprintf("commit size (%d) doesn't match valset length (%d) at height %d\n\n%v\n\n%v",
-				commitSize, valSetLen, b.Height(), lastCommit.Signatures, lastValSet.Validators))
+	voteInfos := make([]stypes.VoteInfo, commitSize)
+	for i, val := range lastValSet.Validators {
+		commitSig := lastCommit.Signatures[i]
+		voteInfos[i] = stypes.VoteInfo{
+			Address:         val.Address,
+			VotingPower:     big.NewInt(int64(val.VotingPower)),
+			SignedLastBlock: commitSig.Signature != nil,
 		}
 
-		for i, val := range lastValSet.Validators {
-			commitSig := lastCommit.Signatures[i]
-			voteInfos[i] = stypes.VoteInfo{
-				Address:         val.Address,
-				VotingPower:     big.NewInt(int64(val.VotingPower)),
-				SignedLastBlock: commitSig.Signature != nil,
-			}
-
-			if b.Height() > 63004 {
-				voteInfos[i].SignedLastBlock = true
-			}
+		if height > 63004 {
+			voteInfos[i].SignedLastBlock = true
 		}
 	}
 
@@ -20,6 +20,7 @@ package cstate
 
 import (
 	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/types"
 )
 
@@ -30,3 +31,9 @@ const ValSetCheckpointInterval = valSetCheckpointInterval
 func SaveValidatorsInfo(db kaidb.Database, height, lastHeightChanged uint64, valSet *types.ValidatorSet) {
 	saveValidatorsInfo(db, height, lastHeightChanged, valSet)
 }
+
+// UpdateState is an alias for the private updateState function in
+// execution.go, exported exclusively and explicitly for testing.
+func UpdateState(state LastestBlockState, blockID types.BlockID, header *types.Header, validatorUpdates []*types.Validator) (LastestBlockState, error) {
+	return updateState(log.New(), state, blockID, header, validatorUpdates)
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cstate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// RollbackBlockStore is the block store Rollback reads the rolled back blocks from.
+type RollbackBlockStore interface {
+	LoadBlockMeta(height uint64) *types.BlockMeta
+}
+
+// Rollback overwrites the latest state saved in db with the state after the
+// block at height. The state is rebuilt from the validator sets saved for every
+// height, and from the headers of the blocks at height and height+1, the latter
+// carrying the app hash.
+func Rollback(db kaidb.Database, blockStore RollbackBlockStore, height uint64) (LastestBlockState, error) {
+	s := &dbStore{db: db}
+	current := s.Load()
+	if current.IsEmpty() {
+		return current, errors.New("no state found")
+	}
+	if height >= current.LastBlockHeight {
+		return current, fmt.Errorf("rollback height %d must be below the state height %d", height, current.LastBlockHeight)
+	}
+	if height < current.InitialHeight {
+		return current, fmt.Errorf("rollback height %d is below the initial height %d", height, current.InitialHeight)
+	}
+
+	rollbackBlock := blockStore.LoadBlockMeta(height)
+	if rollbackBlock == nil {
+		return current, fmt.Errorf("block at height %d not found", height)
+	}
+	nextBlock := blockStore.LoadBlockMeta(height + 1)
+	if nextBlock == nil {
+		return current, fmt.Errorf("block at height %d not found", height+1)
+	}
+
+	lastVals, err := s.LoadValidators(height)
+	if err != nil {
+		return current, err
+	}
+	vals, err := s.LoadValidators(height + 1)
+	if err != nil {
+		return current, err
+	}
+	nextVals, err := s.LoadValidators(height + 2)
+	if err != nil {
+		return current, err
+	}
+	// the validators saved for height+2 are the next validators of the state
+	// at height, along with the last height they changed at
+	nextValsInfo := loadValidatorsInfo(db, height+2)
+
+	state := LastestBlockState{
+		ChainID:       current.ChainID,
+		InitialHeight: current.InitialHeight,
+
+		LastBlockHeight: height,
+		LastBlockID:     rollbackBlock.BlockID,
+		LastBlockTime:   rollbackBlock.Header.Time,
+
+		NextValidators:              nextVals,
+		Validators:                  vals,
+		LastValidators:              lastVals,
+		LastHeightValidatorsChanged: nextValsInfo.LastHeightChanged,
+
+		// updateState carries the consensus params over unchanged
+		ConsensusParams:                  current.ConsensusParams,
+		LastHeightConsensusParamsChanged: current.LastHeightConsensusParamsChanged,
+
+		AppHash: nextBlock.Header.AppHash,
+	}
+	s.Save(state)
+	return state, nil
+}
@@ -0,0 +1,111 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cstate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+type mockRollbackBlockStore map[uint64]*types.BlockMeta
+
+func (bs mockRollbackBlockStore) LoadBlockMeta(height uint64) *types.BlockMeta {
+	return bs[height]
+}
+
+func TestRollback(t *testing.T) {
+	stateDB := memorydb.New()
+	stateStore := cstate.NewStore(stateDB)
+	blockStore := mockRollbackBlockStore{}
+
+	valSet, _ := types.RandValidatorSet(2, 10)
+	state := cstate.LastestBlockState{
+		ChainID:         "kaicon",
+		InitialHeight:   1,
+		LastBlockID:     types.NewZeroBlockID(),
+		LastBlockTime:   time.Now(),
+		Validators:      valSet,
+		LastValidators:  valSet,
+		NextValidators:  valSet.CopyIncrementProposerPriority(1),
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore.Save(state)
+
+	// apply 5 blocks, the validator set changing at block 2
+	states := []cstate.LastestBlockState{state}
+	for height := uint64(1); height <= 5; height++ {
+		header := &types.Header{
+			Height:  height,
+			Time:    state.LastBlockTime.Add(time.Second),
+			AppHash: state.AppHash,
+		}
+		blockID := types.BlockID{Hash: common.BytesToHash([]byte{byte(height)})}
+		blockStore[height] = &types.BlockMeta{BlockID: blockID, Header: header}
+
+		var valUpdates []*types.Validator
+		if height == 2 {
+			val, _ := types.RandValidator(true, 20)
+			valUpdates = append(valUpdates, val)
+		}
+		var err error
+		state, err = cstate.UpdateState(state, blockID, header, valUpdates)
+		require.NoError(t, err)
+		state.InitialHeight = 1
+		state.AppHash = common.BytesToHash([]byte{0xa, byte(height)})
+		stateStore.Save(state)
+		states = append(states, state)
+	}
+
+	// the rollback height must be below the state height
+	_, err := cstate.Rollback(stateDB, blockStore, 5)
+	assert.Error(t, err)
+	_, err = cstate.Rollback(stateDB, blockStore, 0)
+	assert.Error(t, err)
+
+	for _, height := range []uint64{3, 1} {
+		rolledBack, err := cstate.Rollback(stateDB, blockStore, height)
+		require.NoError(t, err)
+		want := states[height]
+		for _, got := range []cstate.LastestBlockState{rolledBack, stateStore.Load()} {
+			assert.Equal(t, height, got.LastBlockHeight)
+			assert.Equal(t, want.LastBlockID, got.LastBlockID)
+			assert.True(t, want.LastBlockTime.Equal(got.LastBlockTime))
+			assert.Equal(t, want.AppHash, got.AppHash)
+			assert.Equal(t, want.LastHeightValidatorsChanged, got.LastHeightValidatorsChanged)
+			assert.Equal(t, want.LastHeightConsensusParamsChanged, got.LastHeightConsensusParamsChanged)
+			assert.Equal(t, want.ConsensusParams, got.ConsensusParams)
+			for _, vals := range [][2]*types.ValidatorSet{
+				{want.LastValidators, got.LastValidators},
+				{want.Validators, got.Validators},
+				{want.NextValidators, got.NextValidators},
+			} {
+				assert.Equal(t, vals[0].Hash(), vals[1].Hash())
+				assert.Equal(t, vals[0].GetProposer().Address, vals[1].GetProposer().Address)
+			}
+		}
+	}
+}
@@ -174,3 +174,26 @@ func TestCopyOfCopy(t *testing.T) {
 		t.Fatalf("2nd copy fail, expected 42, got %v", got)
 	}
 }
+
+// TestSetStorage tests that a replaced storage hides the original one and
+// follows the state object into copies.
+func TestSetStorage(t *testing.T) {
+	sdb, _ := New(log.New(), common.Hash{}, NewDatabase(memorydb.New()))
+	addr := common.HexToAddress("aaaa")
+	original, replaced := common.BytesToHash([]byte{1}), common.BytesToHash([]byte{2})
+	sdb.SetState(addr, original, common.BytesToHash([]byte{1}))
+
+	sdb.SetStorage(addr, map[common.Hash]common.Hash{replaced: common.BytesToHash([]byte{2})})
+	if got := sdb.GetState(addr, original); got != (common.Hash{}) {
+		t.Fatalf("original slot should be hidden, got %x", got)
+	}
+	sdb.SetState(addr, original, common.BytesToHash([]byte{3}))
+
+	cpy := sdb.Copy()
+	if got := cpy.GetState(addr, replaced); got != common.BytesToHash([]byte{2}) {
+		t.Fatalf("replaced slot mismatch, got %x", got)
+	}
+	if got := cpy.GetState(addr, original); got != common.BytesToHash([]byte{3}) {
+		t.Fatalf("updated slot mismatch, got %x", got)
+	}
+}
@@ -95,6 +95,13 @@ func WriteBlockInfo(db kaidb.Writer, hash common.Hash, height uint64, blockInfo
 	}
 }
 
+// DeleteBlockInfo removes the block info belonging to a block.
+func DeleteBlockInfo(db kaidb.KeyValueWriter, hash common.Hash, height uint64) {
+	if err := db.Delete(blockInfoKey(height, hash)); err != nil {
+		log.Crit("Failed to delete block info", "err", err)
+	}
+}
+
 // WriteCanonicalHash stores the hash assigned to a canonical block height.
 func WriteCanonicalHash(db kaidb.Writer, hash common.Hash, height uint64) {
 	if err := db.Put(headerHashKey(height), hash.Bytes()); err != nil {
@@ -113,18 +120,7 @@ func WriteHeadBlockHash(db kaidb.Writer, hash common.Hash) {
 func WriteEvent(db kaidb.Writer, smc *types.KardiaSmartcontract) {
 	if smc.SmcAbi != "" {
 		// Write contract abi
-		smartContract := SmartContract{
-			Address: smc.SmcAddress,
-			ABI:     smc.SmcAbi,
-		}
-		encodedData, err := rlp.EncodeToBytes(smartContract)
-		if err != nil {
-			log.Error("failed to encode smartContract Data")
-		}
-		abiKey := contractAbiKey(smc.SmcAddress)
-		if err := db.Put(abiKey, encodedData); err != nil {
-			log.Error("Failed to store dualAction", "err", err)
-		}
+		WriteSmartContractAbi(db, smc.SmcAddress, smc.SmcAbi)
 	}
 
 	// Write master contract abi
@@ -132,14 +128,7 @@ func WriteEvent(db kaidb.Writer, smc *types.KardiaSmartcontract) {
 		Address: smc.MasterSmc,
 		ABI:     smc.MasterAbi,
 	}
-	encodedData, err := rlp.EncodeToBytes(masterSmc)
-	if err != nil {
-		log.Error("failed to encode smartContract Data")
-	}
-	abiKey := contractAbiKey(masterSmc.Address)
-	if err := db.Put(abiKey, encodedData); err != nil {
-		log.Error("Failed to store dualAction", "err", err)
-	}
+	WriteSmartContractAbi(db, masterSmc.Address, masterSmc.ABI)
 
 	events := make([]string, 0)
 
@@ -475,6 +464,199 @@ func ReadSmartContractAbi(db kaidb.Reader, address string) *abi.ABI {
 	return nil
 }
 
+// ReadRawSmartContractAbi gets watched smart contract abi as it was stored, without decoding it
+func ReadRawSmartContractAbi(db kaidb.Reader, address string) string {
+	data, err := db.Get(contractAbiKey(address))
+	if err != nil || data == nil {
+		return ""
+	}
+	var entry SmartContract
+	if err := rlp.DecodeBytes(data, &entry); err != nil {
+		log.Error("Invalid event lookup rlp", "err", err)
+		return ""
+	}
+	return entry.ABI
+}
+
+// WriteSmartContractAbi stores abi of a watched smart contract. abi may be empty since there are chains
+// which do not use same standard as ETH.
+func WriteSmartContractAbi(db kaidb.Writer, address string, abi string) {
+	encodedData, err := rlp.EncodeToBytes(SmartContract{
+		Address: address,
+		ABI:     abi,
+	})
+	if err != nil {
+		log.Error("failed to encode smartContract Data", "err", err)
+		return
+	}
+	if err := db.Put(contractAbiKey(address), encodedData); err != nil {
+		log.Error("Failed to store smart contract abi", "err", err, "address", address)
+	}
+}
+
+// readKardiaEvents gets the list of watched events of a smart contract
+func readKardiaEvents(db kaidb.Reader, address string) *KardiaEvents {
+	data, err := db.Get(eventsKey(address))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var events KardiaEvents
+	if err := rlp.DecodeBytes(data, &events); err != nil {
+		log.Error("Invalid event lookup rlp", "err", err)
+		return nil
+	}
+	return &events
+}
+
+// writeKardiaEvents stores the list of watched events of a smart contract
+func writeKardiaEvents(db kaidb.Writer, address string, events *KardiaEvents) {
+	encodedEvents, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Error("Failed to encode events list", "err", err, "contract", address)
+		return
+	}
+	if err := db.Put(eventsKey(address), encodedEvents); err != nil {
+		log.Error("Failed to store events list", "err", err, "contract", address)
+	}
+}
+
+// WriteWatcher adds or replaces a single watcher action of a watched smart contract. If the watcher
+// was disabled before, it is enabled again. masterSmc is kept unchanged if it is empty.
+func WriteWatcher(db kaidb.Database, address string, masterSmc string, watcher *types.Watcher) {
+	data, err := rlp.EncodeToBytes(watcher)
+	if err != nil {
+		log.Error("Failed to encode event", "err", err, "method", watcher.Method, "contract", address)
+		return
+	}
+	key := eventKey(address, watcher.Method)
+	if err := db.Put(key, data); err != nil {
+		log.Error("Failed to store event", "err", err, "method", watcher.Method, "contract", address)
+		return
+	}
+	if err := db.Delete(disabledWatcherKey(address, watcher.Method)); err != nil {
+		log.Error("Failed to delete disabled event", "err", err, "method", watcher.Method, "contract", address)
+	}
+
+	events := readKardiaEvents(db, address)
+	if events == nil {
+		events = &KardiaEvents{Events: make([]string, 0)}
+	}
+	if masterSmc != "" {
+		events.MasterSmc = masterSmc
+	}
+	hexKey := common.Bytes2Hex(key)
+	for _, evt := range events.Events {
+		if evt == hexKey {
+			writeKardiaEvents(db, address, events)
+			return
+		}
+	}
+	events.Events = append(events.Events, hexKey)
+	writeKardiaEvents(db, address, events)
+}
+
+// DisableWatcher removes a watcher action from the watched events of a smart contract and keeps it
+// aside, so that it can still be listed and enabled again by WriteWatcher.
+// It returns false if the watcher action is not found.
+func DisableWatcher(db kaidb.Database, address string, method string) bool {
+	key := eventKey(address, method)
+	data, err := db.Get(key)
+	if err != nil || len(data) == 0 {
+		return false
+	}
+	if err := db.Put(disabledWatcherKey(address, method), data); err != nil {
+		log.Error("Failed to store disabled event", "err", err, "method", method, "contract", address)
+		return false
+	}
+	if err := db.Delete(key); err != nil {
+		log.Error("Failed to delete event", "err", err, "method", method, "contract", address)
+		return false
+	}
+
+	if events := readKardiaEvents(db, address); events != nil {
+		hexKey := common.Bytes2Hex(key)
+		remaining := make([]string, 0, len(events.Events))
+		for _, evt := range events.Events {
+			if evt != hexKey {
+				remaining = append(remaining, evt)
+			}
+		}
+		events.Events = remaining
+		writeKardiaEvents(db, address, events)
+	}
+	return true
+}
+
+// ReadDisabledWatchers gets all disabled watcher actions of a smart contract
+func ReadDisabledWatchers(db kaidb.Iteratee, address string) []*types.Watcher {
+	it := db.NewIterator(disabledWatcherKey(address, ""), nil)
+	defer it.Release()
+
+	watchers := make([]*types.Watcher, 0)
+	for it.Next() {
+		var watcher types.Watcher
+		if err := rlp.DecodeBytes(it.Value(), &watcher); err != nil {
+			log.Error("Invalid watcherAction", "err", err)
+			continue
+		}
+		watchers = append(watchers, &watcher)
+	}
+	return watchers
+}
+
+// WriteDualAction maps a dual action to the smart contract it is executed on, see ReadEventFromDualAction.
+func WriteDualAction(db kaidb.Database, action string, address string) {
+	abiKey := contractAbiKey(address)
+	if has, _ := db.Has(abiKey); !has {
+		WriteSmartContractAbi(db, address, "")
+	}
+	if err := db.Put(dualActionKey(action), abiKey); err != nil {
+		log.Error("Failed to store dualAction", "err", err, "action", action)
+	}
+}
+
+// DeleteDualAction removes a dual action
+func DeleteDualAction(db kaidb.Writer, action string) {
+	if err := db.Delete(dualActionKey(action)); err != nil {
+		log.Error("Failed to delete dualAction", "err", err, "action", action)
+	}
+}
+
+// ReadDualActions gets all dual actions, mapped to the address of the smart contract they are executed on
+func ReadDualActions(db kaidb.Iteratee) map[string]string {
+	it := db.NewIterator(dualActionPrefix, nil)
+	defer it.Release()
+
+	actions := make(map[string]string)
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if len(value) < len(contractAbiPrefix) {
+			continue
+		}
+		actions[string(key[len(dualActionPrefix):])] = string(value[len(contractAbiPrefix):])
+	}
+	return actions
+}
+
+// ReadWatcherVersion gets the version of the watchers, dual actions and abi of a smart contract.
+// The version is increased every time they are changed at runtime.
+func ReadWatcherVersion(db kaidb.Reader, address string) uint64 {
+	data, _ := db.Get(watcherVersionKey(address))
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// IncreaseWatcherVersion increases the version of the watchers of a smart contract and returns the new version
+func IncreaseWatcherVersion(db kaidb.Database, address string) uint64 {
+	version := ReadWatcherVersion(db, address) + 1
+	if err := db.Put(watcherVersionKey(address), encodeBlockHeight(version)); err != nil {
+		log.Error("Failed to store watcher version", "err", err, "contract", address)
+	}
+	return version
+}
+
 // ReadBloomBits retrieves the compressed bloom bit vector belonging to the given
 // section and bit index from the.
 func ReadBloomBits(db kaidb.Reader, bit uint, section uint64, head common.Hash) ([]byte, error) {
@@ -493,6 +675,14 @@ func WriteBloomBits(db kaidb.Writer, bit uint, section uint64, head common.Hash,
 	}
 }
 
+// DeleteBloomBits removes the compressed bloom bits vector belonging to the
+// given section and bit index.
+func DeleteBloomBits(db kaidb.KeyValueWriter, bit uint, section uint64, head common.Hash) {
+	if err := db.Delete(bloomBitsKey(bit, section, head)); err != nil {
+		log.Crit("Failed to delete bloom bits", "err", err)
+	}
+}
+
 // ReadBlockMeta returns the BlockMeta for the given height.
 // If no block is found for the given height, it returns nil.
 func ReadBlockMeta(db kaidb.Reader, height uint64) *types.BlockMeta {
@@ -678,6 +868,43 @@ func WriteAppHash(db kaidb.KeyValueWriter, height uint64, hash common.Hash) {
 	_ = db.Put(calcAppHashKey(height), hash.Bytes())
 }
 
+// DeleteAppHash ...
+func DeleteAppHash(db kaidb.KeyValueWriter, height uint64) {
+	_ = db.Delete(calcAppHashKey(height))
+}
+
+// WriteStakingEvents stores the staking events of a block
+func WriteStakingEvents(db kaidb.KeyValueWriter, height uint64, events []*types.StakingEvent) {
+	data, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Crit("Failed to encode staking events", "err", err)
+	}
+	if err := db.Put(stakingEventsKey(height), data); err != nil {
+		log.Crit("Failed to store staking events", "err", err)
+	}
+}
+
+// ReadStakingEvents retrieves the staking events of a block, nil if the block has none
+func ReadStakingEvents(db kaidb.KeyValueReader, height uint64) []*types.StakingEvent {
+	data, _ := db.Get(stakingEventsKey(height))
+	if len(data) == 0 {
+		return nil
+	}
+	var events []*types.StakingEvent
+	if err := rlp.DecodeBytes(data, &events); err != nil {
+		log.Error("Invalid staking events RLP", "height", height, "err", err)
+		return nil
+	}
+	return events
+}
+
+// DeleteStakingEvents removes the staking events of a block
+func DeleteStakingEvents(db kaidb.KeyValueWriter, height uint64) {
+	if err := db.Delete(stakingEventsKey(height)); err != nil {
+		log.Crit("Failed to delete staking events", "err", err)
+	}
+}
+
 // mustEncode proto encodes a proto.message and panics if fails
 func mustEncode(pb proto.Message) []byte {
 	bz, err := proto.Marshal(pb)
@@ -19,6 +19,7 @@
 package kvstore
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -151,3 +152,87 @@ func TestCanonicalMappingStorage(t *testing.T) {
 		t.Fatalf("Deleted canonical mapping returned: %v", entry)
 	}
 }
+
+// Tests watcher actions and dual actions management at runtime.
+func TestWatcherStorage(t *testing.T) {
+	db := memorydb.New()
+	address, master := "0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"
+
+	WriteEvent(db, &types.KardiaSmartcontract{
+		SmcAddress: address,
+		MasterSmc:  master,
+		Watchers:   types.Watchers{{Method: "deposit", DualActions: []string{"withdraw"}}},
+	})
+	WriteWatcher(db, address, "", &types.Watcher{Method: "release", WatcherActions: []string{"${fn:ping()}"}})
+	if masterSmc, watchers := ReadEvents(db, address); masterSmc != master || len(watchers) != 2 {
+		t.Fatalf("Watchers mismatch: have %v %v, want %v and 2 watchers", masterSmc, watchers, master)
+	}
+
+	if !DisableWatcher(db, address, "deposit") {
+		t.Fatalf("Failed to disable watcher")
+	}
+	if DisableWatcher(db, address, "deposit") {
+		t.Fatalf("Disabled watcher was disabled again")
+	}
+	if watcher := ReadEvent(db, address, "deposit"); watcher != nil {
+		t.Fatalf("Disabled watcher returned: %v", watcher)
+	}
+	if _, watchers := ReadEvents(db, address); len(watchers) != 1 || watchers[0].Method != "release" {
+		t.Fatalf("Watchers mismatch after disabling: %v", watchers)
+	}
+	if disabled := ReadDisabledWatchers(db, address); len(disabled) != 1 || disabled[0].Method != "deposit" {
+		t.Fatalf("Disabled watchers mismatch: %v", disabled)
+	}
+
+	// Updating a disabled watcher enables it again
+	WriteWatcher(db, address, "", &types.Watcher{Method: "deposit"})
+	if _, watchers := ReadEvents(db, address); len(watchers) != 2 {
+		t.Fatalf("Watchers mismatch after enabling: %v", watchers)
+	}
+	if disabled := ReadDisabledWatchers(db, address); len(disabled) != 0 {
+		t.Fatalf("Disabled watchers mismatch after enabling: %v", disabled)
+	}
+
+	WriteDualAction(db, "withdraw", master)
+	if smc, _ := ReadEventFromDualAction(db, "withdraw"); smc != master {
+		t.Fatalf("Dual action contract mismatch: have %v, want %v", smc, master)
+	}
+	DeleteDualAction(db, "withdraw")
+	if actions := ReadDualActions(db); len(actions) != 0 {
+		t.Fatalf("Deleted dual action returned: %v", actions)
+	}
+
+	if version := IncreaseWatcherVersion(db, address); version != 1 {
+		t.Fatalf("Watcher version mismatch: have %v, want 1", version)
+	}
+	if version := ReadWatcherVersion(db, address); version != 1 {
+		t.Fatalf("Watcher version mismatch: have %v, want 1", version)
+	}
+}
+
+// Tests staking events storage and retrieval operations.
+func TestStakingEventsStorage(t *testing.T) {
+	db := memorydb.New()
+
+	if events := ReadStakingEvents(db, 10); events != nil {
+		t.Fatalf("Non existent staking events returned: %v", events)
+	}
+	events := []*types.StakingEvent{
+		{Type: types.StakingEventMint, Height: 10, Amount: big.NewInt(100)},
+		{Type: types.StakingEventSlash, Height: 10, Validator: common.HexToAddress("0x01"), Amount: big.NewInt(5), EvidenceHash: common.HexToHash("0x02")},
+	}
+	WriteStakingEvents(db, 10, events)
+	stored := ReadStakingEvents(db, 10)
+	if len(stored) != len(events) {
+		t.Fatalf("Staking events mismatch: have %v, want %v", stored, events)
+	}
+	for i, ev := range stored {
+		if ev.Type != events[i].Type || ev.Validator != events[i].Validator || ev.Amount.Cmp(events[i].Amount) != 0 || ev.EvidenceHash != events[i].EvidenceHash {
+			t.Fatalf("Staking event %d mismatch: have %+v, want %+v", i, ev, events[i])
+		}
+	}
+	DeleteStakingEvents(db, 10)
+	if events := ReadStakingEvents(db, 10); events != nil {
+		t.Fatalf("Deleted staking events returned: %v", events)
+	}
+}
@@ -110,6 +110,76 @@ func (s *StoreDB) ReadSmartContractAbi(address string) *abi.ABI {
 	return ReadSmartContractAbi(s.db, address)
 }
 
+// ReadRawSmartContractAbi gets smart contract abi by smart contract address, without decoding it
+func (s *StoreDB) ReadRawSmartContractAbi(address string) string {
+	return ReadRawSmartContractAbi(s.db, address)
+}
+
+// WriteSmartContractAbi stores abi of a watched smart contract
+func (s *StoreDB) WriteSmartContractAbi(address string, abi string) {
+	WriteSmartContractAbi(s.db, address, abi)
+}
+
+// WriteWatcher adds or replaces a watcher action of a watched smart contract
+func (s *StoreDB) WriteWatcher(address string, masterSmc string, watcher *types.Watcher) {
+	WriteWatcher(s.db, address, masterSmc, watcher)
+}
+
+// DisableWatcher disables a watcher action by smart contract address and method
+func (s *StoreDB) DisableWatcher(address string, method string) bool {
+	return DisableWatcher(s.db, address, method)
+}
+
+// ReadDisabledWatchers returns a list of disabled watcher action by smart contract address
+func (s *StoreDB) ReadDisabledWatchers(address string) []*types.Watcher {
+	return ReadDisabledWatchers(s.db, address)
+}
+
+// WriteDualAction maps a dual action to a smart contract address
+func (s *StoreDB) WriteDualAction(action string, address string) {
+	WriteDualAction(s.db, action, address)
+}
+
+// DeleteDualAction removes a dual action
+func (s *StoreDB) DeleteDualAction(action string) {
+	DeleteDualAction(s.db, action)
+}
+
+// ReadDualActions returns all dual actions with their smart contract address
+func (s *StoreDB) ReadDualActions() map[string]string {
+	return ReadDualActions(s.db)
+}
+
+// ReadEventFromDualAction gets smart contract address and its abi by dual action
+func (s *StoreDB) ReadEventFromDualAction(action string) (string, *abi.ABI) {
+	return ReadEventFromDualAction(s.db, action)
+}
+
+// ReadWatcherVersion gets the watcher version of a smart contract
+func (s *StoreDB) ReadWatcherVersion(address string) uint64 {
+	return ReadWatcherVersion(s.db, address)
+}
+
+// IncreaseWatcherVersion increases and returns the watcher version of a smart contract
+func (s *StoreDB) IncreaseWatcherVersion(address string) uint64 {
+	return IncreaseWatcherVersion(s.db, address)
+}
+
+// WriteStakingEvents stores the staking events of a block
+func (s *StoreDB) WriteStakingEvents(height uint64, events []*types.StakingEvent) {
+	WriteStakingEvents(s.db, height, events)
+}
+
+// ReadStakingEvents retrieves the staking events of a block
+func (s *StoreDB) ReadStakingEvents(height uint64) []*types.StakingEvent {
+	return ReadStakingEvents(s.db, height)
+}
+
+// DeleteStakingEvents removes the staking events of a block
+func (s *StoreDB) DeleteStakingEvents(height uint64) {
+	DeleteStakingEvents(s.db, height)
+}
+
 // ReadEvent gets watcher action by smart contract address and method
 func (s *StoreDB) ReadEvent(address string, method string) *types.Watcher {
 	return ReadEvent(s.db, address, method)
@@ -235,3 +305,23 @@ func (s *StoreDB) DeleteBlockPart(height uint64) error {
 	}
 	return nil
 }
+
+// DeleteBlockInfo removes the block info belonging to a block.
+func (s *StoreDB) DeleteBlockInfo(hash common.Hash, height uint64) {
+	DeleteBlockInfo(s.db, hash, height)
+}
+
+// DeleteTxLookupEntries removes the positional metadata of every transaction
+// of a block.
+func (s *StoreDB) DeleteTxLookupEntries(block *types.Block) {
+	for _, tx := range block.Transactions() {
+		if err := DeleteTxLookupEntry(s.db, tx.Hash()); err != nil {
+			log.Crit("Failed to delete transaction lookup entry", "err", err)
+		}
+	}
+}
+
+// DeleteAppHash removes the app hash of a block.
+func (s *StoreDB) DeleteAppHash(height uint64) {
+	DeleteAppHash(s.db, height)
+}
@@ -45,6 +45,8 @@ var (
 	seenCommitPrefix = []byte("sm") // seenCommitPrefix + num -> seen commit
 	appHashPrefix    = []byte("ah") // appHashPrefix + num -> app hash
 
+	stakingEventsPrefix = []byte("se") // stakingEventsPrefix + num (uint64 big endian) -> staking events of the block
+
 	configPrefix          = []byte("kardia-config-") // config prefix for the db
 	txLookupPrefix        = []byte("l")              // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	dualEventLookupPrefix = []byte("de")             // dualEventLookupPrefix + hash -> dual's event lookup metadata
@@ -55,6 +57,9 @@ var (
 	dualActionPrefix  = []byte("dualAction")
 	contractAbiPrefix = []byte("abi")
 
+	disabledWatcherPrefix = []byte("disabledWatcher") // disabledWatcherPrefix + smart contract address + method -> disabled watcher
+	watcherVersionPrefix  = []byte("watcherVersion")  // watcherVersionPrefix + smart contract address -> version (uint64 big endian)
+
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 )
@@ -183,6 +188,19 @@ func contractAbiKey(smartContractAddress string) []byte {
 	return append(contractAbiPrefix, []byte(smartContractAddress)...)
 }
 
+func disabledWatcherKey(smartContractAddress string, method string) []byte {
+	return append(append(disabledWatcherPrefix, []byte(smartContractAddress)...), []byte(method)...)
+}
+
+func watcherVersionKey(smartContractAddress string) []byte {
+	return append(watcherVersionPrefix, []byte(smartContractAddress)...)
+}
+
+// stakingEventsKey = stakingEventsPrefix + num (uint64 big endian)
+func stakingEventsKey(height uint64) []byte {
+	return append(stakingEventsPrefix, encodeBlockHeight(height)...)
+}
+
 func blockMetaKey(height uint64) []byte {
 	return append(blockMetaPrefix, encodeBlockHeight(height)...)
 }
@@ -60,6 +60,18 @@ func init() {
 		format:             FormatFloat,
 		round:              Round,
 		replaceFunc:        Replace,
+		keccak256:          Keccak256,
+		sha256Func:         Sha256,
+		hexToBytes:         HexToBytes,
+		bytesToHex:         BytesToHex,
+		abiEncode:          AbiEncode,
+		abiDecode:          AbiDecode,
+		ecrecover:          Ecrecover,
+		checksumAddress:    ChecksumAddress,
+		add:                Add,
+		sub:                Sub,
+		mod:                Mod,
+		intCmp:             IntCmp,
 	}
 }
 
@@ -205,7 +217,16 @@ func executeIf(p *Parser, extras ...interface{}) ([]interface{}, error) {
 
 // parseBlockPatterns reads nested patterns with different parser then returns all returned params.
 func parseBlockPatterns(p *Parser, patterns []string, extrasVar map[string]interface{}) ([]interface{}, error) {
+	// each block execution (including every forEach iteration) is counted as a step
+	if p.budget != nil {
+		if err := p.budget.step(); err != nil {
+			return nil, err
+		}
+	}
 	newParser := NewParser(p.ProxyName, p.PublishEndpoint, p.PublishFunction, p.Bc, p.TxPool, p.SmartContractAddress, patterns, p.GlobalMessage, p.CanTrigger)
+	newParser.DryRun, newParser.SenderKey, newParser.Nonce = p.DryRun, p.SenderKey, p.Nonce
+	newParser.Limits, newParser.Context = p.Limits, p.Context
+	newParser.budget, newParser.depth = p.budget, p.depth+1
 	// add all definedVariables in p in overwrite cases.
 	for k, v := range p.UserDefinedVariables {
 		newParser.UserDefinedVariables[k] = v
@@ -223,6 +244,11 @@ func parseBlockPatterns(p *Parser, patterns []string, extrasVar map[string]inter
 	}
 
 	err := newParser.ParseParams()
+	// keep transactions and nonce of nested parser
+	p.Transactions = append(p.Transactions, newParser.Transactions...)
+	if newParser.Nonce > p.Nonce {
+		p.Nonce = newParser.Nonce
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,298 @@
+/*
+ *  Copyright 2019 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ksml
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// arity is the number of arguments accepted by a built-in function. max < 0 means unbounded.
+type arity struct {
+	min, max int
+}
+
+var (
+	// builtInArity defines number of arguments for every function in BuiltInFuncMap.
+	builtInArity = map[string]arity{
+		ping:               {0, 0},
+		currentTimeStamp:   {0, 0},
+		currentBlockHeight: {0, 0},
+		validate:           {3, 3},
+		ifFunc:             {2, 2},
+		elif:               {2, 2},
+		el:                 {1, 1},
+		endIf:              {1, 1},
+		addVarFunc:         {3, 3},
+		forEachFunc:        {3, 3},
+		endForEach:         {1, 1},
+		splitFunc:          {2, 2},
+		replaceFunc:        {3, 3},
+		defineFunc:         {1, -1},
+		endDefineFunc:      {1, 1},
+		callFunc:           {1, -1},
+		getData:            {1, -1},
+		trigger:            {1, -1},
+		publish:            {3, 4},
+		compare:            {4, 4},
+		mul:                {2, 2},
+		div:                {2, 2},
+		toInt:              {1, 1},
+		toFloat:            {1, 1},
+		exp:                {2, 2},
+		format:             {2, 2},
+		round:              {1, 1},
+		keccak256:          {1, 1},
+		sha256Func:         {1, 1},
+		hexToBytes:         {1, 1},
+		bytesToHex:         {1, 1},
+		abiEncode:          {1, -1},
+		abiDecode:          {2, 2},
+		ecrecover:          {2, 2},
+		checksumAddress:    {1, 1},
+		add:                {2, 2},
+		sub:                {2, 2},
+		mod:                {2, 2},
+		intCmp:             {2, 2},
+	}
+
+	// blockFunctions maps functions that open a block to the function that closes it.
+	blockFunctions = map[string]string{
+		ifFunc:      endIf,
+		forEachFunc: endForEach,
+		defineFunc:  endDefineFunc,
+	}
+
+	intLiteral     = regexp.MustCompile(`^-?[0-9]+$`)
+	hexLiteral     = regexp.MustCompile(`^0[xX][0-9a-fA-F]*$`)
+	quotedLiteral  = regexp.MustCompile(`^("[^"]*"|'[^']*')$`)
+	booleanLiteral = regexp.MustCompile(`^(true|false)$`)
+)
+
+// CheckError is an issue found by Checker at a line of patterns.
+type CheckError struct {
+	Line    int
+	Content string
+	Err     error
+}
+
+func (e *CheckError) Error() string {
+	return fmt.Sprintf("line %v: %v - %v", e.Line, e.Content, e.Err)
+}
+
+// Checker statically checks KSML patterns without executing them. It verifies that every ${...} block
+// is well-formed, that fn: and smc: built-in functions exist and are called with the right number of
+// arguments, that blocks are closed and that smc:trigger and smc:getData match the contract abi.
+type Checker struct {
+	abi          *abi.ABI       // abi of the smart contract smc built-ins are called on, may be nil
+	parser       *Parser        // parser is only used to split built-in functions
+	definedFuncs map[string]int // user defined functions and their number of arguments
+	errs         []error
+}
+
+// NewChecker returns a checker. If contractAbi is nil, smc built-ins are not type-checked.
+func NewChecker(contractAbi *abi.ABI) *Checker {
+	return &Checker{
+		abi:    contractAbi,
+		parser: &Parser{},
+	}
+}
+
+// Check checks patterns and returns all found issues.
+func (c *Checker) Check(patterns []string) []error {
+	c.errs = make([]error, 0)
+	c.definedFuncs = make(map[string]int)
+	if len(patterns) == 0 {
+		return []error{sourceIsEmpty}
+	}
+
+	// collect user defined functions first since they can be called before being defined
+	for _, pattern := range patterns {
+		if !isExpression(pattern) {
+			continue
+		}
+		_, method, params, err := c.parser.GetPrefix(pattern[2 : len(pattern)-1])
+		if err == nil && method == defineFunc && len(params) > 0 {
+			c.definedFuncs[params[0]] = len(params) - 1
+		}
+	}
+
+	// opened keeps names of opened blocks, keyed by the function closing them
+	opened := make(map[string]map[string]int)
+	for closing := range map[string]struct{}{endIf: {}, endForEach: {}, endDefineFunc: {}} {
+		opened[closing] = make(map[string]int)
+	}
+	for i, pattern := range patterns {
+		if !isExpression(pattern) {
+			continue
+		}
+		content := pattern[2 : len(pattern)-1]
+		if err := c.checkContent(content); err != nil {
+			c.errs = append(c.errs, &CheckError{Line: i, Content: pattern, Err: err})
+			continue
+		}
+		_, method, params, _ := c.parser.GetPrefix(content)
+		if len(params) == 0 {
+			continue
+		}
+		if closing, ok := blockFunctions[method]; ok {
+			opened[closing][params[0]] = i
+		} else if _, ok := opened[method]; ok {
+			if _, ok := opened[method][params[0]]; !ok {
+				c.errs = append(c.errs, &CheckError{Line: i, Content: pattern, Err: fmt.Errorf("%v(%v) has no opening block", method, params[0])})
+			}
+			delete(opened[method], params[0])
+		}
+	}
+	unclosed := make([]*CheckError, 0)
+	for closing, names := range opened {
+		for name, line := range names {
+			unclosed = append(unclosed, &CheckError{Line: line, Content: patterns[line], Err: fmt.Errorf("block %v is not closed by %v(%v)", name, closing, name)})
+		}
+	}
+	sort.Slice(unclosed, func(i, j int) bool { return unclosed[i].Line < unclosed[j].Line })
+	for _, err := range unclosed {
+		c.errs = append(c.errs, err)
+	}
+	return c.errs
+}
+
+// checkContent checks a built-in function and its nested built-in functions recursively.
+func (c *Checker) checkContent(content string) error {
+	prefix, method, params, err := c.parser.GetPrefix(content)
+	if err != nil {
+		return err
+	}
+	if prefix == "" {
+		return nil
+	}
+	if _, ok := BuiltInFuncMap[method]; !ok {
+		return fmt.Errorf("%v: %v%v%v", methodNotFound, prefix, prefixSeparator, method)
+	}
+	if a, ok := builtInArity[method]; ok {
+		if len(params) < a.min || (a.max >= 0 && len(params) > a.max) {
+			return fmt.Errorf("invalid arguments for %v%v%v, expect %v got %v", prefix, prefixSeparator, method, a.expected(), len(params))
+		}
+	}
+	switch method {
+	case callFunc:
+		nArgs, ok := c.definedFuncs[params[0]]
+		if !ok {
+			return fmt.Errorf("%v: %v", methodNotFound, params[0])
+		}
+		if nArgs != len(params)-1 {
+			return fmt.Errorf("invalid arguments for function %v, expect %v got %v", params[0], nArgs, len(params)-1)
+		}
+	case getData, trigger:
+		if err := c.checkSmcCall(params[0], params[1:]); err != nil {
+			return err
+		}
+	}
+	for _, param := range params {
+		if hasBuiltIn(param) {
+			if err := c.checkContent(param); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkSmcCall checks that method exists in contract abi and that literal arguments match its inputs.
+func (c *Checker) checkSmcCall(method string, params []string) error {
+	if c.abi == nil {
+		return nil
+	}
+	m, ok := c.abi.Methods[method]
+	if !ok {
+		return fmt.Errorf("%v: %v", methodNotFound, method)
+	}
+	if len(m.Inputs) != len(params) {
+		return fmt.Errorf("%v: %v expects %v arguments, got %v", paramsArgumentsNotMatch, method, len(m.Inputs), len(params))
+	}
+	for i, input := range m.Inputs {
+		if err := checkLiteral(input.Type, params[i]); err != nil {
+			return fmt.Errorf("argument %v (%v) of %v: %v", i, input.Name, method, err)
+		}
+	}
+	return nil
+}
+
+// checkLiteral checks that param can be converted to typ if it is a literal.
+// Expressions and nested built-in functions are only known while executing, they are skipped.
+func checkLiteral(typ abi.Type, param string) error {
+	isLiteral := intLiteral.MatchString(param) || hexLiteral.MatchString(param) ||
+		quotedLiteral.MatchString(param) || booleanLiteral.MatchString(param)
+	if !isLiteral {
+		return nil
+	}
+	invalid := fmt.Errorf("%v cannot be used as %v", param, typ.String())
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		v, ok := parseIntLiteral(param)
+		if !ok {
+			return invalid
+		}
+		if typ.T == abi.UintTy && (v.Sign() < 0 || v.BitLen() > typ.Size) {
+			return invalid
+		}
+		if typ.T == abi.IntTy && !fitsInt(v, typ.Size) {
+			return invalid
+		}
+	case abi.BoolTy:
+		if _, err := strconv.ParseBool(param); err != nil {
+			return invalid
+		}
+	case abi.AddressTy:
+		if !common.IsHexAddress(strings.Trim(param, `"'`)) {
+			return invalid
+		}
+	case abi.StringTy, abi.BytesTy, abi.FixedBytesTy:
+		if booleanLiteral.MatchString(param) {
+			return invalid
+		}
+	}
+	return nil
+}
+
+// parseIntLiteral parses a decimal or a 0x prefixed hexadecimal integer literal.
+func parseIntLiteral(param string) (*big.Int, bool) {
+	if hexLiteral.MatchString(param) {
+		return new(big.Int).SetString(param[2:], 16)
+	}
+	return new(big.Int).SetString(param, 10)
+}
+
+func (a arity) expected() string {
+	switch {
+	case a.max < 0:
+		return fmt.Sprintf("at least %v", a.min)
+	case a.min == a.max:
+		return strconv.Itoa(a.min)
+	default:
+		return fmt.Sprintf("%v to %v", a.min, a.max)
+	}
+}
@@ -0,0 +1,467 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ksml
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/common/types/ref"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+// unwrap returns native value of a CEL value.
+func unwrap(val interface{}) interface{} {
+	if v, ok := val.(ref.Val); ok {
+		return v.Value()
+	}
+	return val
+}
+
+// evalArg executes an argument of a built-in function and returns its first value.
+func evalArg(p *Parser, arg interface{}) (interface{}, error) {
+	str, err := InterfaceToString(arg)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := p.handleContent(str)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, fmt.Errorf("%v does not return any value", str)
+	}
+	return unwrap(vals[0]), nil
+}
+
+// toBytes converts val to bytes. Hex strings (0x prefixed) are decoded, other strings are used as they are.
+func toBytes(val interface{}) ([]byte, error) {
+	switch v := unwrap(val).(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return common.Decode(v)
+		}
+		return []byte(v), nil
+	case *big.Int:
+		return common.LeftPadBytes(v.Bytes(), 32), nil
+	case common.Address:
+		return v.Bytes(), nil
+	case common.Hash:
+		return v.Bytes(), nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to bytes", reflect.TypeOf(val))
+}
+
+// toBigInt converts val to big.Int without going through float. Strings can be decimal or 0x prefixed hex.
+func toBigInt(val interface{}) (*big.Int, error) {
+	v := reflect.ValueOf(unwrap(val))
+	switch {
+	case !v.IsValid():
+		return nil, fmt.Errorf("cannot convert nil to big.Int")
+	case isType("big.Int", v):
+		return new(big.Int).Set(v.Interface().(*big.Int)), nil
+	case isType("big.Float", v):
+		f := v.Interface().(*big.Float)
+		if !f.IsInt() {
+			return nil, fmt.Errorf("%v is not an integer", f.String())
+		}
+		i, _ := f.Int(nil)
+		return i, nil
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case reflect.String:
+		i, ok := new(big.Int).SetString(v.String(), 0)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %v to big.Int", v.String())
+		}
+		return i, nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to big.Int", v.Type().String())
+}
+
+// Keccak256 returns keccak256 hash of given data as a hex string
+func Keccak256(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	data, err := bytesArg(p, keccak256, extras...)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{common.Encode(crypto.Keccak256(data))}, nil
+}
+
+// Sha256 returns sha256 hash of given data as a hex string
+func Sha256(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	data, err := bytesArg(p, sha256Func, extras...)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	return []interface{}{common.Encode(hash[:])}, nil
+}
+
+// HexToBytes decodes a 0x prefixed hex string to bytes
+func HexToBytes(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	if len(extras) != 1 {
+		return nil, fmt.Errorf("invalid arguments, expect 1 got %v", len(extras))
+	}
+	val, err := evalArg(p, extras[0])
+	if err != nil {
+		return nil, err
+	}
+	str, err := InterfaceToString(val)
+	if err != nil {
+		return nil, err
+	}
+	data, err := common.Decode(str)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{data}, nil
+}
+
+// BytesToHex encodes bytes to a 0x prefixed hex string
+func BytesToHex(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	data, err := bytesArg(p, bytesToHex, extras...)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{common.Encode(data)}, nil
+}
+
+func bytesArg(p *Parser, method string, extras ...interface{}) ([]byte, error) {
+	if len(extras) != 1 {
+		return nil, fmt.Errorf("invalid arguments for %v, expect 1 got %v", method, len(extras))
+	}
+	val, err := evalArg(p, extras[0])
+	if err != nil {
+		return nil, err
+	}
+	return toBytes(val)
+}
+
+// parseAbiTypes parses a list of abi types separated by abiTypesSeparator, eg: 'address|uint256|bytes32'
+func parseAbiTypes(types string) (abi.Arguments, error) {
+	args := make(abi.Arguments, 0)
+	for _, t := range strings.Split(types, abiTypesSeparator) {
+		typ, err := abi.NewType(strings.TrimSpace(t), "", nil)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	return args, nil
+}
+
+// fitsInt returns true if i is in range of a signed integer of given bit size.
+func fitsInt(i *big.Int, size int) bool {
+	max := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+	return i.Cmp(new(big.Int).Neg(max)) >= 0 && i.Cmp(max) < 0
+}
+
+// toAbiValue converts val to go type of an abi type
+func toAbiValue(typ abi.Type, val interface{}) (interface{}, error) {
+	val = unwrap(val)
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		i, err := toBigInt(val)
+		if err != nil {
+			return nil, err
+		}
+		if typ.T == abi.UintTy && (i.Sign() < 0 || i.BitLen() > typ.Size) {
+			return nil, fmt.Errorf("%v overflows uint%v", i.String(), typ.Size)
+		}
+		if typ.T == abi.IntTy && !fitsInt(i, typ.Size) {
+			return nil, fmt.Errorf("%v overflows int%v", i.String(), typ.Size)
+		}
+		// types wider than 64 bits are *big.Int
+		goType := typ.GetType()
+		if goType.Kind() == reflect.Ptr {
+			return i, nil
+		}
+		if typ.T == abi.UintTy {
+			return reflect.ValueOf(i.Uint64()).Convert(goType).Interface(), nil
+		}
+		return reflect.ValueOf(i.Int64()).Convert(goType).Interface(), nil
+	case abi.BoolTy:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+		str, err := InterfaceToString(val)
+		if err != nil {
+			return nil, err
+		}
+		return str == "true", nil
+	case abi.StringTy:
+		return InterfaceToString(val)
+	case abi.AddressTy:
+		if addr, ok := val.(common.Address); ok {
+			return addr, nil
+		}
+		str, err := InterfaceToString(val)
+		if err != nil {
+			return nil, err
+		}
+		if !common.IsHexAddress(str) {
+			return nil, fmt.Errorf("invalid address %v", str)
+		}
+		return common.HexToAddress(str), nil
+	case abi.BytesTy:
+		return toBytes(val)
+	case abi.FixedBytesTy:
+		data, err := toBytes(val)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != typ.Size {
+			return nil, fmt.Errorf("invalid length for bytes%v, got %v", typ.Size, len(data))
+		}
+		arr := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(arr, reflect.ValueOf(data))
+		return arr.Interface(), nil
+	}
+	return nil, fmt.Errorf("abi type %v is not supported", typ.String())
+}
+
+// fromAbiValue converts unpacked abi value to a value that can be used in ksml.
+// Addresses and bytes are converted to hex strings, numbers bigger than 64 bits are kept as big.Int.
+func fromAbiValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return common.Encode(v)
+	case *big.Int:
+		return v
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return common.Encode(data)
+		}
+	}
+	return val
+}
+
+// AbiEncode packs values with given abi types and returns a hex string, eg: ${fn:abiEncode('address|uint256',to,amount)}
+func AbiEncode(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	if len(extras) < 1 {
+		return nil, fmt.Errorf("invalid arguments, expect at least 1 got %v", len(extras))
+	}
+	types, err := evalArg(p, extras[0])
+	if err != nil {
+		return nil, err
+	}
+	typesStr, err := InterfaceToString(types)
+	if err != nil {
+		return nil, err
+	}
+	args, err := parseAbiTypes(typesStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(extras)-1 {
+		return nil, paramsArgumentsNotMatch
+	}
+	values := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		val, err := evalArg(p, extras[i+1])
+		if err != nil {
+			return nil, err
+		}
+		v, err := toAbiValue(arg.Type, val)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	data, err := args.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{common.Encode(data)}, nil
+}
+
+// AbiDecode unpacks data with given abi types and returns a list of values, eg: ${fn:abiDecode('address|uint256',data)}
+func AbiDecode(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	if len(extras) != 2 {
+		return nil, fmt.Errorf("invalid arguments, expect 2 got %v", len(extras))
+	}
+	types, err := evalArg(p, extras[0])
+	if err != nil {
+		return nil, err
+	}
+	typesStr, err := InterfaceToString(types)
+	if err != nil {
+		return nil, err
+	}
+	args, err := parseAbiTypes(typesStr)
+	if err != nil {
+		return nil, err
+	}
+	val, err := evalArg(p, extras[1])
+	if err != nil {
+		return nil, err
+	}
+	data, err := toBytes(val)
+	if err != nil {
+		return nil, err
+	}
+	values, err := args.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		results = append(results, fromAbiValue(v))
+	}
+	return []interface{}{results}, nil
+}
+
+// Ecrecover returns checksum address of the account which signed hash, eg: ${fn:ecrecover(hash,signature)}
+// signature is 65 bytes [R || S || V], V can be either 0/1 or 27/28.
+func Ecrecover(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	if len(extras) != 2 {
+		return nil, fmt.Errorf("invalid arguments, expect 2 got %v", len(extras))
+	}
+	vals := make([][]byte, 0, 2)
+	for _, extra := range extras {
+		val, err := evalArg(p, extra)
+		if err != nil {
+			return nil, err
+		}
+		data, err := toBytes(val)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, data)
+	}
+	hash, sig := vals[0], common.CopyBytes(vals[1])
+	if len(hash) != common.HashLength {
+		return nil, fmt.Errorf("invalid hash length, expect %v got %v", common.HashLength, len(hash))
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length, expect 65 got %v", len(sig))
+	}
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	if sig[64] > 1 {
+		return nil, fmt.Errorf("invalid signature recovery id %v", sig[64])
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{crypto.PubkeyToAddress(*pub).Hex()}, nil
+}
+
+// ChecksumAddress validates an address and returns its checksum format
+func ChecksumAddress(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	if len(extras) != 1 {
+		return nil, fmt.Errorf("invalid arguments, expect 1 got %v", len(extras))
+	}
+	val, err := evalArg(p, extras[0])
+	if err != nil {
+		return nil, err
+	}
+	str, err := InterfaceToString(val)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsHexAddress(str) {
+		return nil, fmt.Errorf("invalid address %v", str)
+	}
+	return []interface{}{common.HexToAddress(str).Hex()}, nil
+}
+
+func bigIntArgs(p *Parser, extras ...interface{}) (*big.Int, *big.Int, error) {
+	if len(extras) != 2 {
+		return nil, nil, fmt.Errorf("invalid arguments, expect 2 got %v", len(extras))
+	}
+	vals := make([]*big.Int, 0, 2)
+	for _, extra := range extras {
+		val, err := evalArg(p, extra)
+		if err != nil {
+			return nil, nil, err
+		}
+		i, err := toBigInt(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		vals = append(vals, i)
+	}
+	return vals[0], vals[1], nil
+}
+
+// Add returns sum of 2 integers as big.Int
+func Add(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	x, y, err := bigIntArgs(p, extras...)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{x.Add(x, y)}, nil
+}
+
+// Sub returns difference of 2 integers as big.Int
+func Sub(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	x, y, err := bigIntArgs(p, extras...)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{x.Sub(x, y)}, nil
+}
+
+// Mod returns modulus x%y of 2 integers as big.Int
+func Mod(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	x, y, err := bigIntArgs(p, extras...)
+	if err != nil {
+		return nil, err
+	}
+	if y.Sign() == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	return []interface{}{x.Mod(x, y)}, nil
+}
+
+// IntCmp compares 2 integers and returns -1 if x < y, 0 if x == y and 1 if x > y
+func IntCmp(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	x, y, err := bigIntArgs(p, extras...)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{int64(x.Cmp(y))}, nil
+}
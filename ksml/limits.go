@@ -0,0 +1,162 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ksml
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	LimitSteps   = "steps"
+	LimitDepth   = "depth"
+	LimitGas     = "gas"
+	LimitTimeout = "timeout"
+)
+
+// Limits bounds the resources used by a single KSML execution, including all its nested blocks
+// (if, forEach) and user defined function calls.
+type Limits struct {
+	MaxSteps uint64        // maximum number of evaluated expressions and built-in functions
+	MaxDepth int           // maximum nesting of blocks and user defined function calls
+	MaxGas   uint64        // maximum KVM gas used by smc:getData and smc:trigger
+	Timeout  time.Duration // maximum wall time, zero means no limit
+}
+
+// DefaultLimits is used by parsers which do not define their own limits. It has
+// no timeout, so existing watchers calling slow smc are not cut short.
+var DefaultLimits = Limits{
+	MaxSteps: 10000,
+	MaxDepth: 16,
+	MaxGas:   10 * uint64(MaximumGasToCallFunction),
+}
+
+// LimitError is returned when a KSML execution exceeds one of its limits.
+type LimitError struct {
+	Limit string      // name of the exceeded limit
+	Max   interface{} // value of the exceeded limit
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("ksml execution exceeded %v limit (%v)", e.Limit, e.Max)
+}
+
+// budget keeps track of resources used by a parser and all nested parsers created while executing it.
+type budget struct {
+	limits Limits
+	ctx    context.Context
+	cancel context.CancelFunc
+	steps  uint64
+	gas    uint64
+}
+
+func newBudget(ctx context.Context, limits Limits) *budget {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	b := &budget{limits: limits}
+	if limits.Timeout > 0 {
+		b.ctx, b.cancel = context.WithTimeout(ctx, limits.Timeout)
+	} else {
+		b.ctx, b.cancel = context.WithCancel(ctx)
+	}
+	return b
+}
+
+// done returns an error if the execution is timed out or cancelled.
+func (b *budget) done() error {
+	select {
+	case <-b.ctx.Done():
+		if b.ctx.Err() == context.DeadlineExceeded {
+			return &LimitError{Limit: LimitTimeout, Max: b.limits.Timeout}
+		}
+		return b.ctx.Err()
+	default:
+		return nil
+	}
+}
+
+// step counts an evaluation step.
+func (b *budget) step() error {
+	b.steps++
+	if b.limits.MaxSteps > 0 && b.steps > b.limits.MaxSteps {
+		return &LimitError{Limit: LimitSteps, Max: b.limits.MaxSteps}
+	}
+	return b.done()
+}
+
+// checkDepth checks nesting depth of a parser.
+func (b *budget) checkDepth(depth int) error {
+	if b.limits.MaxDepth > 0 && depth > b.limits.MaxDepth {
+		return &LimitError{Limit: LimitDepth, Max: b.limits.MaxDepth}
+	}
+	return nil
+}
+
+// availableGas returns the gas that can be used by the next smc call.
+func (b *budget) availableGas() (uint64, error) {
+	gas := uint64(MaximumGasToCallFunction)
+	if b.limits.MaxGas == 0 {
+		return gas, nil
+	}
+	if b.gas >= b.limits.MaxGas {
+		return 0, &LimitError{Limit: LimitGas, Max: b.limits.MaxGas}
+	}
+	if remaining := b.limits.MaxGas - b.gas; remaining < gas {
+		gas = remaining
+	}
+	return gas, nil
+}
+
+// useGas counts gas used by an smc call.
+func (b *budget) useGas(gas uint64) error {
+	b.gas += gas
+	if b.limits.MaxGas > 0 && b.gas > b.limits.MaxGas {
+		return &LimitError{Limit: LimitGas, Max: b.limits.MaxGas}
+	}
+	return nil
+}
+
+// context returns context of current execution.
+func (p *Parser) context() context.Context {
+	if p.budget != nil {
+		return p.budget.ctx
+	}
+	if p.Context != nil {
+		return p.Context
+	}
+	return context.Background()
+}
+
+// availableGas returns the gas that can be used by the next smc call of p.
+func (p *Parser) availableGas() (uint64, error) {
+	if p.budget == nil {
+		return uint64(MaximumGasToCallFunction), nil
+	}
+	return p.budget.availableGas()
+}
+
+// useGas counts gas used by an smc call of p.
+func (p *Parser) useGas(gas uint64) error {
+	if p.budget == nil {
+		return nil
+	}
+	return p.budget.useGas(gas)
+}
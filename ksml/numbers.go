@@ -44,7 +44,35 @@ func parseInt(val interface{}) (int64, error) {
 	return strconv.ParseInt(v, 10, 64)
 }
 
+// Mul multiplies 2 numbers. Integers are multiplied as big.Int without going through float,
+// result is a big.Float if any of them is a float.
 func Mul(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	return arithmetic(p, mul, func(x, y *big.Int) (*big.Int, error) {
+		return x.Mul(x, y), nil
+	}, func(x, y *big.Float) (*big.Float, error) {
+		return x.Mul(x, y), nil
+	}, extras...)
+}
+
+// Div divides 2 numbers. Integers are divided as big.Int without going through float,
+// result is a big.Float if any of them is a float.
+func Div(p *Parser, extras ...interface{}) ([]interface{}, error) {
+	return arithmetic(p, div, func(x, y *big.Int) (*big.Int, error) {
+		if y.Sign() == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return x.Div(x, y), nil
+	}, func(x, y *big.Float) (*big.Float, error) {
+		if y.Sign() == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return x.Quo(x, y), nil
+	}, extras...)
+}
+
+// arithmetic applies intOp to 2 integers or floatOp if any of them is a float.
+func arithmetic(p *Parser, name string, intOp func(x, y *big.Int) (*big.Int, error),
+	floatOp func(x, y *big.Float) (*big.Float, error), extras ...interface{}) ([]interface{}, error) {
 	if len(extras) != 2 {
 		return nil, fmt.Errorf("invalid arguments, expect 2 got %v", len(extras))
 	}
@@ -52,50 +80,61 @@ func Mul(p *Parser, extras ...interface{}) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	// convert to big.Int or big.Float if returned vals are float64 or int64
-	for i, _ := range vals {
-		if reflect.ValueOf(vals[i]).Kind() == reflect.Float64 {
-			vals[i] = big.NewFloat(reflect.ValueOf(vals[i]).Float())
-		} else if reflect.ValueOf(vals[i]).Kind() == reflect.Int64 {
-			vals[i] = big.NewInt(reflect.ValueOf(vals[i]).Int())
+	if !isFloat(vals[0]) && !isFloat(vals[1]) {
+		x, errX := toBigInt(vals[0])
+		y, errY := toBigInt(vals[1])
+		if errX == nil && errY == nil {
+			result, err := intOp(x, y)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{result}, nil
 		}
 	}
-
-	val1, val2 := reflect.ValueOf(vals[0]), reflect.ValueOf(vals[1])
-	if isType("big.Int", val1, val2) {
-		return []interface{}{big.NewInt(0).Mul(val1.Interface().(*big.Int), val2.Interface().(*big.Int))}, nil
-	} else if isType("big.Float", val1, val2) {
-		return []interface{}{big.NewFloat(0).Mul(val1.Interface().(*big.Float), val2.Interface().(*big.Float))}, nil
+	x, err := toBigFloat(vals[0])
+	if err != nil {
+		return nil, fmt.Errorf("unsupported param in %v func: %v", name, err)
 	}
-	return nil, fmt.Errorf("unsupport type %v or %v in Mul func, expect big.Int or big.Float", val1.Type().String(), val2.Type().String())
-}
-
-func Div(p *Parser, extras ...interface{}) ([]interface{}, error) {
-	if len(extras) != 2 {
-		return nil, fmt.Errorf("invalid arguments, expect 2 got %v", len(extras))
+	y, err := toBigFloat(vals[1])
+	if err != nil {
+		return nil, fmt.Errorf("unsupported param in %v func: %v", name, err)
 	}
-	vals, err := p.handleContents(extras)
+	result, err := floatOp(x, y)
 	if err != nil {
 		return nil, err
 	}
+	return []interface{}{result}, nil
+}
 
-	// convert to big.Int or big.Float if returned vals are float64 or int64
-	for i, _ := range vals {
-		if reflect.ValueOf(vals[i]).Kind() == reflect.Float64 {
-			vals[i] = big.NewFloat(reflect.ValueOf(vals[i]).Float())
-		} else if reflect.ValueOf(vals[i]).Kind() == reflect.Int64 {
-			vals[i] = big.NewInt(reflect.ValueOf(vals[i]).Int())
-		}
+// isFloat returns true if val is a float64 or big.Float.
+func isFloat(val interface{}) bool {
+	v := reflect.ValueOf(unwrap(val))
+	if !v.IsValid() {
+		return false
 	}
+	return isType("big.Float", v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
 
-	val1, val2 := reflect.ValueOf(vals[0]), reflect.ValueOf(vals[1])
-	if isType("big.Int", val1, val2) {
-		return []interface{}{big.NewInt(0).Div(val1.Interface().(*big.Int), val2.Interface().(*big.Int))}, nil
-	} else if isType("big.Float", val1, val2) {
-		return []interface{}{big.NewFloat(0).Quo(val1.Interface().(*big.Float), val2.Interface().(*big.Float))}, nil
+// toBigFloat converts val to big.Float, integers are converted exactly.
+func toBigFloat(val interface{}) (*big.Float, error) {
+	v := reflect.ValueOf(unwrap(val))
+	switch {
+	case !v.IsValid():
+		return nil, fmt.Errorf("cannot convert nil to big.Float")
+	case isType("big.Float", v):
+		return new(big.Float).Set(v.Interface().(*big.Float)), nil
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return big.NewFloat(v.Float()), nil
+	case v.Kind() == reflect.String:
+		if f, ok := new(big.Float).SetString(v.String()); ok {
+			return f, nil
+		}
+	}
+	i, err := toBigInt(val)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("unsupport type %v or %v in Div func, expect big.Int or big.Float", val1.Type().String(), val2.Type().String())
+	return new(big.Float).SetInt(i), nil
 }
 
 func SetInt(p *Parser, extras ...interface{}) ([]interface{}, error) {
@@ -19,6 +19,8 @@
 package ksml
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -33,8 +35,10 @@ import (
 	"github.com/kardiachain/go-kardia/kai/state"
 	message "github.com/kardiachain/go-kardia/ksml/proto"
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	kaiTypes "github.com/kardiachain/go-kardia/types"
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 )
 
@@ -54,6 +58,13 @@ type Parser struct {
 	Pc                   int                    // program counter is used to count and get current read position in globalPatterns
 	Nonce                uint64
 	CanTrigger           bool
+	DryRun               bool                    // if DryRun is true, transactions created by smc:trigger are kept in Transactions instead of being added to TxPool
+	SenderKey            *ecdsa.PrivateKey       // key used to sign transactions created by smc:trigger, node's key is used if it is nil
+	Transactions         []*kaiTypes.Transaction // transactions created by smc:trigger in DryRun mode
+	Limits               *Limits                 // resource limits of an execution, DefaultLimits is used if it is nil
+	Context              context.Context         // execution is aborted when Context is done
+	budget               *budget                 // resources used by current execution, shared with nested parsers
+	depth                int                     // nesting depth of parser
 	mtx                  sync.Mutex
 }
 
@@ -195,8 +206,16 @@ func (p *Parser) CEL(src string) ([]interface{}, error) {
 	return []interface{}{out.Value()}, nil
 }
 
+// sender returns the address signing transactions created by smc:trigger
+func (p *Parser) sender() common.Address {
+	if p.SenderKey != nil {
+		return crypto.PubkeyToAddress(p.SenderKey.PublicKey)
+	}
+	return *p.Bc.P2P().Address()
+}
+
 func (p *Parser) GetNonce() uint64 {
-	nonce := p.TxPool.Nonce(*p.Bc.P2P().Address())
+	nonce := p.TxPool.Nonce(p.sender())
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -349,6 +368,23 @@ func (p *Parser) ParseParams() error {
 		return sourceIsEmpty
 	}
 
+	// top level parser starts a new budget which is shared with all nested parsers
+	if p.budget == nil {
+		limits := DefaultLimits
+		if p.Limits != nil {
+			limits = *p.Limits
+		}
+		b := newBudget(p.Context, limits)
+		p.budget = b
+		defer func() {
+			b.cancel()
+			p.budget = nil
+		}()
+	}
+	if err := p.budget.checkDepth(p.depth); err != nil {
+		return err
+	}
+
 	// check and add userDefinedFunction
 	if err := p.addFunction(); err != nil {
 		return err
@@ -359,11 +395,11 @@ func (p *Parser) ParseParams() error {
 		var val []interface{}
 		var err error
 		// if src is greater or equals minLength and has structure ${...} then CEL is applied
-		if len(pattern) >= elMinLength && strings.HasPrefix(pattern, "${") && strings.HasSuffix(pattern, "}") {
+		if isExpression(pattern) {
 			content := pattern[2 : len(pattern)-1]
 			val, err = p.handleContent(content)
 			if err != nil {
-				return fmt.Errorf("error while handling content at line %v - %v", p.Pc, err)
+				return fmt.Errorf("error while handling content at line %v - %w", p.Pc, err)
 			}
 		} else {
 			val = []interface{}{pattern}
@@ -409,6 +445,11 @@ func (p *Parser) handleContents(contents []interface{}) ([]interface{}, error) {
 }
 
 func (p *Parser) handleContent(content string) ([]interface{}, error) {
+	if p.budget != nil {
+		if err := p.budget.step(); err != nil {
+			return nil, err
+		}
+	}
 	// check if content contains any predefined prefix
 	prefix, method, patterns, err := p.GetPrefix(content)
 	var val []interface{}
@@ -19,6 +19,7 @@
 package ksml
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
@@ -73,8 +74,19 @@ func GetDataFromSmc(p *Parser, extras ...interface{}) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	gas, err := p.availableGas()
+	if err != nil {
+		return nil, err
+	}
 	// get data from smc using above input
-	result, err := callStaticKardiaMasterSmc(*caller, *p.SmartContractAddress, currentHeader, p.Bc, input, p.StateDb)
+	result, leftOverGas, err := callStaticKardiaMasterSmc(p.context(), *caller, *p.SmartContractAddress, currentHeader, p.Bc, input, p.StateDb, gas)
+	if err := p.useGas(gas - leftOverGas); err != nil {
+		return nil, err
+	}
+	if err == kvm.ErrOutOfGas && gas < uint64(MaximumGasToCallFunction) {
+		// gas has been capped by execution limits
+		return nil, &LimitError{Limit: LimitGas, Max: p.budget.limits.MaxGas}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -97,18 +109,36 @@ func triggerSmc(p *Parser, extras ...interface{}) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	gas, err := EstimateGas(*caller, *p.SmartContractAddress, currentHeader, p.Bc, p.StateDb, input)
+	availableGas, err := p.availableGas()
 	if err != nil {
 		return nil, err
 	}
+	gas, err := EstimateGas(p.context(), *caller, *p.SmartContractAddress, currentHeader, p.Bc, p.StateDb, input, availableGas)
+	if err == kvm.ErrOutOfGas && availableGas < uint64(MaximumGasToCallFunction) {
+		// gas has been capped by execution limits
+		return nil, &LimitError{Limit: LimitGas, Max: p.budget.limits.MaxGas}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.useGas(gas); err != nil {
+		return nil, err
+	}
 	// otherwise use gas to create new transaction and add to txPool
-	tx, err := GenerateSmcCall(p.GetNonce(), p.Bc.P2P().PrivKey(), *p.SmartContractAddress, input, gas)
+	senderKey := p.SenderKey
+	if senderKey == nil {
+		senderKey = p.Bc.P2P().PrivKey()
+	}
+	tx, err := GenerateSmcCall(p.GetNonce(), senderKey, *p.SmartContractAddress, input, gas)
 	if err != nil {
 		return nil, err
 	}
 
-	// add tx to txPool
-	if err := p.TxPool.AddLocal(tx); err != nil {
+	if p.DryRun {
+		// keep tx instead of sending it
+		p.Transactions = append(p.Transactions, tx)
+	} else if err := p.TxPool.AddLocal(tx); err != nil {
+		// add tx to txPool
 		return nil, err
 	}
 
@@ -313,28 +343,52 @@ func getPackedInput(p *Parser, kaiAbi *abi.ABI, method string, patterns []string
 	return input, nil
 }
 
-// callStaticKardiaMasterSmc calls smc and return result in bytes format
-func callStaticKardiaMasterSmc(from common.Address, to common.Address, currentHeader *types.Header, chain vm.ChainContext, input []byte, statedb *state.StateDB) (result []byte, err error) {
-	ctx := vm.NewKVMContextFromDualNodeCall(from, currentHeader, chain)
-	vmenv := kvm.NewKVM(ctx, statedb, kvm.Config{})
+// callStaticKardiaMasterSmc calls smc with given gas and return result in bytes format and left over gas.
+// The call is aborted when ctx is done.
+func callStaticKardiaMasterSmc(ctx context.Context, from common.Address, to common.Address, currentHeader *types.Header, chain vm.ChainContext,
+	input []byte, statedb *state.StateDB, gas uint64) (result []byte, leftOverGas uint64, err error) {
+	vmContext := vm.NewKVMContextFromDualNodeCall(from, currentHeader, chain)
+	vmenv := kvm.NewKVM(vmContext, statedb, kvm.Config{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			vmenv.Cancel()
+		case <-done:
+		}
+	}()
 	sender := kvm.AccountRef(from)
-	ret, _, err := vmenv.StaticCall(sender, to, input, uint64(MaximumGasToCallFunction))
+	ret, leftOverGas, err := vmenv.StaticCall(sender, to, input, gas)
+	if vmenv.Cancelled() {
+		return make([]byte, 0), leftOverGas, fmt.Errorf("execution aborted")
+	}
 	if err != nil {
-		return make([]byte, 0), err
+		return make([]byte, 0), leftOverGas, err
 	}
-	return ret, nil
+	return ret, leftOverGas, nil
 }
 
-// EstimateGas estimates spent in order to
-func EstimateGas(from common.Address, to common.Address, currentHeader *types.Header, bc base.BaseBlockChain, stateDb *state.StateDB, input []byte) (uint64, error) {
+// EstimateGas estimates gas spent in order to call smc at to with given input. The call may use at most gas,
+// kvm.ErrOutOfGas is returned if it needs more. The call is aborted when ctx is done.
+func EstimateGas(ctx context.Context, from common.Address, to common.Address, currentHeader *types.Header, bc base.BaseBlockChain,
+	stateDb *state.StateDB, input []byte, gas uint64) (uint64, error) {
 	// Create new call message
-	msg := types.NewMessage(from, &to, 0, big.NewInt(0), uint64(MaximumGasToCallFunction), big.NewInt(1), input, false)
+	msg := types.NewMessage(from, &to, 0, big.NewInt(0), gas, big.NewInt(1), input, false)
 	// Create a new context to be used in the KVM environment
 	vmContext := vm.NewKVMContext(msg, currentHeader, bc)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
 	kaiVm := kvm.NewKVM(vmContext, stateDb, kvm.Config{})
-	defer kaiVm.Cancel()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			kaiVm.Cancel()
+		case <-done:
+		}
+	}()
 	// Apply the transaction to the current state (included in the env)
 	gp := new(types.GasPool).AddGas(common.MaxUint64)
 	result, err := bc.ApplyMessage(kaiVm, msg, gp)
@@ -345,6 +399,9 @@ func EstimateGas(from common.Address, to common.Address, currentHeader *types.He
 	if kaiVm.Cancelled() {
 		return 0, fmt.Errorf("execution aborted")
 	}
+	if result.Err == kvm.ErrOutOfGas {
+		return 0, result.Err
+	}
 	return result.UsedGas + bufferGas, nil // need to add some bufferGas to prevent out of gas
 }
 
@@ -27,6 +27,7 @@ import (
 	message "github.com/kardiachain/go-kardia/ksml/proto"
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1289,3 +1290,136 @@ func TestReplaceFunction(t *testing.T) {
 	expectedResult := "helloWorld"
 	require.Equal(t, expectedResult, parser.UserDefinedVariables["testReplace"])
 }
+
+func TestCryptoBuiltIns(t *testing.T) {
+	parser, err := setup(sampleCode2, sampleDefinition2, []string{
+		"${fn:sha256('abc')}",
+		"${fn:keccak256('')}",
+		"${fn:bytesToHex(fn:hexToBytes('0x0102ff'))}",
+		"${fn:checksumAddress('0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed')}",
+		"${fn:add('0xff',1)}",
+		"${fn:sub(message.params[0],'1000000000000000000000')}",
+		"${fn:mod(message.params[0],10)}",
+		"${fn:intCmp(1,2)}",
+		"${fn:abiDecode('address|uint256',fn:abiEncode('address|uint256','0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed',message.params[0]))}",
+	}, &message.EventMessage{
+		Params: []string{"1000000000000000000001"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseParams())
+
+	amount, _ := big.NewInt(0).SetString("1000000000000000000001", 10)
+	require.Equal(t, []interface{}{
+		"0xba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"0x0102ff",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		big.NewInt(256),
+		big.NewInt(1),
+		big.NewInt(1),
+		int64(-1),
+		[]interface{}{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", amount},
+	}, parser.GetGlobalParams())
+}
+
+func TestMulDiv_uint256(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	parser, err := setup(sampleCode2, sampleDefinition2, []string{
+		"${fn:mul(message.params[0],1)}",
+		"${fn:div(message.params[0],3)}",
+		"${fn:mul(fn:int(message.params[1]),fn:int(message.params[1]))}",
+		"${fn:div(fn:float(message.params[2]),4)}",
+	}, &message.EventMessage{
+		Params: []string{maxUint256.String(), "1000000000000000000001", "2.5"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseParams())
+
+	amount, _ := big.NewInt(0).SetString("1000000000000000000001", 10)
+	params := parser.GetGlobalParams()
+	require.Len(t, params, 4)
+	require.Equal(t, maxUint256, params[0])
+	require.Equal(t, new(big.Int).Div(maxUint256, big.NewInt(3)), params[1])
+	require.Equal(t, new(big.Int).Mul(amount, amount), params[2])
+	require.Equal(t, "0.625", params[3].(*big.Float).Text('f', 3))
+
+	parser, err = setup(sampleCode2, sampleDefinition2, []string{"${fn:div(message.params[0],0)}"}, &message.EventMessage{
+		Params: []string{"1"},
+	})
+	require.NoError(t, err)
+	require.Error(t, parser.ParseParams())
+}
+
+func TestAbiEncode_int8Range(t *testing.T) {
+	parser, err := setup(sampleCode2, sampleDefinition2, []string{
+		"${fn:abiEncode('int8',-128)}",
+		"${fn:abiEncode('int8',127)}",
+	}, &message.EventMessage{})
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseParams())
+	require.Equal(t, []interface{}{
+		"0x" + strings.Repeat("ff", 31) + "80",
+		"0x" + strings.Repeat("00", 31) + "7f",
+	}, parser.GetGlobalParams())
+
+	for _, pattern := range []string{"${fn:abiEncode('int8',-129)}", "${fn:abiEncode('int8',128)}"} {
+		parser, err = setup(sampleCode2, sampleDefinition2, []string{pattern}, &message.EventMessage{})
+		require.NoError(t, err)
+		require.Error(t, parser.ParseParams(), pattern)
+	}
+}
+
+func TestAbiEncode_bigIntRange(t *testing.T) {
+	maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	parser, err := setup(sampleCode2, sampleDefinition2, []string{
+		"${fn:abiEncode('uint128',fn:int(message.params[0]))}",
+		"${fn:abiEncode('int256',-1)}",
+	}, &message.EventMessage{Params: []string{maxUint128.String()}})
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseParams())
+	require.Equal(t, []interface{}{
+		"0x" + strings.Repeat("00", 16) + strings.Repeat("ff", 16),
+		"0x" + strings.Repeat("ff", 32),
+	}, parser.GetGlobalParams())
+
+	overflow := new(big.Int).Add(maxUint128, big.NewInt(1))
+	for _, pattern := range []string{
+		"${fn:abiEncode('uint256',-1)}",
+		"${fn:abiEncode('uint128',fn:int(message.params[0]))}",
+		"${fn:abiEncode('int128',fn:int(message.params[0]))}",
+	} {
+		parser, err = setup(sampleCode2, sampleDefinition2, []string{pattern}, &message.EventMessage{
+			Params: []string{overflow.String()},
+		})
+		require.NoError(t, err)
+		require.Error(t, parser.ParseParams(), pattern)
+	}
+}
+
+func TestEcrecover_verifyWithdrawal(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	// sign keccak256(abi.encode(to, amount)) off-chain
+	to, amount := common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), big.NewInt(1000)
+	addressTy, _ := abi.NewType("address", "", nil)
+	uintTy, _ := abi.NewType("uint256", "", nil)
+	data, err := abi.Arguments{{Type: addressTy}, {Type: uintTy}}.Pack(to, amount)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(crypto.Keccak256(data), key)
+	require.NoError(t, err)
+	sig[64] += 27
+
+	parser, err := setup(sampleCode2, sampleDefinition2, []string{
+		"${fn:var(hash,string,fn:keccak256(fn:abiEncode('address|uint256',message.params[0],message.params[1])))}",
+		"${fn:var(signer,string,fn:ecrecover(hash,message.params[2]))}",
+		"${fn:validate(signer==message.params[3],SIGNAL_CONTINUE,SIGNAL_STOP)}",
+		"${signer}",
+	}, &message.EventMessage{
+		Params: []string{to.Hex(), amount.String(), common.Encode(sig), signer.Hex()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseParams())
+	require.Equal(t, []interface{}{signer.Hex()}, parser.GetGlobalParams())
+}
@@ -19,9 +19,12 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/kardiachain/go-kardia/configs"
@@ -30,7 +33,9 @@ import (
 	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
 	"github.com/kardiachain/go-kardia/ksml"
 	message "github.com/kardiachain/go-kardia/ksml/proto"
+	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/mainchain/blockchain"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
@@ -460,3 +465,133 @@ func TestSimulateDexDepositNEO(t *testing.T) {
 	err = parser.ParseParams()
 	require.NoError(t, err)
 }
+
+func TestValidatePatterns(t *testing.T) {
+	require.NoError(t, ksml.ValidatePatterns([]string{
+		"${fn:ping()}",
+		"${fn:int(fn:mul(message.amount,10))}",
+		"plain text",
+	}, nil))
+	require.Error(t, ksml.ValidatePatterns([]string{}, nil))
+	require.Error(t, ksml.ValidatePatterns([]string{"${fn:unknown(1)}"}, nil))
+	require.Error(t, ksml.ValidatePatterns([]string{"${fn:int(fn:unknown(1))}"}, nil))
+	require.Error(t, ksml.ValidatePatterns([]string{"${fn:ping(}"}, nil))
+}
+
+func TestChecker(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(sampleDefinition5))
+	require.NoError(t, err)
+	checker := ksml.NewChecker(&contractAbi)
+
+	require.Empty(t, checker.Check([]string{
+		"${smc:trigger(setData,10)}",
+		"${smc:trigger(setData,0x0a)}",
+		"${smc:trigger(setData,message.params[0])}",
+		"${fn:defineFunc(double,x)}",
+		"${fn:mul(x,2)}",
+		"${fn:endDefineFunc(double)}",
+		"${fn:call(double,1)}",
+		"${fn:forEach(loop,message.params,i)}",
+		"${fn:endForEach(loop)}",
+	}))
+
+	errs := checker.Check([]string{
+		"${smc:trigger(setData,300)}",
+		"${smc:trigger(setData,0x12c)}",
+		"${smc:trigger(setData)}",
+		"${smc:getData(unknown)}",
+		"${fn:call(double,1)}",
+		"${fn:mul(1)}",
+		"${fn:if(block,true)}",
+		"${fn:endForEach(loop)}",
+	})
+	require.Len(t, errs, 8)
+	for i, err := range errs[:6] {
+		require.Equal(t, i, err.(*ksml.CheckError).Line)
+	}
+	require.Equal(t, 7, errs[6].(*ksml.CheckError).Line)
+	require.Equal(t, 6, errs[7].(*ksml.CheckError).Line)
+}
+
+func TestParseParams_dryRun(t *testing.T) {
+	patterns := []string{
+		"${smc:trigger(setData,message.params[0])}",
+	}
+	msg := &message.EventMessage{
+		Params: []string{"10"},
+	}
+	parser, err := setup(sampleCode5, sampleDefinition5, patterns, msg)
+	require.NoError(t, err)
+	parser.DryRun = true
+	parser.SenderKey, err = crypto.GenerateKey()
+	require.NoError(t, err)
+
+	require.NoError(t, parser.ParseParams())
+	require.Len(t, parser.Transactions, 1)
+	require.Equal(t, parser.GetGlobalParams(), []interface{}{parser.Transactions[0].Hash().Hex()})
+	pending, queued := parser.TxPool.Stats()
+	require.Zero(t, pending+queued)
+}
+
+func TestParseParams_limits(t *testing.T) {
+	msg := &message.EventMessage{
+		Params: []string{"10"},
+	}
+
+	// recursive function exceeds depth limit
+	parser, err := setup(sampleCode4, sampleDefinition4, []string{
+		"${fn:defineFunc(loop)}",
+		"${fn:call(loop)}",
+		"${fn:endDefineFunc(loop)}",
+		"${fn:call(loop)}",
+	}, msg)
+	require.NoError(t, err)
+	err = parser.ParseParams()
+	var limitErr *ksml.LimitError
+	require.True(t, errors.As(err, &limitErr), "unexpected error %v", err)
+	require.Equal(t, ksml.LimitDepth, limitErr.Limit)
+
+	// too many steps
+	parser, err = setup(sampleCode4, sampleDefinition4, []string{
+		"${1 + 1}",
+		"${2 + 2}",
+		"${3 + 3}",
+	}, msg)
+	require.NoError(t, err)
+	parser.Limits = &ksml.Limits{MaxSteps: 2}
+	err = parser.ParseParams()
+	require.True(t, errors.As(err, &limitErr), "unexpected error %v", err)
+	require.Equal(t, ksml.LimitSteps, limitErr.Limit)
+
+	// not enough gas for smc call
+	parser, err = setup(sampleCode4, sampleDefinition4, []string{
+		"${smc:getData(getSingleUintValue)}",
+	}, msg)
+	require.NoError(t, err)
+	parser.Limits = &ksml.Limits{MaxGas: 10}
+	err = parser.ParseParams()
+	require.True(t, errors.As(err, &limitErr), "unexpected error %v", err)
+	require.Equal(t, ksml.LimitGas, limitErr.Limit)
+
+	// not enough gas for smc trigger
+	parser, err = setup(sampleCode5, sampleDefinition5, []string{
+		"${smc:trigger(setData,message.params[0])}",
+	}, msg)
+	require.NoError(t, err)
+	parser.DryRun = true
+	parser.SenderKey, err = crypto.GenerateKey()
+	require.NoError(t, err)
+	parser.Limits = &ksml.Limits{MaxGas: 30000}
+	err = parser.ParseParams()
+	require.True(t, errors.As(err, &limitErr), "unexpected error %v", err)
+	require.Equal(t, ksml.LimitGas, limitErr.Limit)
+	require.Empty(t, parser.Transactions)
+
+	// cancelled context
+	parser, err = setup(sampleCode4, sampleDefinition4, []string{"${1 + 1}"}, msg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	parser.Context = ctx
+	require.True(t, errors.Is(parser.ParseParams(), context.Canceled))
+}
@@ -58,6 +58,18 @@ const (
 	exp                = "exp"
 	format             = "format"
 	round              = "round"
+	keccak256          = "keccak256"
+	sha256Func         = "sha256"
+	hexToBytes         = "hexToBytes"
+	bytesToHex         = "bytesToHex"
+	abiEncode          = "abiEncode"
+	abiDecode          = "abiDecode"
+	ecrecover          = "ecrecover"
+	checksumAddress    = "checksumAddress"
+	add                = "add"
+	sub                = "sub"
+	mod                = "mod"
+	intCmp             = "intCmp"
 
 	MaximumGasToCallFunction = uint(5000000)
 	intType                  = "int"
@@ -86,6 +98,7 @@ const (
 	globalContractAddress = "contractAddress"
 	globalProxyName       = "proxyName"
 	prefixSeparator       = ":"
+	abiTypesSeparator     = "|"
 	messagePackage        = "protocol.EventMessage"
 
 	signalContinue = "SIGNAL_CONTINUE"
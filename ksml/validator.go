@@ -0,0 +1,39 @@
+/*
+ *  Copyright 2019 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ksml
+
+import (
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+)
+
+// isExpression checks if pattern has ${exp} format, which is evaluated by parser.
+func isExpression(pattern string) bool {
+	return len(pattern) >= elMinLength && strings.HasPrefix(pattern, "${") && strings.HasSuffix(pattern, "}")
+}
+
+// ValidatePatterns statically checks a list of KSML patterns before they are stored, see Checker.
+// contractAbi is the abi of the smart contract that smc built-ins are called on, it may be nil.
+func ValidatePatterns(patterns []string, contractAbi *abi.ABI) error {
+	if errs := NewChecker(contractAbi).Check(patterns); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
@@ -43,6 +43,7 @@ var (
 	ErrReturnStackExceeded      = errors.New("return stack limit reached")
 	ErrInterpreterNotCompatible = errors.New("interpreter not compatible")
 	ErrTraceLimitReached        = errors.New("the number of logs reached the specified limit")
+	ErrStatefulPrecompileCode   = errors.New("stateful precompiled contract called through callcode or delegatecall")
 )
 
 // ErrStackUnderflow wraps an evm error when the items on the stack less
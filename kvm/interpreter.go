@@ -19,6 +19,7 @@ package kvm
 
 import (
 	"hash"
+	"math/big"
 	"sync/atomic"
 
 	"github.com/kardiachain/go-kardia/lib/common"
@@ -32,6 +33,12 @@ type Config struct {
 	// delegate call and create.
 	NoRecursion             bool
 	EnablePreimageRecording bool // Enables recording of SHA3/keccak preimages
+	// CallHook, if set, is invoked before every message call, including the
+	// internal ones made by contracts.
+	CallHook func(caller common.Address, addr common.Address, input []byte, value *big.Int, depth int)
+	// CallExitHook, if set, is invoked when a message call seen by CallHook
+	// returns, with the error of the call, nil if it succeeded.
+	CallExitHook func(depth int, err error)
 	// JumpTable contains the KVM instruction table. This
 	// may be left uninitialised and will be set to the default
 	// table.
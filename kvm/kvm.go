@@ -46,12 +46,37 @@ type (
 )
 
 func (kvm *KVM) precompile(addr common.Address) (PrecompiledContract, bool) {
+	if r, ok := registeredPrecompiles[addr]; ok && kvm.BlockHeight != nil && r.active(kvm.ChainConfig, kvm.BlockHeight.Uint64()) {
+		return r.contract, true
+	}
 	var precompiles map[common.Address]PrecompiledContract
-	precompiles = PrecompiledContractsV0
+	switch {
+	case kvm.BlockHeight != nil && kvm.ChainConfig.IsPrecompilesV1(kvm.BlockHeight.Uint64()):
+		precompiles = PrecompiledContractsV1
+	default:
+		precompiles = PrecompiledContractsV0
+	}
 	p, ok := precompiles[addr]
 	return p, ok
 }
 
+// runPrecompiledContract runs a precompiled contract called by caller, giving
+// the stateful ones access to the KVM.
+func (kvm *KVM) runPrecompiledContract(p PrecompiledContract, caller common.Address, input []byte, gas uint64, value *big.Int, readOnly bool) ([]byte, uint64, error) {
+	sp, ok := p.(StatefulPrecompiledContract)
+	if !ok {
+		return RunPrecompiledContract(p, input, gas)
+	}
+	gasCost := p.RequiredGas(input)
+	if gas < gasCost {
+		return nil, 0, ErrOutOfGas
+	}
+	// The calls made by the contract are one level deeper than its caller
+	kvm.depth++
+	defer func() { kvm.depth-- }()
+	return sp.RunStateful(kvm, caller, input, value, gas-gasCost, readOnly || kvm.interpreter.readOnly)
+}
+
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(kvm *KVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if kvm.interpreter.CanRun(contract.Code) {
@@ -81,6 +106,10 @@ type Context struct {
 	GasLimit    uint64         // Provides information for GASLIMIT
 	BlockHeight *big.Int       // Provides information for HEIGHT
 	Time        *big.Int       // Provides information for TIME
+
+	// ChainConfig provides the heights from which the KVM features are
+	// active, nil keeps all of them disabled
+	ChainConfig *configs.ChainConfig
 }
 
 // KVM is the Kardia Virtual Machine base object and provides
@@ -162,12 +191,18 @@ func (kvm *KVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		return nil, gas, ErrInsufficientBalance
 	}
 
+	if kvm.vmConfig.CallHook != nil {
+		kvm.vmConfig.CallHook(caller.Address(), addr, input, value, kvm.depth)
+		if kvm.vmConfig.CallExitHook != nil {
+			defer func(depth int) { kvm.vmConfig.CallExitHook(depth, err) }(kvm.depth)
+		}
+	}
+
 	snapshot := kvm.StateDB.Snapshot()
 	p, isPrecompile := kvm.precompile(addr)
 
 	if !kvm.StateDB.Exist(addr) {
-		precompiles := PrecompiledContractsV0
-		if precompiles[addr] == nil && value.Sign() == 0 {
+		if !isPrecompile && value.Sign() == 0 {
 			// Calling a non existing account, don't do antything, but ping the tracer
 			if kvm.vmConfig.Debug && kvm.depth == 0 {
 				kvm.vmConfig.Tracer.CaptureStart(caller.Address(), addr, false, input, gas, value)
@@ -188,7 +223,7 @@ func (kvm *KVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	}
 
 	if isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas)
+		ret, gas, err = kvm.runPrecompiledContract(p, caller.Address(), input, gas, value, false)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
@@ -245,9 +280,14 @@ func (kvm *KVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	}
 
 	var snapshot = kvm.StateDB.Snapshot()
-	// It is allowed to call precompiles, even via delegatecall
+	// It is allowed to call precompiles, even via delegatecall, except the
+	// stateful ones which act on behalf of their caller
 	if p, isPrecompile := kvm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas)
+		if _, ok := p.(StatefulPrecompiledContract); ok {
+			ret, gas, err = nil, 0, ErrStatefulPrecompileCode
+		} else {
+			ret, gas, err = RunPrecompiledContract(p, input, gas)
+		}
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and set the code that is to be used by the EVM.
@@ -282,9 +322,14 @@ func (kvm *KVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 
 	var snapshot = kvm.StateDB.Snapshot()
 
-	// It is allowed to call precompiles, even via delegatecall
+	// It is allowed to call precompiles, even via delegatecall, except the
+	// stateful ones which act on behalf of their caller
 	if p, isPrecompile := kvm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas)
+		if _, ok := p.(StatefulPrecompiledContract); ok {
+			ret, gas, err = nil, 0, ErrStatefulPrecompileCode
+		} else {
+			ret, gas, err = RunPrecompiledContract(p, input, gas)
+		}
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and make initialise the delegate values
@@ -328,7 +373,7 @@ func (kvm *KVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	kvm.StateDB.AddBalance(addr, big0)
 
 	if p, isPrecompile := kvm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas)
+		ret, gas, err = kvm.runPrecompiledContract(p, caller.Address(), input, gas, new(big.Int), true)
 	} else {
 		// At this point, we use a copy of address. If we don't, the go compiler will
 		// leak the 'contract' to the outer scope, and make allocation for 'contract'
@@ -0,0 +1,328 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// Unlike the other dpos contracts, Governance is built with solc 0.8.21, optimizer
+// enabled with 200 runs and evmVersion petersburg (the KVM has no CHAINID nor PUSH0).
+// Its bytecode and ABI are GovernanceContract in configs/contracts.go.
+
+interface IGovernanceStaking {
+    function totalBonded() external view returns (uint256);
+    function treasury() external view returns (address);
+    function params() external view returns (address);
+    function getValidatorsByDelegator(address delAddr) external view returns (address[] memory);
+}
+
+interface IGovernanceValidator {
+    function getDelegatorStake(address delAddr) external view returns (uint256);
+}
+
+/**
+ * @dev Governance lets bonded validators and delegators change staking params.
+ * A proposal is a list of param changes (keys are `Params.ParamKey`) and a target height.
+ * A vote is weighted by the stake of the voter when the vote is cast, voting again
+ * updates the option and the weight.
+ * Proposals reaching their target height are executed by `finalize`, which the chain
+ * calls at every block. It returns the changes of the passed proposals and the chain
+ * writes them to the params contract, which has no setter.
+ */
+contract Governance {
+    enum ProposalStatus {
+        Pending,
+        Passed,
+        Rejected
+    }
+
+    enum VoteOption {
+        Abstain,
+        Yes,
+        No
+    }
+
+    // same as Params.ParamKey
+    enum ParamKey {
+        baseProposerReward,
+        bonusProposerReward,
+        maxProposers,
+        downtimeJailDuration,
+        slashFractionDowntime,
+        unbondingTime,
+        slashFractionDoubleSign,
+        signedBlockWindow,
+        minSignedPerWindow,
+        minStake,
+        minValidatorStake,
+        minAmountChangeName,
+        minSelfDelegation,
+        inflationRateChange,
+        goalBonded,
+        blocksPerYear,
+        inflationMax,
+        inflationMin,
+        Deposit,
+        VotingPeriod
+    }
+
+    struct Proposal {
+        address proposer;
+        uint256[] keys;
+        uint256[] values;
+        uint256 deposit;
+        uint256 startHeight;
+        uint256 targetHeight;
+        ProposalStatus status;
+        uint256 yes;
+        uint256 no;
+        uint256 abstain;
+    }
+
+    struct Vote {
+        bool voted;
+        VoteOption option;
+        uint256 weight;
+    }
+
+    uint256 public constant ONE = 10**18;
+    uint256 public constant MAX_PARAM_KEY = uint256(ParamKey.VotingPeriod);
+    uint256 public constant minDeposit = 5 * 10**23; // 500000 KAI
+    uint256 public constant minVotingPeriod = 17280; // ~1 day in blocks
+    uint256 public constant maxPendingProposals = 20;
+    uint256 public constant quorum = 334 * 10**15; // 33.4% of total bonded must vote
+    uint256 public constant threshold = 5 * 10**17; // more than 50% of yes and no votes must be yes
+
+    IGovernanceStaking public constant staking = IGovernanceStaking(0x0000000000000000000000000000000000001337);
+
+    Proposal[] private _proposals;
+    uint256[] private _pendingProposals;
+    mapping(uint256 => mapping(address => Vote)) private _votes;
+    // deposits which could not be sent back, see withdraw
+    mapping(address => uint256) public refunds;
+
+    event ProposalCreated(uint256 indexed proposalId, address proposer, uint256[] keys, uint256[] values, uint256 targetHeight);
+    event Voted(uint256 indexed proposalId, address voter, VoteOption option, uint256 weight);
+    event ProposalExecuted(uint256 indexed proposalId, ProposalStatus status, uint256 yes, uint256 no, uint256 abstain);
+
+    // create a param change proposal executed at targetHeight
+    function propose(uint256[] calldata keys, uint256[] calldata values, uint256 targetHeight) external payable returns (uint256) {
+        require(keys.length > 0 && keys.length == values.length && keys.length <= MAX_PARAM_KEY + 1, "invalid params");
+        require(msg.value >= minDeposit, "min deposit");
+        require(targetHeight >= block.number + minVotingPeriod, "target height is too early");
+        require(_pendingProposals.length < maxPendingProposals, "too many pending proposals");
+        require(stakeOf(msg.sender) > 0, "proposer is not bonded");
+        for (uint i = 0; i < keys.length; i++) {
+            (bool allowed, uint256 min, uint256 max) = paramBounds(keys[i]);
+            require(allowed, "param cannot be changed");
+            require(values[i] >= min && values[i] <= max, "param value out of bounds");
+        }
+
+        uint256 proposalId = _proposals.length;
+        Proposal storage proposal = _proposals.push();
+        proposal.proposer = msg.sender;
+        proposal.keys = keys;
+        proposal.values = values;
+        proposal.deposit = msg.value;
+        proposal.startHeight = block.number;
+        proposal.targetHeight = targetHeight;
+        _pendingProposals.push(proposalId);
+        emit ProposalCreated(proposalId, msg.sender, keys, values, targetHeight);
+        return proposalId;
+    }
+
+    // vote or change vote of a pending proposal, the vote weighs the current stake of the voter
+    function vote(uint256 proposalId, VoteOption option) external {
+        require(proposalId < _proposals.length, "proposal not found");
+        Proposal storage proposal = _proposals[proposalId];
+        require(proposal.status == ProposalStatus.Pending, "inactive proposal");
+        require(block.number < proposal.targetHeight, "voting is closed");
+        uint256 weight = stakeOf(msg.sender);
+        require(weight > 0, "voter is not bonded");
+
+        Vote storage v = _votes[proposalId][msg.sender];
+        if (v.voted) {
+            _count(proposal, v.option, v.weight, false);
+        }
+        v.voted = true;
+        v.option = option;
+        v.weight = weight;
+        _count(proposal, option, weight, true);
+        emit Voted(proposalId, msg.sender, option, weight);
+    }
+
+    // execute proposals which reach their target height, called by the chain at every block.
+    // It returns the param changes of the passed proposals, in execution order.
+    function finalize() external returns (uint256[] memory keys, uint256[] memory values) {
+        require(msg.sender == address(this), "caller is not the chain");
+        uint256[] memory passed = new uint256[](_pendingProposals.length);
+        uint256 passedCount = 0;
+        uint256 changes = 0;
+        uint i = 0;
+        while (i < _pendingProposals.length) {
+            uint256 proposalId = _pendingProposals[i];
+            if (_proposals[proposalId].targetHeight > block.number) {
+                i++;
+                continue;
+            }
+            _pendingProposals[i] = _pendingProposals[_pendingProposals.length - 1];
+            _pendingProposals.pop();
+            if (_execute(proposalId)) {
+                passed[passedCount++] = proposalId;
+                changes += _proposals[proposalId].keys.length;
+            }
+        }
+
+        keys = new uint256[](changes);
+        values = new uint256[](changes);
+        changes = 0;
+        for (i = 0; i < passedCount; i++) {
+            Proposal storage proposal = _proposals[passed[i]];
+            for (uint j = 0; j < proposal.keys.length; j++) {
+                keys[changes] = proposal.keys[j];
+                values[changes] = proposal.values[j];
+                changes++;
+            }
+        }
+    }
+
+    // withdraw a deposit which could not be sent back when its proposal was executed
+    function withdraw() external {
+        uint256 amount = refunds[msg.sender];
+        require(amount > 0, "nothing to withdraw");
+        refunds[msg.sender] = 0;
+        payable(msg.sender).transfer(amount);
+    }
+
+    // paramBounds returns whether governance can change a param and the bounds of its value
+    function paramBounds(uint256 key) public pure returns (bool allowed, uint256 min, uint256 max) {
+        if (key > MAX_PARAM_KEY) {
+            return (false, 0, 0);
+        }
+        ParamKey k = ParamKey(key);
+        if (k == ParamKey.baseProposerReward || k == ParamKey.bonusProposerReward ||
+            k == ParamKey.slashFractionDowntime || k == ParamKey.slashFractionDoubleSign ||
+            k == ParamKey.minSignedPerWindow || k == ParamKey.inflationRateChange ||
+            k == ParamKey.inflationMax || k == ParamKey.inflationMin) {
+            return (true, 0, ONE);
+        }
+        if (k == ParamKey.goalBonded) {
+            return (true, 1, ONE);
+        }
+        if (k == ParamKey.maxProposers) {
+            return (true, 1, 100);
+        }
+        if (k == ParamKey.downtimeJailDuration) {
+            return (true, 1 minutes, 30 days);
+        }
+        if (k == ParamKey.unbondingTime) {
+            return (true, 1 days, 365 days);
+        }
+        if (k == ParamKey.minStake || k == ParamKey.minValidatorStake ||
+            k == ParamKey.minAmountChangeName || k == ParamKey.minSelfDelegation) {
+            return (true, 1 ether, 10**9 * 1 ether);
+        }
+        if (k == ParamKey.blocksPerYear) {
+            return (true, 10**5, 10**8);
+        }
+        // signedBlockWindow sizes the missed blocks of the running validators,
+        // Deposit and VotingPeriod belong to the proposals of the params contract
+        return (false, 0, 0);
+    }
+
+    // stakeOf returns total stake the address delegated to validators
+    function stakeOf(address voter) public view returns (uint256 stake) {
+        address[] memory vals = staking.getValidatorsByDelegator(voter);
+        for (uint i = 0; i < vals.length; i++) {
+            try IGovernanceValidator(vals[i]).getDelegatorStake(voter) returns (uint256 amount) {
+                stake += amount;
+            } catch {
+                // the delegation has been withdrawn
+            }
+        }
+    }
+
+    // params returns the address of the params contract updated by the passed proposals
+    function params() external view returns (address) {
+        return staking.params();
+    }
+
+    function allProposals() external view returns (uint256) {
+        return _proposals.length;
+    }
+
+    function getPendingProposals() external view returns (uint256[] memory) {
+        return _pendingProposals;
+    }
+
+    function getProposal(uint256 proposalId) external view returns (
+        address proposer,
+        uint256[] memory keys,
+        uint256[] memory values,
+        uint256 deposit,
+        uint256 startHeight,
+        uint256 targetHeight,
+        ProposalStatus status
+    ) {
+        require(proposalId < _proposals.length, "proposal not found");
+        Proposal storage proposal = _proposals[proposalId];
+        return (
+            proposal.proposer,
+            proposal.keys,
+            proposal.values,
+            proposal.deposit,
+            proposal.startHeight,
+            proposal.targetHeight,
+            proposal.status
+        );
+    }
+
+    function getProposalResults(uint256 proposalId) external view returns (uint256 yes, uint256 no, uint256 abstain) {
+        require(proposalId < _proposals.length, "proposal not found");
+        Proposal storage proposal = _proposals[proposalId];
+        return (proposal.yes, proposal.no, proposal.abstain);
+    }
+
+    function getVote(uint256 proposalId, address voter) external view returns (bool voted, VoteOption option, uint256 weight) {
+        require(proposalId < _proposals.length, "proposal not found");
+        Vote storage v = _votes[proposalId][voter];
+        return (v.voted, v.option, v.weight);
+    }
+
+    // _execute decides a proposal, its deposit is sent back to the proposer if it passed
+    // and to the treasury otherwise. It returns whether the proposal passed.
+    function _execute(uint256 proposalId) private returns (bool passed) {
+        Proposal storage proposal = _proposals[proposalId];
+        uint256 totalVoted = proposal.yes + proposal.no + proposal.abstain;
+        try staking.totalBonded() returns (uint256 totalBonded) {
+            passed = totalVoted > 0 &&
+                totalVoted * ONE >= totalBonded * quorum &&
+                proposal.yes * ONE > (proposal.yes + proposal.no) * threshold;
+        } catch {
+            passed = false;
+        }
+
+        address receiver = proposal.proposer;
+        if (passed) {
+            proposal.status = ProposalStatus.Passed;
+        } else {
+            proposal.status = ProposalStatus.Rejected;
+            try staking.treasury() returns (address treasury) {
+                receiver = treasury;
+            } catch {
+                // the proposer can withdraw the deposit
+            }
+        }
+        // use send so a failed transfer cannot stop finalize
+        if (!payable(receiver).send(proposal.deposit)) {
+            refunds[receiver] += proposal.deposit;
+        }
+        emit ProposalExecuted(proposalId, proposal.status, proposal.yes, proposal.no, proposal.abstain);
+    }
+
+    function _count(Proposal storage proposal, VoteOption option, uint256 weight, bool add) private {
+        if (option == VoteOption.Yes) {
+            proposal.yes = add ? proposal.yes + weight : proposal.yes - weight;
+        } else if (option == VoteOption.No) {
+            proposal.no = add ? proposal.no + weight : proposal.no - weight;
+        } else {
+            proposal.abstain = add ? proposal.abstain + weight : proposal.abstain - weight;
+        }
+    }
+}
@@ -64,6 +64,7 @@ contract Params is Ownable {
     }
 
     IStaking private _staking;
+    // storage slot 2, the chain writes the params changed by Governance.sol into it
     mapping(uint256 => uint256) public params;
     Proposal[] public proposals;
 
@@ -29,6 +29,8 @@ interface IStaking {
     function startValidator() external;
     function getValidatorSets() external view returns (address[] memory, uint256[] memory);
     function treasury() external view returns(address);
+    function params() external view returns(address);
+    function getValidatorsByDelegator(address delAddr) external view returns (address[] memory);
 
     // @dev Emitted when validator is created;
     event CreatedValidator(
@@ -24,6 +24,7 @@ interface IValidator {
     function getCommissionRewards() external view returns (uint256);
     function getDelegationRewards(address _delAddr) external view returns (uint256);
     function getDelegations() external view returns (address[] memory, uint256[] memory);
+    function getDelegatorStake(address _delAddr) external view returns (uint256);
     function validateSignature(uint256 _votingPower, bool _signed) external;
     function getSlashEventsLength() external view returns(uint256);
     function selfDelegate(address payable val, uint256 amount) external;
@@ -196,6 +196,14 @@ func (g *Group) MinIndex() int {
 	return g.minIndex
 }
 
+// FilePath returns the path of the file with the given index, the head being
+// the file at MaxIndex.
+func (g *Group) FilePath(index int) string {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return filePathForIndex(g.Head.Path, index, g.maxIndex)
+}
+
 // Write writes the contents of p into the current head of the group. It
 // returns the number of bytes written. If nn < len(p), it also returns an
 // error explaining why the write is short.
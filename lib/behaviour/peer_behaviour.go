@@ -47,3 +47,19 @@ type blockPart struct {
 func BlockPart(peerID p2p.ID, explanation string) PeerBehaviour {
 	return PeerBehaviour{peerID: peerID, reason: blockPart{explanation}}
 }
+
+type usefulMessage struct {
+	explanation string
+}
+
+func UsefulMessage(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: usefulMessage{explanation}}
+}
+
+type uselessMessage struct {
+	explanation string
+}
+
+func UselessMessage(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: uselessMessage{explanation}}
+}
@@ -33,12 +33,14 @@ func (spbr *SwitchReporter) Report(behaviour PeerBehaviour) error {
 	}
 
 	switch reason := behaviour.reason.(type) {
-	case consensusVote, blockPart:
+	case consensusVote, blockPart, usefulMessage:
 		spbr.sw.MarkPeerAsGood(peer)
 	case badMessage:
-		spbr.sw.StopPeerForError(peer, reason.explanation)
+		spbr.stopPeerForViolation(peer, reason.explanation)
 	case messageOutOfOrder:
-		spbr.sw.StopPeerForError(peer, reason.explanation)
+		spbr.stopPeerForViolation(peer, reason.explanation)
+	case uselessMessage:
+		spbr.sw.MarkPeerAsBad(peer, reason.explanation)
 	default:
 		return errors.New("unknown reason reported")
 	}
@@ -46,6 +48,17 @@ func (spbr *SwitchReporter) Report(behaviour PeerBehaviour) error {
 	return nil
 }
 
+// stopPeerForViolation lowers the trust of peer and counts the violation
+// towards an automatic ban, then disconnects the peer unless either already
+// did.
+func (spbr *SwitchReporter) stopPeerForViolation(peer p2p.Peer, explanation string) {
+	lowTrust := spbr.sw.MarkPeerAsBad(peer, explanation)
+	banned := spbr.sw.RecordViolation(peer, explanation)
+	if !lowTrust && !banned {
+		spbr.sw.StopPeerForError(peer, explanation)
+	}
+}
+
 // MockReporter is a concrete implementation of the Reporter
 // interface used in reactor tests to ensure reactors report the correct
 // behaviour in manufactured scenarios.
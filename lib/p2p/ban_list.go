@@ -0,0 +1,316 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	ksync "github.com/kardiachain/go-kardia/lib/sync"
+	"github.com/kardiachain/go-kardia/lib/tempfile"
+)
+
+// violationWindow is the period over which protocol violations of a peer are
+// counted towards an automatic ban.
+const violationWindow = time.Hour
+
+// BanEntry is a banned node ID or IP range. A zero Until bans forever.
+type BanEntry struct {
+	ID     ID        `json:"id,omitempty"`
+	CIDR   string    `json:"cidr,omitempty"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+
+	ipNet  *net.IPNet
+	static bool // set by the configuration, never saved
+}
+
+// Target returns the banned node ID or IP range.
+func (e *BanEntry) Target() string {
+	if e.ID != "" {
+		return string(e.ID)
+	}
+	return e.CIDR
+}
+
+func (e *BanEntry) expired(now time.Time) bool {
+	return !e.Until.IsZero() && !now.Before(e.Until)
+}
+
+type violations struct {
+	count int
+	since time.Time
+}
+
+// BanList keeps node IDs and IP ranges which are neither accepted nor dialed
+// until their ban expires. Changes are saved to a JSON file right away, except
+// for the static bans of the configuration which are kept in memory.
+type BanList struct {
+	mtx      ksync.RWMutex
+	filePath string
+	entries  map[string]*BanEntry // by target
+
+	// automatic bans of peers repeatedly violating the protocol, disabled if
+	// autoBanThreshold is 0
+	autoBanThreshold int
+	autoBanDuration  time.Duration
+	violations       map[ID]*violations
+
+	logger log.Logger
+}
+
+// NewBanList returns a ban list saved to filePath, loading the bans already in
+// it. An empty filePath keeps the bans in memory only.
+func NewBanList(filePath string) (*BanList, error) {
+	bl := &BanList{
+		filePath:   filePath,
+		entries:    make(map[string]*BanEntry),
+		violations: make(map[ID]*violations),
+		logger:     log.NewNopLogger(),
+	}
+	if err := bl.loadFromFile(); err != nil {
+		return nil, err
+	}
+	return bl, nil
+}
+
+// SetLogger sets the logger of the ban list.
+func (bl *BanList) SetLogger(l log.Logger) {
+	bl.logger = l
+}
+
+// SetAutoBan bans peers for duration once they commit threshold protocol
+// violations within an hour. A zero threshold disables automatic bans.
+func (bl *BanList) SetAutoBan(threshold int, duration time.Duration) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.autoBanThreshold, bl.autoBanDuration = threshold, duration
+}
+
+// Ban bans target, a node ID, an IP or a CIDR range, for duration. A zero
+// duration bans forever. It returns the resulting entry.
+func (bl *BanList) Ban(target string, duration time.Duration, reason string) (*BanEntry, error) {
+	entry, err := newBanEntry(target)
+	if err != nil {
+		return nil, err
+	}
+	if duration > 0 {
+		entry.Until = time.Now().Add(duration)
+	}
+	entry.Reason = reason
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	if old, ok := bl.entries[entry.Target()]; ok && old.static {
+		return old, nil
+	}
+	// never shorten an existing ban
+	if old, ok := bl.entries[entry.Target()]; ok && !old.expired(time.Now()) {
+		if old.Until.IsZero() || (!entry.Until.IsZero() && old.Until.After(entry.Until)) {
+			entry.Until = old.Until
+		}
+	}
+	bl.entries[entry.Target()] = entry
+	bl.logger.Info("Banned peer", "target", entry.Target(), "until", entry.Until, "reason", reason)
+	return entry, bl.saveToFile()
+}
+
+// AddStaticBan bans target, a node ID, an IP or a CIDR range, for ever without
+// saving the ban, so it is lifted once target is removed from the
+// configuration.
+func (bl *BanList) AddStaticBan(target string, reason string) error {
+	entry, err := newBanEntry(target)
+	if err != nil {
+		return err
+	}
+	entry.Reason, entry.static = reason, true
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.entries[entry.Target()] = entry
+	return nil
+}
+
+// Unban lifts the ban of target. It returns false if target isn't banned, and
+// an error if it is a static ban.
+func (bl *BanList) Unban(target string) (bool, error) {
+	entry, err := newBanEntry(target)
+	if err != nil {
+		return false, err
+	}
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	old, ok := bl.entries[entry.Target()]
+	if !ok {
+		return false, nil
+	}
+	if old.static {
+		return false, fmt.Errorf("%s is banned by the configuration", entry.Target())
+	}
+	delete(bl.entries, entry.Target())
+	if entry.ID != "" {
+		delete(bl.violations, entry.ID)
+	}
+	return true, bl.saveToFile()
+}
+
+// IsBanned returns the ban matching the node id or ip, nil if there is none.
+// Either of them may be empty.
+func (bl *BanList) IsBanned(id ID, ip net.IP) *BanEntry {
+	bl.mtx.RLock()
+	defer bl.mtx.RUnlock()
+
+	now := time.Now()
+	if id != "" {
+		if entry, ok := bl.entries[string(id)]; ok && !entry.expired(now) {
+			return entry
+		}
+	}
+	if ip == nil {
+		return nil
+	}
+	for _, entry := range bl.entries {
+		if entry.ipNet != nil && !entry.expired(now) && entry.ipNet.Contains(ip) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// List returns the bans in effect, sorted by target.
+func (bl *BanList) List() []*BanEntry {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	bl.pruneExpired()
+	entries := make([]*BanEntry, 0, len(bl.entries))
+	for _, entry := range bl.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Target() < entries[j].Target() })
+	return entries
+}
+
+// RecordViolation counts a protocol violation of the peer with the given id
+// and bans its id and ip once it reaches the automatic ban threshold. It
+// returns true if the peer got banned.
+func (bl *BanList) RecordViolation(id ID, ip net.IP, reason string) bool {
+	bl.mtx.Lock()
+	if bl.autoBanThreshold <= 0 {
+		bl.mtx.Unlock()
+		return false
+	}
+	now := time.Now()
+	v, ok := bl.violations[id]
+	if !ok || now.Sub(v.since) > violationWindow {
+		v = &violations{since: now}
+		bl.violations[id] = v
+	}
+	v.count++
+	if v.count < bl.autoBanThreshold {
+		bl.mtx.Unlock()
+		return false
+	}
+	delete(bl.violations, id)
+	duration := bl.autoBanDuration
+	bl.mtx.Unlock()
+
+	reason = fmt.Sprintf("%d protocol violations, last: %s", v.count, reason)
+	if _, err := bl.Ban(string(id), duration, reason); err != nil {
+		bl.logger.Error("Failed to save ban list", "err", err)
+	}
+	if ip != nil {
+		if _, err := bl.Ban(ip.String(), duration, reason); err != nil {
+			bl.logger.Error("Failed to save ban list", "err", err)
+		}
+	}
+	return true
+}
+
+// newBanEntry parses target as a node ID, an IP or a CIDR range.
+func newBanEntry(target string) (*BanEntry, error) {
+	target = strings.TrimSpace(target)
+	if _, ipNet, err := net.ParseCIDR(target); err == nil {
+		return &BanEntry{CIDR: ipNet.String(), ipNet: ipNet}, nil
+	}
+	if ip := net.ParseIP(target); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		ipNet := &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		return &BanEntry{CIDR: ipNet.String(), ipNet: ipNet}, nil
+	}
+	if err := validateID(ID(target)); err != nil {
+		return nil, fmt.Errorf("%q is neither a node ID, an IP nor a CIDR range: %w", target, err)
+	}
+	return &BanEntry{ID: ID(target)}, nil
+}
+
+// pruneExpired removes expired bans. The caller must hold the lock.
+func (bl *BanList) pruneExpired() {
+	now := time.Now()
+	for target, entry := range bl.entries {
+		if entry.expired(now) {
+			delete(bl.entries, target)
+		}
+	}
+}
+
+/* Loading & Saving */
+
+// saveToFile writes the bans in effect to the file. The caller must hold the lock.
+func (bl *BanList) saveToFile() error {
+	if bl.filePath == "" {
+		return nil
+	}
+	bl.pruneExpired()
+	entries := make([]*BanEntry, 0, len(bl.entries))
+	for _, entry := range bl.entries {
+		if !entry.static {
+			entries = append(entries, entry)
+		}
+	}
+	jsonBytes, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(bl.filePath, jsonBytes, 0644)
+}
+
+// loadFromFile loads the bans saved in the file, if it exists.
+func (bl *BanList) loadFromFile() error {
+	if bl.filePath == "" {
+		return nil
+	}
+	jsonBytes, err := ioutil.ReadFile(bl.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading ban list %s: %w", bl.filePath, err)
+	}
+	var entries []*BanEntry
+	if err := json.Unmarshal(jsonBytes, &entries); err != nil {
+		return fmt.Errorf("error parsing ban list %s: %w", bl.filePath, err)
+	}
+	now := time.Now()
+	for _, saved := range entries {
+		if saved.expired(now) {
+			continue
+		}
+		entry, err := newBanEntry(saved.Target())
+		if err != nil {
+			return fmt.Errorf("invalid entry in ban list %s: %w", bl.filePath, err)
+		}
+		entry.Until, entry.Reason = saved.Until, saved.Reason
+		bl.entries[entry.Target()] = entry
+	}
+	return nil
+}
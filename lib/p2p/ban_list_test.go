@@ -0,0 +1,154 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBanID = ID("00112233445566778899aabbccddeeff00112233")
+
+func TestBanListBanAndUnban(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+
+	ip := net.ParseIP("10.0.0.1")
+	assert.Nil(t, bl.IsBanned(testBanID, ip))
+
+	_, err = bl.Ban("not an id", time.Hour, "test")
+	assert.Error(t, err)
+
+	entry, err := bl.Ban(string(testBanID), time.Hour, "test")
+	require.NoError(t, err)
+	assert.Equal(t, string(testBanID), entry.Target())
+	assert.NotNil(t, bl.IsBanned(testBanID, nil))
+	assert.Nil(t, bl.IsBanned("other", ip))
+
+	// a shorter ban doesn't lift the existing one early
+	entry, err = bl.Ban(string(testBanID), time.Minute, "test")
+	require.NoError(t, err)
+	assert.True(t, entry.Until.After(time.Now().Add(30*time.Minute)))
+
+	ok, err := bl.Unban(string(testBanID))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, bl.IsBanned(testBanID, nil))
+
+	ok, err = bl.Unban(string(testBanID))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestBanListStaticBans(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banlist")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "banlist.json")
+	bl, err := NewBanList(filePath)
+	require.NoError(t, err)
+
+	require.NoError(t, bl.AddStaticBan(string(testBanID), "config"))
+	require.NoError(t, bl.AddStaticBan("10.0.0.0/24", "config"))
+	_, err = bl.Ban("192.168.1.1", 0, "test")
+	require.NoError(t, err)
+	assert.NotNil(t, bl.IsBanned(testBanID, nil))
+	assert.Len(t, bl.List(), 3)
+
+	// static bans are neither replaced nor lifted at runtime
+	entry, err := bl.Ban(string(testBanID), time.Minute, "test")
+	require.NoError(t, err)
+	assert.True(t, entry.Until.IsZero())
+	_, err = bl.Unban(string(testBanID))
+	assert.Error(t, err)
+	assert.NotNil(t, bl.IsBanned(testBanID, nil))
+
+	// nor saved
+	reloaded, err := NewBanList(filePath)
+	require.NoError(t, err)
+	assert.Nil(t, reloaded.IsBanned(testBanID, nil))
+	assert.Nil(t, reloaded.IsBanned("", net.ParseIP("10.0.0.1")))
+	assert.NotNil(t, reloaded.IsBanned("", net.ParseIP("192.168.1.1")))
+}
+
+func TestBanListIPRanges(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+
+	_, err = bl.Ban("10.0.0.0/24", 0, "test")
+	require.NoError(t, err)
+	_, err = bl.Ban("192.168.1.1", 0, "test")
+	require.NoError(t, err)
+
+	assert.NotNil(t, bl.IsBanned("", net.ParseIP("10.0.0.200")))
+	assert.Nil(t, bl.IsBanned("", net.ParseIP("10.0.1.1")))
+	assert.NotNil(t, bl.IsBanned("", net.ParseIP("192.168.1.1")))
+	assert.Nil(t, bl.IsBanned("", net.ParseIP("192.168.1.2")))
+
+	targets := []string{}
+	for _, entry := range bl.List() {
+		assert.True(t, entry.Until.IsZero())
+		targets = append(targets, entry.Target())
+	}
+	assert.Equal(t, []string{"10.0.0.0/24", "192.168.1.1/32"}, targets)
+}
+
+func TestBanListExpiry(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+
+	_, err = bl.Ban(string(testBanID), time.Millisecond, "test")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	assert.Nil(t, bl.IsBanned(testBanID, nil))
+	assert.Empty(t, bl.List())
+}
+
+func TestBanListPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banlist")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "banlist.json")
+	bl, err := NewBanList(filePath)
+	require.NoError(t, err)
+
+	_, err = bl.Ban(string(testBanID), time.Hour, "test")
+	require.NoError(t, err)
+	_, err = bl.Ban("10.0.0.0/8", 0, "test")
+	require.NoError(t, err)
+	_, err = bl.Ban("10.0.0.1", time.Millisecond, "test")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	reloaded, err := NewBanList(filePath)
+	require.NoError(t, err)
+	assert.NotNil(t, reloaded.IsBanned(testBanID, nil))
+	assert.NotNil(t, reloaded.IsBanned("", net.ParseIP("10.1.2.3")))
+	assert.Len(t, reloaded.List(), 2)
+}
+
+func TestBanListAutoBan(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+	ip := net.ParseIP("10.0.0.1")
+
+	// disabled by default
+	for i := 0; i < 5; i++ {
+		assert.False(t, bl.RecordViolation(testBanID, ip, "bad message"))
+	}
+
+	bl.SetAutoBan(3, time.Hour)
+	assert.False(t, bl.RecordViolation(testBanID, ip, "bad message"))
+	assert.False(t, bl.RecordViolation(testBanID, ip, "bad message"))
+	assert.True(t, bl.RecordViolation(testBanID, ip, "bad message"))
+
+	assert.NotNil(t, bl.IsBanned(testBanID, nil))
+	assert.NotNil(t, bl.IsBanned("other", ip))
+}
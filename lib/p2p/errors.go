@@ -138,6 +138,20 @@ func (e ErrSwitchAuthenticationFailure) Error() string {
 	)
 }
 
+// ErrPeerBanned is raised when connecting to or from a banned node ID or IP.
+type ErrPeerBanned struct {
+	ID    ID
+	IP    net.IP
+	Entry *BanEntry
+}
+
+func (e ErrPeerBanned) Error() string {
+	if e.Entry.Until.IsZero() {
+		return fmt.Sprintf("peer %v (%v) is banned: %s", e.ID, e.IP, e.Entry.Reason)
+	}
+	return fmt.Sprintf("peer %v (%v) is banned until %v: %s", e.ID, e.IP, e.Entry.Until, e.Entry.Reason)
+}
+
 // ErrTransportClosed is raised when the Transport has been closed.
 type ErrTransportClosed struct{}
 
@@ -76,6 +76,9 @@ type AddrBook interface {
 
 	Size() int
 
+	// List all known addresses, including banned ones
+	ListAddresses() []*AddressInfo
+
 	// Persist to disk
 	Save()
 }
@@ -479,6 +482,50 @@ func (a *addrBook) Size() int {
 	return a.size()
 }
 
+// AddressInfo describes an address known by the address book.
+type AddressInfo struct {
+	Addr        *p2p.NetAddress `json:"addr"`
+	Src         *p2p.NetAddress `json:"src"`
+	Attempts    int32           `json:"attempts"`
+	IsOld       bool            `json:"is_old"`
+	LastAttempt time.Time       `json:"last_attempt"`
+	LastSuccess time.Time       `json:"last_success"`
+	Banned      bool            `json:"banned"`
+	BannedUntil time.Time       `json:"banned_until"`
+}
+
+// ListAddresses implements AddrBook - returns every known address followed
+// by the banned ones.
+func (a *addrBook) ListAddresses() []*AddressInfo {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	infos := make([]*AddressInfo, 0, len(a.addrLookup)+len(a.badPeers))
+	for _, ka := range a.addrLookup {
+		infos = append(infos, newAddressInfo(ka, false))
+	}
+	for _, ka := range a.badPeers {
+		infos = append(infos, newAddressInfo(ka, true))
+	}
+	return infos
+}
+
+func newAddressInfo(ka *knownAddress, banned bool) *AddressInfo {
+	info := &AddressInfo{
+		Addr:        ka.Addr,
+		Src:         ka.Src,
+		Attempts:    ka.Attempts,
+		IsOld:       ka.isOld(),
+		LastAttempt: ka.LastAttempt,
+		LastSuccess: ka.LastSuccess,
+		Banned:      banned,
+	}
+	if banned {
+		info.BannedUntil = ka.LastBanTime
+	}
+	return info
+}
+
 func (a *addrBook) size() int {
 	return a.nNew + a.nOld
 }
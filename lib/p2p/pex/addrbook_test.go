@@ -440,6 +440,35 @@ func TestBanBadPeers(t *testing.T) {
 	assert.False(t, book.IsGood(addr))
 }
 
+func TestAddrBookListAddresses(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	good, bad := randIPv4Address(t), randIPv4Address(t)
+	require.NoError(t, book.AddAddress(good, good))
+	require.NoError(t, book.AddAddress(bad, bad))
+	book.MarkGood(good.ID)
+	book.MarkBad(bad, time.Minute)
+
+	infos := book.ListAddresses()
+	require.Len(t, infos, 2)
+	for _, info := range infos {
+		switch info.Addr.ID {
+		case good.ID:
+			assert.True(t, info.IsOld)
+			assert.False(t, info.Banned)
+		case bad.ID:
+			assert.True(t, info.Banned)
+			assert.True(t, info.BannedUntil.After(time.Now()))
+		default:
+			t.Fatalf("unexpected address %v", info.Addr)
+		}
+	}
+}
+
 func TestAddrBookEmpty(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
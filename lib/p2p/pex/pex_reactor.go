@@ -271,7 +271,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 			r.lastReceivedRequests.Set(id, time.Now())
 
 			// Send addrs and disconnect
-			r.SendAddrs(src, r.book.GetSelectionWithBias(biasToSelectNewPeers))
+			r.SendAddrs(src, r.trustedAddrs(r.book.GetSelectionWithBias(biasToSelectNewPeers)))
 			go func() {
 				// In a go-routine so it doesn't block .Receive.
 				src.FlushStop()
@@ -285,7 +285,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 				r.book.MarkBad(src.SocketAddr(), defaultBanTime)
 				return
 			}
-			r.SendAddrs(src, r.book.GetSelection())
+			r.SendAddrs(src, r.trustedAddrs(r.book.GetSelection()))
 		}
 
 	case *kp2p.PexAddrs:
@@ -380,6 +380,10 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 	}
 
 	for _, netAddr := range addrs {
+		// banned peers are kept out of the book
+		if r.Switch != nil && r.Switch.IsBanned(netAddr) {
+			continue
+		}
 		// NOTE: we check netAddr validity and routability in book#AddAddress.
 		err = r.book.AddAddress(netAddr, srcAddr)
 		if err != nil {
@@ -415,6 +419,21 @@ func (r *Reactor) SendAddrs(p Peer, netAddrs []*p2p.NetAddress) {
 	p.Send(PexChannel, mustEncode(&kp2p.PexAddrs{Addrs: p2p.NetAddressesToProto(netAddrs)}))
 }
 
+// trustedAddrs filters out addresses of banned peers and peers with a low
+// trust score, so they aren't gossiped.
+func (r *Reactor) trustedAddrs(addrs []*p2p.NetAddress) []*p2p.NetAddress {
+	if r.Switch == nil {
+		return addrs
+	}
+	trusted := addrs[:0]
+	for _, addr := range addrs {
+		if r.Switch.IsPeerTrusted(addr.ID) && !r.Switch.IsBanned(addr) {
+			trusted = append(trusted, addr)
+		}
+	}
+	return trusted
+}
+
 // SetEnsurePeersPeriod sets period to ensure peers connected.
 func (r *Reactor) SetEnsurePeersPeriod(d time.Duration) {
 	r.ensurePeersPeriod = d
@@ -479,6 +498,8 @@ func (r *Reactor) ensurePeers() {
 	newBias := kmath.MinInt(out, 8)*10 + 10
 
 	toDial := make(map[p2p.ID]*p2p.NetAddress)
+	// addresses of peers with a low trust score, only dialed if we lack others
+	lowTrust := make(map[p2p.ID]*p2p.NetAddress)
 	// Try maxAttempts times to pick numToDial addresses to dial
 	maxAttempts := numToDial * 3
 
@@ -493,12 +514,27 @@ func (r *Reactor) ensurePeers() {
 		if r.Switch.IsDialingOrExistingAddress(try) {
 			continue
 		}
+		if r.Switch.IsBanned(try) {
+			r.book.RemoveAddress(try)
+			continue
+		}
+		if !r.Switch.IsPeerTrusted(try.ID) {
+			lowTrust[try.ID] = try
+			continue
+		}
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialling again, or have dialed too many times already
 		r.Logger.Info("Will dial address", "addr", try)
 		toDial[try.ID] = try
 	}
+	for id, addr := range lowTrust {
+		if len(toDial) >= numToDial {
+			break
+		}
+		r.Logger.Info("Will dial low trust address", "addr", addr)
+		toDial[id] = addr
+	}
 
 	// Dial picked addresses
 	for _, addr := range toDial {
@@ -3,12 +3,14 @@ package p2p
 import (
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
 	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/lib/cmap"
 	"github.com/kardiachain/go-kardia/lib/p2p/conn"
+	"github.com/kardiachain/go-kardia/lib/p2p/trust"
 	"github.com/kardiachain/go-kardia/lib/rand"
 	"github.com/kardiachain/go-kardia/lib/service"
 )
@@ -27,6 +29,9 @@ const (
 	// ie. 3**10 = 16hrs
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
+
+	// how long a peer disconnected for its low trust score is banned
+	lowTrustBanTime = 24 * time.Hour
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -50,6 +55,7 @@ type AddrBook interface {
 	AddOurAddress(*NetAddress)
 	OurAddress(*NetAddress) bool
 	MarkGood(ID)
+	MarkBad(*NetAddress, time.Duration)
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
@@ -79,6 +85,7 @@ type Switch struct {
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
 	// peers addresses with whom we'll maintain constant connection
+	persistentMtx        sync.RWMutex
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
 
@@ -87,6 +94,9 @@ type Switch struct {
 	filterTimeout time.Duration
 	peerFilters   []PeerFilterFunc
 
+	trustStore *trust.MetricStore // trust metrics of peers, nil if disabled
+	banList    *BanList           // banned node IDs and IPs, nil if disabled
+
 	rng *rand.Rand // seed for randomizing dial times and orders
 
 	metrics *Metrics
@@ -144,6 +154,18 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchTrustMetricStore sets the store tracking the trust metrics of peers.
+// The store is started and stopped with the switch.
+func SwitchTrustMetricStore(store *trust.MetricStore) SwitchOption {
+	return func(sw *Switch) { sw.trustStore = store }
+}
+
+// SwitchBanList sets the list of banned node IDs and IPs, which are neither
+// accepted nor dialed.
+func SwitchBanList(banList *BanList) SwitchOption {
+	return func(sw *Switch) { sw.banList = banList }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -221,6 +243,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
+	if sw.trustStore != nil {
+		if err := sw.trustStore.Start(); err != nil {
+			return fmt.Errorf("failed to start trust metric store: %w", err)
+		}
+	}
+
 	// Start reactors
 	for _, reactor := range sw.reactors {
 		err := reactor.Start()
@@ -249,6 +277,12 @@ func (sw *Switch) OnStop() {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
 	}
+
+	if sw.trustStore != nil {
+		if err := sw.trustStore.Stop(); err != nil {
+			sw.Logger.Error("error while stopping trust metric store", "error", err)
+		}
+	}
 }
 
 //---------------------------------------------------------------------
@@ -304,6 +338,8 @@ func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
 }
 
 func (sw *Switch) IsPeerUnconditional(id ID) bool {
+	sw.persistentMtx.RLock()
+	defer sw.persistentMtx.RUnlock()
 	_, ok := sw.unconditionalPeerIDs[id]
 	return ok
 }
@@ -365,6 +401,9 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	// https://github.com/tendermint/tendermint/issues/3338
 	if sw.peers.Remove(peer) {
 		sw.metrics.Peers.Inc(int64(-1))
+		if sw.trustStore != nil {
+			sw.trustStore.PeerDisconnected(string(peer.ID()))
+		}
 	}
 }
 
@@ -395,6 +434,8 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if _, ok := err.(ErrPeerBanned); ok {
+			return
 		}
 
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
@@ -419,6 +460,8 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if _, ok := err.(ErrPeerBanned); ok {
+			return
 		}
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 	}
@@ -436,6 +479,119 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	if sw.addrBook != nil {
 		sw.addrBook.MarkGood(peer.ID())
 	}
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).GoodEvents(1)
+	}
+}
+
+// MarkPeerAsBad records a misbehaviour of the given peer into its trust
+// metric. Once the metric has the configured minimum number of events and the
+// trust score drops below the configured minimum, the peer is disconnected and
+// banned, unless it is persistent or unconditional. Returns true if the peer
+// was disconnected.
+func (sw *Switch) MarkPeerAsBad(peer Peer, reason interface{}) bool {
+	if sw.trustStore == nil {
+		return false
+	}
+	tm := sw.trustStore.GetPeerTrustMetric(string(peer.ID()))
+	tm.BadEvents(1)
+	if tm.Events() < sw.config.MinTrustEvents {
+		return false
+	}
+
+	score := tm.TrustScore()
+	if score >= sw.config.MinTrustScore || peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) {
+		return false
+	}
+	sw.Logger.Info("Disconnecting peer with low trust score", "peer", peer, "score", score, "reason", reason)
+	if sw.addrBook != nil {
+		sw.addrBook.MarkBad(peer.SocketAddr(), lowTrustBanTime)
+	}
+	sw.StopPeerGracefully(peer)
+	return true
+}
+
+// PeerTrustScore returns the trust score (0-100) of the peer with the given
+// id, and false if no score is tracked for it.
+func (sw *Switch) PeerTrustScore(id ID) (int, bool) {
+	if sw.trustStore == nil {
+		return 0, false
+	}
+	tm, ok := sw.trustStore.LookupPeerTrustMetric(string(id))
+	if !ok {
+		return 0, false
+	}
+	return tm.TrustScore(), true
+}
+
+// IsPeerTrusted returns false if the trust score of the peer with the given id
+// is below the configured minimum. Peers without a score are trusted.
+func (sw *Switch) IsPeerTrusted(id ID) bool {
+	score, ok := sw.PeerTrustScore(id)
+	return !ok || score >= sw.config.MinTrustScore
+}
+
+// BanList returns the list of banned node IDs and IPs, nil if bans are disabled.
+func (sw *Switch) BanList() *BanList {
+	return sw.banList
+}
+
+// IsBanned returns true if the node ID or the IP of addr is banned.
+func (sw *Switch) IsBanned(addr *NetAddress) bool {
+	return sw.checkBanned(addr.ID, addr.IP) != nil
+}
+
+// checkBanned returns ErrPeerBanned if id or ip is banned.
+func (sw *Switch) checkBanned(id ID, ip net.IP) error {
+	if sw.banList == nil {
+		return nil
+	}
+	if entry := sw.banList.IsBanned(id, ip); entry != nil {
+		return ErrPeerBanned{ID: id, IP: ip, Entry: entry}
+	}
+	return nil
+}
+
+// BanPeer bans target, a node ID, an IP or a CIDR range, for duration (zero
+// for ever) and disconnects the matching peers.
+func (sw *Switch) BanPeer(target string, duration time.Duration, reason string) (*BanEntry, error) {
+	if sw.banList == nil {
+		return nil, fmt.Errorf("ban list is disabled")
+	}
+	entry, err := sw.banList.Ban(target, duration, reason)
+	if err != nil {
+		return nil, err
+	}
+	if entry.ID != "" && sw.addrBook != nil {
+		sw.addrBook.RemoveAddress(&NetAddress{ID: entry.ID})
+	}
+	sw.stopBannedPeers()
+	return entry, nil
+}
+
+// RecordViolation counts a protocol violation of peer towards an automatic
+// ban. It returns true if the peer got banned, in which case it is
+// disconnected. Persistent and unconditional peers are never banned
+// automatically.
+func (sw *Switch) RecordViolation(peer Peer, reason interface{}) bool {
+	if peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) {
+		return false
+	}
+	if sw.banList == nil || !sw.banList.RecordViolation(peer.ID(), peer.RemoteIP(), fmt.Sprintf("%v", reason)) {
+		return false
+	}
+	sw.stopBannedPeers()
+	return true
+}
+
+// stopBannedPeers disconnects the peers which are banned.
+func (sw *Switch) stopBannedPeers() {
+	for _, peer := range sw.peers.List() {
+		if err := sw.checkBanned(peer.ID(), peer.RemoteIP()); err != nil {
+			sw.Logger.Info("Disconnecting banned peer", "peer", peer, "err", err)
+			sw.StopPeerGracefully(peer)
+		}
+	}
 }
 
 //---------------------------------------------------------------------
@@ -530,6 +686,9 @@ func (sw *Switch) dialPeersAsync(netAddrs []*NetAddress) {
 // If we're currently dialing this address or it belongs to an existing peer,
 // ErrCurrentlyDialingOrExistingAddress is returned.
 func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
+	if err := sw.checkBanned(addr.ID, addr.IP); err != nil {
+		return err
+	}
 	if sw.IsDialingOrExistingAddress(addr) {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
@@ -554,7 +713,7 @@ func (sw *Switch) IsDialingOrExistingAddress(addr *NetAddress) bool {
 		(!sw.config.AllowDuplicateIP && sw.peers.HasIP(addr.IP))
 }
 
-// AddPersistentPeers allows you to set persistent peers. It ignores
+// AddPersistentPeers adds addrs to the persistent peers. It ignores
 // ErrNetAddressLookup. However, if there are other errors, first encounter is
 // returned.
 func (sw *Switch) AddPersistentPeers(addrs []string) error {
@@ -571,10 +730,30 @@ func (sw *Switch) AddPersistentPeers(addrs []string) error {
 		}
 		return err
 	}
-	sw.persistentPeersAddrs = netAddrs
+	sw.persistentMtx.Lock()
+	defer sw.persistentMtx.Unlock()
+	for _, na := range netAddrs {
+		if !sw.isPeerPersistent(na) {
+			sw.persistentPeersAddrs = append(sw.persistentPeersAddrs, na)
+		}
+	}
 	return nil
 }
 
+// RemovePersistentPeer stops maintaining a connection to the peer with the
+// given id. It returns false if the peer is not persistent.
+func (sw *Switch) RemovePersistentPeer(id ID) bool {
+	sw.persistentMtx.Lock()
+	defer sw.persistentMtx.Unlock()
+	for i, pa := range sw.persistentPeersAddrs {
+		if pa.ID == id {
+			sw.persistentPeersAddrs = append(sw.persistentPeersAddrs[:i], sw.persistentPeersAddrs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
 	sw.Logger.Info("Adding unconditional peer ids", "ids", ids)
 	for i, id := range ids {
@@ -582,6 +761,10 @@ func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
 		if err != nil {
 			return fmt.Errorf("wrong ID #%d: %w", i, err)
 		}
+	}
+	sw.persistentMtx.Lock()
+	defer sw.persistentMtx.Unlock()
+	for _, id := range ids {
 		sw.unconditionalPeerIDs[ID(id)] = struct{}{}
 	}
 	return nil
@@ -603,6 +786,12 @@ func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 }
 
 func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
+	sw.persistentMtx.RLock()
+	defer sw.persistentMtx.RUnlock()
+	return sw.isPeerPersistent(na)
+}
+
+func (sw *Switch) isPeerPersistent(na *NetAddress) bool {
 	for _, pa := range sw.persistentPeersAddrs {
 		if pa.Equals(na) {
 			return true
@@ -667,6 +856,12 @@ func (sw *Switch) acceptRoutine() {
 			break
 		}
 
+		if err := sw.checkBanned(p.ID(), p.RemoteIP()); err != nil {
+			sw.Logger.Info("Ignoring inbound connection: peer is banned", "err", err)
+			sw.transport.Cleanup(p)
+			continue
+		}
+
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
 			// Ignore connection if we already have enough peers.
 			_, in, _ := sw.NumPeers()
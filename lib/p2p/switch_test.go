@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
 	"github.com/kardiachain/go-kardia/lib/crypto"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p/conn"
+	"github.com/kardiachain/go-kardia/lib/p2p/trust"
 	ksync "github.com/kardiachain/go-kardia/lib/sync"
 )
 
@@ -390,6 +392,152 @@ func TestSwitchStopsNonPersistentPeerOnError(t *testing.T) {
 	assert.False(p.IsRunning())
 }
 
+func TestSwitchDisconnectsLowTrustPeer(t *testing.T) {
+	store := trust.NewTrustMetricStore(memorydb.New(), trust.DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchTrustMetricStore(store))
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	priv, _ := crypto.GenerateKey()
+	rp := &remotePeer{PrivKey: priv, Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+		chDescs:      sw.chDescs,
+		onPeerError:  sw.StopPeerForError,
+		isPersistent: sw.IsPeerPersistent,
+		reactorsByCh: sw.reactorsByCh,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sw.addPeer(p))
+
+	_, tracked := sw.PeerTrustScore(p.ID())
+	assert.False(t, tracked)
+	assert.True(t, sw.IsPeerTrusted(p.ID()))
+
+	for i := 0; i < 10; i++ {
+		sw.MarkPeerAsGood(p)
+	}
+	score, tracked := sw.PeerTrustScore(p.ID())
+	assert.True(t, tracked)
+	assert.Equal(t, 100, score)
+
+	// a few faults among good behaviour are tolerated
+	assert.False(t, sw.MarkPeerAsBad(p, "fault"))
+	assert.NotNil(t, sw.Peers().Get(p.ID()))
+
+	disconnected := false
+	for i := 0; i < 20 && !disconnected; i++ {
+		disconnected = sw.MarkPeerAsBad(p, "fault")
+	}
+	assert.True(t, disconnected)
+	assertNoPeersAfterTimeout(t, sw, 100*time.Millisecond)
+	assert.False(t, sw.IsPeerTrusted(p.ID()))
+}
+
+func TestSwitchToleratesNewPeerFault(t *testing.T) {
+	store := trust.NewTrustMetricStore(memorydb.New(), trust.DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchTrustMetricStore(store))
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	priv, _ := crypto.GenerateKey()
+	rp := &remotePeer{PrivKey: priv, Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+		chDescs:      sw.chDescs,
+		onPeerError:  sw.StopPeerForError,
+		isPersistent: sw.IsPeerPersistent,
+		reactorsByCh: sw.reactorsByCh,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sw.addPeer(p))
+
+	// the low score of a new peer is not acted on until it has enough events
+	assert.False(t, sw.MarkPeerAsBad(p, "fault"))
+	score, _ := sw.PeerTrustScore(p.ID())
+	assert.Less(t, score, cfg.MinTrustScore)
+	assert.NotNil(t, sw.Peers().Get(p.ID()))
+}
+
+func TestSwitchBannedPeer(t *testing.T) {
+	banList, err := NewBanList("")
+	require.NoError(t, err)
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchBanList(banList))
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	priv, _ := crypto.GenerateKey()
+	rp := &remotePeer{PrivKey: priv, Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	require.NoError(t, sw.DialPeerWithAddress(rp.Addr()))
+	require.NotNil(t, sw.Peers().Get(rp.ID()))
+
+	// banning a connected peer disconnects it
+	_, err = sw.BanPeer(string(rp.ID()), time.Hour, "test")
+	require.NoError(t, err)
+	assertNoPeersAfterTimeout(t, sw, 100*time.Millisecond)
+	assert.True(t, sw.IsBanned(rp.Addr()))
+
+	// and it can't be dialed anymore
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.Error(t, err)
+	_, ok := err.(ErrPeerBanned)
+	assert.True(t, ok)
+
+	ok, err = banList.Unban(string(rp.ID()))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, sw.DialPeerWithAddress(rp.Addr()))
+}
+
+// transientPeer is a mock peer which isn't persistent
+type transientPeer struct {
+	*mockPeer
+}
+
+func (tp transientPeer) IsPersistent() bool { return false }
+
+func TestSwitchRecordViolation(t *testing.T) {
+	banList, err := NewBanList("")
+	require.NoError(t, err)
+	banList.SetAutoBan(1, time.Hour)
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchBanList(banList))
+
+	unconditional := transientPeer{newMockPeer(net.IP{10, 0, 0, 2})}
+	require.NoError(t, sw.AddUnconditionalPeerIDs([]string{string(unconditional.ID())}))
+
+	// persistent and unconditional peers are never banned automatically
+	for _, peer := range []Peer{newMockPeer(net.IP{10, 0, 0, 1}), unconditional} {
+		assert.False(t, sw.RecordViolation(peer, "bad message"))
+		assert.Nil(t, banList.IsBanned(peer.ID(), peer.RemoteIP()))
+	}
+
+	peer := transientPeer{newMockPeer(net.IP{10, 0, 0, 3})}
+	assert.True(t, sw.RecordViolation(peer, "bad message"))
+	assert.NotNil(t, banList.IsBanned(peer.ID(), nil))
+	assert.NotNil(t, banList.IsBanned("", peer.RemoteIP()))
+}
+
 func TestSwitchStopPeerForError(t *testing.T) {
 	s := httptest.NewServer(promhttp.Handler())
 	defer s.Close()
@@ -74,6 +74,9 @@ type Metric struct {
 	// The number of recorded good and bad events for the current time interval
 	bad, good float64
 
+	// The number of events recorded since the metric was created
+	events int
+
 	// While true, history data is not modified
 	paused bool
 
@@ -179,6 +182,7 @@ func (tm *Metric) BadEvents(num int) {
 
 	tm.unpause()
 	tm.bad += float64(num)
+	tm.events += num
 }
 
 // GoodEvents indicates that a desirable event(s) took place
@@ -188,6 +192,16 @@ func (tm *Metric) GoodEvents(num int) {
 
 	tm.unpause()
 	tm.good += float64(num)
+	tm.events += num
+}
+
+// Events returns the number of good and bad events recorded since the metric
+// was created
+func (tm *Metric) Events() int {
+	tm.mtx.Lock()
+	defer tm.mtx.Unlock()
+
+	return tm.events
 }
 
 // TrustValue gets the dependable trust value; always between 0 and 1
@@ -277,6 +291,7 @@ func (tm *Metric) Copy() *Metric {
 		historyValue:       tm.historyValue,
 		good:               tm.good,
 		bad:                tm.bad,
+		events:             tm.events,
 		paused:             tm.paused,
 	}
 
@@ -119,6 +119,16 @@ func (tms *MetricStore) GetPeerTrustMetric(key string) *Metric {
 	return tm
 }
 
+// LookupPeerTrustMetric returns the trust metric of a peer key, without
+// creating one for unknown peers
+func (tms *MetricStore) LookupPeerTrustMetric(key string) (*Metric, bool) {
+	tms.mtx.Lock()
+	defer tms.mtx.Unlock()
+
+	tm, ok := tms.peerMetrics[key]
+	return tm, ok
+}
+
 // PeerDisconnected pauses the trust metric associated with the peer identified by the key
 func (tms *MetricStore) PeerDisconnected(key string) {
 	tms.mtx.Lock()
@@ -125,8 +125,17 @@ func TestTrustMetricStoreLookup(t *testing.T) {
 		// Check that the trust metric was successfully entered
 		ktm := store.peerMetrics[key]
 		assert.NotNil(t, ktm, "Expected to find TrustMetric %s but wasn't there.", key)
+
+		ltm, ok := store.LookupPeerTrustMetric(key)
+		assert.True(t, ok)
+		assert.Equal(t, ktm, ltm)
 	}
 
+	// Looking up an unknown peer doesn't create its metric
+	_, ok := store.LookupPeerTrustMetric("unknown")
+	assert.False(t, ok)
+	assert.Equal(t, 100, store.Size())
+
 	err = store.Stop()
 	require.NoError(t, err)
 }
@@ -0,0 +1,735 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	kmath "github.com/kardiachain/go-kardia/lib/math"
+	"github.com/kardiachain/go-kardia/light/provider"
+	"github.com/kardiachain/go-kardia/light/store"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+type mode byte
+
+const (
+	sequential mode = iota + 1
+	skipping
+
+	defaultPruningSize   = 1000
+	defaultMaxClockDrift = 10 * time.Second
+
+	// pivot of the skipping verification: the light client tries to verify
+	// the block in the middle of the trusted and the new block.
+	verifySkippingNumerator   = 1
+	verifySkippingDenominator = 2
+)
+
+// TrustOptions are the trust parameters needed when a new light client
+// connects to the network or when an existing light client that has been
+// offline for longer than the trusting period connects to the network.
+//
+// The expectation is the user will get this information from a trusted source
+// like a validator, a friend, or a secure website. A more user friendly
+// solution with trust tradeoffs is that we establish an https based protocol
+// with a default end point that populates this information. Also an on-chain
+// registry of roots-of-trust (e.g. on the Kardia chain) can help.
+type TrustOptions struct {
+	// Period should be significantly less than the unbonding period (e.g.
+	// unbonding period = 3 weeks, trusting period = 2 weeks).
+	//
+	// More specifically, trusting period + time needed to check headers + time
+	// needed to report and punish misbehavior should be less than the unbonding
+	// period.
+	Period time.Duration
+
+	// Header's Height and Hash must both be provided to force the trusting of a
+	// particular header.
+	Height uint64
+	Hash   common.Hash
+}
+
+// ValidateBasic performs basic validation.
+func (opts TrustOptions) ValidateBasic() error {
+	if opts.Period <= 0 {
+		return errors.New("negative or zero period")
+	}
+	if opts.Height == 0 {
+		return errors.New("zero height")
+	}
+	if opts.Hash.IsZero() {
+		return errors.New("empty hash")
+	}
+	return nil
+}
+
+// Option sets a parameter for the light client.
+type Option func(*Client)
+
+// SequentialVerification option configures the light client to sequentially
+// check the blocks (every block, in ascending height order). Note this is
+// much slower than SkippingVerification, albeit more secure.
+func SequentialVerification() Option {
+	return func(c *Client) {
+		c.verificationMode = sequential
+	}
+}
+
+// SkippingVerification option configures the light client to skip blocks as
+// long as {trustLevel} of the old validator set signed the new header. The
+// verifySkipping algorithm from the specification is used for finding the
+// minimal "trust path".
+//
+// trustLevel - fraction of the old validator set (in terms of voting power),
+// which must sign the new header in order for us to trust it. NOTE this only
+// applies to non-adjacent headers. For adjacent headers, sequential
+// verification is used.
+func SkippingVerification(trustLevel kmath.Fraction) Option {
+	return func(c *Client) {
+		c.verificationMode = skipping
+		c.trustLevel = trustLevel
+	}
+}
+
+// PruningSize option sets the maximum amount of light blocks that the light
+// client stores. When Prune() is run, all light blocks (along with the
+// associated validator sets) that are earlier than the h amount of light
+// blocks will be removed from the store. Default: 1000. A pruning size of 0
+// will not prune the light client at all.
+func PruningSize(h uint16) Option {
+	return func(c *Client) {
+		c.pruningSize = h
+	}
+}
+
+// Logger option can be used to set a logger for the client.
+func Logger(l log.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// MaxClockDrift defines how much new header's time can drift into
+// the future. Default: 10s.
+func MaxClockDrift(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxClockDrift = d
+	}
+}
+
+// Client represents a light client, connected to a single chain, which gets
+// light blocks from a primary provider, verifies them either sequentially or by
+// skipping some and stores them in a trusted store (usually, a local FS).
+//
+// Default verification: SkippingVerification(DefaultTrustLevel)
+type Client struct {
+	chainID          string
+	trustingPeriod   time.Duration // see TrustOptions.Period
+	verificationMode mode
+	trustLevel       kmath.Fraction
+	maxClockDrift    time.Duration
+
+	// Mutex for locking during changes of the light clients providers
+	providerMutex sync.Mutex
+	// Primary provider of new headers.
+	primary provider.Provider
+	// Providers used to "witness" new headers.
+	witnesses []provider.Provider
+
+	// Verifications are done one at a time.
+	verifyMtx sync.Mutex
+	// Where trusted light blocks are stored.
+	trustedStore store.Store
+	// Highest trusted light block from the store (height=X).
+	trustedMtx         sync.RWMutex
+	latestTrustedBlock *types.LightBlock
+
+	pruningSize uint16
+
+	logger log.Logger
+}
+
+// NewClient returns a new light client. It returns an error if it fails to
+// obtain the light block from the primary or they are invalid (e.g. trust
+// hash does not match with the one from the headers).
+//
+// Witnesses are providers, which will be used for cross-checking the primary
+// provider. A witness can become a primary iff the current primary is
+// unavailable.
+//
+// The trusted store already containing a trusted light block more recent than
+// the trust options is resumed from.
+func NewClient(
+	ctx context.Context,
+	chainID string,
+	trustOptions TrustOptions,
+	primary provider.Provider,
+	witnesses []provider.Provider,
+	trustedStore store.Store,
+	options ...Option) (*Client, error) {
+
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid TrustOptions: %w", err)
+	}
+
+	c := &Client{
+		chainID:          chainID,
+		trustingPeriod:   trustOptions.Period,
+		verificationMode: skipping,
+		trustLevel:       DefaultTrustLevel,
+		maxClockDrift:    defaultMaxClockDrift,
+		primary:          primary,
+		witnesses:        witnesses,
+		trustedStore:     trustedStore,
+		pruningSize:      defaultPruningSize,
+		logger:           log.NewNopLogger(),
+	}
+	for _, o := range options {
+		o(c)
+	}
+
+	// Validate the number of witnesses.
+	if len(c.witnesses) == 0 {
+		c.logger.Warn("No witnesses, the primary won't be cross-checked")
+	}
+
+	// Verify witnesses are all on the same chain.
+	for i, w := range witnesses {
+		if w.ChainID() != chainID {
+			return nil, fmt.Errorf("witness #%d: %v is on another chain %s, expected %s",
+				i, w, w.ChainID(), chainID)
+		}
+	}
+	if primary.ChainID() != chainID {
+		return nil, fmt.Errorf("primary %v is on another chain %s, expected %s", primary, primary.ChainID(), chainID)
+	}
+
+	// Validate trust level.
+	if err := ValidateTrustLevel(c.trustLevel); err != nil {
+		return nil, err
+	}
+
+	if err := c.restoreTrustedLightBlock(); err != nil {
+		return nil, err
+	}
+
+	// Initialize from the trust options unless the store already has a more
+	// recent trusted light block.
+	if c.latestTrustedBlock == nil || c.latestTrustedBlock.Height < trustOptions.Height {
+		if err := c.initializeWithTrustOptions(ctx, trustOptions); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	if lb, err := c.trustedStore.LightBlock(trustOptions.Height); err == nil && !lb.Hash().Equal(trustOptions.Hash) {
+		return nil, fmt.Errorf("trusted light block #%d %X doesn't match the trust options hash %X, "+
+			"the trusted store must be reset", lb.Height, lb.Hash(), trustOptions.Hash)
+	}
+	return c, nil
+}
+
+// restoreTrustedLightBlock loads the latest trusted light block from the
+// store.
+func (c *Client) restoreTrustedLightBlock() error {
+	lastHeight, err := c.trustedStore.LastLightBlockHeight()
+	if err != nil {
+		return fmt.Errorf("can't get last trusted light block height: %w", err)
+	}
+	if lastHeight == 0 {
+		return nil
+	}
+
+	trustedBlock, err := c.trustedStore.LightBlock(lastHeight)
+	if err != nil {
+		return fmt.Errorf("can't get last trusted light block: %w", err)
+	}
+	c.latestTrustedBlock = trustedBlock
+	c.logger.Info("Restored trusted light block", "height", lastHeight)
+	return nil
+}
+
+// initializeWithTrustOptions fetches the light block at the trusted height
+// and checks it against the trusted hash and the witnesses.
+func (c *Client) initializeWithTrustOptions(ctx context.Context, options TrustOptions) error {
+	// 1) Fetch and verify the light block.
+	l, err := c.lightBlockFromPrimary(ctx, options.Height)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: - Verify block hash is the trusted one.
+	if !l.Hash().Equal(options.Hash) {
+		return fmt.Errorf("expected header's hash %X, but got %X", options.Hash, l.Hash())
+	}
+
+	// 2) Ensure that +2/3 of validators signed correctly.
+	err = l.ValidatorSet.VerifyCommitLight(c.chainID, l.Commit.BlockID, l.Height, l.Commit)
+	if err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+
+	// 3) Cross-verify with witnesses to ensure everybody has the same state.
+	if err := c.compareFirstHeaderWithWitnesses(ctx, l.SignedHeader); err != nil {
+		return err
+	}
+
+	// 4) Persist both of them and continue.
+	return c.updateTrustedLightBlock(l)
+}
+
+// TrustedLightBlock returns a trusted light block at the given height (0 -
+// the latest).
+//
+// It returns an error if:
+//   - there are some issues with the trusted store, although that should not
+//     happen normally;
+//   - height is greater than the latest trusted height;
+//   - light block is not found.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) TrustedLightBlock(height uint64) (*types.LightBlock, error) {
+	if height == 0 {
+		c.trustedMtx.RLock()
+		defer c.trustedMtx.RUnlock()
+		if c.latestTrustedBlock == nil {
+			return nil, errors.New("no light blocks exist")
+		}
+		return c.latestTrustedBlock, nil
+	}
+	return c.trustedStore.LightBlock(height)
+}
+
+// LastTrustedHeight returns the height of the latest trusted light block, 0
+// if there is none.
+func (c *Client) LastTrustedHeight() uint64 {
+	c.trustedMtx.RLock()
+	defer c.trustedMtx.RUnlock()
+	if c.latestTrustedBlock == nil {
+		return 0
+	}
+	return c.latestTrustedBlock.Height
+}
+
+// ChainID returns the chain ID the light client was configured with.
+func (c *Client) ChainID() string {
+	return c.chainID
+}
+
+// Primary returns the primary provider.
+//
+// NOTE: provider may be not safe for concurrent access.
+func (c *Client) Primary() provider.Provider {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	return c.primary
+}
+
+// Witnesses returns the witness providers.
+//
+// NOTE: providers may be not safe for concurrent access.
+func (c *Client) Witnesses() []provider.Provider {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	return c.witnesses
+}
+
+// Update attempts to advance the state by downloading the latest light
+// block and verifying it. It returns a new light block on a successful
+// update. Otherwise, it returns nil (plus an error, if any).
+func (c *Client) Update(ctx context.Context, now time.Time) (*types.LightBlock, error) {
+	lastTrustedHeight := c.LastTrustedHeight()
+
+	latestBlock, err := c.lightBlockFromPrimary(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if latestBlock.Height > lastTrustedHeight {
+		err = c.verifyLightBlock(ctx, latestBlock, now)
+		if err != nil {
+			return nil, err
+		}
+		c.logger.Info("Advanced to new state", "height", latestBlock.Height, "hash", latestBlock.Hash())
+		return latestBlock, nil
+	}
+
+	return nil, nil
+}
+
+// VerifyLightBlockAtHeight fetches the light block at the given height
+// and verifies it. It returns the block immediately if it exists in
+// the trustedStore (no verification is needed).
+//
+// height must be > 0.
+//
+// It returns provider.ErrLightBlockNotFound if light block is not found by
+// primary.
+func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height uint64, now time.Time) (*types.LightBlock, error) {
+	if height == 0 {
+		return nil, errors.New("zero height")
+	}
+
+	// Check if the light block is already verified.
+	h, err := c.TrustedLightBlock(height)
+	if err == nil {
+		c.logger.Debug("Header has already been verified", "height", height, "hash", h.Hash())
+		// Return already trusted light block
+		return h, nil
+	}
+
+	// Request the light block from primary
+	l, err := c.lightBlockFromPrimary(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, c.verifyLightBlock(ctx, l, now)
+}
+
+// verifyLightBlock verifies newLightBlock from the closest trusted light
+// block, cross-checks it with the witnesses and saves it.
+func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.LightBlock, now time.Time) error {
+	c.verifyMtx.Lock()
+	defer c.verifyMtx.Unlock()
+
+	c.logger.Info("VerifyHeader", "height", newLightBlock.Height, "hash", newLightBlock.Hash())
+
+	// a block at the same height is already trusted
+	if trusted, err := c.trustedStore.LightBlock(newLightBlock.Height); err == nil {
+		if !trusted.Hash().Equal(newLightBlock.Hash()) {
+			return fmt.Errorf("existing trusted header %X does not match newHeader %X", trusted.Hash(), newLightBlock.Hash())
+		}
+		return nil
+	}
+
+	var (
+		trace []*types.LightBlock
+		err   error
+	)
+
+	firstBlockHeight, err := c.trustedStore.FirstLightBlockHeight()
+	if err != nil {
+		return fmt.Errorf("can't get first light block height: %w", err)
+	}
+	latestBlock, err := c.TrustedLightBlock(0)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	// Verifying forwards
+	case newLightBlock.Height >= latestBlock.Height:
+		trace, err = c.verify(ctx, latestBlock, newLightBlock, now)
+
+	// Verifying backwards
+	case newLightBlock.Height < firstBlockHeight:
+		var firstBlock *types.LightBlock
+		firstBlock, err = c.trustedStore.LightBlock(firstBlockHeight)
+		if err != nil {
+			return fmt.Errorf("can't get first light block: %w", err)
+		}
+		err = c.backwards(ctx, firstBlock, newLightBlock, now)
+
+	// Verifying between first and last trusted light block
+	default:
+		var closestBlock *types.LightBlock
+		closestBlock, err = c.trustedStore.LightBlockBefore(newLightBlock.Height)
+		if err != nil {
+			return fmt.Errorf("can't get signed header before height %d: %w", newLightBlock.Height, err)
+		}
+		trace, err = c.verify(ctx, closestBlock, newLightBlock, now)
+	}
+	if err != nil {
+		c.logger.Error("Can't verify", "err", err)
+		return err
+	}
+
+	// Cross-check the verified block with the witnesses. Backwards
+	// verification relies on hashes only, there is nothing to cross-check.
+	if trace != nil {
+		if err := c.detectDivergence(ctx, trace, now); err != nil {
+			return err
+		}
+	}
+
+	return c.updateTrustedLightBlock(newLightBlock)
+}
+
+// verify verifies newLightBlock from trusted using the configured mode and
+// returns the trace of light blocks it went through.
+func (c *Client) verify(ctx context.Context, trusted, newLightBlock *types.LightBlock,
+	now time.Time) ([]*types.LightBlock, error) {
+
+	switch c.verificationMode {
+	case sequential:
+		return c.verifySequential(ctx, trusted, newLightBlock, now)
+	case skipping:
+		return c.verifySkipping(ctx, c.Primary(), trusted, newLightBlock, now)
+	default:
+		panic(fmt.Sprintf("Unknown verification mode: %b", c.verificationMode))
+	}
+}
+
+// verifySequential verifies every block between trusted and newLightBlock.
+func (c *Client) verifySequential(ctx context.Context, trusted, newLightBlock *types.LightBlock,
+	now time.Time) ([]*types.LightBlock, error) {
+
+	var (
+		verifiedBlock = trusted
+		trace         = []*types.LightBlock{trusted}
+	)
+
+	for height := trusted.Height + 1; height <= newLightBlock.Height; height++ {
+		// 1) Fetch interim light block if needed.
+		interimBlock := newLightBlock
+		if height < newLightBlock.Height {
+			var err error
+			interimBlock, err = c.lightBlockFromPrimary(ctx, height)
+			if err != nil {
+				return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: height, Reason: err}
+			}
+		}
+
+		// 2) Verify them
+		c.logger.Debug("Verify adjacent newLightBlock against verifiedBlock",
+			"trustedHeight", verifiedBlock.Height,
+			"trustedHash", verifiedBlock.Hash(),
+			"newHeight", interimBlock.Height,
+			"newHash", interimBlock.Hash())
+
+		err := VerifyAdjacent(c.chainID, verifiedBlock.SignedHeader, interimBlock.SignedHeader,
+			interimBlock.ValidatorSet, c.trustingPeriod, now, c.maxClockDrift)
+		if err != nil {
+			return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: interimBlock.Height, Reason: err}
+		}
+
+		// 3) Update verifiedBlock
+		verifiedBlock = interimBlock
+		trace = append(trace, interimBlock)
+	}
+
+	return trace, nil
+}
+
+// verifySkipping verifies newLightBlock from trusted, skipping as many
+// blocks as possible: when less than trustLevel of the trusted validators
+// signed the new block, the block in the middle is verified first.
+//
+// source is the provider the intermediate blocks are fetched from, so that
+// the trace of a witness can be built as well.
+func (c *Client) verifySkipping(
+	ctx context.Context,
+	source provider.Provider,
+	trusted *types.LightBlock,
+	newLightBlock *types.LightBlock,
+	now time.Time) ([]*types.LightBlock, error) {
+
+	var (
+		blockCache = []*types.LightBlock{newLightBlock}
+		depth      = 0
+
+		verifiedBlock = trusted
+		trace         = []*types.LightBlock{trusted}
+	)
+
+	for {
+		c.logger.Debug("Verify non-adjacent newHeader against verifiedBlock",
+			"trustedHeight", verifiedBlock.Height,
+			"trustedHash", verifiedBlock.Hash(),
+			"newHeight", blockCache[depth].Height,
+			"newHash", blockCache[depth].Hash())
+
+		err := Verify(c.chainID, verifiedBlock.SignedHeader, verifiedBlock.ValidatorSet, blockCache[depth].SignedHeader,
+			blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+		switch err.(type) {
+		case nil:
+			// Have we verified the last header
+			if depth == 0 {
+				trace = append(trace, newLightBlock)
+				return trace, nil
+			}
+			// If not, update the lower bound to the previous upper bound
+			verifiedBlock = blockCache[depth]
+			// Remove the light block at the lower bound in the header cache - it will no longer be needed
+			blockCache = blockCache[:depth]
+			// Reset the cache depth so that we start from the upper bound again
+			depth = 0
+			// add verifiedBlock to the trace
+			trace = append(trace, verifiedBlock)
+
+		case ErrNewValSetCantBeTrusted:
+			// do add another header to the end of the cache
+			if depth == len(blockCache)-1 {
+				pivotHeight := verifiedBlock.Height + (blockCache[depth].Height-verifiedBlock.
+					Height)*verifySkippingNumerator/verifySkippingDenominator
+				interimBlock, err := source.LightBlock(ctx, pivotHeight)
+				if err == nil {
+					err = interimBlock.ValidateBasic()
+				}
+				if err != nil {
+					return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: pivotHeight, Reason: err}
+				}
+				blockCache = append(blockCache, interimBlock)
+			}
+			depth++
+
+		default:
+			return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: blockCache[depth].Height, Reason: err}
+		}
+	}
+}
+
+// backwards verifies newLightBlock against the first trusted light block by
+// following the hashes of the previous blocks.
+func (c *Client) backwards(ctx context.Context, trusted, newLightBlock *types.LightBlock, now time.Time) error {
+	if HeaderExpired(trusted.SignedHeader, c.trustingPeriod, now) {
+		return ErrOldHeaderExpired{trusted.Time.Add(c.trustingPeriod), now}
+	}
+
+	verifiedHeader := trusted.Header
+	for verifiedHeader.Height > newLightBlock.Height+1 {
+		interimBlock, err := c.lightBlockFromPrimary(ctx, verifiedHeader.Height-1)
+		if err != nil {
+			return ErrVerificationFailed{From: verifiedHeader.Height, To: verifiedHeader.Height - 1, Reason: err}
+		}
+		c.logger.Debug("Verify newHeader against verifiedHeader",
+			"trustedHeight", verifiedHeader.Height,
+			"trustedHash", verifiedHeader.Hash(),
+			"newHeight", interimBlock.Height,
+			"newHash", interimBlock.Hash())
+		if err := VerifyBackwards(interimBlock.Header, verifiedHeader); err != nil {
+			return ErrVerificationFailed{From: verifiedHeader.Height, To: interimBlock.Height, Reason: err}
+		}
+		verifiedHeader = interimBlock.Header
+	}
+
+	if err := VerifyBackwards(newLightBlock.Header, verifiedHeader); err != nil {
+		return ErrVerificationFailed{From: verifiedHeader.Height, To: newLightBlock.Height, Reason: err}
+	}
+	return nil
+}
+
+// updateTrustedLightBlock saves the light block, prunes the store and moves
+// the latest trusted light block forward if needed.
+func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
+	if err := c.trustedStore.SaveLightBlock(l); err != nil {
+		return fmt.Errorf("failed to save trusted header: %w", err)
+	}
+
+	if c.pruningSize > 0 {
+		if err := c.trustedStore.Prune(c.pruningSize); err != nil {
+			return fmt.Errorf("prune: %w", err)
+		}
+	}
+
+	c.trustedMtx.Lock()
+	defer c.trustedMtx.Unlock()
+	if c.latestTrustedBlock == nil || l.Height > c.latestTrustedBlock.Height {
+		c.latestTrustedBlock = l
+	}
+	return nil
+}
+
+// Cleanup removes all the data (headers and validator sets) stored. Note:
+// the client must be stopped at this point.
+func (c *Client) Cleanup() error {
+	c.logger.Info("Removing all light blocks")
+	c.trustedMtx.Lock()
+	c.latestTrustedBlock = nil
+	c.trustedMtx.Unlock()
+	return c.trustedStore.Prune(0)
+}
+
+// lightBlockFromPrimary retrieves the light block from the primary provider
+// at the specified height. If the primary doesn't respond or sends an invalid
+// light block, it is replaced by a witness.
+func (c *Client) lightBlockFromPrimary(ctx context.Context, height uint64) (*types.LightBlock, error) {
+	for {
+		primary := c.Primary()
+		l, err := primary.LightBlock(ctx, height)
+		if err == nil {
+			if err = l.ValidateBasic(); err != nil {
+				err = provider.ErrBadLightBlock{Reason: err}
+			}
+		}
+		switch err.(type) {
+		case nil:
+			return l, nil
+		case provider.ErrBadLightBlock:
+		default:
+			if err != provider.ErrNoResponse {
+				return nil, err
+			}
+		}
+
+		c.logger.Info("Primary failed to provide the light block, replacing it",
+			"primary", primary, "height", height, "err", err)
+		if !c.replacePrimaryProvider() {
+			return nil, err
+		}
+	}
+}
+
+// replacePrimaryProvider makes the first witness the new primary. It returns
+// false if there are no witnesses left.
+func (c *Client) replacePrimaryProvider() bool {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	if len(c.witnesses) == 0 {
+		return false
+	}
+	c.primary = c.witnesses[0]
+	c.witnesses = c.witnesses[1:]
+	c.logger.Info("Replaced primary", "primary", c.primary)
+	return true
+}
+
+// removeWitnesses removes the given witnesses.
+func (c *Client) removeWitnesses(faulty []provider.Provider) {
+	if len(faulty) == 0 {
+		return
+	}
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	witnesses := make([]provider.Provider, 0, len(c.witnesses))
+	for _, w := range c.witnesses {
+		removed := false
+		for _, f := range faulty {
+			if w == f {
+				removed = true
+				break
+			}
+		}
+		if removed {
+			c.logger.Info("Removing witness", "witness", w)
+			continue
+		}
+		witnesses = append(witnesses, w)
+	}
+	c.witnesses = witnesses
+}
@@ -0,0 +1,236 @@
+package light
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/light/provider"
+	mockp "github.com/kardiachain/go-kardia/light/provider/mock"
+	dbs "github.com/kardiachain/go-kardia/light/store/db"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	chainID = "test"
+)
+
+var (
+	ctx         = context.Background()
+	bTime       = time.Now().Add(-time.Hour)
+	trustPeriod = 4 * time.Hour
+)
+
+func newTestClient(t *testing.T, lightBlocks map[uint64]*types.LightBlock, witnesses []provider.Provider,
+	options ...Option) *Client {
+
+	c, err := NewClient(
+		ctx,
+		chainID,
+		TrustOptions{Period: trustPeriod, Height: 1, Hash: lightBlocks[1].Hash()},
+		mockp.New(chainID, lightBlocks),
+		witnesses,
+		dbs.New(memorydb.New(), chainID),
+		options...,
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClientSequentialVerification(t *testing.T) {
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 10, 4, 1, bTime)
+	c := newTestClient(t, lightBlocks, nil, SequentialVerification())
+
+	lb, err := c.VerifyLightBlockAtHeight(ctx, 10, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, lightBlocks[10].Hash(), lb.Hash())
+	assert.EqualValues(t, 10, c.LastTrustedHeight())
+
+	// already trusted
+	lb, err = c.TrustedLightBlock(10)
+	require.NoError(t, err)
+	assert.Equal(t, lightBlocks[10].Hash(), lb.Hash())
+}
+
+func TestClientSkippingVerification(t *testing.T) {
+	// the whole validator set changes every 2 blocks, so the client can't
+	// skip more than one block at a time
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 10, 4, 2, bTime)
+	c := newTestClient(t, lightBlocks, nil)
+
+	lb, err := c.VerifyLightBlockAtHeight(ctx, 9, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, lightBlocks[9].Hash(), lb.Hash())
+
+	// the latest block
+	lb, err = c.Update(ctx, time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, lb)
+	assert.EqualValues(t, 10, lb.Height)
+
+	// nothing new
+	lb, err = c.Update(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, lb)
+}
+
+func TestClientRejectsForgedBlock(t *testing.T) {
+	lightBlocks, keys := genLightBlocksWithKeys(chainID, 3, 4, 0, bTime)
+
+	// height 3 signed by unknown validators
+	forgers := genPrivKeys(4)
+	vals := forgers.ToValidators(2, 0)
+	lightBlocks[3] = &types.LightBlock{
+		SignedHeader: forgers.GenSignedHeader(chainID, 3, bTime.Add(3*time.Minute), lightBlocks[2].Commit.BlockID,
+			vals, vals, common.BytesToHash([]byte("app_hash")), 0, len(forgers)),
+		ValidatorSet: vals,
+	}
+	c := newTestClient(t, lightBlocks, nil)
+
+	_, err := c.VerifyLightBlockAtHeight(ctx, 3, time.Now())
+	require.Error(t, err)
+	_, ok := err.(ErrVerificationFailed)
+	assert.True(t, ok, err)
+
+	// height 2 signed by a third of the validators only
+	sh := keys[2].GenSignedHeader(chainID, 2, bTime.Add(2*time.Minute), lightBlocks[1].Commit.BlockID,
+		keys[2].ToValidators(2, 0), keys[3].ToValidators(2, 0), common.BytesToHash([]byte("app_hash")), 0, 1)
+	lightBlocks[2] = &types.LightBlock{SignedHeader: sh, ValidatorSet: keys[2].ToValidators(2, 0)}
+	_, err = c.VerifyLightBlockAtHeight(ctx, 2, time.Now())
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, c.LastTrustedHeight())
+}
+
+func TestClientBackwardsVerification(t *testing.T) {
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 5, 4, 1, bTime)
+	c, err := NewClient(
+		ctx,
+		chainID,
+		TrustOptions{Period: trustPeriod, Height: 5, Hash: lightBlocks[5].Hash()},
+		mockp.New(chainID, lightBlocks),
+		nil,
+		dbs.New(memorydb.New(), chainID),
+	)
+	require.NoError(t, err)
+
+	lb, err := c.VerifyLightBlockAtHeight(ctx, 2, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, lightBlocks[2].Hash(), lb.Hash())
+	assert.EqualValues(t, 5, c.LastTrustedHeight())
+}
+
+func TestClientExpiredTrust(t *testing.T) {
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 3, 4, 0, bTime)
+	c := newTestClient(t, lightBlocks, nil)
+
+	_, err := c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(trustPeriod+time.Hour))
+	require.Error(t, err)
+	verr, ok := err.(ErrVerificationFailed)
+	require.True(t, ok, err)
+	_, ok = verr.Reason.(ErrOldHeaderExpired)
+	assert.True(t, ok, err)
+}
+
+func TestClientRestoresFromStore(t *testing.T) {
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 5, 4, 1, bTime)
+	trustedStore := dbs.New(memorydb.New(), chainID)
+	c, err := NewClient(ctx, chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: lightBlocks[1].Hash()},
+		mockp.New(chainID, lightBlocks), nil, trustedStore)
+	require.NoError(t, err)
+	_, err = c.VerifyLightBlockAtHeight(ctx, 4, time.Now())
+	require.NoError(t, err)
+
+	// the primary doesn't need to serve the trusted blocks again
+	c, err = NewClient(ctx, chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: lightBlocks[1].Hash()},
+		mockp.New(chainID, map[uint64]*types.LightBlock{5: lightBlocks[5]}), nil, trustedStore)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, c.LastTrustedHeight())
+
+	// a store trusting another chain is rejected
+	_, err = NewClient(ctx, chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: lightBlocks[2].Hash()},
+		mockp.New(chainID, lightBlocks), nil, trustedStore)
+	assert.Error(t, err)
+}
+
+func TestClientReplacesUnavailablePrimary(t *testing.T) {
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 3, 4, 0, bTime)
+	primary := mockp.New(chainID, lightBlocks)
+	witness := mockp.New(chainID, lightBlocks)
+	c, err := NewClient(ctx, chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: lightBlocks[1].Hash()},
+		primary, []provider.Provider{witness}, dbs.New(memorydb.New(), chainID))
+	require.NoError(t, err)
+
+	primary.Unavailable(true)
+	_, err = c.VerifyLightBlockAtHeight(ctx, 3, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, witness, c.Primary())
+	assert.Empty(t, c.Witnesses())
+}
+
+func TestClientDetectsConflictingHeaders(t *testing.T) {
+	lightBlocks, keys := genLightBlocksWithKeys(chainID, 5, 4, 0, bTime)
+
+	// the same validators sign another block at height 5
+	vals := keys[5].ToValidators(2, 0)
+	forked := make(map[uint64]*types.LightBlock, len(lightBlocks))
+	for h, lb := range lightBlocks {
+		forked[h] = lb
+	}
+	forked[5] = &types.LightBlock{
+		SignedHeader: keys[5].GenSignedHeader(chainID, 5, bTime.Add(5*time.Minute), lightBlocks[4].Commit.BlockID,
+			vals, vals, common.BytesToHash([]byte("forked_app_hash")), 0, len(keys[5])),
+		ValidatorSet: vals,
+	}
+
+	witness := mockp.New(chainID, forked)
+	c := newTestClient(t, lightBlocks, []provider.Provider{witness})
+
+	_, err := c.VerifyLightBlockAtHeight(ctx, 5, time.Now())
+	require.Error(t, err)
+	attack, ok := err.(ErrConflictingHeaders)
+	require.True(t, ok, err)
+	assert.Equal(t, forked[5].Hash(), attack.Block.Hash())
+	assert.EqualValues(t, 1, attack.CommonHeight)
+	// nothing got trusted
+	assert.EqualValues(t, 1, c.LastTrustedHeight())
+
+	// the evidence against each provider was reported to the other one
+	evAgainstPrimary := newLightClientAttackEvidence(lightBlocks[5], forked[5], lightBlocks[1])
+	evAgainstWitness := newLightClientAttackEvidence(forked[5], lightBlocks[5], lightBlocks[1])
+	for _, ev := range []*types.LightClientAttackEvidence{evAgainstPrimary, evAgainstWitness} {
+		require.NoError(t, ev.ValidateBasic())
+		assert.EqualValues(t, 1, ev.CommonHeight)
+		assert.Len(t, ev.ByzantineValidators, 4)
+	}
+	assert.True(t, witness.HasEvidence(evAgainstPrimary))
+	assert.True(t, c.Primary().(*mockp.Mock).HasEvidence(evAgainstWitness))
+}
+
+func TestClientRemovesFaultyWitness(t *testing.T) {
+	lightBlocks, _ := genLightBlocksWithKeys(chainID, 5, 4, 0, bTime)
+	honest := mockp.New(chainID, lightBlocks)
+
+	// a witness serving blocks signed by unknown validators
+	forgers := genPrivKeys(4)
+	vals := forgers.ToValidators(2, 0)
+	forged := make(map[uint64]*types.LightBlock, len(lightBlocks))
+	for h, lb := range lightBlocks {
+		forged[h] = lb
+	}
+	forged[5] = &types.LightBlock{
+		SignedHeader: forgers.GenSignedHeader(chainID, 5, bTime.Add(5*time.Minute), lightBlocks[4].Commit.BlockID,
+			vals, vals, common.BytesToHash([]byte("app_hash")), 0, len(forgers)),
+		ValidatorSet: vals,
+	}
+	faulty := mockp.New(chainID, forged)
+
+	c := newTestClient(t, lightBlocks, []provider.Provider{faulty, honest})
+	_, err := c.VerifyLightBlockAtHeight(ctx, 5, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []provider.Provider{honest}, c.Witnesses())
+}
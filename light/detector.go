@@ -0,0 +1,274 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package light
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kardiachain/go-kardia/light/provider"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// errConflictingHeaders is returned when a witness returns a different light
+// block than the primary at the same height. Whether the witness block is
+// valid isn't known yet.
+type errConflictingHeaders struct {
+	Block        *types.LightBlock
+	WitnessIndex int
+}
+
+func (e errConflictingHeaders) Error() string {
+	return fmt.Sprintf(
+		"header hash (%X) from witness (%d) does not match primary",
+		e.Block.Hash(), e.WitnessIndex)
+}
+
+// detectDivergence cross-checks the last light block of the primary trace
+// with the witnesses.
+//
+// A witness serving a different light block, which verifies from the root of
+// the primary trace, is evidence of a light client attack: the verification
+// is halted with ErrConflictingHeaders. Witnesses serving invalid light
+// blocks are removed.
+//
+// If no witness responded with the same light block,
+// ErrFailedHeaderCrossReferencing is returned.
+func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.LightBlock, now time.Time) error {
+	if len(primaryTrace) < 2 {
+		return fmt.Errorf("nil or single block primary trace")
+	}
+	var (
+		headerMatched      bool
+		lastVerifiedHeader = primaryTrace[len(primaryTrace)-1].SignedHeader
+		witnessesToRemove  = make([]provider.Provider, 0)
+	)
+	witnesses := c.Witnesses()
+	if len(witnesses) == 0 {
+		return nil
+	}
+	c.logger.Debug("Running detector against trace", "endBlockHeight", lastVerifiedHeader.Height,
+		"endBlockHash", lastVerifiedHeader.Hash(), "length", len(primaryTrace))
+
+	// launch one goroutine per witness to retrieve the light block of the
+	// target height and compare it with the header from the primary
+	errc := make(chan error, len(witnesses))
+	for i, witness := range witnesses {
+		go c.compareNewHeaderWithWitness(ctx, errc, lastVerifiedHeader, witness, i)
+	}
+
+	// handle errors from the header comparisons as they come in
+	for i := 0; i < cap(errc); i++ {
+		err := <-errc
+
+		switch e := err.(type) {
+		case nil: // at least one header matched
+			headerMatched = true
+		case errConflictingHeaders:
+			// We have conflicting headers. This could possibly imply an attack
+			// on the light client. First we need to verify the witness's
+			// header using the same skipping verification and then find where
+			// the point of bifurcation is.
+			attack := c.handleConflictingHeaders(ctx, primaryTrace, e.Block, e.WitnessIndex, witnesses[e.WitnessIndex], now)
+			if attack != nil {
+				return attack
+			}
+			// the witness sent an invalid light block
+			witnessesToRemove = append(witnessesToRemove, witnesses[e.WitnessIndex])
+		case errBadWitness:
+			c.logger.Info("Witness returned an error during header comparison", "witness",
+				witnesses[e.WitnessIndex], "err", e.Reason)
+			// if witness sent us an invalid header, then remove it
+			if _, ok := e.Reason.(provider.ErrBadLightBlock); ok {
+				witnessesToRemove = append(witnessesToRemove, witnesses[e.WitnessIndex])
+			}
+		default:
+			c.logger.Debug("Error in light block comparison", "err", err)
+		}
+	}
+
+	// remove witnesses that have misbehaved
+	c.removeWitnesses(witnessesToRemove)
+
+	// 1. If we had at least one witness that returned the same header then we
+	// conclude that we can trust the header
+	if headerMatched {
+		return nil
+	}
+
+	// 2. Else all witnesses have either not responded, don't have the block or
+	// sent invalid blocks.
+	return ErrFailedHeaderCrossReferencing
+}
+
+// compareNewHeaderWithWitness takes the verified header from the primary and
+// compares it with the header from the witness.
+//
+// 1: errConflictingHeaders -> there may have been an attack on this light
+// client
+// 2: errBadWitness -> the witness has either not responded, doesn't have the
+// header or has given us an invalid one
+// 3: nil -> the hashes of the two headers match
+func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan error, h *types.SignedHeader,
+	witness provider.Provider, witnessIndex int) {
+
+	lightBlock, err := witness.LightBlock(ctx, h.Height)
+	if err == nil {
+		if verr := lightBlock.ValidateBasic(); verr != nil {
+			err = provider.ErrBadLightBlock{Reason: verr}
+		}
+	}
+	if err != nil {
+		errc <- errBadWitness{Reason: err, WitnessIndex: witnessIndex}
+		return
+	}
+
+	if !lightBlock.Hash().Equal(h.Hash()) {
+		errc <- errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
+		return
+	}
+
+	c.logger.Debug("Matching header received by witness", "height", h.Height, "witness", witnessIndex)
+	errc <- nil
+}
+
+// handleConflictingHeaders verifies the challenging light block of a witness
+// from the root of the primary trace. If it verifies, both the primary and the
+// witness sent valid but conflicting light blocks: light client attack
+// evidence is reported to both of them and ErrConflictingHeaders is returned.
+// Otherwise the witness is faulty and nil is returned.
+func (c *Client) handleConflictingHeaders(
+	ctx context.Context,
+	primaryTrace []*types.LightBlock,
+	challengingBlock *types.LightBlock,
+	witnessIndex int,
+	witness provider.Provider,
+	now time.Time,
+) error {
+	witnessTrace, err := c.verifySkipping(ctx, witness, primaryTrace[0], challengingBlock, now)
+	if err != nil {
+		c.logger.Info("Witness sent an invalid light block, removing it",
+			"witness", witness, "height", challengingBlock.Height, "err", err)
+		return nil
+	}
+
+	// find the last block both traces agree on, where the attack started
+	commonBlock := primaryTrace[0]
+	trusted := make(map[uint64]*types.LightBlock, len(primaryTrace))
+	for _, lb := range primaryTrace {
+		trusted[lb.Height] = lb
+	}
+	for _, lb := range witnessTrace[:len(witnessTrace)-1] {
+		if p, ok := trusted[lb.Height]; ok && p.Hash().Equal(lb.Hash()) && lb.Height > commonBlock.Height {
+			commonBlock = p
+		}
+	}
+	primaryBlock := primaryTrace[len(primaryTrace)-1]
+
+	c.logger.Error("Attack detected: the primary and a witness sent conflicting light blocks",
+		"height", challengingBlock.Height, "primaryHash", primaryBlock.Hash(),
+		"witness", witness, "witnessHash", challengingBlock.Hash(), "commonHeight", commonBlock.Height)
+
+	// We don't know which of the two providers is lying, so the evidence
+	// against each of them is reported to the other one. The full nodes
+	// verify it against their own chain and gossip it if it is valid.
+	evAgainstPrimary := newLightClientAttackEvidence(primaryBlock, challengingBlock, commonBlock)
+	c.sendEvidence(ctx, evAgainstPrimary, witness)
+
+	evAgainstWitness := newLightClientAttackEvidence(challengingBlock, primaryBlock, commonBlock)
+	c.sendEvidence(ctx, evAgainstWitness, c.Primary())
+
+	return ErrConflictingHeaders{Block: challengingBlock, WitnessIndex: witnessIndex, CommonHeight: commonBlock.Height}
+}
+
+// sendEvidence sends evidence to a provider on a best effort basis.
+func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
+	if err := receiver.ReportEvidence(ctx, ev); err != nil {
+		c.logger.Error("Failed to report evidence to provider", "ev", ev.Hash(), "provider", receiver, "err", err)
+		return
+	}
+	c.logger.Info("Reported evidence to provider", "ev", ev.Hash(), "provider", receiver)
+}
+
+// newLightClientAttackEvidence creates the evidence that the conflicted
+// block was signed by byzantine validators, given the trusted block at the
+// same height and the last block both traces agree on.
+func newLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock) *types.LightClientAttackEvidence {
+	ev := &types.LightClientAttackEvidence{ConflictingBlock: conflicted}
+	// If this is an equivocation or amnesia attack, i.e. the validator sets
+	// are the same, then we use the height of the conflicting block, else if
+	// it is a lunatic attack and the validator sets are not the same then we
+	// use the height of the common block.
+	if ev.ConflictingHeaderIsInvalid(trusted.Header) {
+		ev.CommonHeight = common.Height
+		ev.Timestamp = common.Time
+		ev.TotalVotingPower = common.ValidatorSet.TotalVotingPower()
+	} else {
+		ev.CommonHeight = trusted.Height
+		ev.Timestamp = trusted.Time
+		ev.TotalVotingPower = trusted.ValidatorSet.TotalVotingPower()
+	}
+	ev.ByzantineValidators = ev.GetByzantineValidators(common.ValidatorSet, trusted.SignedHeader)
+	return ev
+}
+
+// compareFirstHeaderWithWitnesses compares h with all witnesses. If any
+// witness reports a different header than h, the function returns an error.
+func (c *Client) compareFirstHeaderWithWitnesses(ctx context.Context, h *types.SignedHeader) error {
+	witnesses := c.Witnesses()
+	if len(witnesses) == 0 {
+		return nil
+	}
+
+	errc := make(chan error, len(witnesses))
+	for i, witness := range witnesses {
+		go c.compareNewHeaderWithWitness(ctx, errc, h, witness, i)
+	}
+
+	witnessesToRemove := make([]provider.Provider, 0, len(witnesses))
+
+	// handle errors from the header comparisons as they come in
+	for i := 0; i < cap(errc); i++ {
+		err := <-errc
+
+		switch e := err.(type) {
+		case nil:
+			continue
+		case errConflictingHeaders:
+			c.logger.Error(fmt.Sprintf(`Witness #%d has a different header. Please check primary is correct
+and remove witness. Otherwise, use a different primary`, e.WitnessIndex), "witness", witnesses[e.WitnessIndex])
+			return err
+		case errBadWitness:
+			// If witness sent us an invalid header, then remove it
+			if _, ok := e.Reason.(provider.ErrBadLightBlock); ok {
+				c.logger.Info("Witness sent an invalid light block, removing it",
+					"witness", witnesses[e.WitnessIndex],
+					"err", err)
+				witnessesToRemove = append(witnessesToRemove, witnesses[e.WitnessIndex])
+			}
+		default:
+			c.logger.Error("Unexpected error", "err", err)
+			return err
+		}
+	}
+
+	c.removeWitnesses(witnessesToRemove)
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package light
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// ErrOldHeaderExpired means the old (trusted) header has expired according to
+// the given trustingPeriod and current time. If so, the light client must be
+// reset subjectively.
+type ErrOldHeaderExpired struct {
+	At  time.Time
+	Now time.Time
+}
+
+func (e ErrOldHeaderExpired) Error() string {
+	return fmt.Sprintf("old header has expired at %v (now: %v)", e.At, e.Now)
+}
+
+// ErrNewValSetCantBeTrusted means the new validator set cannot be trusted
+// because < 1/3rd (+trustLevel+) of the old validator set has signed.
+type ErrNewValSetCantBeTrusted struct {
+	Reason types.ErrNotEnoughVotingPowerSigned
+}
+
+func (e ErrNewValSetCantBeTrusted) Error() string {
+	return fmt.Sprintf("cant trust new val set: %v", e.Reason)
+}
+
+// ErrInvalidHeader means the header either failed the basic validation or
+// commit is not signed by 2/3+.
+type ErrInvalidHeader struct {
+	Reason error
+}
+
+func (e ErrInvalidHeader) Error() string {
+	return fmt.Sprintf("invalid header: %v", e.Reason)
+}
+
+// ErrFailedHeaderCrossReferencing is returned when the detector was not able to
+// cross reference the header with any of the connected witnesses.
+var ErrFailedHeaderCrossReferencing = errors.New("all witnesses have either not responded, don't have the " +
+	" blocks or sent invalid blocks. You should look to change your witnesses" +
+	" or review the light client's logs for more information")
+
+// ErrVerificationFailed means either sequential or skipping verification has
+// failed to verify from header #1 to header #2 due to some reason.
+type ErrVerificationFailed struct {
+	From   uint64
+	To     uint64
+	Reason error
+}
+
+// Unwrap returns underlying reason.
+func (e ErrVerificationFailed) Unwrap() error {
+	return e.Reason
+}
+
+func (e ErrVerificationFailed) Error() string {
+	return fmt.Sprintf("verify from #%d to #%d failed: %v", e.From, e.To, e.Reason)
+}
+
+// ErrConflictingHeaders is returned when a witness serves a valid light block
+// at the same height as the primary but with a different hash: either the
+// primary or the witness is lying and the validators which signed both blocks
+// are attacking the light client.
+type ErrConflictingHeaders struct {
+	// Block is the light block of the witness
+	Block *types.LightBlock
+	// WitnessIndex is the index of the witness among the client witnesses
+	WitnessIndex int
+	// CommonHeight is the last height trusted by both the primary and the
+	// witness
+	CommonHeight uint64
+}
+
+func (e ErrConflictingHeaders) Error() string {
+	return fmt.Sprintf(
+		"header hash (%X) from witness (%d) does not match primary, light client attack from height %d",
+		e.Block.Hash(), e.WitnessIndex, e.CommonHeight)
+}
+
+// errBadWitness is returned when the witness either does not respond or
+// responds with an invalid header.
+type errBadWitness struct {
+	Reason       error
+	WitnessIndex int
+}
+
+func (e errBadWitness) Error() string {
+	return fmt.Sprintf("Witness %d returned error: %s", e.WitnessIndex, e.Reason.Error())
+}
+
+var errNoDivergence = errors.New(
+	"sanity check failed: no divergence between the original trace and the provider's new trace",
+)
@@ -0,0 +1,148 @@
+package light
+
+import (
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	kproto "github.com/kardiachain/go-kardia/proto/kardiachain/types"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// privKeys is a helper type for testing.
+//
+// It lets us simulate signing with many keys. The main use case is to create
+// a set, and call GenSignedHeader to get properly signed header for testing.
+type privKeys []types.PrivValidator
+
+// genPrivKeys produces an array of private keys to generate commits.
+func genPrivKeys(n int) privKeys {
+	res := make(privKeys, n)
+	for i := range res {
+		res[i] = types.NewMockPV()
+	}
+	return res
+}
+
+// Extend adds n more keys (to remove, just take a slice).
+func (pkz privKeys) Extend(n int) privKeys {
+	extra := genPrivKeys(n)
+	return append(pkz, extra...)
+}
+
+// ToValidators produces a valset from the set of keys.
+// The first key has weight `init` and it increases by `inc` every step
+// so we can have all the same weight, or a simple linear distribution
+// (should be enough for testing).
+func (pkz privKeys) ToValidators(init, inc int64) *types.ValidatorSet {
+	res := make([]*types.Validator, len(pkz))
+	for i, k := range pkz {
+		res[i] = types.NewValidator(k.GetAddress(), init+int64(i)*inc)
+	}
+	return types.NewValidatorSet(res)
+}
+
+// signHeader properly signs the header with all keys from first to last
+// exclusive.
+func (pkz privKeys) signHeader(chainID string, header *types.Header, valSet *types.ValidatorSet, first, last int) *types.Commit {
+	commitSigs := make([]types.CommitSig, len(valSet.Validators))
+	for i := 0; i < len(valSet.Validators); i++ {
+		commitSigs[i] = types.NewCommitSigAbsent()
+	}
+
+	blockID := types.BlockID{
+		Hash:        header.Hash(),
+		PartsHeader: types.PartSetHeader{Total: 1, Hash: common.BytesToHash(crypto.Keccak256([]byte("parts")))},
+	}
+
+	// Fill in the votes we want.
+	for i := first; i < last && i < len(pkz); i++ {
+		idx, _ := valSet.GetByAddress(pkz[i].GetAddress())
+		if idx < 0 {
+			continue
+		}
+		vote := makeVote(chainID, header, idx, pkz[i], blockID)
+		commitSigs[idx] = types.NewCommitSigForBlock(vote.Signature, vote.ValidatorAddress, vote.Timestamp)
+	}
+
+	return types.NewCommit(header.Height, 1, blockID, commitSigs)
+}
+
+func makeVote(chainID string, header *types.Header, valIndex int, pv types.PrivValidator,
+	blockID types.BlockID) *types.Vote {
+
+	vote := &types.Vote{
+		ValidatorAddress: pv.GetAddress(),
+		ValidatorIndex:   uint32(valIndex),
+		Height:           header.Height,
+		Round:            1,
+		Timestamp:        header.Time.Add(time.Second),
+		Type:             kproto.PrecommitType,
+		BlockID:          blockID,
+	}
+
+	v := vote.ToProto()
+	if err := pv.SignVote(chainID, v); err != nil {
+		panic(err)
+	}
+	vote.Signature = v.Signature
+	return vote
+}
+
+func genHeader(height uint64, bTime time.Time, lastBlockID types.BlockID,
+	valset, nextValset *types.ValidatorSet, appHash common.Hash) *types.Header {
+
+	return &types.Header{
+		Height:             height,
+		Time:               bTime,
+		LastBlockID:        lastBlockID,
+		ValidatorsHash:     valset.Hash(),
+		NextValidatorsHash: nextValset.Hash(),
+		AppHash:            appHash,
+		ProposerAddress:    valset.Validators[0].Address,
+	}
+}
+
+// GenSignedHeader calls genHeader and signHeader and combines them into a SignedHeader.
+func (pkz privKeys) GenSignedHeader(chainID string, height uint64, bTime time.Time, lastBlockID types.BlockID,
+	valset, nextValset *types.ValidatorSet, appHash common.Hash, first, last int) *types.SignedHeader {
+
+	header := genHeader(height, bTime, lastBlockID, valset, nextValset, appHash)
+	return &types.SignedHeader{
+		Header: header,
+		Commit: pkz.signHeader(chainID, header, valset, first, last),
+	}
+}
+
+// genLightBlocksWithKeys generates the light blocks of a chain of numBlocks
+// blocks, starting at height 1. valVariation is the number of validators
+// replaced at each height.
+func genLightBlocksWithKeys(
+	chainID string,
+	numBlocks uint64,
+	valSize int,
+	valVariation int,
+	bTime time.Time) (map[uint64]*types.LightBlock, map[uint64]privKeys) {
+
+	var (
+		lightBlocks = make(map[uint64]*types.LightBlock, numBlocks)
+		keymap      = make(map[uint64]privKeys, numBlocks+1)
+		keys        = genPrivKeys(valSize)
+		newKeys     privKeys
+	)
+
+	keymap[1] = keys
+	lastBlockID := types.BlockID{}
+	for height := uint64(1); height <= numBlocks; height++ {
+		newKeys = keys[valVariation:].Extend(valVariation)
+		keymap[height+1] = newKeys
+
+		sh := keys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute), lastBlockID,
+			keys.ToValidators(2, 0), newKeys.ToValidators(2, 0), common.BytesToHash([]byte("app_hash")), 0, len(keys))
+		lightBlocks[height] = &types.LightBlock{SignedHeader: sh, ValidatorSet: keys.ToValidators(2, 0)}
+		lastBlockID = sh.Commit.BlockID
+		keys = newKeys
+	}
+
+	return lightBlocks, keymap
+}
@@ -0,0 +1,120 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/light/provider"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// http provider uses the kai_getLightBlock RPC method of a full node to fetch
+// light blocks and kai_broadcastEvidence to report evidence.
+type http struct {
+	chainID string
+	remote  string
+	client  *rpc.Client
+}
+
+var _ provider.Provider = (*http)(nil)
+
+// New creates a HTTP provider, which is using the rpc.Client under the hood.
+// The remote is the RPC endpoint of a full node, e.g. http://localhost:8545.
+func New(chainID, remote string) (provider.Provider, error) {
+	client, err := rpc.Dial(remote)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithClient(chainID, remote, client), nil
+}
+
+// NewWithClient allows you to provide a custom client.
+func NewWithClient(chainID, remote string, client *rpc.Client) provider.Provider {
+	return &http{
+		chainID: chainID,
+		remote:  remote,
+		client:  client,
+	}
+}
+
+// ChainID returns the blockchain ID.
+func (p *http) ChainID() string {
+	return p.chainID
+}
+
+func (p *http) String() string {
+	return fmt.Sprintf("http{%s}", p.remote)
+}
+
+// LightBlock fetches a LightBlock at the given height and checks the
+// chainID matches.
+func (p *http) LightBlock(ctx context.Context, height uint64) (*types.LightBlock, error) {
+	var arg interface{} = height
+	if height == 0 {
+		arg = "latest"
+	}
+
+	lb := new(types.LightBlock)
+	if err := p.client.CallContext(ctx, lb, "kai_getLightBlock", arg); err != nil {
+		return nil, wrapError(err)
+	}
+	if err := lb.ValidateBasic(); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+	return lb, nil
+}
+
+// ReportEvidence submits the evidence to the evidence pool of the full node,
+// which gossips it to its peers.
+func (p *http) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	evp, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return err
+	}
+	bz, err := evp.Marshal()
+	if err != nil {
+		return err
+	}
+	var hash common.Hash
+	return wrapError(p.client.CallContext(ctx, &hash, "kai_broadcastEvidence", common.Bytes(bz)))
+}
+
+// wrapError maps RPC errors to provider errors.
+func wrapError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return provider.ErrNoResponse
+	case errors.Is(err, rpc.ErrNoResult), strings.Contains(err.Error(), "not found"):
+		return provider.ErrLightBlockNotFound
+	}
+	return err
+}
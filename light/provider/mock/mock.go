@@ -0,0 +1,134 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/light/provider"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Mock is a provider serving a fixed set of light blocks, used in tests.
+type Mock struct {
+	chainID string
+
+	mtx              sync.Mutex
+	lightBlocks      map[uint64]*types.LightBlock
+	latestHeight     uint64
+	unavailableBlock bool
+	evidenceToReport map[common.Hash]types.Evidence // hash => evidence
+}
+
+var _ provider.Provider = (*Mock)(nil)
+
+// New creates a mock provider with the given set of light blocks.
+func New(chainID string, lightBlocks map[uint64]*types.LightBlock) *Mock {
+	var height uint64
+	for h := range lightBlocks {
+		if h > height {
+			height = h
+		}
+	}
+	return &Mock{
+		chainID:          chainID,
+		lightBlocks:      lightBlocks,
+		latestHeight:     height,
+		evidenceToReport: make(map[common.Hash]types.Evidence),
+	}
+}
+
+// ChainID returns the blockchain ID.
+func (p *Mock) ChainID() string {
+	return p.chainID
+}
+
+func (p *Mock) String() string {
+	var headers strings.Builder
+	for _, l := range p.lightBlocks {
+		fmt.Fprintf(&headers, " %d:%X", l.Height, l.Hash())
+	}
+	return fmt.Sprintf("Mock{headers: %s}", headers.String())
+}
+
+// LightBlock returns the light block at height, the latest one if height is 0.
+func (p *Mock) LightBlock(ctx context.Context, height uint64) (*types.LightBlock, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.unavailableBlock {
+		return nil, provider.ErrNoResponse
+	}
+	if height == 0 && len(p.lightBlocks) > 0 {
+		height = p.latestHeight
+	}
+	if height > p.latestHeight {
+		return nil, provider.ErrHeightTooHigh
+	}
+	if lb, ok := p.lightBlocks[height]; ok {
+		return lb, nil
+	}
+	return nil, provider.ErrLightBlockNotFound
+}
+
+// ReportEvidence records the evidence.
+func (p *Mock) ReportEvidence(_ context.Context, ev types.Evidence) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.unavailableBlock {
+		return provider.ErrNoResponse
+	}
+	p.evidenceToReport[ev.Hash()] = ev
+	return nil
+}
+
+// HasEvidence returns true if the evidence was reported.
+func (p *Mock) HasEvidence(ev types.Evidence) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	_, ok := p.evidenceToReport[ev.Hash()]
+	return ok
+}
+
+// AddLightBlock adds a light block, moving the latest height if needed.
+func (p *Mock) AddLightBlock(lb *types.LightBlock) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if err := lb.ValidateBasic(); err != nil {
+		panic(fmt.Errorf("unable to add light block: %w", err))
+	}
+	p.lightBlocks[lb.Height] = lb
+	if lb.Height > p.latestHeight {
+		p.latestHeight = lb.Height
+	}
+}
+
+// Unavailable makes the provider fail to respond to any request.
+func (p *Mock) Unavailable(unavailable bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.unavailableBlock = unavailable
+}
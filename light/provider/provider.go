@@ -0,0 +1,47 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Provider provides information for the light client to sync (verification
+// happens in the client).
+type Provider interface {
+	// ChainID returns the blockchain ID.
+	ChainID() string
+
+	// LightBlock returns the LightBlock that corresponds to the given
+	// height.
+	//
+	// 0 - the latest.
+	// height must be >= 0.
+	//
+	// If the provider fails to fetch the LightBlock due to the IO or other
+	// issues, an error will be returned.
+	// If there's no LightBlock for the given height, ErrLightBlockNotFound
+	// error is returned.
+	LightBlock(ctx context.Context, height uint64) (*types.LightBlock, error)
+
+	// ReportEvidence reports an evidence of misbehavior.
+	ReportEvidence(context.Context, types.Evidence) error
+}
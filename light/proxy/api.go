@@ -0,0 +1,285 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// kaiAPI serves verified blocks and headers.
+type kaiAPI struct {
+	p *Proxy
+}
+
+// BlockNumber returns the height of the latest verified block.
+func (api *kaiAPI) BlockNumber(ctx context.Context) (uint64, error) {
+	lb, err := api.p.lightBlock(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+	return lb.Height, nil
+}
+
+// GetLightBlock returns the verified light block at the given height.
+func (api *kaiAPI) GetLightBlock(ctx context.Context, blockNumber rpc.BlockNumber) (*types.LightBlock, error) {
+	return api.p.lightBlock(ctx, blockNumber)
+}
+
+// GetBlockHeaderByNumber returns the verified header at the given height.
+func (api *kaiAPI) GetBlockHeaderByNumber(ctx context.Context, blockNumber rpc.BlockNumber) (*kai.BlockHeaderJSON, error) {
+	lb, err := api.p.lightBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	var remote *kai.BlockHeaderJSON
+	if err := api.p.remote.CallContext(ctx, &remote, "kai_getBlockHeaderByNumber", lb.Height); err != nil {
+		return nil, err
+	}
+	return verifiedHeaderJSON(lb.Header, remote)
+}
+
+// GetBlockHeaderByHash returns the verified header with the given hash.
+func (api *kaiAPI) GetBlockHeaderByHash(ctx context.Context, blockHash common.Hash) (*kai.BlockHeaderJSON, error) {
+	var remote *kai.BlockHeaderJSON
+	if err := api.p.remote.CallContext(ctx, &remote, "kai_getBlockHeaderByHash", blockHash); err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, nil
+	}
+	lb, err := api.p.lightBlock(ctx, rpc.BlockNumber(remote.Height))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHash("header", blockHash.Hex(), lb.Hash()); err != nil {
+		return nil, err
+	}
+	return verifiedHeaderJSON(lb.Header, remote)
+}
+
+// GetBlockByNumber returns the verified block at the given height.
+func (api *kaiAPI) GetBlockByNumber(ctx context.Context, blockNumber rpc.BlockNumber) (*kai.BlockJSON, error) {
+	block, err := api.p.block(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	var remote *kai.BlockJSON
+	if err := api.p.remote.CallContext(ctx, &remote, "kai_getBlockByNumber", block.Height()); err != nil {
+		return nil, err
+	}
+	return verifiedBlockJSON(block, remote)
+}
+
+// GetBlockByHash returns the verified block with the given hash.
+func (api *kaiAPI) GetBlockByHash(ctx context.Context, blockHash common.Hash) (*kai.BlockJSON, error) {
+	var remote *kai.BlockJSON
+	if err := api.p.remote.CallContext(ctx, &remote, "kai_getBlockByHash", blockHash); err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, nil
+	}
+	block, err := api.p.block(ctx, rpc.BlockNumber(remote.Height))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHash("block", blockHash.Hex(), block.Hash()); err != nil {
+		return nil, err
+	}
+	return verifiedBlockJSON(block, remote)
+}
+
+// verifiedHeaderJSON builds the JSON header from the verified header. The
+// gas used, the rewards and the logs bloom are left empty since headers don't
+// commit to them.
+func verifiedHeaderJSON(header *types.Header, remote *kai.BlockHeaderJSON) (*kai.BlockHeaderJSON, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("header %d not found", header.Height)
+	}
+	if err := checkHash("header", remote.Hash, header.Hash()); err != nil {
+		return nil, err
+	}
+	verified := kai.NewBlockHeaderJSON(header, &types.BlockInfo{})
+	verified.Rewards = ""
+	return verified, nil
+}
+
+// verifiedBlockJSON builds the JSON block from the verified block. The gas
+// used, the rewards, the logs bloom and the receipts are left empty since
+// headers don't commit to them.
+func verifiedBlockJSON(block *types.Block, remote *kai.BlockJSON) (*kai.BlockJSON, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("block %d not found", block.Height())
+	}
+	if err := checkHash("block", remote.Hash, block.Hash()); err != nil {
+		return nil, err
+	}
+	verified := kai.NewBlockJSON(block, nil)
+	verified.Rewards = ""
+	return verified, nil
+}
+
+// txAPI serves verified transactions.
+type txAPI struct {
+	p *Proxy
+}
+
+// SendRawTransaction forwards a signed transaction to the full node.
+func (api *txAPI) SendRawTransaction(ctx context.Context, txs string) (string, error) {
+	var hash string
+	err := api.p.remote.CallContext(ctx, &hash, "tx_sendRawTransaction", txs)
+	return hash, err
+}
+
+// SendPrivateTransaction forwards a signed private transaction to the full node.
+func (api *txAPI) SendPrivateTransaction(ctx context.Context, txs string, maxBlockHeight uint64) (string, error) {
+	var hash string
+	err := api.p.remote.CallContext(ctx, &hash, "tx_sendPrivateTransaction", txs, maxBlockHeight)
+	return hash, err
+}
+
+// SendBundle forwards a bundle of signed transactions to the full node.
+func (api *txAPI) SendBundle(ctx context.Context, args kai.SendBundleArgs) (string, error) {
+	var hash string
+	err := api.p.remote.CallContext(ctx, &hash, "tx_sendBundle", args)
+	return hash, err
+}
+
+// GetTransaction returns a transaction included in a verified block.
+func (api *txAPI) GetTransaction(ctx context.Context, hash string) (*kai.PublicTransaction, error) {
+	var remote *kai.PublicTransaction
+	if err := api.p.remote.CallContext(ctx, &remote, "tx_getTransaction", hash); err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, fmt.Errorf("tx for hash not found")
+	}
+	block, tx, err := api.p.transaction(ctx, common.HexToHash(hash), remote.BlockNumber, uint64(remote.TransactionIndex))
+	if err != nil {
+		return nil, err
+	}
+	verified := kai.NewPublicTransaction(tx, block.Hash(), block.Height(), uint64(remote.TransactionIndex))
+	verified.Time = block.Time()
+	return verified, nil
+}
+
+// receiptAPI forwards receipts, whose execution results can't be verified.
+type receiptAPI struct {
+	p *Proxy
+}
+
+// GetTransactionReceipt returns the receipt of a transaction included in a
+// verified block. Only the location, the sender and the recipient of the
+// transaction are verified, not the status, gas and logs of the receipt.
+func (api *receiptAPI) GetTransactionReceipt(ctx context.Context, hash string) (*kai.PublicReceipt, error) {
+	var remote *kai.PublicReceipt
+	if err := api.p.remote.CallContext(ctx, &remote, "tx_getTransactionReceipt", hash); err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, nil
+	}
+	block, tx, err := api.p.transaction(ctx, common.HexToHash(hash), remote.BlockHeight, remote.TransactionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHash("receipt block", remote.BlockHash, block.Hash()); err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err != nil {
+		return nil, err
+	}
+	remote.TransactionHash, remote.From = tx.Hash().Hex(), from.Hex()
+	if tx.To() != nil {
+		remote.To = tx.To().Hex()
+	} else {
+		remote.To = "0x"
+	}
+	return remote, nil
+}
+
+// accountAPI forwards the account methods without verification.
+type accountAPI struct {
+	p *Proxy
+}
+
+// Balance forwards account_balance.
+func (api *accountAPI) Balance(ctx context.Context, address common.Address, blockNrOrHash json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "account_balance", address, blockNrOrHash)
+}
+
+// Nonce forwards account_nonce.
+func (api *accountAPI) Nonce(ctx context.Context, address string) (json.RawMessage, error) {
+	return api.p.forward(ctx, "account_nonce", address)
+}
+
+// GetCode forwards account_getCode.
+func (api *accountAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "account_getCode", address, blockNrOrHash)
+}
+
+// GetStorageAt forwards account_getStorageAt.
+func (api *accountAPI) GetStorageAt(ctx context.Context, address common.Address, key string, blockNrOrHash json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "account_getStorageAt", address, key, blockNrOrHash)
+}
+
+// callAPI forwards the contract call and fee methods without verification.
+type callAPI struct {
+	p *Proxy
+}
+
+// KardiaCall forwards kai_kardiaCall.
+func (api *callAPI) KardiaCall(ctx context.Context, args json.RawMessage, blockNrOrHash json.RawMessage, overrides json.RawMessage, blockOverrides json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "kai_kardiaCall", args, blockNrOrHash, overrides, blockOverrides)
+}
+
+// EstimateGas forwards kai_estimateGas.
+func (api *callAPI) EstimateGas(ctx context.Context, args json.RawMessage, blockNrOrHash json.RawMessage, overrides json.RawMessage, blockOverrides json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "kai_estimateGas", args, blockNrOrHash, overrides, blockOverrides)
+}
+
+// Simulate forwards kai_simulate.
+func (api *callAPI) Simulate(ctx context.Context, calls json.RawMessage, blockNrOrHash json.RawMessage, overrides json.RawMessage, blockOverrides json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "kai_simulate", calls, blockNrOrHash, overrides, blockOverrides)
+}
+
+// GasPrice forwards kai_gasPrice.
+func (api *callAPI) GasPrice(ctx context.Context) (json.RawMessage, error) {
+	return api.p.forward(ctx, "kai_gasPrice")
+}
+
+// FeeHistory forwards kai_feeHistory.
+func (api *callAPI) FeeHistory(ctx context.Context, blockCount json.RawMessage, lastBlock json.RawMessage, rewardPercentiles json.RawMessage) (json.RawMessage, error) {
+	return api.p.forward(ctx, "kai_feeHistory", blockCount, lastBlock, rewardPercentiles)
+}
+
+// forward calls method on the full node and returns its result as is.
+func (p *Proxy) forward(ctx context.Context, method string, args ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := p.remote.CallContext(ctx, &result, method, args...)
+	return result, err
+}
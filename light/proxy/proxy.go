@@ -0,0 +1,184 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package proxy implements a local RPC server which forwards the requests of
+// wallets to a full node and verifies the responses with a light client
+// before returning them.
+//
+// Blocks, headers and transactions are checked against the light blocks
+// verified by the light client. Block headers don't commit to execution
+// results, so the gas used, rewards, logs bloom and receipts of blocks are
+// left out. Receipts, which are only checked to belong to a verified
+// transaction, and the account and contract call methods, which need state
+// proofs that full nodes don't serve yet, are only forwarded without
+// verification if the proxy is created with forwardUnverified.
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/light"
+	kproto "github.com/kardiachain/go-kardia/proto/kardiachain/types"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Proxy is a local RPC server which verifies the responses of a full node.
+type Proxy struct {
+	client *light.Client
+	remote *rpc.Client
+	server *rpc.Server
+	logger log.Logger
+}
+
+// NewProxy creates a proxy verifying the responses of the full node behind
+// remote with client.
+func NewProxy(client *light.Client, remote *rpc.Client, forwardUnverified bool, logger log.Logger) (*Proxy, error) {
+	p := &Proxy{
+		client: client,
+		remote: remote,
+		server: rpc.NewServer(),
+		logger: logger,
+	}
+	for _, api := range p.apis(forwardUnverified) {
+		if err := p.server.RegisterName(api.Namespace, api.Service); err != nil {
+			return nil, err
+		}
+	}
+	if forwardUnverified {
+		logger.Warn("Receipt, account and call methods are forwarded without verification")
+	}
+	return p, nil
+}
+
+func (p *Proxy) apis(forwardUnverified bool) []rpc.API {
+	apis := []rpc.API{
+		{
+			Namespace: "kai",
+			Version:   "1.0",
+			Service:   &kaiAPI{p},
+			Public:    true,
+		}, {
+			Namespace: "tx",
+			Version:   "1.0",
+			Service:   &txAPI{p},
+			Public:    true,
+		},
+	}
+	if forwardUnverified {
+		apis = append(apis, rpc.API{
+			Namespace: "tx",
+			Version:   "1.0",
+			Service:   &receiptAPI{p},
+			Public:    true,
+		}, rpc.API{
+			Namespace: "account",
+			Version:   "1.0",
+			Service:   &accountAPI{p},
+			Public:    true,
+		}, rpc.API{
+			Namespace: "kai",
+			Version:   "1.0",
+			Service:   &callAPI{p},
+			Public:    true,
+		})
+	}
+	return apis
+}
+
+// ServeHTTP serves JSON-RPC requests over HTTP.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.server.ServeHTTP(w, r)
+}
+
+// Stop stops serving requests and closes the connection to the full node.
+func (p *Proxy) Stop() {
+	p.server.Stop()
+	p.remote.Close()
+}
+
+// lightBlock returns the verified light block at the given height, the
+// latest one for rpc.LatestBlockNumber and rpc.PendingBlockNumber.
+func (p *Proxy) lightBlock(ctx context.Context, number rpc.BlockNumber) (*types.LightBlock, error) {
+	now := time.Now()
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		if _, err := p.client.Update(ctx, now); err != nil {
+			return nil, err
+		}
+		return p.client.TrustedLightBlock(0)
+	}
+	if number == rpc.EarliestBlockNumber {
+		return nil, fmt.Errorf("genesis block can't be verified")
+	}
+	return p.client.VerifyLightBlockAtHeight(ctx, number.Uint64(), now)
+}
+
+// block returns the block at the given height, checked against the verified
+// light block.
+func (p *Proxy) block(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	lb, err := p.lightBlock(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw common.Bytes
+	if err := p.remote.CallContext(ctx, &raw, "kai_getRawBlockByNumber", lb.Height); err != nil {
+		return nil, err
+	}
+	var pb kproto.Block
+	if err := pb.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("invalid block %d: %w", lb.Height, err)
+	}
+	// the transactions are checked against the header
+	block, err := types.BlockFromProto(&pb)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block %d: %w", lb.Height, err)
+	}
+	if !block.Hash().Equal(lb.Hash()) {
+		return nil, fmt.Errorf("block %d hash %X doesn't match the verified hash %X", lb.Height, block.Hash(), lb.Hash())
+	}
+	return block, nil
+}
+
+// transaction returns the transaction at index in the block at the given
+// height, checking it has the expected hash.
+func (p *Proxy) transaction(ctx context.Context, hash common.Hash, height, index uint64) (*types.Block, *types.Transaction, error) {
+	block, err := p.block(ctx, rpc.BlockNumber(height))
+	if err != nil {
+		return nil, nil, err
+	}
+	txs := block.Transactions()
+	if index >= uint64(len(txs)) || !txs[index].Hash().Equal(hash) {
+		return nil, nil, fmt.Errorf("transaction %s not found at index %d of block %d", hash.Hex(), index, height)
+	}
+	return block, txs[index], nil
+}
+
+// checkHash returns an error if the hash returned by the full node doesn't
+// match the verified one.
+func checkHash(what string, got string, want common.Hash) error {
+	if !common.HexToHash(got).Equal(want) {
+		return fmt.Errorf("%s hash %s doesn't match the verified hash %s", what, got, want.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package db
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/light/store"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+var lightBlockPrefix = []byte("lb/")
+
+type dbs struct {
+	db     kaidb.Database
+	prefix []byte
+
+	mtx  sync.RWMutex
+	size uint16
+}
+
+// New returns a Store that wraps any kaidb.Database implementation, keeping
+// its keys under the given prefix so that several light clients can share
+// the same database.
+func New(db kaidb.Database, prefix string) store.Store {
+	s := &dbs{db: db, prefix: append([]byte(prefix), lightBlockPrefix...)}
+
+	it := db.NewIterator(s.prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		s.size++
+	}
+	return s
+}
+
+// SaveLightBlock persists LightBlock to the db.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) SaveLightBlock(lb *types.LightBlock) error {
+	if lb.Height == 0 {
+		panic("negative or zero height")
+	}
+
+	lbBz, err := json.Marshal(lb)
+	if err != nil {
+		return fmt.Errorf("marshalling LightBlock: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := s.lbKey(lb.Height)
+	exists, err := s.db.Has(key)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put(key, lbBz); err != nil {
+		return err
+	}
+	if !exists {
+		s.size++
+	}
+	return nil
+}
+
+// DeleteLightBlock deletes the LightBlock with the given height.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) DeleteLightBlock(height uint64) error {
+	if height == 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := s.lbKey(height)
+	exists, err := s.db.Has(key)
+	if err != nil || !exists {
+		return err
+	}
+	if err := s.db.Delete(key); err != nil {
+		return err
+	}
+	s.size--
+	return nil
+}
+
+// LightBlock retrieves the LightBlock at the given height.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) LightBlock(height uint64) (*types.LightBlock, error) {
+	if height == 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	key := s.lbKey(height)
+	exists, err := s.db.Has(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, store.ErrLightBlockNotFound
+	}
+	bz, err := s.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	lb := new(types.LightBlock)
+	if err := json.Unmarshal(bz, lb); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+	return lb, nil
+}
+
+// LastLightBlockHeight returns the last LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) LastLightBlockHeight() (uint64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var height uint64
+	it := s.db.NewIterator(s.prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		height = s.heightFromKey(it.Key())
+	}
+	return height, it.Error()
+}
+
+// FirstLightBlockHeight returns the first LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) FirstLightBlockHeight() (uint64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	it := s.db.NewIterator(s.prefix, nil)
+	defer it.Release()
+	if it.Next() {
+		return s.heightFromKey(it.Key()), nil
+	}
+	return 0, it.Error()
+}
+
+// LightBlockBefore iterates over light blocks until it finds a block before
+// the given height. It returns ErrLightBlockNotFound if no such block exists.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) LightBlockBefore(height uint64) (*types.LightBlock, error) {
+	if height == 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	var before uint64
+	it := s.db.NewIterator(s.prefix, nil)
+	for it.Next() {
+		h := s.heightFromKey(it.Key())
+		if h >= height {
+			break
+		}
+		before = h
+	}
+	it.Release()
+	s.mtx.RUnlock()
+
+	if before == 0 {
+		return nil, store.ErrLightBlockNotFound
+	}
+	return s.LightBlock(before)
+}
+
+// Prune prunes header & validator set pairs until there are only size pairs
+// left, the oldest ones going first.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) Prune(size uint16) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.size <= size {
+		return nil
+	}
+	numToPrune := s.size - size
+
+	b := s.db.NewBatch()
+	it := s.db.NewIterator(s.prefix, nil)
+	for pruned := uint16(0); pruned < numToPrune && it.Next(); pruned++ {
+		key := append([]byte{}, it.Key()...)
+		if err := b.Delete(key); err != nil {
+			it.Release()
+			return err
+		}
+	}
+	it.Release()
+	if err := b.Write(); err != nil {
+		return err
+	}
+	s.size = size
+	return nil
+}
+
+// Size returns the number of header & validator set pairs.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) Size() uint16 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.size
+}
+
+// lbKey returns the key of the light block at height, heights are big endian
+// encoded so that the iteration order is the height order.
+func (s *dbs) lbKey(height uint64) []byte {
+	key := make([]byte, len(s.prefix)+8)
+	copy(key, s.prefix)
+	binary.BigEndian.PutUint64(key[len(s.prefix):], height)
+	return key
+}
+
+func (s *dbs) heightFromKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(key)-8:])
+}
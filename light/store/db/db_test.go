@@ -0,0 +1,167 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/light/store"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestLast_FirstLightBlockHeight(t *testing.T) {
+	dbStore := New(memorydb.New(), "TestLast_FirstLightBlockHeight")
+
+	// Empty store
+	height, err := dbStore.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, height)
+
+	height, err = dbStore.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, height)
+
+	// 1 key
+	err = dbStore.SaveLightBlock(randLightBlock(1))
+	require.NoError(t, err)
+
+	height, err = dbStore.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, height)
+
+	height, err = dbStore.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, height)
+
+	// heights sort numerically
+	require.NoError(t, dbStore.SaveLightBlock(randLightBlock(256)))
+	height, err = dbStore.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 256, height)
+}
+
+func Test_SaveLightBlock(t *testing.T) {
+	dbStore := New(memorydb.New(), "Test_SaveLightBlockAndValidatorSet")
+
+	// Empty store
+	h, err := dbStore.LightBlock(1)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+	assert.Nil(t, h)
+
+	// 1 key
+	lb := randLightBlock(1)
+	err = dbStore.SaveLightBlock(lb)
+	require.NoError(t, err)
+
+	size := dbStore.Size()
+	assert.EqualValues(t, 1, size)
+
+	h, err = dbStore.LightBlock(1)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(), h.Hash())
+	assert.Equal(t, lb.ValidatorSet.Hash(), h.ValidatorSet.Hash())
+
+	// saving the same block again doesn't change the size
+	require.NoError(t, dbStore.SaveLightBlock(lb))
+	assert.EqualValues(t, 1, dbStore.Size())
+
+	// Empty store
+	err = dbStore.DeleteLightBlock(1)
+	require.NoError(t, err)
+
+	h, err = dbStore.LightBlock(1)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+	assert.Nil(t, h)
+	assert.EqualValues(t, 0, dbStore.Size())
+}
+
+func Test_LightBlockBefore(t *testing.T) {
+	dbStore := New(memorydb.New(), "Test_LightBlockBefore")
+
+	assert.Panics(t, func() {
+		_, _ = dbStore.LightBlockBefore(0)
+	})
+
+	err := dbStore.SaveLightBlock(randLightBlock(2))
+	require.NoError(t, err)
+
+	h, err := dbStore.LightBlockBefore(3)
+	require.NoError(t, err)
+	if assert.NotNil(t, h) {
+		assert.EqualValues(t, 2, h.Height)
+	}
+
+	_, err = dbStore.LightBlockBefore(2)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+}
+
+func Test_Prune(t *testing.T) {
+	db := memorydb.New()
+	dbStore := New(db, "Test_Prune")
+
+	// Empty store
+	assert.EqualValues(t, 0, dbStore.Size())
+	err := dbStore.Prune(0)
+	require.NoError(t, err)
+
+	// One header
+	err = dbStore.SaveLightBlock(randLightBlock(2))
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, dbStore.Size())
+
+	err = dbStore.Prune(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, dbStore.Size())
+
+	err = dbStore.Prune(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, dbStore.Size())
+
+	// Multiple headers
+	for i := 1; i <= 10; i++ {
+		err = dbStore.SaveLightBlock(randLightBlock(uint64(i)))
+		require.NoError(t, err)
+	}
+
+	err = dbStore.Prune(11)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, dbStore.Size())
+
+	err = dbStore.Prune(7)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, dbStore.Size())
+
+	// the oldest ones are gone
+	height, err := dbStore.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, height)
+
+	// the size is restored from the database
+	assert.EqualValues(t, 7, New(db, "Test_Prune").Size())
+	assert.EqualValues(t, 0, New(db, "other").Size())
+}
+
+func randLightBlock(height uint64) *types.LightBlock {
+	vals, _ := types.RandValidatorSet(2, 1)
+	header := &types.Header{
+		Height:             height,
+		Time:               time.Now(),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		AppHash:            common.BytesToHash([]byte("app_hash")),
+		ProposerAddress:    vals.Validators[0].Address,
+	}
+	blockID := types.BlockID{Hash: header.Hash()}
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: header,
+			Commit: types.NewCommit(height, 1, blockID, []types.CommitSig{types.NewCommitSigAbsent()}),
+		},
+		ValidatorSet: vals,
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"errors"
+
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// ErrLightBlockNotFound is returned when a store does not have the
+// requested light block.
+var ErrLightBlockNotFound = errors.New("light block not found")
+
+// Store is anything that can persistently store headers.
+type Store interface {
+	// SaveLightBlock saves a LightBlock (h: sh.Height).
+	//
+	// height must be > 0.
+	SaveLightBlock(lb *types.LightBlock) error
+
+	// DeleteLightBlock deletes the LightBlock with the given height.
+	//
+	// height must be > 0.
+	DeleteLightBlock(height uint64) error
+
+	// LightBlock returns the LightBlock that corresponds to the given height.
+	//
+	// height must be > 0.
+	//
+	// If LightBlock is not found, ErrLightBlockNotFound is returned.
+	LightBlock(height uint64) (*types.LightBlock, error)
+
+	// LastLightBlockHeight returns the last (newest) LightBlock height.
+	//
+	// If the store is empty, 0 and nil error are returned.
+	LastLightBlockHeight() (uint64, error)
+
+	// FirstLightBlockHeight returns the first (oldest) LightBlock height.
+	//
+	// If the store is empty, 0 and nil error are returned.
+	FirstLightBlockHeight() (uint64, error)
+
+	// LightBlockBefore returns the LightBlock before a certain height.
+	//
+	// height must be > 0 && <= LastLightBlockHeight.
+	LightBlockBefore(height uint64) (*types.LightBlock, error)
+
+	// Prune removes headers & the associated validator sets when Store reaches a
+	// defined size (number of header & validator set pairs).
+	Prune(size uint16) error
+
+	// Size returns a number of currently existing header & validator set pairs.
+	Size() uint16
+}
@@ -0,0 +1,166 @@
+package light
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	kmath "github.com/kardiachain/go-kardia/lib/math"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestVerifyAdjacentHeaders(t *testing.T) {
+	var (
+		keys    = genPrivKeys(4)
+		vals    = keys.ToValidators(20, 10)
+		appHash = common.BytesToHash([]byte("app_hash"))
+		header  = keys.GenSignedHeader(chainID, 1, bTime, types.BlockID{}, vals, vals, appHash, 0, len(keys))
+		now     = bTime.Add(time.Hour)
+	)
+
+	testCases := []struct {
+		name      string
+		newHeader *types.SignedHeader
+		newVals   *types.ValidatorSet
+		now       time.Time
+		expErr    bool
+	}{
+		{
+			"good",
+			keys.GenSignedHeader(chainID, 2, bTime.Add(time.Minute), header.Commit.BlockID, vals, vals, appHash,
+				0, len(keys)),
+			vals,
+			now,
+			false,
+		},
+		{
+			"not adjacent",
+			keys.GenSignedHeader(chainID, 3, bTime.Add(time.Minute), header.Commit.BlockID, vals, vals, appHash,
+				0, len(keys)),
+			vals,
+			now,
+			true,
+		},
+		{
+			"old header expired",
+			keys.GenSignedHeader(chainID, 2, bTime.Add(time.Minute), header.Commit.BlockID, vals, vals, appHash,
+				0, len(keys)),
+			vals,
+			bTime.Add(trustPeriod),
+			true,
+		},
+		{
+			"time from the future",
+			keys.GenSignedHeader(chainID, 2, now.Add(time.Hour), header.Commit.BlockID, vals, vals, appHash,
+				0, len(keys)),
+			vals,
+			now,
+			true,
+		},
+		{
+			"less than 2/3 signed",
+			keys.GenSignedHeader(chainID, 2, bTime.Add(time.Minute), header.Commit.BlockID, vals, vals, appHash,
+				2, len(keys)),
+			vals,
+			now,
+			true,
+		},
+		{
+			"validators not matching the next validators of the trusted header",
+			keys.Extend(1).GenSignedHeader(chainID, 2, bTime.Add(time.Minute), header.Commit.BlockID,
+				keys.Extend(1).ToValidators(20, 10), vals, appHash, 0, len(keys)),
+			keys.Extend(1).ToValidators(20, 10),
+			now,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyAdjacent(chainID, header, tc.newHeader, tc.newVals, trustPeriod, tc.now, time.Second)
+			if tc.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyNonAdjacentHeaders(t *testing.T) {
+	var (
+		keys    = genPrivKeys(4)
+		vals    = keys.ToValidators(20, 10)
+		appHash = common.BytesToHash([]byte("app_hash"))
+		header  = keys.GenSignedHeader(chainID, 1, bTime, types.BlockID{}, vals, vals, appHash, 0, len(keys))
+		now     = bTime.Add(time.Hour)
+
+		// 3/4 of the validators are replaced
+		newKeys = keys[3:].Extend(3)
+		newVals = newKeys.ToValidators(10, 1)
+		// the whole set is replaced
+		lostKeys = genPrivKeys(4)
+		lostVals = lostKeys.ToValidators(10, 1)
+	)
+
+	// the one validator left has 50 of 140 voting power
+	err := VerifyNonAdjacent(chainID, header, vals,
+		newKeys.GenSignedHeader(chainID, 5, bTime.Add(time.Minute), types.BlockID{}, newVals, newVals, appHash,
+			0, len(newKeys)),
+		newVals, trustPeriod, now, time.Second, DefaultTrustLevel)
+	assert.NoError(t, err)
+
+	// but not 2/3 of the trusted validators
+	err = VerifyNonAdjacent(chainID, header, vals,
+		newKeys.GenSignedHeader(chainID, 5, bTime.Add(time.Minute), types.BlockID{}, newVals, newVals, appHash,
+			0, len(newKeys)),
+		newVals, trustPeriod, now, time.Second, kmath.Fraction{Numerator: 2, Denominator: 3})
+	_, ok := err.(ErrNewValSetCantBeTrusted)
+	assert.True(t, ok, err)
+
+	err = VerifyNonAdjacent(chainID, header, vals,
+		lostKeys.GenSignedHeader(chainID, 5, bTime.Add(time.Minute), types.BlockID{}, lostVals, lostVals, appHash,
+			0, len(lostKeys)),
+		lostVals, trustPeriod, now, time.Second, DefaultTrustLevel)
+	_, ok = err.(ErrNewValSetCantBeTrusted)
+	assert.True(t, ok, err)
+
+	// adjacent headers are rejected
+	err = VerifyNonAdjacent(chainID, header, vals,
+		keys.GenSignedHeader(chainID, 2, bTime.Add(time.Minute), types.BlockID{}, vals, vals, appHash,
+			0, len(keys)),
+		vals, trustPeriod, now, time.Second, DefaultTrustLevel)
+	assert.Error(t, err)
+}
+
+func TestValidateTrustLevel(t *testing.T) {
+	testCases := []struct {
+		lvl   kmath.Fraction
+		valid bool
+	}{
+		// valid
+		0: {kmath.Fraction{Numerator: 1, Denominator: 1}, true},
+		1: {kmath.Fraction{Numerator: 1, Denominator: 3}, true},
+		2: {kmath.Fraction{Numerator: 2, Denominator: 3}, true},
+		3: {kmath.Fraction{Numerator: 3, Denominator: 3}, true},
+		4: {kmath.Fraction{Numerator: 4, Denominator: 5}, true},
+
+		// invalid
+		5: {kmath.Fraction{Numerator: 6, Denominator: 5}, false},
+		6: {kmath.Fraction{Numerator: 0, Denominator: 1}, false},
+		7: {kmath.Fraction{Numerator: 0, Denominator: 0}, false},
+		8: {kmath.Fraction{Numerator: 1, Denominator: 0}, false},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateTrustLevel(tc.lvl)
+		if !tc.valid {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
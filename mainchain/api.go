@@ -26,13 +26,16 @@ import (
 	"time"
 
 	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/state"
 	"github.com/kardiachain/go-kardia/kvm"
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/rlp"
 	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/staking"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	kproto "github.com/kardiachain/go-kardia/proto/kardiachain/types"
 	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
 )
@@ -221,6 +224,65 @@ func (s *PublicKaiAPI) GetBlockByHash(ctx context.Context, blockHash rpc.BlockNu
 	return NewBlockJSON(block, blockInfo)
 }
 
+// GetLightBlock returns the header of the block with the given number along
+// with the commit and the validator set which signed it, so that light clients
+// can verify it.
+func (s *PublicKaiAPI) GetLightBlock(ctx context.Context, blockNumber rpc.BlockNumber) (*types.LightBlock, error) {
+	header := s.kaiService.HeaderByNumber(ctx, blockNumber)
+	if header == nil {
+		return nil, ErrBlockNotFound
+	}
+	// the commit of the latest block is only known locally until the next
+	// block is committed
+	commit := s.kaiService.blockchain.LoadBlockCommit(header.Height)
+	if commit == nil {
+		commit = s.kaiService.blockchain.LoadSeenCommit(header.Height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("commit of block %d not found", header.Height)
+	}
+	vals, err := s.kaiService.stateDB.LoadValidators(header.Height)
+	if err != nil {
+		return nil, err
+	}
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}, nil
+}
+
+// GetRawBlockByNumber returns the protobuf encoding of the block with the
+// given number, so that its transactions can be checked against its header.
+func (s *PublicKaiAPI) GetRawBlockByNumber(ctx context.Context, blockNumber rpc.BlockNumber) (common.Bytes, error) {
+	block := s.kaiService.BlockByNumber(ctx, blockNumber)
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return pb.Marshal()
+}
+
+// BroadcastEvidence verifies the given protobuf encoded evidence and adds it
+// to the evidence pool, which gossips it to the peers. It returns the hash of
+// the evidence.
+func (s *PublicKaiAPI) BroadcastEvidence(ctx context.Context, evBytes common.Bytes) (common.Hash, error) {
+	var evp kproto.Evidence
+	if err := evp.Unmarshal(evBytes); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid evidence: %w", err)
+	}
+	ev, err := types.EvidenceFromProto(&evp)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid evidence: %w", err)
+	}
+	if err := s.kaiService.evPool.AddEvidence(ev); err != nil {
+		return common.Hash{}, err
+	}
+	return ev.Hash(), nil
+}
+
 type Validator struct {
 	Name                  string       `json:"name"`
 	Address               string       `json:"address"`
@@ -256,23 +318,188 @@ type Delegator struct {
 	Reward       string `json:"reward"`
 }
 
-// Validator returns node's validator, nil if current node is not a validator
-func (s *PublicKaiAPI) Validator(ctx context.Context, valAddr common.Address, isGetDelegators bool) (*Validator, error) {
-	val, err := s.kaiService.GetValidator(valAddr)
+type UBDEntry struct {
+	Amount         string `json:"amount"`
+	CompletionTime uint64 `json:"completionTime"`
+}
+
+type Delegation struct {
+	Validator    string      `json:"validator"`
+	SmcAddress   string      `json:"smcAddress"`
+	StakedAmount string      `json:"stakedAmount"`
+	Reward       string      `json:"reward"`
+	UBDEntries   []*UBDEntry `json:"ubdEntries"`
+}
+
+type DelegatorInfo struct {
+	Address     string        `json:"address"`
+	BlockHeight uint64        `json:"blockHeight"`
+	Delegations []*Delegation `json:"delegations"`
+}
+
+// latestIfNil returns the latest block if no block is specified
+func latestIfNil(blockNrOrHash *rpc.BlockNumberOrHash) rpc.BlockNumberOrHash {
+	if blockNrOrHash == nil {
+		return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	}
+	return *blockNrOrHash
+}
+
+// Validator returns a validator at the given block, latest block if it is omitted.
+// Delegators are only returned if isGetDelegators is true, paginated by page.
+func (s *PublicKaiAPI) Validator(ctx context.Context, valAddr common.Address, isGetDelegators bool, blockNrOrHash *rpc.BlockNumberOrHash, page *staking.Pagination) (*Validator, error) {
+	val, err := s.kaiService.GetValidator(ctx, valAddr, latestIfNil(blockNrOrHash), isGetDelegators, page)
 	if err != nil {
 		return nil, err
 	}
-	var delegatorsList []*Delegator
-	if isGetDelegators {
-		for _, del := range val.Delegators {
-			delegatorsList = append(delegatorsList, &Delegator{
-				Address:      del.Address.Hex(),
-				StakedAmount: del.StakedAmount.String(),
-				Reward:       del.Reward.String(),
+	return newValidator(val), nil
+}
+
+// Validators returns a list of validator at the given block, latest block if it is omitted
+func (s *PublicKaiAPI) Validators(ctx context.Context, isGetDelegators bool, blockNrOrHash *rpc.BlockNumberOrHash, page *staking.Pagination) ([]*Validator, error) {
+	valList, err := s.kaiService.GetValidators(ctx, latestIfNil(blockNrOrHash), isGetDelegators, page)
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]*Validator, 0, len(valList))
+	for _, val := range valList {
+		validators = append(validators, newValidator(val))
+	}
+	return validators, nil
+}
+
+// GetDelegatorInfo returns every validator a delegator staked to at the given block,
+// with staked amount, pending reward and unbonding entries of each delegation
+func (s *PublicKaiAPI) GetDelegatorInfo(ctx context.Context, delAddr common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*DelegatorInfo, error) {
+	query, err := s.kaiService.StakingQuery(ctx, latestIfNil(blockNrOrHash))
+	if err != nil {
+		return nil, err
+	}
+	delegations, err := query.DelegatorInfo(delAddr)
+	if err != nil {
+		return nil, err
+	}
+	info := &DelegatorInfo{
+		Address:     delAddr.Hex(),
+		BlockHeight: query.Header().Height,
+		Delegations: make([]*Delegation, 0, len(delegations)),
+	}
+	for _, d := range delegations {
+		delegation := &Delegation{
+			Validator:    d.ValAddr.Hex(),
+			SmcAddress:   d.ValStakingSmc.Hex(),
+			StakedAmount: d.StakedAmount.String(),
+			Reward:       d.Reward.String(),
+			UBDEntries:   make([]*UBDEntry, 0, len(d.UBDEntries)),
+		}
+		for _, entry := range d.UBDEntries {
+			delegation.UBDEntries = append(delegation.UBDEntries, &UBDEntry{
+				Amount:         entry.Amount.String(),
+				CompletionTime: entry.CompletionTime.Uint64(),
 			})
 		}
-	} else {
-		delegatorsList = nil
+		info.Delegations = append(info.Delegations, delegation)
+	}
+	return info, nil
+}
+
+type StakingEvent struct {
+	Type         string `json:"type"`
+	BlockHeight  uint64 `json:"blockHeight"`
+	Validator    string `json:"validator,omitempty"`
+	Amount       string `json:"amount"`
+	EvidenceHash string `json:"evidenceHash,omitempty"`
+	TxHash       string `json:"txHash,omitempty"`
+}
+
+type DelegatorReward struct {
+	Type        string `json:"type"`
+	BlockHeight uint64 `json:"blockHeight"`
+	Validator   string `json:"validator"`
+	Reward      string `json:"reward"`
+}
+
+type DelegatorRewardHistory struct {
+	Address string             `json:"address"`
+	Total   string             `json:"total"`
+	Rewards []*DelegatorReward `json:"rewards"`
+}
+
+// GetStakingEvents returns minting, rewards, slashes and jails applied by the chain in blocks
+// [fromBlock, toBlock]. If validator is specified, only its events are returned, it may be
+// either the validator address or its staking contract address.
+func (s *PublicKaiAPI) GetStakingEvents(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, validator *common.Address) ([]*StakingEvent, error) {
+	if validator != nil {
+		query, err := s.kaiService.StakingQuery(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+		if err != nil {
+			return nil, err
+		}
+		valSmcAddr, err := query.ValidatorSmcAddr(*validator)
+		if err != nil {
+			return nil, err
+		}
+		if valSmcAddr != (common.Address{}) {
+			validator = &valSmcAddr
+		}
+	}
+	events, err := s.kaiService.GetStakingEvents(ctx, fromBlock, toBlock, validator)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*StakingEvent, 0, len(events))
+	for _, ev := range events {
+		event := &StakingEvent{
+			Type:        ev.Type.String(),
+			BlockHeight: ev.Height,
+			Amount:      ev.Amount.String(),
+		}
+		if ev.Validator != (common.Address{}) {
+			event.Validator = ev.Validator.Hex()
+		}
+		if ev.EvidenceHash != (common.Hash{}) {
+			event.EvidenceHash = ev.EvidenceHash.Hex()
+		}
+		if ev.TxHash != (common.Hash{}) {
+			event.TxHash = ev.TxHash.Hex()
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+// GetDelegatorRewardHistory returns the rewards a delegator earned from each allocation
+// to its validators in blocks [fromBlock, toBlock]
+func (s *PublicKaiAPI) GetDelegatorRewardHistory(ctx context.Context, delAddr common.Address, fromBlock, toBlock rpc.BlockNumber) (*DelegatorRewardHistory, error) {
+	rewards, err := s.kaiService.GetDelegatorRewards(ctx, delAddr, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	total := new(big.Int)
+	history := &DelegatorRewardHistory{
+		Address: delAddr.Hex(),
+		Rewards: make([]*DelegatorReward, 0, len(rewards)),
+	}
+	for _, r := range rewards {
+		total.Add(total, r.Reward)
+		history.Rewards = append(history.Rewards, &DelegatorReward{
+			Type:        r.Type.String(),
+			BlockHeight: r.Height,
+			Validator:   r.ValStakingSmc.Hex(),
+			Reward:      r.Reward.String(),
+		})
+	}
+	history.Total = total.String()
+	return history, nil
+}
+
+func newValidator(val *staking.Validator) *Validator {
+	var delegatorsList []*Delegator
+	for _, del := range val.Delegators {
+		delegatorsList = append(delegatorsList, &Delegator{
+			Address:      del.Address.Hex(),
+			StakedAmount: del.StakedAmount.String(),
+			Reward:       del.Reward.String(),
+		})
 	}
 
 	var name []byte
@@ -288,7 +515,7 @@ func (s *PublicKaiAPI) Validator(ctx context.Context, valAddr common.Address, is
 		SmcAddress:            val.ValStakingSmc.String(),
 		StakedAmount:          val.Tokens.String(),
 		CommissionRate:        val.CommissionRate.String(),
-		TotalDelegators:       len(val.Delegators),
+		TotalDelegators:       val.TotalDelegators,
 		MaxRate:               val.MaxRate.String(),
 		MaxChangeRate:         val.MaxChangeRate.String(),
 		Jailed:                val.Jailed,
@@ -307,24 +534,101 @@ func (s *PublicKaiAPI) Validator(ctx context.Context, valAddr common.Address, is
 			JailedUntil:        val.SigningInfo.JailedUntil.Uint64(),
 		},
 		Delegators: delegatorsList,
-	}, nil
+	}
 }
 
-// Validators returns a list of validator
-func (s *PublicKaiAPI) Validators(ctx context.Context, isGetDelegators bool) ([]*Validator, error) {
-	var validators []*Validator
-	valList, err := s.kaiService.GetValidators()
+type Proposal struct {
+	ID           uint64   `json:"id"`
+	Proposer     string   `json:"proposer"`
+	Keys         []uint64 `json:"keys"`
+	Values       []string `json:"values"`
+	Deposit      string   `json:"deposit"`
+	StartHeight  uint64   `json:"startHeight"`
+	TargetHeight uint64   `json:"targetHeight"`
+	Status       string   `json:"status"`
+	Yes          string   `json:"yes"`
+	No           string   `json:"no"`
+	Abstain      string   `json:"abstain"`
+}
+
+type ProposalVote struct {
+	ProposalID  uint64 `json:"proposalId"`
+	Voter       string `json:"voter"`
+	Voted       bool   `json:"voted"`
+	Option      string `json:"option,omitempty"`
+	VotingPower string `json:"votingPower"`
+}
+
+var (
+	proposalStatuses = map[uint8]string{
+		staking.ProposalPending:  "pending",
+		staking.ProposalPassed:   "passed",
+		staking.ProposalRejected: "rejected",
+	}
+	voteOptions = map[uint8]string{
+		staking.VoteAbstain: "abstain",
+		staking.VoteYes:     "yes",
+		staking.VoteNo:      "no",
+	}
+)
+
+func newProposal(p *staking.Proposal) *Proposal {
+	proposal := &Proposal{
+		ID:           p.ID.Uint64(),
+		Proposer:     p.Proposer.Hex(),
+		Deposit:      p.Deposit.String(),
+		StartHeight:  p.StartHeight.Uint64(),
+		TargetHeight: p.TargetHeight.Uint64(),
+		Status:       proposalStatuses[p.Status],
+		Yes:          p.Yes.String(),
+		No:           p.No.String(),
+		Abstain:      p.Abstain.String(),
+	}
+	for i := range p.Keys {
+		proposal.Keys = append(proposal.Keys, p.Keys[i].Uint64())
+		proposal.Values = append(proposal.Values, p.Values[i].String())
+	}
+	return proposal
+}
+
+// Proposals returns governance proposals, only the ones which are not executed yet if pending is true
+func (s *PublicKaiAPI) Proposals(ctx context.Context, pending bool) ([]*Proposal, error) {
+	proposals, err := s.kaiService.GetProposals(pending)
 	if err != nil {
 		return nil, err
 	}
-	for _, val := range valList {
-		validator, err := s.Validator(ctx, val.ValAddr, isGetDelegators)
-		if err != nil {
-			return nil, err
-		}
-		validators = append(validators, validator)
+	results := make([]*Proposal, 0, len(proposals))
+	for _, p := range proposals {
+		results = append(results, newProposal(p))
 	}
-	return validators, nil
+	return results, nil
+}
+
+// Proposal returns a governance proposal with its current results
+func (s *PublicKaiAPI) Proposal(ctx context.Context, id uint64) (*Proposal, error) {
+	proposal, err := s.kaiService.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	return newProposal(proposal), nil
+}
+
+// ProposalVote returns vote of an address in a governance proposal and its voting power
+func (s *PublicKaiAPI) ProposalVote(ctx context.Context, id uint64, voter common.Address) (*ProposalVote, error) {
+	voted, option, stake, err := s.kaiService.GetProposalVote(id, voter)
+	if err != nil {
+		return nil, err
+	}
+	vote := &ProposalVote{
+		ProposalID:  id,
+		Voter:       voter.Hex(),
+		Voted:       voted,
+		VotingPower: stake.String(),
+	}
+	if voted {
+		vote.Option = voteOptions[option]
+	}
+	return vote, nil
 }
 
 type PublicTransaction struct {
@@ -436,6 +740,73 @@ func (a *PublicTransactionAPI) SendRawTransaction(ctx context.Context, txs strin
 	return tx.Hash().Hex(), a.s.TxPool().AddLocal(tx)
 }
 
+// SendPrivateTransaction decodes a raw tx and adds it to the pool as a private
+// tx, which is never gossiped but forwarded to the current and next proposers
+// only. The tx is dropped if it's not included by maxBlockHeight, a zero
+// maxBlockHeight gives it DefaultPrivateTxBlocks blocks.
+func (a *PublicTransactionAPI) SendPrivateTransaction(ctx context.Context, txs string, maxBlockHeight uint64) (string, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(common.FromHex(txs), tx); err != nil {
+		return common.Hash{}.Hex(), err
+	}
+	// Drop tx exceeds gas requirements (DDoS protection)
+	if err := checkGas(tx.GasPrice(), tx.Gas()); err != nil {
+		return common.Hash{}.Hex(), err
+	}
+	if maxBlockHeight == 0 {
+		maxBlockHeight = a.s.blockchain.CurrentBlock().Height() + tx_pool.DefaultPrivateTxBlocks
+	}
+	if err := a.s.TxPool().AddPrivate(tx, maxBlockHeight); err != nil {
+		return common.Hash{}.Hex(), err
+	}
+	if a.s.txpoolR.SendPrivateTxs(types.Transactions{tx}, maxBlockHeight) == 0 {
+		log.Warn("Private transaction not forwarded to any proposer", "hash", tx.Hash())
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// SendBundleArgs represents the arguments of a bundle submission.
+type SendBundleArgs struct {
+	Txs               []string      `json:"txs"`
+	BlockHeight       uint64        `json:"blockHeight"`
+	RevertingTxHashes []common.Hash `json:"revertingTxHashes"`
+}
+
+// SendBundle decodes raw txs into a bundle the proposer of the given height
+// includes all-or-nothing and contiguously, provided none of them reverts
+// apart from the ones in revertingTxHashes. A zero blockHeight targets the
+// next block. Bundles are kept by this node only, which must be a proposer of
+// the target height, they are rejected by nodes which aren't validators. It
+// returns the bundle hash.
+func (a *PublicTransactionAPI) SendBundle(ctx context.Context, args SendBundleArgs) (string, error) {
+	if !a.s.txpoolR.IsValidator() {
+		return common.Hash{}.Hex(), tx_pool.ErrBundleNotValidator
+	}
+	bundle := &types.Bundle{
+		Txs:               make(types.Transactions, len(args.Txs)),
+		Height:            args.BlockHeight,
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+	for i, raw := range args.Txs {
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(common.FromHex(raw), tx); err != nil {
+			return common.Hash{}.Hex(), fmt.Errorf("tx %d: %v", i, err)
+		}
+		// Drop tx exceeds gas requirements (DDoS protection)
+		if err := checkGas(tx.GasPrice(), tx.Gas()); err != nil {
+			return common.Hash{}.Hex(), fmt.Errorf("tx %d: %v", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	if bundle.Height == 0 {
+		bundle.Height = a.s.blockchain.CurrentBlock().Height() + 1
+	}
+	if err := a.s.bundlePool.Add(bundle); err != nil {
+		return common.Hash{}.Hex(), err
+	}
+	return bundle.Hash().Hex(), nil
+}
+
 // revertError is an API error that encompassas an KVM revertal with JSON error
 // code and a binary data blob.
 type revertError struct {
@@ -457,9 +828,10 @@ func newRevertError(result *kvm.ExecutionResult) *revertError {
 
 // KardiaCall execute a contract method call only against
 // state on the local node. No tx is generated and submitted
-// onto the blockchain
-func (s *PublicKaiAPI) KardiaCall(ctx context.Context, args types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash) (common.Bytes, error) {
-	result, err := s.doCall(ctx, args, blockNrOrHash, kvm.Config{}, configs.DefaultTimeOutForStaticCall*time.Second)
+// onto the blockchain. The optional overrides are applied to
+// a scratch copy of the state and the block context before the call.
+func (s *PublicKaiAPI) KardiaCall(ctx context.Context, args types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides) (common.Bytes, error) {
+	result, err := s.doCall(ctx, args, blockNrOrHash, overrides, blockOverrides, kvm.Config{}, configs.DefaultTimeOutForStaticCall*time.Second)
 	if err != nil {
 		return nil, err
 	}
@@ -470,9 +842,10 @@ func (s *PublicKaiAPI) KardiaCall(ctx context.Context, args types.CallArgsJSON,
 	return result.Return(), result.Err
 }
 
-// PendingTransactions returns pending transactions
+// PendingTransactions returns pending transactions, private ones left out
 func (a *PublicTransactionAPI) PendingTransactions() ([]*PublicTransaction, error) {
-	pendingTxs := a.s.TxPool().GetPendingData()
+	pool := a.s.TxPool()
+	pendingTxs := withoutPrivate(pool, pool.GetPendingData())
 	transactions := make([]*PublicTransaction, 0, len(pendingTxs))
 
 	for _, tx := range pendingTxs {
@@ -501,9 +874,14 @@ func (a *PublicTransactionAPI) GetTransaction(hash string) (*PublicTransaction,
 
 // getReceiptLogs gets logs from receipt
 func getReceiptLogs(receipt types.Receipt) []Log {
-	if receipt.Logs != nil {
-		logs := make([]Log, 0, len(receipt.Logs))
-		for _, l := range receipt.Logs {
+	return getLogs(receipt.Logs)
+}
+
+// getLogs converts logs to their public representation
+func getLogs(receiptLogs []*types.Log) []Log {
+	if receiptLogs != nil {
+		logs := make([]Log, 0, len(receiptLogs))
+		for _, l := range receiptLogs {
 			topics := make([]string, 0, len(l.Topics))
 			for _, topic := range l.Topics {
 				topics = append(topics, topic.Hex())
@@ -637,13 +1015,16 @@ func (a *PublicAccountAPI) GetStorageAt(ctx context.Context, address common.Addr
 
 // doCall is an interface to make smart contract call against the state of local node
 // No tx is generated or submitted to the blockchain
-func (s *PublicKaiAPI) doCall(ctx context.Context, args types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash, vmCfg kvm.Config, timeout time.Duration) (*kvm.ExecutionResult, error) {
+func (s *PublicKaiAPI) doCall(ctx context.Context, args types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides, vmCfg kvm.Config, timeout time.Duration) (*kvm.ExecutionResult, error) {
 	defer func(start time.Time) { log.Debug("Executing KVM call finished", "runtime", time.Since(start)) }(time.Now())
 
 	state, header, err := s.kaiService.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return nil, err
 	}
+	if err := overrides.Apply(state); err != nil {
+		return nil, err
+	}
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -657,14 +1038,18 @@ func (s *PublicKaiAPI) doCall(ctx context.Context, args types.CallArgsJSON, bloc
 	// this makes sure resources are cleaned up.
 	defer cancel()
 
-	// Create new call message
-	msg := args.ToMessage()
+	return s.applyCall(ctx, args.ToMessage(), state, header, blockOverrides, timeout)
+}
 
+// applyCall executes msg on top of state until ctx is done. The state is left
+// modified by the call, so it can be chained with the next one.
+func (s *PublicKaiAPI) applyCall(ctx context.Context, msg types.Message, state *state.StateDB, header *types.Header, blockOverrides *BlockOverrides, timeout time.Duration) (*kvm.ExecutionResult, error) {
 	// Get a new instance of the KVM.
 	kvm, vmError, err := s.kaiService.GetKVM(ctx, msg, state, header)
 	if err != nil {
 		return nil, err
 	}
+	blockOverrides.Apply(&kvm.Context)
 
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -694,7 +1079,7 @@ func (s *PublicKaiAPI) doCall(ctx context.Context, args types.CallArgsJSON, bloc
 
 // EstimateGas returns an estimate of the amount of gas needed to execute the
 // given transaction against the current pending block.
-func (s *PublicKaiAPI) EstimateGas(ctx context.Context, args types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash) (uint64, error) {
+func (s *PublicKaiAPI) EstimateGas(ctx context.Context, args types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides) (uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  = configs.TxGas - 1
@@ -708,6 +1093,8 @@ func (s *PublicKaiAPI) EstimateGas(ctx context.Context, args types.CallArgsJSON,
 
 	if args.Gas >= configs.TxGas {
 		hi = args.Gas
+	} else if blockOverrides != nil && blockOverrides.GasLimit != nil {
+		hi = *blockOverrides.GasLimit
 	} else {
 		// Retrieve the block to act as the gas ceiling
 		block, err := s.kaiService.BlockByNumberOrHash(ctx, blockNrOrHash)
@@ -725,7 +1112,7 @@ func (s *PublicKaiAPI) EstimateGas(ctx context.Context, args types.CallArgsJSON,
 	executable := func(gas uint64) (bool, *kvm.ExecutionResult, error) {
 		args.Gas = gas
 
-		result, err := s.doCall(ctx, args, blockNrOrHash, kvm.Config{}, 0)
+		result, err := s.doCall(ctx, args, blockNrOrHash, overrides, blockOverrides, kvm.Config{}, 0)
 		if err != nil {
 			if errors.Is(err, tx_pool.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -778,6 +1165,51 @@ func (s *PublicKaiAPI) EstimateGas(ctx context.Context, args types.CallArgsJSON,
 	return hi, nil
 }
 
+// GasPrice returns a suggestion of gas price for a new transaction to be
+// included in a timely manner, based on the prices paid in the recent blocks.
+func (s *PublicKaiAPI) GasPrice(ctx context.Context) (uint64, error) {
+	price, err := s.kaiService.gpo.SuggestPrice(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return price.Uint64(), nil
+}
+
+// FeeHistory is the fee market history of a range of blocks.
+type FeeHistory struct {
+	OldestBlock  uint64     `json:"oldestBlock"`
+	Reward       [][]uint64 `json:"reward,omitempty"`
+	GasUsedRatio []float64  `json:"gasUsedRatio"`
+}
+
+// FeeHistory returns the fee market history of the blockCount blocks ending at
+// lastBlock: the ratio of gas used to gas limit of each block and the gas
+// prices at the given percentiles of the gas used by its transactions.
+func (s *PublicKaiAPI) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistory, error) {
+	last := lastBlock.Uint64()
+	if lastBlock == rpc.LatestBlockNumber || lastBlock == rpc.PendingBlockNumber {
+		last = s.kaiService.blockchain.CurrentBlock().Height()
+	}
+	oldest, reward, gasUsedRatio, err := s.kaiService.gpo.FeeHistory(ctx, blockCount, last, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	history := &FeeHistory{
+		OldestBlock:  oldest,
+		GasUsedRatio: gasUsedRatio,
+	}
+	if reward != nil {
+		history.Reward = make([][]uint64, len(reward))
+		for i, prices := range reward {
+			history.Reward[i] = make([]uint64, len(prices))
+			for j, price := range prices {
+				history.Reward[i][j] = price.Uint64()
+			}
+		}
+	}
+	return history, nil
+}
+
 // checkGas is a function used to check whether the fee of
 // a transaction meets the requirements.
 func checkGas(gasPrice *big.Int, gas uint64) error {
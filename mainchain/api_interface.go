@@ -35,6 +35,13 @@ import (
 	"github.com/kardiachain/go-kardia/types"
 )
 
+const (
+	maxStakingEventsRange = 10000 // max number of blocks to query staking events
+	maxRewardHistoryRange = 1000  // max number of blocks to query delegator rewards
+)
+
+var rateDecimals = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
 type APIBackend interface {
 	// Blockchain API
 	BlockByNumber(ctx context.Context, number rpc.BlockNumber) *types.Block
@@ -43,10 +50,14 @@ type APIBackend interface {
 	BlockInfoByBlockHash(ctx context.Context, hash common.Hash) *types.BlockInfo
 
 	GetKVM(ctx context.Context, msg types.Message, state *state.StateDB, header *types.Header) (*kvm.KVM, func() error, error)
-	GetValidators() ([]*staking.Validator, error)
-	GetValidator(valAddr common.Address) (*staking.Validator, error)
+	StakingQuery(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*staking.StakingQuery, error)
+	GetValidators(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, withDelegators bool, page *staking.Pagination) ([]*staking.Validator, error)
+	GetValidator(ctx context.Context, valAddr common.Address, blockNrOrHash rpc.BlockNumberOrHash, withDelegators bool, page *staking.Pagination) (*staking.Validator, error)
 	GetValidatorCommission(valAddr common.Address) (uint64, error)
-	GetDelegationsByValidator(valAddr common.Address) ([]*staking.Delegator, error)
+	GetDelegationsByValidator(ctx context.Context, valContractAddr common.Address, blockNrOrHash rpc.BlockNumberOrHash, page *staking.Pagination) ([]*staking.Delegator, error)
+	GetDelegatorInfo(ctx context.Context, delAddr common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]*staking.Delegation, error)
+	GetStakingEvents(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, valSmcAddr *common.Address) ([]*types.StakingEvent, error)
+	GetDelegatorRewards(ctx context.Context, delAddr common.Address, fromBlock, toBlock rpc.BlockNumber) ([]*staking.DelegatorReward, error)
 
 	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) *types.Header
 	HeaderByHash(ctx context.Context, hash common.Hash) *types.Header
@@ -170,79 +181,237 @@ func (k *KardiaService) GetKVM(ctx context.Context, msg types.Message, state *st
 	return kvm.NewKVM(context, state, *k.blockchain.GetVMConfig()), vmError, nil
 }
 
-// ValidatorsListFromStakingContract returns all validators on staking
-// contract at the moment
-func (k *KardiaService) GetValidators() ([]*staking.Validator, error) {
-	block := k.blockchain.CurrentBlock()
-	st, header, kvmConfig, err := k.getValidatorInfoParams(block)
+// StakingQuery returns a query over staking contracts at the state of the given block
+func (k *KardiaService) StakingQuery(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*staking.StakingQuery, error) {
+	st, header, err := k.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil || header == nil {
+		return nil, ErrHeaderNotFound
+	}
+	return staking.NewStakingQuery(k.staking, k.validator, st, header, k.blockchain, kvm.Config{}), nil
+}
+
+// GetValidators returns all validators on staking contract at the given block
+func (k *KardiaService) GetValidators(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, withDelegators bool, page *staking.Pagination) ([]*staking.Validator, error) {
+	query, err := k.StakingQuery(ctx, blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
-	allValsLen, err := k.staking.GetAllValsLength(st, header, k.blockchain, kvmConfig)
+	return query.Validators(withDelegators, page)
+}
+
+// GetValidator returns info of one validator on staking contract at the
+// given block based on his address
+func (k *KardiaService) GetValidator(ctx context.Context, valAddr common.Address, blockNrOrHash rpc.BlockNumberOrHash, withDelegators bool, page *staking.Pagination) (*staking.Validator, error) {
+	query, err := k.StakingQuery(ctx, blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
-	var (
-		one      = big.NewInt(1)
-		valsInfo []*staking.Validator
-	)
-	zero := new(big.Int).SetInt64(0)
-	for i := new(big.Int).SetInt64(0); i.Cmp(allValsLen) < 0; i.Add(i, one) {
-		valContractAddr, err := k.staking.GetValSmcAddr(st, header, k.blockchain, kvmConfig, i)
+	return query.Validator(valAddr, withDelegators, page)
+}
+
+// GetDelegationsByValidator returns delegations info of one validator on staking contract based on their contract addresses
+func (k *KardiaService) GetDelegationsByValidator(ctx context.Context, valContractAddr common.Address, blockNrOrHash rpc.BlockNumberOrHash, page *staking.Pagination) ([]*staking.Delegator, error) {
+	query, err := k.StakingQuery(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return query.Delegators(valContractAddr, page)
+}
+
+// GetDelegatorInfo returns all delegations of a delegator at the given block
+func (k *KardiaService) GetDelegatorInfo(ctx context.Context, delAddr common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]*staking.Delegation, error) {
+	query, err := k.StakingQuery(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return query.DelegatorInfo(delAddr)
+}
+
+// blockRange resolves a range of block numbers to heights, the range must not contain more than maxRange blocks
+func (k *KardiaService) blockRange(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, maxRange uint64) (uint64, uint64, error) {
+	from, to := k.HeaderByNumber(ctx, fromBlock), k.HeaderByNumber(ctx, toBlock)
+	if from == nil || to == nil {
+		return 0, 0, ErrHeaderNotFound
+	}
+	if from.Height > to.Height {
+		return 0, 0, ErrInvalidBlockRange
+	}
+	if to.Height-from.Height >= maxRange {
+		return 0, 0, ErrBlockRangeTooBig
+	}
+	return from.Height, to.Height, nil
+}
+
+// GetStakingEvents returns staking events of blocks in range [fromBlock, toBlock],
+// only the ones of valSmcAddr if it is specified
+func (k *KardiaService) GetStakingEvents(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, valSmcAddr *common.Address) ([]*types.StakingEvent, error) {
+	from, to, err := k.blockRange(ctx, fromBlock, toBlock, maxStakingEventsRange)
+	if err != nil {
+		return nil, err
+	}
+	var events []*types.StakingEvent
+	for height := from; height <= to; height++ {
+		for _, ev := range k.blockchain.DB().ReadStakingEvents(height) {
+			if valSmcAddr == nil || ev.Validator == *valSmcAddr {
+				events = append(events, ev)
+			}
+		}
+	}
+	return events, nil
+}
+
+// GetDelegatorRewards returns the rewards earned by a delegator in range [fromBlock, toBlock].
+// The reward of each allocation is estimated from the validator commission rate and the
+// delegator stake at the state of the allocation block.
+func (k *KardiaService) GetDelegatorRewards(ctx context.Context, delAddr common.Address, fromBlock, toBlock rpc.BlockNumber) ([]*staking.DelegatorReward, error) {
+	from, to, err := k.blockRange(ctx, fromBlock, toBlock, maxRewardHistoryRange)
+	if err != nil {
+		return nil, err
+	}
+	// validators this delegator staked to at any end of the range
+	vals := make(map[common.Address]bool)
+	for _, height := range []uint64{from, to} {
+		query, err := k.StakingQuery(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(height)))
 		if err != nil {
 			return nil, err
 		}
-		valInfo, err := k.validator.GetInforValidator(st, header, k.blockchain, kvmConfig, valContractAddr)
+		valSmcAddrs, err := query.ValidatorsByDelegator(delAddr)
 		if err != nil {
 			return nil, err
 		}
-		if valInfo.Tokens.Cmp(zero) == 1 {
-			valInfo.Delegators, err = k.GetDelegationsByValidator(valContractAddr)
+		for _, valSmcAddr := range valSmcAddrs {
+			vals[valSmcAddr] = true
+		}
+	}
+
+	var rewards []*staking.DelegatorReward
+	for height := from; height <= to; height++ {
+		var (
+			st     *state.StateDB
+			header *types.Header
+		)
+		for _, ev := range k.blockchain.DB().ReadStakingEvents(height) {
+			if (ev.Type != types.StakingEventReward && ev.Type != types.StakingEventProposerReward) || !vals[ev.Validator] {
+				continue
+			}
+			if st == nil {
+				if header = k.blockchain.GetHeaderByHeight(height); header == nil {
+					return nil, ErrHeaderNotFound
+				}
+				if st, err = k.blockchain.StateAt(height); err != nil {
+					return nil, err
+				}
+			}
+			reward, err := k.delegatorShare(st, header, ev.Validator, delAddr, ev.Amount)
 			if err != nil {
 				return nil, err
 			}
+			if reward.Sign() == 0 {
+				continue
+			}
+			rewards = append(rewards, &staking.DelegatorReward{
+				Height:        height,
+				Type:          ev.Type,
+				ValStakingSmc: ev.Validator,
+				Reward:        reward,
+			})
 		}
-		valInfo.ValStakingSmc = valContractAddr
-		valsInfo = append(valsInfo, valInfo)
 	}
-	return valsInfo, nil
+	return rewards, nil
 }
 
-// ValidatorsListFromStakingContract returns info of one validator on staking
-// contract based on his address
-func (k *KardiaService) GetValidator(valAddr common.Address) (*staking.Validator, error) {
-	block := k.blockchain.CurrentBlock()
-	st, header, kvmConfig, err := k.getValidatorInfoParams(block)
+// delegatorShare returns the part of an allocation to a validator which goes to delAddr,
+// after the validator commission
+func (k *KardiaService) delegatorShare(st *state.StateDB, header *types.Header, valSmcAddr common.Address, delAddr common.Address, amount *big.Int) (*big.Int, error) {
+	val, err := k.validator.GetInforValidator(st, header, k.blockchain, kvm.Config{}, valSmcAddr)
 	if err != nil {
 		return nil, err
 	}
-	valContractAddr, err := k.staking.GetValFromOwner(st, header, k.blockchain, kvmConfig, valAddr)
+	if val.Tokens.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	stake, err := k.validator.GetDelegatorStakedAmount(st, header, k.blockchain, kvm.Config{}, valSmcAddr, delAddr)
 	if err != nil {
 		return nil, err
 	}
-	val, err := k.validator.GetInforValidator(st, header, k.blockchain, kvmConfig, valContractAddr)
+	// commission rate is a fixed point number with 18 decimals, truncated like in validator contract
+	commission := new(big.Int).Div(new(big.Int).Mul(amount, val.CommissionRate), rateDecimals)
+	shared := new(big.Int).Sub(amount, commission)
+	return shared.Div(shared.Mul(shared, stake), val.Tokens), nil
+}
+
+// governance returns governance contract util and params to call it at head block
+func (k *KardiaService) governance() (*staking.GovernanceSmcUtil, *state.StateDB, *types.Header, kvm.Config, error) {
+	gov := k.staking.Governance
+	if gov == nil {
+		return nil, nil, nil, kvm.Config{}, staking.ErrGovernanceNotDeployed
+	}
+	st, header, kvmConfig, err := k.getValidatorInfoParams(k.blockchain.CurrentBlock())
+	if err != nil {
+		return nil, nil, nil, kvmConfig, err
+	}
+	return gov, st, header, kvmConfig, nil
+}
+
+// GetProposals returns all governance proposals, or only the ones which are not executed yet if pending is true
+func (k *KardiaService) GetProposals(pending bool) ([]*staking.Proposal, error) {
+	gov, st, header, kvmConfig, err := k.governance()
 	if err != nil {
 		return nil, err
 	}
-	zero := new(big.Int).SetInt64(0)
-	if val.Tokens.Cmp(zero) == 1 {
-		val.Delegators, err = k.GetDelegationsByValidator(valContractAddr)
+	var ids []*big.Int
+	if pending {
+		if ids, err = gov.GetPendingProposals(st, header, k.blockchain, kvmConfig); err != nil {
+			return nil, err
+		}
+	} else {
+		length, err := gov.GetAllProposalsLength(st, header, k.blockchain, kvmConfig)
+		if err != nil {
+			return nil, err
+		}
+		for i := int64(0); i < length.Int64(); i++ {
+			ids = append(ids, big.NewInt(i))
+		}
+	}
+	proposals := make([]*staking.Proposal, 0, len(ids))
+	for _, id := range ids {
+		proposal, err := gov.GetProposal(st, header, k.blockchain, kvmConfig, id)
 		if err != nil {
 			return nil, err
 		}
+		proposals = append(proposals, proposal)
 	}
-	val.ValStakingSmc = valContractAddr
-	return val, nil
+	return proposals, nil
 }
 
-// GetDelegationsByValidator returns delegations info of one validator on staking contract based on their contract addresses
-func (k *KardiaService) GetDelegationsByValidator(valContractAddr common.Address) ([]*staking.Delegator, error) {
-	block := k.blockchain.CurrentBlock()
-	st, header, kvmConfig, err := k.getValidatorInfoParams(block)
+// GetProposal returns a governance proposal by its id
+func (k *KardiaService) GetProposal(id uint64) (*staking.Proposal, error) {
+	gov, st, header, kvmConfig, err := k.governance()
 	if err != nil {
 		return nil, err
 	}
-	return k.validator.GetDelegators(st, header, k.blockchain, kvmConfig, valContractAddr)
+	return gov.GetProposal(st, header, k.blockchain, kvmConfig, new(big.Int).SetUint64(id))
+}
+
+// GetProposalVote returns vote of an address in a governance proposal and its voting power, which is
+// the weight of the vote if the address voted and its current stake otherwise
+func (k *KardiaService) GetProposalVote(id uint64, voter common.Address) (bool, uint8, *big.Int, error) {
+	gov, st, header, kvmConfig, err := k.governance()
+	if err != nil {
+		return false, 0, nil, err
+	}
+	voted, option, weight, err := gov.GetVote(st, header, k.blockchain, kvmConfig, new(big.Int).SetUint64(id), voter)
+	if err != nil || voted {
+		return voted, option, weight, err
+	}
+	stake, err := gov.GetStake(st, header, k.blockchain, kvmConfig, voter)
+	if err != nil {
+		return false, 0, nil, err
+	}
+	return false, option, stake, nil
 }
 
 // getValidatorInfoParams returns params for getting validators info on
@@ -0,0 +1,191 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// maxSimulateCalls is the maximum number of calls a single kai_simulate
+// request may bundle.
+const maxSimulateCalls = 256
+
+// OverrideAccount indicates the overriding fields of account during the execution
+// of a message call.
+// Note, state and stateDiff can't be specified at the same time. If state is
+// set, message execution will only use the data in the given state. Otherwise
+// if stateDiff is set, all diff will be applied first and then execute the call
+// message.
+type OverrideAccount struct {
+	Nonce     *uint64                      `json:"nonce"`
+	Code      *common.Bytes                `json:"code"`
+	Balance   *big.Int                     `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// Apply overrides the fields of specified accounts into the given state.
+func (diff *StateOverride) Apply(state *state.StateDB) error {
+	if diff == nil {
+		return nil
+	}
+	for addr, account := range *diff {
+		// Override account nonce.
+		if account.Nonce != nil {
+			state.SetNonce(addr, *account.Nonce)
+		}
+		// Override account(contract) code.
+		if account.Code != nil {
+			state.SetCode(addr, *account.Code)
+		}
+		// Override account balance.
+		if account.Balance != nil {
+			state.SetBalance(addr, account.Balance)
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		// Replace entire state if caller requires.
+		if account.State != nil {
+			state.SetStorage(addr, *account.State)
+		}
+		// Apply state diff into specified accounts.
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				state.SetState(addr, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// BlockOverrides is a set of header fields to override in the block context
+// of a message call.
+type BlockOverrides struct {
+	Height   *uint64 `json:"height"`
+	Time     *uint64 `json:"time"`
+	GasLimit *uint64 `json:"gasLimit"`
+}
+
+// Apply overrides the given block context.
+func (diff *BlockOverrides) Apply(blockCtx *kvm.Context) {
+	if diff == nil {
+		return
+	}
+	if diff.Height != nil {
+		blockCtx.BlockHeight = new(big.Int).SetUint64(*diff.Height)
+	}
+	if diff.Time != nil {
+		blockCtx.Time = new(big.Int).SetUint64(*diff.Time)
+	}
+	if diff.GasLimit != nil {
+		blockCtx.GasLimit = *diff.GasLimit
+	}
+}
+
+// SimulateCallResult is the outcome of one call of a simulated bundle.
+type SimulateCallResult struct {
+	ReturnData common.Bytes `json:"returnData"`
+	Logs       []Log        `json:"logs"`
+	GasUsed    uint64       `json:"gasUsed"`
+	Status     uint         `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	// Revert is the hex encoded revert data, if the call reverted
+	Revert string `json:"revert,omitempty"`
+}
+
+// Simulate executes the calls in order on the same scratch copy of the state
+// at blockNrOrHash, so each call sees the changes of the previous ones. No tx
+// is generated or submitted to the blockchain.
+// The logs of the i-th call carry the index i as their transaction hash.
+func (s *PublicKaiAPI) Simulate(ctx context.Context, calls []types.CallArgsJSON, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides) ([]*SimulateCallResult, error) {
+	defer func(start time.Time) { log.Debug("Executing KVM simulation finished", "runtime", time.Since(start)) }(time.Now())
+
+	if len(calls) == 0 {
+		return nil, errors.New("empty call bundle")
+	}
+	if len(calls) > maxSimulateCalls {
+		return nil, fmt.Errorf("too many calls in bundle: %d > %d", len(calls), maxSimulateCalls)
+	}
+	state, header, err := s.kaiService.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	if err := overrides.Apply(state); err != nil {
+		return nil, err
+	}
+	height := header.Height
+	if blockOverrides != nil && blockOverrides.Height != nil {
+		height = *blockOverrides.Height
+	}
+
+	// The whole bundle shares a single timeout.
+	timeout := configs.DefaultTimeOutForStaticCall * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := make([]*SimulateCallResult, 0, len(calls))
+	for i, args := range calls {
+		txHash := common.BigToHash(big.NewInt(int64(i)))
+		state.Prepare(txHash, header.Hash(), i)
+
+		result, err := s.applyCall(ctx, args.ToMessage(), state, header, blockOverrides, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		// Finalise the call, so the next one only reverts to its own snapshots.
+		state.Finalise(true)
+
+		logs := state.GetLogs(txHash)
+		for _, l := range logs {
+			l.BlockHeight = height
+		}
+		callResult := &SimulateCallResult{
+			ReturnData: result.Return(),
+			Logs:       getLogs(logs),
+			GasUsed:    result.UsedGas,
+			Status:     uint(types.ReceiptStatusSuccessful),
+		}
+		if result.Failed() {
+			callResult.Status = uint(types.ReceiptStatusFailed)
+			callResult.Error = result.Err.Error()
+			if len(result.Revert()) > 0 {
+				callResult.Error = newRevertError(result).Error()
+				callResult.Revert = common.Encode(result.Revert())
+			}
+		}
+		results = append(results, callResult)
+	}
+	return results, nil
+}
@@ -17,3 +17,74 @@
  */
 
 package kai
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+)
+
+func TestStateOverrideApply(t *testing.T) {
+	sdb, err := state.New(log.New(), common.Hash{}, state.NewDatabase(memorydb.New()))
+	require.NoError(t, err)
+	var (
+		addr  = common.HexToAddress("0x1234")
+		other = common.HexToAddress("0x5678")
+		slot1 = common.BytesToHash([]byte{1})
+		slot2 = common.BytesToHash([]byte{2})
+	)
+	sdb.SetState(addr, slot1, common.BytesToHash([]byte{1}))
+	sdb.SetState(other, slot1, common.BytesToHash([]byte{1}))
+
+	var overrides StateOverride
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"0x0000000000000000000000000000000000001234": {
+			"nonce": 7,
+			"balance": 1000,
+			"code": "0x6001",
+			"state": {"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000002"}
+		},
+		"0x0000000000000000000000000000000000005678": {
+			"stateDiff": {"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000002"}
+		}
+	}`), &overrides))
+	require.NoError(t, overrides.Apply(sdb))
+
+	assert.Equal(t, uint64(7), sdb.GetNonce(addr))
+	assert.Equal(t, big.NewInt(1000), sdb.GetBalance(addr))
+	assert.Equal(t, []byte{0x60, 0x01}, sdb.GetCode(addr))
+	// a full state replaces the storage, a diff is applied on top of it
+	assert.Equal(t, common.Hash{}, sdb.GetState(addr, slot1))
+	assert.Equal(t, slot2, sdb.GetState(addr, slot2))
+	assert.Equal(t, common.BytesToHash([]byte{1}), sdb.GetState(other, slot1))
+	assert.Equal(t, slot2, sdb.GetState(other, slot2))
+
+	storage := map[common.Hash]common.Hash{}
+	invalid := StateOverride{addr: OverrideAccount{State: &storage, StateDiff: &storage}}
+	assert.Error(t, invalid.Apply(sdb))
+
+	var none *StateOverride
+	assert.NoError(t, none.Apply(sdb))
+}
+
+func TestBlockOverridesApply(t *testing.T) {
+	blockCtx := kvm.Context{BlockHeight: big.NewInt(10), Time: big.NewInt(100), GasLimit: 1000}
+	var none *BlockOverrides
+	none.Apply(&blockCtx)
+	assert.Equal(t, big.NewInt(10), blockCtx.BlockHeight)
+
+	height, gasLimit := uint64(20), uint64(2000)
+	(&BlockOverrides{Height: &height, GasLimit: &gasLimit}).Apply(&blockCtx)
+	assert.Equal(t, big.NewInt(20), blockCtx.BlockHeight)
+	assert.Equal(t, big.NewInt(100), blockCtx.Time)
+	assert.Equal(t, uint64(2000), blockCtx.GasLimit)
+}
@@ -0,0 +1,216 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// PublicTxPoolAPI offers an API to inspect the transaction pool.
+type PublicTxPoolAPI struct {
+	s *KardiaService
+}
+
+// NewPublicTxPoolAPI creates a new tx pool service that gives information about the transaction pool.
+func NewPublicTxPoolAPI(s *KardiaService) *PublicTxPoolAPI {
+	return &PublicTxPoolAPI{s}
+}
+
+// Status returns the number of pending and queued transactions in the pool.
+func (api *PublicTxPoolAPI) Status() map[string]uint64 {
+	pending, queue := api.s.TxPool().Stats()
+	return map[string]uint64{
+		"pending": uint64(pending),
+		"queued":  uint64(queue),
+	}
+}
+
+// Content returns the transactions contained within the transaction pool,
+// grouped by sender and nonce. Private transactions are left out.
+func (api *PublicTxPoolAPI) Content() map[string]map[string]map[string]*PublicTransaction {
+	content := map[string]map[string]map[string]*PublicTransaction{
+		"pending": make(map[string]map[string]*PublicTransaction),
+		"queued":  make(map[string]map[string]*PublicTransaction),
+	}
+	pool := api.s.TxPool()
+	pending, queue := pool.Content()
+	for account, txs := range pending {
+		if txs = withoutPrivate(pool, txs); len(txs) > 0 {
+			content["pending"][account.Hex()] = publicTransactionsByNonce(txs)
+		}
+	}
+	for account, txs := range queue {
+		if txs = withoutPrivate(pool, txs); len(txs) > 0 {
+			content["queued"][account.Hex()] = publicTransactionsByNonce(txs)
+		}
+	}
+	return content
+}
+
+// ContentFrom returns the transactions of the given address contained within
+// the transaction pool, grouped by nonce. Private transactions are left out.
+func (api *PublicTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*PublicTransaction {
+	pool := api.s.TxPool()
+	pending, queue := pool.ContentFrom(addr)
+	return map[string]map[string]*PublicTransaction{
+		"pending": publicTransactionsByNonce(withoutPrivate(pool, pending)),
+		"queued":  publicTransactionsByNonce(withoutPrivate(pool, queue)),
+	}
+}
+
+// Inspect retrieves a summary of the transactions contained within the pool,
+// grouped by sender and nonce, for quick inspection. Private transactions are
+// left out.
+func (api *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+	content := map[string]map[string]map[string]string{
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
+	}
+	pool := api.s.TxPool()
+	pending, queue := pool.Content()
+
+	// Define a formatter to flatten a transaction into a string
+	var format = func(tx *types.Transaction) string {
+		if to := tx.To(); to != nil {
+			return fmt.Sprintf("%s: %v hydro + %v gas × %v hydro", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+		return fmt.Sprintf("contract creation: %v hydro + %v gas × %v hydro", tx.Value(), tx.Gas(), tx.GasPrice())
+	}
+	for account, txs := range pending {
+		if txs = withoutPrivate(pool, txs); len(txs) > 0 {
+			content["pending"][account.Hex()] = inspectByNonce(txs, format)
+		}
+	}
+	for account, txs := range queue {
+		if txs = withoutPrivate(pool, txs); len(txs) > 0 {
+			content["queued"][account.Hex()] = inspectByNonce(txs, format)
+		}
+	}
+	return content
+}
+
+// TransactionStatus describes where a transaction is and, while it is in the
+// pool, what it waits for.
+type TransactionStatus struct {
+	Status string `json:"status"` // unknown, queued, pending or included
+	// Set while the transaction is in the pool. A queued transaction waits for
+	// the transactions of its sender up to Nonce-1, NextNonce is the nonce the
+	// pool expects next from the sender.
+	From      string `json:"from,omitempty"`
+	Nonce     uint64 `json:"nonce,omitempty"`
+	NextNonce uint64 `json:"nextNonce,omitempty"`
+	GasPrice  uint64 `json:"gasPrice,omitempty"`
+	// Set once the transaction is included.
+	BlockHash   string `json:"blockHash,omitempty"`
+	BlockHeight uint64 `json:"blockHeight,omitempty"`
+}
+
+// TxStatus returns the status of a transaction: queued or pending in the pool,
+// included in a block or unknown. Private transactions are unknown until they
+// are included.
+func (api *PublicTxPoolAPI) TxStatus(hash common.Hash) *TransactionStatus {
+	pool := api.s.TxPool()
+	if pool.IsPrivate(hash) {
+		return &TransactionStatus{Status: "unknown"}
+	}
+	if status := pool.Status([]common.Hash{hash})[0]; status != tx_pool.TxStatusUnknown {
+		// the transaction may have been included since
+		if tx := pool.Get(hash); tx != nil {
+			from, _ := types.Sender(types.HomesteadSigner{}, tx)
+			result := &TransactionStatus{
+				Status:    "queued",
+				From:      from.Hex(),
+				Nonce:     tx.Nonce(),
+				NextNonce: pool.Nonce(from),
+				GasPrice:  tx.GasPrice().Uint64(),
+			}
+			if status == tx_pool.TxStatusPending {
+				result.Status = "pending"
+			}
+			return result
+		}
+	}
+	if tx, blockHash, height, _ := api.s.DB().ReadTransaction(hash); tx != nil {
+		return &TransactionStatus{
+			Status:      "included",
+			BlockHash:   blockHash.Hex(),
+			BlockHeight: height,
+		}
+	}
+	return &TransactionStatus{Status: "unknown"}
+}
+
+// withoutPrivate returns txs without the private transactions of pool.
+func withoutPrivate(pool *tx_pool.TxPool, txs types.Transactions) types.Transactions {
+	public := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if !pool.IsPrivate(tx.Hash()) {
+			public = append(public, tx)
+		}
+	}
+	return public
+}
+
+// inspectByNonce groups the summaries of txs by their nonce.
+func inspectByNonce(txs types.Transactions, format func(*types.Transaction) string) map[string]string {
+	dump := make(map[string]string, len(txs))
+	for _, tx := range txs {
+		dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	}
+	return dump
+}
+
+// publicTransactionsByNonce groups txs by their nonce.
+func publicTransactionsByNonce(txs types.Transactions) map[string]*PublicTransaction {
+	dump := make(map[string]*PublicTransaction, len(txs))
+	for _, tx := range txs {
+		dump[fmt.Sprintf("%d", tx.Nonce())] = NewPublicTransaction(tx, common.Hash{}, 0, 0)
+	}
+	return dump
+}
+
+// PrivateTxPoolAPI offers the administrative methods of the transaction pool,
+// it is registered in the admin namespace.
+type PrivateTxPoolAPI struct {
+	s *KardiaService
+}
+
+// NewPrivateTxPoolAPI creates a new tx pool administration service.
+func NewPrivateTxPoolAPI(s *KardiaService) *PrivateTxPoolAPI {
+	return &PrivateTxPoolAPI{s}
+}
+
+// SetGasPrice sets the minimum gas price accepted by the pool, the pooled
+// transactions priced below it are dropped.
+func (api *PrivateTxPoolAPI) SetGasPrice(gasPrice uint64) bool {
+	api.s.TxPool().SetGasPrice(new(big.Int).SetUint64(gasPrice))
+	return true
+}
+
+// DropTransaction removes a transaction from the pool, the following pending
+// transactions of its sender go back to the queue. It returns false if the
+// transaction isn't in the pool.
+func (api *PrivateTxPoolAPI) DropTransaction(hash common.Hash) bool {
+	return api.s.TxPool().RemoveTx(hash)
+}
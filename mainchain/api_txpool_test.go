@@ -0,0 +1,114 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/events"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// poolChain is the chain of a transaction pool holding a single state
+type poolChain struct {
+	statedb *state.StateDB
+	feed    event.Feed
+}
+
+func (bc *poolChain) CurrentBlock() *types.Block {
+	return types.NewBlock(&types.Header{GasLimit: 10000000}, nil, nil, nil)
+}
+
+func (bc *poolChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.CurrentBlock()
+}
+
+func (bc *poolChain) StateAt(height uint64) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *poolChain) SubscribeChainHeadEvent(ch chan<- events.ChainHeadEvent) event.Subscription {
+	return bc.feed.Subscribe(ch)
+}
+
+func TestTxPoolAPIHidesPrivateTransactions(t *testing.T) {
+	statedb, err := state.New(log.New(), common.Hash{}, state.NewDatabase(memorydb.New()))
+	require.NoError(t, err)
+	privKey, _ := crypto.GenerateKey()
+	pubKey, _ := crypto.GenerateKey()
+	privAddr, pubAddr := crypto.PubkeyToAddress(privKey.PublicKey), crypto.PubkeyToAddress(pubKey.PublicKey)
+	statedb.AddBalance(privAddr, big.NewInt(1e18))
+	statedb.AddBalance(pubAddr, big.NewInt(1e18))
+
+	config := tx_pool.DefaultTxPoolConfig
+	config.Journal = ""
+	pool := tx_pool.NewTxPool(config, configs.TestChainConfig, &poolChain{statedb: statedb})
+	defer pool.Stop()
+
+	sign := func(nonce uint64, key *ecdsa.PrivateKey) *types.Transaction {
+		tx, err := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(nonce, common.HexToAddress("0x1234"), big.NewInt(1), 100000, big.NewInt(1), nil), key)
+		require.NoError(t, err)
+		return tx
+	}
+	pending, queued, public := sign(0, privKey), sign(5, privKey), sign(0, pubKey)
+	require.NoError(t, pool.AddPrivate(pending, 2))
+	require.NoError(t, pool.AddPrivate(queued, 2))
+	require.NoError(t, pool.AddRemotesSync([]*types.Transaction{public})[0])
+	require.True(t, pool.Has(pending.Hash()) && pool.Has(queued.Hash()))
+
+	s := &KardiaService{txPool: pool}
+	api := NewPublicTxPoolAPI(s)
+	content := api.Content()
+	assert.Len(t, content["pending"], 1)
+	assert.Contains(t, content["pending"], pubAddr.Hex())
+	assert.Empty(t, content["queued"])
+
+	from := api.ContentFrom(privAddr)
+	assert.Empty(t, from["pending"])
+	assert.Empty(t, from["queued"])
+	assert.Len(t, api.ContentFrom(pubAddr)["pending"], 1)
+
+	inspect := api.Inspect()
+	assert.Len(t, inspect["pending"], 1)
+	assert.Contains(t, inspect["pending"], pubAddr.Hex())
+	assert.Empty(t, inspect["queued"])
+
+	assert.Equal(t, "unknown", api.TxStatus(pending.Hash()).Status)
+	assert.Equal(t, "unknown", api.TxStatus(queued.Hash()).Status)
+	assert.Equal(t, "pending", api.TxStatus(public.Hash()).Status)
+
+	txs, err := (&PublicTransactionAPI{s}).PendingTransactions()
+	require.NoError(t, err)
+	if assert.Len(t, txs, 1) {
+		assert.Equal(t, public.Hash().Hex(), txs[0].Hash)
+	}
+}
@@ -19,6 +19,8 @@
 package blockchain
 
 import (
+	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/kardiachain/go-kardia/mainchain/staking"
 	stypes "github.com/kardiachain/go-kardia/mainchain/staking/types"
 
+	"github.com/kardiachain/go-kardia/kai/state"
 	"github.com/kardiachain/go-kardia/kai/state/cstate"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
@@ -50,6 +53,7 @@ type BlockOperations struct {
 
 	blockchain *BlockChain
 	txPool     *tx_pool.TxPool
+	bundlePool *tx_pool.BundlePool
 	evPool     EvidencePool
 	base       uint64
 	height     uint64
@@ -57,17 +61,28 @@ type BlockOperations struct {
 }
 
 // NewBlockOperations returns a new BlockOperations with reference to the latest state of blockchain.
+// Its height is the one of the last saved block, which may be ahead of the head
+// block if the node stopped before committing it.
 func NewBlockOperations(logger log.Logger, blockchain *BlockChain, txPool *tx_pool.TxPool, evpool EvidencePool, staking *staking.StakingSmcUtil) *BlockOperations {
+	height := blockchain.CurrentBlock().Height()
+	for blockchain.LoadBlockMeta(height+1) != nil {
+		height++
+	}
 	return &BlockOperations{
 		logger:     logger,
 		blockchain: blockchain,
 		txPool:     txPool,
-		height:     blockchain.CurrentBlock().Height(),
+		height:     height,
 		evPool:     evpool,
 		staking:    staking,
 	}
 }
 
+// SetBundlePool sets the pool of the bundles included in the proposed blocks.
+func (bo *BlockOperations) SetBundlePool(bundlePool *tx_pool.BundlePool) {
+	bo.bundlePool = bundlePool
+}
+
 // Base returns the first known contiguous block height, or 0 for empty block stores.
 func (bo *BlockOperations) Base() uint64 {
 	bo.mtx.RLock()
@@ -95,9 +110,6 @@ func (bo *BlockOperations) CreateProposalBlock(
 	maxNumEvidence, _ := types.MaxEvidencePerBlock(lastState.ConsensusParams.Evidence.MaxBytes)
 	evidence, _ := bo.evPool.PendingEvidence(maxNumEvidence)
 
-	txs := bo.txPool.ProposeTransactions()
-	bo.logger.Debug("Collected transactions", "txs count", len(txs))
-
 	// Set time.
 	var timestamp time.Time
 	if height == 1 {
@@ -106,9 +118,19 @@ func (bo *BlockOperations) CreateProposalBlock(
 		timestamp = cstate.MedianTime(commit, lastState.LastValidators)
 	}
 
-	header := bo.newHeader(timestamp, height, uint64(len(txs)), lastState.LastBlockID, proposerAddr, lastState.Validators.Hash(),
+	header := bo.newHeader(timestamp, height, 0, lastState.LastBlockID, proposerAddr, lastState.Validators.Hash(),
 		lastState.NextValidators.Hash(), lastState.AppHash)
 	header.GasLimit = lastState.ConsensusParams.Block.MaxGas
+
+	// Bundles go first, each one contiguously, followed by the pool txs
+	txs := bo.proposeBundles(header, lastState, commit, evidence)
+	for _, tx := range bo.txPool.ProposeTransactions() {
+		if !txs.Contains(tx.Hash()) {
+			txs = append(txs, tx)
+		}
+	}
+	bo.logger.Debug("Collected transactions", "txs count", len(txs))
+	header.NumTxs = uint64(len(txs))
 	bo.logger.Info("Creates new header", "header", header)
 
 	block = bo.newBlock(header, txs, commit, evidence)
@@ -120,11 +142,12 @@ func (bo *BlockOperations) CreateProposalBlock(
 // New calculated state root is validated against the root field in block.
 // Transactions, new state and receipts are saved to storage.
 func (bo *BlockOperations) CommitAndValidateBlockTxs(block *types.Block, lastCommit stypes.LastCommitInfo, byzVals []stypes.Evidence) ([]*types.Validator, common.Hash, error) {
-	vals, root, blockInfo, _, err := bo.commitTransactions(block.Transactions(), block.Header(), lastCommit, byzVals)
+	vals, root, blockInfo, _, stakingEvents, err := bo.commitTransactions(block.Transactions(), block.Header(), lastCommit, byzVals)
 	if err != nil {
 		return nil, common.Hash{}, err
 	}
 	bo.saveBlockInfo(blockInfo, block)
+	bo.blockchain.DB().WriteStakingEvents(block.Height(), stakingEvents)
 	bo.blockchain.DB().WriteHeadBlockHash(block.Hash())
 	bo.blockchain.DB().WriteTxLookupEntries(block)
 	bo.blockchain.DB().WriteAppHash(block.Height(), root)
@@ -202,6 +225,22 @@ func (bo *BlockOperations) LoadSeenCommit(height uint64) *types.Commit {
 	return commit
 }
 
+// AppHeight returns the height of the last block whose transactions were committed.
+func (bo *BlockOperations) AppHeight() uint64 {
+	return bo.blockchain.CurrentBlock().Height()
+}
+
+// LoadAppHash returns the state root committed for the block at the given height.
+func (bo *BlockOperations) LoadAppHash(height uint64) common.Hash {
+	return bo.blockchain.DB().ReadAppHash(height)
+}
+
+// RewindApp moves the application back to the state committed for the block
+// at the given height, keeping the saved blocks above it.
+func (bo *BlockOperations) RewindApp(height uint64) error {
+	return bo.blockchain.RewindHead(height)
+}
+
 // newHeader creates new block header from given data.
 // Some header fields are not ready at this point.
 func (bo *BlockOperations) newHeader(time time.Time, height uint64, numTxs uint64, blockID types.BlockID,
@@ -228,7 +267,7 @@ func (bo *BlockOperations) newBlock(header *types.Header, txs []*types.Transacti
 // commitTransactions executes the given transactions and commits the result stateDB to disk.
 func (bo *BlockOperations) commitTransactions(txs types.Transactions, header *types.Header,
 	lastCommit stypes.LastCommitInfo, byzVals []stypes.Evidence) ([]*types.Validator, common.Hash, *types.BlockInfo,
-	types.Transactions, error) {
+	types.Transactions, []*types.StakingEvent, error) {
 	var (
 		newTxs   = types.Transactions{}
 		receipts = types.Receipts{}
@@ -239,7 +278,7 @@ func (bo *BlockOperations) commitTransactions(txs types.Transactions, header *ty
 	state, err := bo.blockchain.State()
 	if err != nil {
 		bo.logger.Error("Fail to get blockchain head state", "err", err)
-		return nil, common.Hash{}, nil, nil, err
+		return nil, common.Hash{}, nil, nil, nil, err
 	}
 
 	// GasPool
@@ -247,39 +286,28 @@ func (bo *BlockOperations) commitTransactions(txs types.Transactions, header *ty
 	gasPool := new(types.GasPool).AddGas(header.GasLimit)
 
 	kvmConfig := kvm.Config{}
+	// records rewards, slashes and jails applied by staking system calls
+	recorder := staking.NewEventRecorder(bo.staking.ContractAddress, header.Height, byzVals)
 
-	blockReward, err := bo.staking.Mint(state, header, bo.blockchain, kvmConfig)
+	blockReward, err := bo.beginBlock(state, header, lastCommit, byzVals, recorder, kvmConfig)
 	if err != nil {
-		bo.logger.Error("Fail to mint", "err", err)
-		return nil, common.Hash{}, nil, nil, err
-	}
-
-	if err := bo.staking.FinalizeCommit(state, header, bo.blockchain, kvmConfig, lastCommit); err != nil {
-		bo.logger.Error("Fail to finalize commit", "err", err)
-		return nil, common.Hash{}, nil, nil, err
-	}
-
-	if err := bo.staking.DoubleSign(state, header, bo.blockchain, kvmConfig, byzVals); err != nil {
-		bo.logger.Error("Fail to apply double sign", "err", err)
-		return nil, common.Hash{}, nil, nil, err
+		return nil, common.Hash{}, nil, nil, nil, err
 	}
 
 	// TODO(thientn): verifies the list is sorted by nonce so tx with lower nonce is execute first.
 LOOP:
 	for i, tx := range txs {
-		state.Prepare(tx.Hash(), header.Hash(), i)
-		snap := state.Snapshot()
 		// TODO(thientn): confirms nil coinbase is acceptable.
-		receipt, _, err := ApplyTransaction(bo.logger, bo.blockchain, gasPool, state, header, tx, usedGas, kvmConfig)
+		// validators stopped by an undelegation are recorded with the tx
+		recorder.StartTx(tx.Hash())
+		receipt, err := bo.applyTransaction(state, header, gasPool, tx, i, usedGas, recorder.Config(kvmConfig))
 		if err != nil {
 			bo.logger.Error("ApplyTransaction failed", "tx", tx.Hash().Hex(), "nonce", tx.Nonce(), "err", err)
-			state.RevertToSnapshot(snap)
 			// TODO(thientn): check error type and jump to next tx if possible
 			// kiendn: instead of return nil and err, jump to next tx
 			//return common.Hash{}, nil, nil, err
 			continue LOOP
 		}
-		i++
 		receipts = append(receipts, receipt)
 		newTxs = append(newTxs, tx)
 		// send logs of emitted events to logs feed for collecting
@@ -288,21 +316,23 @@ LOOP:
 		}
 	}
 
+	recorder.RecordUnjails(newTxs, receipts)
+
 	vals, err := bo.staking.ApplyAndReturnValidatorSets(state, header, bo.blockchain, kvmConfig)
 	if err != nil {
-		return nil, common.Hash{}, nil, nil, err
+		return nil, common.Hash{}, nil, nil, nil, err
 	}
 
 	root, err := state.Commit(true)
 
 	if err != nil {
 		bo.logger.Error("Fail to commit new statedb after txs", "err", err)
-		return nil, common.Hash{}, nil, nil, err
+		return nil, common.Hash{}, nil, nil, nil, err
 	}
 	err = bo.blockchain.CommitTrie(root)
 	if err != nil {
 		bo.logger.Error("Fail to write statedb trie to disk", "err", err)
-		return nil, common.Hash{}, nil, nil, err
+		return nil, common.Hash{}, nil, nil, nil, err
 	}
 
 	blockInfo := &types.BlockInfo{
@@ -312,7 +342,127 @@ LOOP:
 		Bloom:    types.CreateBloom(receipts),
 	}
 
-	return vals, root, blockInfo, newTxs, nil
+	return vals, root, blockInfo, newTxs, recorder.Events(), nil
+}
+
+// beginBlock runs the system calls made on state before the txs of a block:
+// it mints the block reward, finalizes the last commit, slashes double signers
+// and executes the governance proposals reaching their target height. It
+// returns the block reward.
+func (bo *BlockOperations) beginBlock(state *state.StateDB, header *types.Header, lastCommit stypes.LastCommitInfo,
+	byzVals []stypes.Evidence, recorder *staking.EventRecorder, kvmConfig kvm.Config) (*big.Int, error) {
+	blockReward, err := bo.staking.Mint(state, header, bo.blockchain, kvmConfig)
+	if err != nil {
+		bo.logger.Error("Fail to mint", "err", err)
+		return nil, err
+	}
+	recorder.Minted(blockReward)
+
+	if err := bo.staking.FinalizeCommit(state, header, bo.blockchain, recorder.Config(kvmConfig), lastCommit); err != nil {
+		bo.logger.Error("Fail to finalize commit", "err", err)
+		return nil, err
+	}
+
+	if err := bo.staking.DoubleSign(state, header, bo.blockchain, recorder.Config(kvmConfig), byzVals); err != nil {
+		bo.logger.Error("Fail to apply double sign", "err", err)
+		return nil, err
+	}
+
+	// execute governance proposals reaching their target height.
+	// a reverted finalize does not change state, therefore it does not stop the block.
+	if gov := bo.staking.Governance; gov != nil && gov.IsDeployed(state) {
+		if err := gov.Finalize(state, header, bo.blockchain, kvmConfig); err != nil {
+			bo.logger.Error("Fail to finalize governance proposals", "err", err)
+		}
+	}
+	return blockReward, nil
+}
+
+// applyTransaction executes the tx at index i of the block on top of state,
+// reverting its changes if it can't be applied.
+func (bo *BlockOperations) applyTransaction(state *state.StateDB, header *types.Header, gasPool *types.GasPool,
+	tx *types.Transaction, i int, usedGas *uint64, kvmConfig kvm.Config) (*types.Receipt, error) {
+	state.Prepare(tx.Hash(), header.Hash(), i)
+	snap := state.Snapshot()
+	receipt, _, err := ApplyTransaction(bo.logger, bo.blockchain, gasPool, state, header, tx, usedGas, kvmConfig)
+	if err != nil {
+		state.RevertToSnapshot(snap)
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// proposeBundles simulates the bundles targeting the height of the header, in
+// arrival order, on the state the txs of the block will run on: the head
+// state after the system calls of beginBlock, for the given last commit and
+// evidence. Their txs are executed the way commitTransactions does. A bundle
+// with a tx failing, or reverting without being allowed to, is rejected and
+// dropped from the bundle pool. It returns the txs of the accepted bundles.
+func (bo *BlockOperations) proposeBundles(header *types.Header, lastState cstate.LastestBlockState,
+	commit *types.Commit, evidence []types.Evidence) types.Transactions {
+	var txs types.Transactions
+	if bo.bundlePool == nil {
+		return txs
+	}
+	bundles := bo.bundlePool.Bundles(header.Height)
+	if len(bundles) == 0 {
+		return txs
+	}
+	state, err := bo.blockchain.State()
+	if err != nil {
+		bo.logger.Error("Fail to get blockchain head state", "err", err)
+		return txs
+	}
+	var (
+		lastCommit stypes.LastCommitInfo
+		byzVals    []stypes.Evidence
+	)
+	if header.Height > 1 {
+		lastCommit = cstate.LastCommitInfo(header.Height, commit, lastState.LastValidators)
+	}
+	for _, ev := range evidence {
+		byzVals = append(byzVals, ev.VM()...)
+	}
+	recorder := staking.NewEventRecorder(bo.staking.ContractAddress, header.Height, byzVals)
+	if _, err := bo.beginBlock(state, header, lastCommit, byzVals, recorder, kvm.Config{}); err != nil {
+		return txs
+	}
+	var (
+		gasPool = new(types.GasPool).AddGas(header.GasLimit)
+		usedGas = new(uint64)
+	)
+	for _, bundle := range bundles {
+		// Keep the state before the bundle, a snapshot doesn't survive the
+		// finalisation of every tx
+		var (
+			backup = state.Copy()
+			gas    = gasPool.Gas()
+			used   = *usedGas
+		)
+		if err := bo.simulateBundle(state, header, gasPool, bundle, len(txs), usedGas); err != nil {
+			bo.logger.Debug("Bundle rejected", "hash", bundle.Hash(), "err", err)
+			bo.bundlePool.Remove(bundle.Hash())
+			state, gasPool, *usedGas = backup, new(types.GasPool).AddGas(gas), used
+			continue
+		}
+		txs = append(txs, bundle.Txs...)
+	}
+	return txs
+}
+
+// simulateBundle executes the txs of a bundle starting at index i of the block.
+func (bo *BlockOperations) simulateBundle(state *state.StateDB, header *types.Header, gasPool *types.GasPool,
+	bundle *types.Bundle, i int, usedGas *uint64) error {
+	for j, tx := range bundle.Txs {
+		receipt, err := bo.applyTransaction(state, header, gasPool, tx, i+j, usedGas, kvm.Config{})
+		if err != nil {
+			return fmt.Errorf("tx %s failed: %w", tx.Hash().Hex(), err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.MayRevert(tx.Hash()) {
+			return fmt.Errorf("tx %s reverted", tx.Hash().Hex())
+		}
+	}
+	return nil
 }
 
 // saveReceipts saves receipts of block transactions to storage.
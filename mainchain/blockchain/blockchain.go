@@ -168,6 +168,12 @@ func (bc *BlockChain) GetBlockByHeight(height uint64) *types.Block {
 	return bc.GetBlock(hash, height)
 }
 
+// GetBlockInfo retrieves the execution info of a block, its receipts and gas
+// used, from the database.
+func (bc *BlockChain) GetBlockInfo(hash common.Hash, height uint64) *types.BlockInfo {
+	return bc.db.ReadBlockInfo(hash, height)
+}
+
 // LoadBlockPart ...
 func (bc *BlockChain) LoadBlockPart(height uint64, index int) *types.Part {
 	return bc.db.ReadBlockPart(height, index)
@@ -381,6 +387,63 @@ func (bc *BlockChain) SetHead(head uint64) error {
 	return bc.loadLastState()
 }
 
+// RewindHead moves the head block back to the given height. Unlike SetHead the
+// blocks above it are kept, so they can be executed again on top of its state.
+func (bc *BlockChain) RewindHead(height uint64) error {
+	bc.logger.Warn("Rewinding head block", "target", height)
+
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	block := bc.GetBlockByHeight(height)
+	if block == nil {
+		return fmt.Errorf("missing block height: %d", height)
+	}
+	if !bc.CheckCommittedStateRoot(bc.db.ReadAppHash(height)) {
+		return fmt.Errorf("missing state of block height: %d", height)
+	}
+	bc.db.WriteHeadBlockHash(block.Hash())
+	bc.currentBlock.Store(block)
+	bc.hc.SetCurrentHeader(block.Header())
+	return nil
+}
+
+// StoreHeight returns the height of the last saved block, which may be above
+// the head block if its txs were not executed yet.
+func (bc *BlockChain) StoreHeight() uint64 {
+	height := bc.CurrentBlock().Height()
+	for bc.LoadBlockMeta(height+1) != nil {
+		height++
+	}
+	return height
+}
+
+// Rollback moves the head block back to the given height like RewindHead, and
+// removes the receipts, tx lookups, staking events and app hashes of the
+// saved blocks above it, including those above the head. The blocks themselves
+// are kept to be executed again.
+func (bc *BlockChain) Rollback(height uint64) error {
+	head := bc.CurrentBlock().Height()
+	if height > head {
+		return fmt.Errorf("rollback height %d is above the head block height %d", height, head)
+	}
+	storeHeight := bc.StoreHeight()
+	if err := bc.RewindHead(height); err != nil {
+		return err
+	}
+	for h := storeHeight; h > height; h-- {
+		block := bc.GetBlockByHeight(h)
+		if block == nil {
+			return fmt.Errorf("missing block height: %d", h)
+		}
+		bc.db.DeleteBlockInfo(block.Hash(), h)
+		bc.db.DeleteTxLookupEntries(block)
+		bc.db.DeleteStakingEvents(h)
+		bc.db.DeleteAppHash(h)
+	}
+	return nil
+}
+
 // InsertHeadBlock inserts new head block to blockchain and send new head event.
 // This function assumes block transactions & app hash are already committed.
 func (bc *BlockChain) InsertHeadBlock(block *types.Block) {
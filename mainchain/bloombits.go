@@ -219,6 +219,30 @@ func (b *BloomIndexer) verifyLastHead() {
 	}
 }
 
+// Rollback drops the sections holding blocks above height, from the valid
+// sections and from the database. The sections below, whose blocks stay
+// canonical, are kept. The section heads are read from chainDb, whose
+// canonical hashes up to storeHeight must still be in place.
+func (b *BloomIndexer) Rollback(chainDb types.StoreDB, height, storeHeight uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	first := (height + 1) / b.sectionSize
+	if b.storedSections > first {
+		b.setValidSections(first)
+	}
+	batch := b.db.NewBatch()
+	for section := first; (section+1)*b.sectionSize-1 <= storeHeight; section++ {
+		head := chainDb.ReadCanonicalHash((section+1)*b.sectionSize - 1)
+		for bit := uint(0); bit < types.BloomBitLength; bit++ {
+			kvstore.DeleteBloomBits(batch, bit, section, head)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		b.log.Error("Failed to delete bloom bits", "err", err)
+	}
+}
+
 // Sections returns the number of processed sections maintained by the indexer
 // and also the information about the last header indexed for potential canonical
 // verifications.
@@ -0,0 +1,56 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+func TestBloomIndexerRollback(t *testing.T) {
+	const sectionSize = 8
+	db := storage.NewMemoryDatabase()
+	indexer := NewBloomIndexer(db.DB(), sectionSize, 0)
+	for height := uint64(0); height < 4*sectionSize; height++ {
+		kvstore.WriteCanonicalHash(db.DB(), common.BytesToHash([]byte{byte(height + 1)}), height)
+	}
+	for section := uint64(0); section < 4; section++ {
+		head := db.ReadCanonicalHash((section+1)*sectionSize - 1)
+		kvstore.WriteBloomBits(db.DB(), 0, section, head, []byte{1})
+		indexer.setSectionHead(section, head)
+	}
+	indexer.setValidSections(4)
+
+	// the section holding the rollback height and the ones above are dropped
+	indexer.Rollback(db, 12, 4*sectionSize-1)
+	assert.Equal(t, uint64(1), indexer.storedSections)
+	assert.Equal(t, db.ReadCanonicalHash(sectionSize-1), indexer.SectionHead(0))
+	for section := uint64(0); section < 4; section++ {
+		_, err := kvstore.ReadBloomBits(db.DB(), 0, section, db.ReadCanonicalHash((section+1)*sectionSize-1))
+		assert.Equal(t, section == 0, err == nil, "section %d", section)
+		if section > 0 {
+			assert.Equal(t, common.Hash{}, indexer.SectionHead(section))
+		}
+	}
+}
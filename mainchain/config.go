@@ -21,6 +21,7 @@ package kai
 import (
 	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/mainchain/gasprice"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
 )
@@ -31,6 +32,8 @@ var DefaultConfig = Config{
 	NetworkId: 1,
 
 	TxPool: tx_pool.DefaultTxPoolConfig,
+
+	GPO: gasprice.DefaultConfig,
 }
 
 //go:generate gencodec -type Config -field-override configMarshaling -formats toml -out gen_config.go
@@ -48,6 +51,9 @@ type Config struct {
 	// Transaction pool options
 	TxPool tx_pool.TxPoolConfig
 
+	// Gas Price Oracle options
+	GPO gasprice.Config
+
 	// DbInfo stores configuration information to setup database
 	DBInfo storage.DbInfo
 
@@ -62,4 +68,8 @@ type Config struct {
 	Consensus *configs.ConsensusConfig
 
 	FastSync *configs.FastSyncConfig
+
+	// LogsBlockRange is the maximum number of blocks a logs query may span,
+	// 0 if unlimited.
+	LogsBlockRange uint64
 }
@@ -16,4 +16,6 @@ var (
 	ErrNotEnoughGasPrice = errors.New("not enough gas price")
 	ErrNilGasPrice       = errors.New("nil gas price")
 	ErrBlockNotFound     = errors.New("block not found")
+	ErrInvalidBlockRange = errors.New("invalid block range")
+	ErrBlockRangeTooBig  = errors.New("block range is too big")
 )
@@ -58,15 +58,19 @@ type PublicFilterAPI struct {
 	events    *EventSystem
 	filtersMu sync.Mutex
 	filters   map[rpc.ID]*filter
+
+	logsBlockRange uint64 // Maximum number of blocks queried by a range filter, 0 if unlimited
 }
 
-// NewPublicFilterAPI returns a new PublicFilterAPI instance.
-func NewPublicFilterAPI(backend Backend) *PublicFilterAPI {
+// NewPublicFilterAPI returns a new PublicFilterAPI instance. Range filters
+// spanning more than logsBlockRange blocks are rejected, unless it's 0.
+func NewPublicFilterAPI(backend Backend, logsBlockRange uint64) *PublicFilterAPI {
 	api := &PublicFilterAPI{
-		backend: backend,
-		chainDb: backend.ChainDb(),
-		events:  NewEventSystem(backend),
-		filters: make(map[rpc.ID]*filter),
+		backend:        backend,
+		chainDb:        backend.ChainDb(),
+		events:         NewEventSystem(backend),
+		filters:        make(map[rpc.ID]*filter),
+		logsBlockRange: logsBlockRange,
 	}
 	go api.timeoutLoop()
 
@@ -331,6 +335,7 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([
 	} else {
 		// Construct the range filter
 		filter = NewRangeFilter(api.backend, crit.FromBlock, crit.ToBlock, crit.Addresses, crit.Topics)
+		filter.rangeLimit = api.logsBlockRange
 	}
 	// Run the filter and return all the logs
 	logs, err := filter.Logs(ctx)
@@ -377,6 +382,7 @@ func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ty
 	} else {
 		// Construct the range filter
 		filter = NewRangeFilter(api.backend, f.crit.FromBlock, f.crit.ToBlock, f.crit.Addresses, f.crit.Topics)
+		filter.rangeLimit = api.logsBlockRange
 	}
 	// Run the filter and return all the logs
 	logs, err := filter.Logs(ctx)
@@ -24,6 +24,7 @@ import (
 
 // API Err
 var (
-	ErrHeaderNotFound    = errors.New("header for hash not found")
-	ErrBlockInfoNotFound = errors.New("block info is missing")
+	ErrHeaderNotFound     = errors.New("header for hash not found")
+	ErrBlockInfoNotFound  = errors.New("block info is missing")
+	ErrBlockRangeTooLarge = errors.New("block range too large")
 )
@@ -20,6 +20,7 @@ package filters
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/kardiachain/go-kardia/kai/events"
 	"github.com/kardiachain/go-kardia/lib/bloombits"
@@ -54,6 +55,7 @@ type Filter struct {
 
 	block      common.Hash // Block hash if filtering a single block
 	begin, end uint64      // Range interval if filtering multiple blocks
+	rangeLimit uint64      // Maximum number of blocks of the range, 0 if unlimited
 
 	matcher *bloombits.Matcher
 }
@@ -138,6 +140,9 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	if f.end == 0 || end >= rpc.PendingBlockNumber.Uint64() {
 		end = header.Height
 	}
+	if f.rangeLimit > 0 && end >= f.begin && end-f.begin >= f.rangeLimit {
+		return nil, fmt.Errorf("%w, %d blocks requested, the limit is %d", ErrBlockRangeTooLarge, end-f.begin+1, f.rangeLimit)
+	}
 	// Gather all indexed logs, and finish with non indexed ones
 	var (
 		logs []*types.Log
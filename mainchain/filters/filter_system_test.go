@@ -20,6 +20,7 @@ package filters
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -164,7 +165,7 @@ func (b *testBackend) ServiceFilter(ctx context.Context, session *bloombits.Matc
 //	var (
 //		db          = storage.NewMemoryDatabase()
 //		backend     = &testBackend{db: db}
-//		api         = NewPublicFilterAPI(backend)
+//		api         = NewPublicFilterAPI(backend, 0)
 //		genesis     = new(genesis.Genesis).MustCommit(db)
 //		chain, _    = events.GenerateChain(configs.TestChainConfig, genesis, ethash.NewFaker(), db, 10, func(i int, gen *events.BlockGen) {})
 //		chainEvents []events.ChainEvent
@@ -215,7 +216,7 @@ func TestLogFilterCreation(t *testing.T) {
 	var (
 		db      = storage.NewMemoryDatabase()
 		backend = &testBackend{db: db}
-		api     = NewPublicFilterAPI(backend)
+		api     = NewPublicFilterAPI(backend, 0)
 
 		testCases = []struct {
 			crit    FilterCriteria
@@ -259,7 +260,7 @@ func TestInvalidLogFilterCreation(t *testing.T) {
 	var (
 		db      = storage.NewMemoryDatabase()
 		backend = &testBackend{db: db}
-		api     = NewPublicFilterAPI(backend)
+		api     = NewPublicFilterAPI(backend, 0)
 	)
 
 	// different situations where log filter creation should fail.
@@ -280,7 +281,7 @@ func TestInvalidGetLogsRequest(t *testing.T) {
 	var (
 		db        = storage.NewMemoryDatabase()
 		backend   = &testBackend{db: db}
-		api       = NewPublicFilterAPI(backend)
+		api       = NewPublicFilterAPI(backend, 0)
 		blockHash = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
 	)
 
@@ -298,6 +299,41 @@ func TestInvalidGetLogsRequest(t *testing.T) {
 	}
 }
 
+// headBackend is a testBackend whose chain head is head.
+type headBackend struct {
+	*testBackend
+	head *types.Header
+}
+
+func (b *headBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) *types.Header {
+	return b.head
+}
+
+// TestGetLogsBlockRange tests that range queries above the block range limit are rejected.
+func TestGetLogsBlockRange(t *testing.T) {
+	var (
+		db      = storage.NewMemoryDatabase()
+		backend = &headBackend{testBackend: &testBackend{db: db}, head: &types.Header{Height: 100}}
+		api     = NewPublicFilterAPI(backend, 10)
+	)
+	testCases := []struct {
+		crit    FilterCriteria
+		tooWide bool
+	}{
+		{FilterCriteria{FromBlock: 1, ToBlock: 50}, true},
+		{FilterCriteria{FromBlock: 90}, true},
+		{FilterCriteria{FromBlock: 91}, false},
+		{FilterCriteria{FromBlock: 20, ToBlock: 29}, false},
+		{FilterCriteria{FromBlock: 50, ToBlock: 10}, false},
+	}
+	for i, test := range testCases {
+		_, err := api.GetLogs(context.Background(), test.crit)
+		if tooWide := errors.Is(err, ErrBlockRangeTooLarge); tooWide != test.tooWide {
+			t.Errorf("case #%d: block range too large %v, want %v (err: %v)", i, tooWide, test.tooWide, err)
+		}
+	}
+}
+
 // TestLogFilter tests whether log filters match the correct logs that are posted to the event feed.
 func TestLogFilter(t *testing.T) {
 	t.Parallel()
@@ -305,7 +341,7 @@ func TestLogFilter(t *testing.T) {
 	var (
 		db      = storage.NewMemoryDatabase()
 		backend = &testBackend{db: db}
-		api     = NewPublicFilterAPI(backend)
+		api     = NewPublicFilterAPI(backend, 0)
 
 		firstAddr      = common.HexToAddress("0x1111111111111111111111111111111111111111")
 		secondAddr     = common.HexToAddress("0x2222222222222222222222222222222222222222")
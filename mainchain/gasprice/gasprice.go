@@ -0,0 +1,321 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package gasprice suggests gas prices for new transactions from the prices
+// paid in the recent blocks.
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	sampleNumber  = 3    // Number of transactions sampled in a block
+	maxFeeHistory = 1024 // Maximum number of blocks of a fee history query
+)
+
+var (
+	// DefaultMaxPrice is the default upper bound of the suggested price, 500 OXY.
+	DefaultMaxPrice = new(big.Int).Mul(big.NewInt(500), big.NewInt(configs.OXY))
+
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errBeyondHead        = errors.New("request beyond head block")
+)
+
+// Config holds the settings of the gas price oracle.
+type Config struct {
+	Blocks     int      // Number of recent blocks sampled
+	Percentile int      // Percentile of the sampled prices suggested
+	Default    *big.Int `toml:",omitempty"` // Price suggested until a block with transactions is sampled
+	MaxPrice   *big.Int `toml:",omitempty"` // Upper bound of the suggested price
+}
+
+// DefaultConfig contains the default oracle settings.
+var DefaultConfig = Config{
+	Blocks:     20,
+	Percentile: 60,
+	Default:    configs.GasPriceCap,
+	MaxPrice:   DefaultMaxPrice,
+}
+
+// OracleBackend provides the blocks sampled by the oracle.
+type OracleBackend interface {
+	CurrentBlock() *types.Block
+	GetBlockByHeight(height uint64) *types.Block
+	GetBlockInfo(hash common.Hash, height uint64) *types.BlockInfo
+}
+
+// TxPool provides the minimum gas price accepted for new transactions.
+type TxPool interface {
+	GasPrice() *big.Int
+}
+
+// Oracle recommends gas prices based on the content of recent blocks.
+type Oracle struct {
+	backend   OracleBackend
+	pool      TxPool
+	lastHead  common.Hash
+	lastPrice *big.Int
+	maxPrice  *big.Int
+	cacheLock sync.RWMutex
+	fetchLock sync.Mutex
+
+	checkBlocks int
+	percentile  int
+}
+
+// NewOracle returns a new gas price oracle which can recommend suitable
+// gas prices for newly created transactions.
+func NewOracle(backend OracleBackend, pool TxPool, params Config) *Oracle {
+	blocks := params.Blocks
+	if blocks < 1 {
+		blocks = 1
+		log.Warn("Sanitizing invalid gasprice oracle sample blocks", "provided", params.Blocks, "updated", blocks)
+	}
+	percent := params.Percentile
+	if percent < 0 {
+		percent = 0
+		log.Warn("Sanitizing invalid gasprice oracle sample percentile", "provided", params.Percentile, "updated", percent)
+	}
+	if percent > 100 {
+		percent = 100
+		log.Warn("Sanitizing invalid gasprice oracle sample percentile", "provided", params.Percentile, "updated", percent)
+	}
+	maxPrice := params.MaxPrice
+	if maxPrice == nil || maxPrice.Sign() <= 0 {
+		maxPrice = DefaultMaxPrice
+		log.Warn("Sanitizing invalid gasprice oracle price cap", "provided", params.MaxPrice, "updated", maxPrice)
+	}
+	lastPrice := params.Default
+	if lastPrice == nil || lastPrice.Sign() <= 0 {
+		lastPrice = DefaultConfig.Default
+	}
+	return &Oracle{
+		backend:     backend,
+		pool:        pool,
+		lastPrice:   lastPrice,
+		maxPrice:    maxPrice,
+		checkBlocks: blocks,
+		percentile:  percent,
+	}
+}
+
+// SuggestPrice returns the configured percentile of the lowest gas prices paid
+// in the recent blocks, bounded below by the minimum price of the tx pool. The
+// result is cached until the head changes.
+func (oracle *Oracle) SuggestPrice(ctx context.Context) (*big.Int, error) {
+	head := oracle.backend.CurrentBlock()
+	headHash := head.Hash()
+
+	// If the latest gasprice is still available, return it.
+	oracle.cacheLock.RLock()
+	lastHead, lastPrice := oracle.lastHead, oracle.lastPrice
+	oracle.cacheLock.RUnlock()
+	if headHash == lastHead {
+		return oracle.bound(lastPrice), nil
+	}
+	oracle.fetchLock.Lock()
+	defer oracle.fetchLock.Unlock()
+
+	// Try checking the cache again, maybe the last fetch fetched what we need
+	oracle.cacheLock.RLock()
+	lastHead, lastPrice = oracle.lastHead, oracle.lastPrice
+	oracle.cacheLock.RUnlock()
+	if headHash == lastHead {
+		return oracle.bound(lastPrice), nil
+	}
+
+	var prices []*big.Int
+	for i := 0; i < oracle.checkBlocks && uint64(i) <= head.Height(); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block := oracle.backend.GetBlockByHeight(head.Height() - uint64(i))
+		if block == nil {
+			break
+		}
+		prices = append(prices, lowestPrices(block, sampleNumber)...)
+	}
+	price := lastPrice
+	if len(prices) > 0 {
+		sort.Sort(bigIntArray(prices))
+		price = prices[(len(prices)-1)*oracle.percentile/100]
+	}
+	if price.Cmp(oracle.maxPrice) > 0 {
+		price = new(big.Int).Set(oracle.maxPrice)
+	}
+	oracle.cacheLock.Lock()
+	oracle.lastHead = headHash
+	oracle.lastPrice = price
+	oracle.cacheLock.Unlock()
+
+	return oracle.bound(price), nil
+}
+
+// FeeHistory returns the fee market history of the blocks range ending at
+// lastBlock, at most blocks long: the height of the oldest block, the ratio of
+// gas used to gas limit and, for every block, the gas prices at the given
+// percentiles of the gas used by its transactions. The percentiles must be
+// increasing values between 0 and 100.
+func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []float64, error) {
+	if blocks < 1 {
+		return 0, nil, nil, nil
+	}
+	if blocks > maxFeeHistory {
+		log.Warn("Sanitizing fee history length", "requested", blocks, "truncated", maxFeeHistory)
+		blocks = maxFeeHistory
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return 0, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return 0, nil, nil, fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	if head := oracle.backend.CurrentBlock().Height(); lastBlock > head {
+		return 0, nil, nil, fmt.Errorf("%w: requested %d, head %d", errBeyondHead, lastBlock, head)
+	}
+	if uint64(blocks) > lastBlock+1 {
+		blocks = int(lastBlock + 1)
+	}
+	oldest := lastBlock + 1 - uint64(blocks)
+
+	var (
+		reward       [][]*big.Int
+		gasUsedRatio = make([]float64, 0, blocks)
+	)
+	if len(rewardPercentiles) > 0 {
+		reward = make([][]*big.Int, 0, blocks)
+	}
+	for height := oldest; height <= lastBlock; height++ {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, nil, err
+		}
+		block := oracle.backend.GetBlockByHeight(height)
+		if block == nil {
+			return 0, nil, nil, fmt.Errorf("block %d not found", height)
+		}
+		ratio, rewards := oracle.processBlock(block, rewardPercentiles)
+		gasUsedRatio = append(gasUsedRatio, ratio)
+		if len(rewardPercentiles) > 0 {
+			reward = append(reward, rewards)
+		}
+	}
+	return oldest, reward, gasUsedRatio, nil
+}
+
+// processBlock returns the gas used ratio of block and the gas prices at the
+// given percentiles of the gas used by its transactions.
+func (oracle *Oracle) processBlock(block *types.Block, percentiles []float64) (float64, []*big.Int) {
+	var (
+		txs  = block.Transactions()
+		info = oracle.backend.GetBlockInfo(block.Hash(), block.Height())
+	)
+	var ratio float64
+	if info != nil && block.GasLimit() > 0 {
+		ratio = float64(info.GasUsed) / float64(block.GasLimit())
+	}
+	if len(percentiles) == 0 {
+		return ratio, nil
+	}
+	rewards := make([]*big.Int, len(percentiles))
+	if len(txs) == 0 {
+		// return an all zero row if there are no transactions to gather data from
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return ratio, rewards
+	}
+
+	// Weight the prices by the gas used by each transaction, fall back to
+	// their gas limit if the receipts are missing.
+	sorter := make([]txGasAndPrice, len(txs))
+	var totalGas uint64
+	for i, tx := range txs {
+		gas := tx.Gas()
+		if info != nil && len(info.Receipts) == len(txs) {
+			gas = info.Receipts[i].GasUsed
+		}
+		sorter[i] = txGasAndPrice{gasUsed: gas, price: tx.GasPrice()}
+		totalGas += gas
+	}
+	sort.Slice(sorter, func(i, j int) bool { return sorter[i].price.Cmp(sorter[j].price) < 0 })
+
+	var txIndex int
+	sumGasUsed := sorter[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGas) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorter)-1 {
+			txIndex++
+			sumGasUsed += sorter[txIndex].gasUsed
+		}
+		rewards[i] = sorter[txIndex].price
+	}
+	return ratio, rewards
+}
+
+// bound returns price raised to the minimum price of the tx pool.
+func (oracle *Oracle) bound(price *big.Int) *big.Int {
+	if min := oracle.pool.GasPrice(); price.Cmp(min) < 0 {
+		return min
+	}
+	return new(big.Int).Set(price)
+}
+
+// lowestPrices returns the lowest gas prices of the transactions of block, at
+// most limit of them. Transactions sent by the block proposer are ignored.
+func lowestPrices(block *types.Block, limit int) []*big.Int {
+	txs := make([]*types.Transaction, len(block.Transactions()))
+	copy(txs, block.Transactions())
+	sort.Slice(txs, func(i, j int) bool { return txs[i].GasPriceCmp(txs[j]) < 0 })
+
+	var prices []*big.Int
+	for _, tx := range txs {
+		sender, err := types.Sender(types.HomesteadSigner{}, tx)
+		if err != nil || sender == block.ProposerAddress() {
+			continue
+		}
+		prices = append(prices, tx.GasPrice())
+		if len(prices) >= limit {
+			break
+		}
+	}
+	return prices
+}
+
+type txGasAndPrice struct {
+	gasUsed uint64
+	price   *big.Int
+}
+
+type bigIntArray []*big.Int
+
+func (s bigIntArray) Len() int           { return len(s) }
+func (s bigIntArray) Less(i, j int) bool { return s[i].Cmp(s[j]) < 0 }
+func (s bigIntArray) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
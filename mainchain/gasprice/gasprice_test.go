@@ -0,0 +1,158 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+type testBackend struct {
+	blocks []*types.Block
+	infos  map[common.Hash]*types.BlockInfo
+}
+
+func (b *testBackend) CurrentBlock() *types.Block { return b.blocks[len(b.blocks)-1] }
+
+func (b *testBackend) GetBlockByHeight(height uint64) *types.Block {
+	if height >= uint64(len(b.blocks)) {
+		return nil
+	}
+	return b.blocks[height]
+}
+
+func (b *testBackend) GetBlockInfo(hash common.Hash, height uint64) *types.BlockInfo {
+	return b.infos[hash]
+}
+
+type testPool struct{ price *big.Int }
+
+func (p *testPool) GasPrice() *big.Int { return new(big.Int).Set(p.price) }
+
+// newTestBackend creates a chain of blocks, the block at height i holding
+// transactions priced i*prices[j] for each j. The proposer of each block
+// also includes a transaction priced 1.
+func newTestBackend(t *testing.T, blocks int, prices []int64) *testBackend {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	proposerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	proposer := crypto.PubkeyToAddress(proposerKey.PublicKey)
+	signer := types.HomesteadSigner{}
+
+	backend := &testBackend{infos: make(map[common.Hash]*types.BlockInfo)}
+	var nonce uint64
+	for height := 0; height < blocks; height++ {
+		var (
+			txs  []*types.Transaction
+			info = &types.BlockInfo{}
+		)
+		if height > 0 {
+			for _, price := range prices {
+				tx, err := types.SignTx(signer, types.NewTransaction(nonce, common.Address{}, big.NewInt(1), 21000, big.NewInt(int64(height)*price), nil), key)
+				require.NoError(t, err)
+				txs = append(txs, tx)
+				info.Receipts = append(info.Receipts, &types.Receipt{GasUsed: 21000})
+				info.GasUsed += 21000
+				nonce++
+			}
+			tx, err := types.SignTx(signer, types.NewTransaction(uint64(height), common.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil), proposerKey)
+			require.NoError(t, err)
+			txs = append(txs, tx)
+			info.Receipts = append(info.Receipts, &types.Receipt{GasUsed: 21000})
+			info.GasUsed += 21000
+		}
+		header := &types.Header{Height: uint64(height), GasLimit: 210000, ProposerAddress: proposer}
+		block := types.NewBlock(header, txs, &types.Commit{}, nil)
+		backend.blocks = append(backend.blocks, block)
+		backend.infos[block.Hash()] = info
+	}
+	return backend
+}
+
+func TestSuggestPrice(t *testing.T) {
+	backend := newTestBackend(t, 11, []int64{100, 200, 300, 400})
+	pool := &testPool{price: big.NewInt(1)}
+	oracle := NewOracle(backend, pool, Config{Blocks: 2, Percentile: 50, Default: big.NewInt(5), MaxPrice: big.NewInt(10000)})
+
+	// blocks 10 and 9 sample their 3 lowest prices not sent by the proposer:
+	// 900 1000 1800 2000 2700 3000
+	price, err := oracle.SuggestPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1800), price)
+
+	// the price is cached per head, but always bounded by the pool
+	pool.price = big.NewInt(5000)
+	price, err = oracle.SuggestPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5000), price)
+
+	// the price is capped
+	oracle = NewOracle(backend, pool, Config{Blocks: 2, Percentile: 100, MaxPrice: big.NewInt(2500)})
+	pool.price = big.NewInt(1)
+	price, err = oracle.SuggestPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2500), price)
+
+	// a chain without transactions returns the default price
+	oracle = NewOracle(newTestBackend(t, 1, nil), pool, Config{Blocks: 2, Percentile: 50, Default: big.NewInt(5), MaxPrice: big.NewInt(10000)})
+	price, err = oracle.SuggestPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), price)
+}
+
+func TestFeeHistory(t *testing.T) {
+	backend := newTestBackend(t, 11, []int64{100, 200, 300, 400})
+	oracle := NewOracle(backend, &testPool{price: big.NewInt(1)}, DefaultConfig)
+
+	oldest, reward, ratio, err := oracle.FeeHistory(context.Background(), 3, 10, []float64{0, 50, 100})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), oldest)
+	assert.Equal(t, []float64{0.5, 0.5, 0.5}, ratio)
+	require.Len(t, reward, 3)
+	// the prices of block 10, weighted by gas used: 1 1000 2000 3000 4000
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2000), big.NewInt(4000)}, reward[2])
+
+	// the range is clipped to the genesis block, which has no transactions
+	oldest, reward, ratio, err = oracle.FeeHistory(context.Background(), 5, 1, []float64{50})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), oldest)
+	assert.Len(t, ratio, 2)
+	assert.Equal(t, []*big.Int{new(big.Int)}, reward[0])
+
+	// without percentiles no reward is returned
+	_, reward, _, err = oracle.FeeHistory(context.Background(), 1, 10, nil)
+	require.NoError(t, err)
+	assert.Nil(t, reward)
+
+	_, _, _, err = oracle.FeeHistory(context.Background(), 1, 11, nil)
+	assert.Error(t, err)
+	_, _, _, err = oracle.FeeHistory(context.Background(), 1, 10, []float64{50, 10})
+	assert.Error(t, err)
+	_, _, _, err = oracle.FeeHistory(context.Background(), 1, 10, []float64{101})
+	assert.Error(t, err)
+}
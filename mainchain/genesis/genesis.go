@@ -384,5 +384,11 @@ func setupGenesisStaking(stakingUtil *staking.StakingSmcUtil, statedb *state.Sta
 			return fmt.Errorf("apply start validator err: %s  Validator info: %+v", err, val)
 		}
 	}
+	// deploy the governance contract, it updates staking params
+	if stakingUtil.Governance != nil {
+		if err := stakingUtil.Governance.CreateGovernanceContract(statedb, header, cfg); err != nil {
+			return fmt.Errorf("create governance contract err: %s", err)
+		}
+	}
 	return nil
 }
@@ -20,16 +20,20 @@
 package kai
 
 import (
+	"fmt"
+
 	bcReactor "github.com/kardiachain/go-kardia/blockchain"
 	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/consensus"
 	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/kvm"
 	"github.com/kardiachain/go-kardia/lib/bloombits"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/mainchain/blockchain"
 	"github.com/kardiachain/go-kardia/mainchain/filters"
+	"github.com/kardiachain/go-kardia/mainchain/gasprice"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/staking"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
@@ -56,13 +60,17 @@ type KardiaService struct {
 	shutdownChan chan bool
 
 	// DB interfaces
-	kaiDb types.StoreDB // Local key-value store endpoint. Each use types should use wrapper layer with unique prefixes.
+	kaiDb   types.StoreDB // Local key-value store endpoint. Each use types should use wrapper layer with unique prefixes.
+	stateDB cstate.Store  // Consensus state, validator sets by height
 
 	// Handlers
 	txPool     *tx_pool.TxPool
+	bundlePool *tx_pool.BundlePool
 	blockchain *blockchain.BlockChain
+	gpo        *gasprice.Oracle
 	csManager  *consensus.ConsensusManager
 	txpoolR    *tx_pool.Reactor
+	evPool     *evidence.Pool
 	evR        *evidence.Reactor
 	bcR        p2p.Reactor // for fast-syncing
 
@@ -103,6 +111,11 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 	if err != nil {
 		return nil, err
 	}
+	stakingPrecompile, err := staking.NewPrecompile(stakingUtil, validator)
+	if err != nil {
+		return nil, err
+	}
+	kvm.RegisterPrecompiledContract(staking.PrecompileAddress, stakingPrecompile, (*configs.ChainConfig).IsStakingPrecompile)
 
 	chainConfig, _, genesisErr := genesis.SetupGenesisBlock(logger, kaiDb, config.Genesis, stakingUtil)
 	if genesisErr != nil {
@@ -123,6 +136,7 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 		logger:       logger,
 		config:       config,
 		kaiDb:        kaiDb,
+		stateDB:      ctx.StateDB,
 		chainConfig:  chainConfig,
 		shutdownChan: make(chan bool),
 		networkID:    config.NetworkId,
@@ -145,9 +159,13 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 	kai.txPool = tx_pool.NewTxPool(config.TxPool, kai.chainConfig, kai.blockchain)
 	kai.txpoolR = tx_pool.NewReactor(config.TxPool, kai.txPool)
 	kai.txpoolR.SetLogger(kai.logger)
+	kai.gpo = gasprice.NewOracle(kai.blockchain, kai.txPool, config.GPO)
 
 	bOper := blockchain.NewBlockOperations(kai.logger, kai.blockchain, kai.txPool, evPool, stakingUtil)
+	kai.bundlePool = tx_pool.NewBundlePool(kai.blockchain)
+	bOper.SetBundlePool(kai.bundlePool)
 
+	kai.evPool = evPool
 	kai.evR = evidence.NewReactor(evPool)
 	kai.evR.SetLogger(kai.logger)
 	blockExec := cstate.NewBlockExecutor(ctx.StateDB, logger, evPool, bOper)
@@ -157,9 +175,18 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 		return nil, err
 	}
 
+	// Replay the blocks saved but not applied before the node stopped, so the
+	// block store, the state and the app agree before consensus starts.
+	blockExec.SetEventBus(eventBus)
+	state, err = consensus.NewHandshaker(logger, state, bOper, blockExec).Handshake()
+	if err != nil {
+		return nil, fmt.Errorf("error during handshake: %v", err)
+	}
+
 	// state starting configs
 	// Set private validator for consensus manager.
 	privValidator := types.NewDefaultPrivValidator(ctx.Config.NodeKey())
+	kai.txpoolR.SetValidatorState(ctx.StateDB, privValidator)
 	// Determine whether we should do fast sync. This must happen after the handshake, since the
 	// app may modify the validator set, specifying ourself as the only validator.
 	config.FastSync.Enable = config.FastSync.Enable && !onlyValidatorIsUs(state, privValidator.GetAddress())
@@ -186,15 +213,17 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 func NewKardiaService(ctx *node.ServiceContext) (node.Service, error) {
 	chainConfig := ctx.Config.MainChainConfig
 	kai, err := newKardiaService(ctx, &Config{
-		NetworkId:   chainConfig.NetworkId,
-		ServiceName: chainConfig.ServiceName,
-		ChainId:     chainConfig.ChainId,
-		DBInfo:      chainConfig.DBInfo,
-		Genesis:     chainConfig.Genesis,
-		TxPool:      chainConfig.TxPool,
-		AcceptTxs:   chainConfig.AcceptTxs,
-		Consensus:   chainConfig.Consensus,
-		FastSync:    chainConfig.FastSync,
+		NetworkId:      chainConfig.NetworkId,
+		ServiceName:    chainConfig.ServiceName,
+		ChainId:        chainConfig.ChainId,
+		DBInfo:         chainConfig.DBInfo,
+		Genesis:        chainConfig.Genesis,
+		TxPool:         chainConfig.TxPool,
+		GPO:            chainConfig.GPO,
+		AcceptTxs:      chainConfig.AcceptTxs,
+		Consensus:      chainConfig.Consensus,
+		FastSync:       chainConfig.FastSync,
+		LogsBlockRange: ctx.Config.RPCLimits.LogsBlockRange,
 	})
 
 	if err != nil {
@@ -254,7 +283,7 @@ func (s *KardiaService) APIs() []rpc.API {
 		{
 			Namespace: "kai",
 			Version:   "1.0",
-			Service:   filters.NewPublicFilterAPI(s),
+			Service:   filters.NewPublicFilterAPI(s, s.config.LogsBlockRange),
 			Public:    true,
 		},
 		{
@@ -269,6 +298,18 @@ func (s *KardiaService) APIs() []rpc.API {
 			Service:   NewPublicAccountAPI(s),
 			Public:    true,
 		},
+		{
+			Namespace: "txpool",
+			Version:   "1.0",
+			Service:   NewPublicTxPoolAPI(s),
+			Public:    true,
+		},
+		{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   NewPrivateTxPoolAPI(s),
+			Public:    false,
+		},
 	}
 }
 
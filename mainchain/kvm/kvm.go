@@ -21,6 +21,7 @@ package kvm
 import (
 	"math/big"
 
+	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/kvm"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/types"
@@ -31,6 +32,9 @@ import (
 type ChainContext interface {
 	// GetHeader returns the hash corresponding to their hash.
 	GetHeader(common.Hash, uint64) *types.Header
+
+	// Config returns the chain configuration.
+	Config() *configs.ChainConfig
 }
 
 // NewKVMContext creates a new context for use in the KVM.
@@ -45,6 +49,7 @@ func NewKVMContext(msg types.Message, header *types.Header, chain ChainContext)
 		Time:        new(big.Int).SetUint64(uint64(header.Time.Unix())),
 		GasLimit:    header.GasLimit,
 		GasPrice:    new(big.Int).Set(msg.GasPrice()),
+		ChainConfig: chainConfig(chain),
 	}
 }
 
@@ -60,7 +65,16 @@ func NewKVMContextFromDualNodeCall(from common.Address, header *types.Header, ch
 		Time:        new(big.Int).SetUint64(uint64(header.Time.Unix())),
 		GasLimit:    header.GasLimit,
 		GasPrice:    big.NewInt(1),
+		ChainConfig: chainConfig(chain),
+	}
+}
+
+// chainConfig returns the configuration of chain, nil without a chain.
+func chainConfig(chain ChainContext) *configs.ChainConfig {
+	if chain == nil {
+		return nil
 	}
+	return chain.Config()
 }
 
 // GetHashFn returns a GetHashFunc which retrieves header hashes by height
@@ -0,0 +1,96 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/mainchain/staking"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Rollback reverts the chain data of a stopped node to the given height: the
+// consensus state, the head block, and the receipts, tx lookups, staking
+// events and bloom bits of the blocks above it. The blocks themselves are kept
+// and executed again by the handshake when the node starts.
+//
+// It refuses to run if validator signed a block above height, as the votes it
+// casts again at those heights may conflict with the ones it already cast,
+// unless force is set.
+func Rollback(logger log.Logger, db types.StoreDB, gs *genesis.Genesis, height uint64,
+	validator common.Address, force bool) (cstate.LastestBlockState, error) {
+	stakingUtil, err := staking.NewSmcStakingUtil()
+	if err != nil {
+		return cstate.LastestBlockState{}, err
+	}
+	chainConfig, _, err := genesis.SetupGenesisBlock(logger, db, gs, stakingUtil)
+	if err != nil {
+		return cstate.LastestBlockState{}, err
+	}
+	bc, err := blockchain.NewBlockChain(logger, db, chainConfig)
+	if err != nil {
+		return cstate.LastestBlockState{}, err
+	}
+
+	storeHeight := bc.StoreHeight()
+	if !force {
+		if signed := lastSignedHeight(bc, validator, height, storeHeight); signed > height {
+			return cstate.LastestBlockState{}, fmt.Errorf("validator %v signed block %d above the rollback height %d", validator.Hex(), signed, height)
+		}
+	}
+
+	// Roll the state back first, the handshake rewinds an app left ahead of it.
+	state, err := cstate.Rollback(db.DB(), bc, height)
+	if err != nil {
+		return state, err
+	}
+	if err := bc.Rollback(height); err != nil {
+		return state, err
+	}
+	NewBloomIndexer(db.DB(), configs.BloomBitsBlocksClient, configs.HelperTrieConfirmations).Rollback(db, height, storeHeight)
+
+	logger.Info("Rolled back", "height", height, "appHash", state.AppHash, "blocksToReplay", storeHeight-height)
+	return state, nil
+}
+
+// lastSignedHeight returns the highest height in (from, to] of a block the
+// validator signed, or 0 if there is none.
+func lastSignedHeight(bc *blockchain.BlockChain, validator common.Address, from, to uint64) uint64 {
+	for height := to; height > from; height-- {
+		commit := bc.LoadBlockCommit(height)
+		if commit == nil {
+			commit = bc.LoadSeenCommit(height)
+		}
+		if commit == nil {
+			continue
+		}
+		for _, sig := range commit.Signatures {
+			if sig.ValidatorAddress.Equal(validator) && len(sig.Signature) > 0 {
+				return height
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,192 @@
+package staking
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	stypes "github.com/kardiachain/go-kardia/mainchain/staking/types"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// selectors of the calls made between staking and validator contracts
+var (
+	allocateTokenSelector  = methodSelector("allocateToken(uint256)")
+	burnSelector           = methodSelector("burn(uint256,uint256)")
+	removeFromSetsSelector = methodSelector("removeFromSets()")
+	doubleSignSelector     = methodSelector("doubleSign(address,uint256,uint256)")
+	finalizeSelector       = methodSelector("finalize(address[],uint256[],bool[])")
+	unjailSelector         = methodSelector("unjail()")
+	undelegateSelector     = methodSelector("undelegate()")
+	undelegateAmtSelector  = methodSelector("undelegateWithAmount(uint256)")
+)
+
+// burnReasonSlash is the reason of a burn made by a slash, others are fees
+var burnReasonSlash = new(big.Int)
+
+func methodSelector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+type evidenceKey struct {
+	address common.Address
+	height  uint64
+}
+
+// callFrame is a running call observed by the recorder, with the recorder
+// state to restore if the call fails.
+type callFrame struct {
+	addr     common.Address
+	selector []byte
+	events   int
+	current  common.Hash
+	rewarded bool
+}
+
+// EventRecorder records the staking side effects of a block. Rewards, slashes
+// and jails are applied by system calls that produce no receipts, so they are
+// collected by observing the calls between staking and validator contracts.
+// Events of a call are dropped when the call, or one of its callers, fails.
+type EventRecorder struct {
+	stakingAddr common.Address
+	height      uint64
+
+	evidence map[evidenceKey]common.Hash
+	current  common.Hash // evidence being applied
+	tx       common.Hash // transaction being applied
+	rewarded bool        // whether the proposer bonus was allocated
+
+	frames []callFrame
+	events []*types.StakingEvent
+}
+
+// NewEventRecorder returns a recorder of the staking events of the block at height
+func NewEventRecorder(stakingAddr common.Address, height uint64, byzVals []stypes.Evidence) *EventRecorder {
+	r := &EventRecorder{
+		stakingAddr: stakingAddr,
+		height:      height,
+		evidence:    make(map[evidenceKey]common.Hash, len(byzVals)),
+	}
+	for _, ev := range byzVals {
+		r.evidence[evidenceKey{ev.Address, ev.Height}] = ev.Hash
+	}
+	return r
+}
+
+// Config returns cfg with the recorder observing its calls
+func (r *EventRecorder) Config(cfg kvm.Config) kvm.Config {
+	cfg.CallHook = r.onCall
+	cfg.CallExitHook = r.onCallExit
+	return cfg
+}
+
+// Events returns the recorded events
+func (r *EventRecorder) Events() []*types.StakingEvent {
+	return r.events
+}
+
+// Minted records the tokens minted for the block
+func (r *EventRecorder) Minted(amount *big.Int) {
+	if amount == nil {
+		return
+	}
+	r.add(types.StakingEventMint, common.Address{}, amount)
+}
+
+// StartTx marks the events recorded from now on as caused by the transaction txHash
+func (r *EventRecorder) StartTx(txHash common.Hash) {
+	r.current, r.tx = common.Hash{}, txHash
+}
+
+// RecordUnjails records the successful unjail transactions of the block
+func (r *EventRecorder) RecordUnjails(txs types.Transactions, receipts types.Receipts) {
+	r.current, r.tx = common.Hash{}, common.Hash{}
+	for i, tx := range txs {
+		if tx.To() == nil || !bytes.Equal(tx.Data(), unjailSelector) {
+			continue
+		}
+		if i >= len(receipts) || receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		ev := r.add(types.StakingEventUnjail, *tx.To(), new(big.Int))
+		ev.TxHash = tx.Hash()
+	}
+}
+
+func (r *EventRecorder) onCall(caller common.Address, addr common.Address, input []byte, value *big.Int, depth int) {
+	frame := callFrame{addr: addr, events: len(r.events), current: r.current, rewarded: r.rewarded}
+	if len(input) >= 4 {
+		frame.selector = input[:4]
+	}
+	r.frames = append(r.frames, frame)
+	if len(input) < 4 {
+		return
+	}
+	selector := input[:4]
+	switch {
+	case depth == 0 && addr == r.stakingAddr && bytes.Equal(selector, finalizeSelector):
+		r.current, r.rewarded = common.Hash{}, false
+	case depth == 0 && addr == r.stakingAddr && bytes.Equal(selector, doubleSignSelector) && len(input) >= 100:
+		signer := common.BytesToAddress(input[4:36])
+		height := new(big.Int).SetBytes(input[68:100]).Uint64()
+		r.current = r.evidence[evidenceKey{signer, height}]
+	case caller == r.stakingAddr && bytes.Equal(selector, allocateTokenSelector) && len(input) >= 36:
+		// the previous proposer is always allocated first
+		typ := types.StakingEventReward
+		if !r.rewarded {
+			typ, r.rewarded = types.StakingEventProposerReward, true
+		}
+		r.add(typ, addr, new(big.Int).SetBytes(input[4:36]))
+	case addr == r.stakingAddr && caller != r.stakingAddr && bytes.Equal(selector, burnSelector) && len(input) >= 68:
+		// other burns are fees, such as the one of a name change
+		if new(big.Int).SetBytes(input[36:68]).Cmp(burnReasonSlash) == 0 {
+			r.add(types.StakingEventSlash, caller, new(big.Int).SetBytes(input[4:36]))
+		}
+	case addr == r.stakingAddr && caller != r.stakingAddr && bytes.Equal(selector, removeFromSetsSelector):
+		// a validator leaves the sets when it is jailed, or stopped by an
+		// undelegation taking its stake below the minimum
+		typ := types.StakingEventJail
+		if r.undelegating(caller) {
+			typ = types.StakingEventStop
+		}
+		r.add(typ, caller, new(big.Int))
+	}
+}
+
+// onCallExit drops the events of a failed call
+func (r *EventRecorder) onCallExit(depth int, err error) {
+	if len(r.frames) == 0 {
+		return
+	}
+	frame := r.frames[len(r.frames)-1]
+	r.frames = r.frames[:len(r.frames)-1]
+	if err != nil {
+		r.events, r.current, r.rewarded = r.events[:frame.events], frame.current, frame.rewarded
+	}
+}
+
+// undelegating returns whether the innermost running call of the validator
+// contract at valSmcAddr is an undelegation
+func (r *EventRecorder) undelegating(valSmcAddr common.Address) bool {
+	for i := len(r.frames) - 1; i >= 0; i-- {
+		if r.frames[i].addr == valSmcAddr {
+			return bytes.Equal(r.frames[i].selector, undelegateSelector) || bytes.Equal(r.frames[i].selector, undelegateAmtSelector)
+		}
+	}
+	return false
+}
+
+func (r *EventRecorder) add(typ types.StakingEventType, validator common.Address, amount *big.Int) *types.StakingEvent {
+	ev := &types.StakingEvent{
+		Type:         typ,
+		Height:       r.height,
+		Validator:    validator,
+		Amount:       amount,
+		EvidenceHash: r.current,
+		TxHash:       r.tx,
+	}
+	r.events = append(r.events, ev)
+	return ev
+}
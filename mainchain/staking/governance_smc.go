@@ -0,0 +1,267 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Proposal statuses of governance contract
+const (
+	ProposalPending uint8 = iota
+	ProposalPassed
+	ProposalRejected
+)
+
+// Vote options of governance contract
+const (
+	VoteAbstain uint8 = iota
+	VoteYes
+	VoteNo
+)
+
+// ErrGovernanceNotDeployed is returned when governance contract does not exist in state
+var ErrGovernanceNotDeployed = errors.New("governance contract is not deployed")
+
+// paramsSlot is the storage slot of the params mapping of the params contract
+var paramsSlot = common.BigToHash(big.NewInt(2))
+
+// GovernanceSmcUtil ...
+type GovernanceSmcUtil struct {
+	Abi             *abi.ABI
+	ContractAddress common.Address
+	Bytecode        string
+	logger          log.Logger
+}
+
+// Proposal is a param change proposal of governance contract
+type Proposal struct {
+	ID           *big.Int       `json:"id"`
+	Proposer     common.Address `json:"proposer"`
+	Keys         []*big.Int     `json:"keys"`
+	Values       []*big.Int     `json:"values"`
+	Deposit      *big.Int       `json:"deposit"`
+	StartHeight  *big.Int       `json:"startHeight"`
+	TargetHeight *big.Int       `json:"targetHeight"`
+	Status       uint8          `json:"status"`
+	Yes          *big.Int       `json:"yes"`
+	No           *big.Int       `json:"no"`
+	Abstain      *big.Int       `json:"abstain"`
+}
+
+// NewSmcGovernanceUtil ...
+func NewSmcGovernanceUtil() (*GovernanceSmcUtil, error) {
+	contract, ok := configs.GetContracts()[configs.GovernanceContractKey]
+	if !ok || contract.ABI == "" || contract.ByteCode == "" {
+		return nil, fmt.Errorf("governance contract is not configured")
+	}
+	abi, err := abi.JSON(strings.NewReader(contract.ABI))
+	if err != nil {
+		log.Error("Error reading abi", "err", err)
+		return nil, err
+	}
+	address := contract.Address
+	if address == "" {
+		address = configs.DefaultGovernanceContractAddress
+	}
+	return &GovernanceSmcUtil{Abi: &abi, ContractAddress: common.HexToAddress(address), Bytecode: contract.ByteCode}, nil
+}
+
+// IsDeployed returns true if governance contract exists in statedb
+func (g *GovernanceSmcUtil) IsDeployed(statedb *state.StateDB) bool {
+	return statedb.GetCodeSize(g.ContractAddress) > 0
+}
+
+// CreateGovernanceContract deploys governance contract at genesis
+func (g *GovernanceSmcUtil) CreateGovernanceContract(statedb *state.StateDB, header *types.Header, cfg kvm.Config) error {
+	msg := types.NewMessage(
+		configs.GenesisDeployerAddr,
+		nil,
+		0,
+		big.NewInt(0),
+		100000000,
+		big.NewInt(0),
+		common.FromHex(g.Bytecode),
+		false,
+	)
+
+	// Create a new context to be used in the EVM environment
+	context := vm.NewKVMContext(msg, header, nil)
+	vmenv := kvm.NewKVM(context, statedb, cfg)
+	sender := kvm.AccountRef(msg.From())
+	if err := vmenv.CreateGenesisContractAddress(sender, msg.Data(), msg.Gas(), msg.Value(), g.ContractAddress); err != nil {
+		return err
+	}
+	// Update the state with pending changes
+	statedb.Finalise(true)
+	return nil
+}
+
+// ConstructAndApplySmcCallMsg ...
+func (g *GovernanceSmcUtil) ConstructAndApplySmcCallMsg(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, payload []byte) ([]byte, error) {
+	msg := types.NewMessage(
+		g.ContractAddress,
+		&g.ContractAddress,
+		0,
+		big.NewInt(0),
+		100000000,
+		big.NewInt(0),
+		payload,
+		false,
+	)
+	return Apply(g.logger, bc, statedb, header, cfg, msg)
+}
+
+// Finalize executes proposals which reach their target height and writes the
+// params changed by the passed ones to the params contract, which has no setter.
+func (g *GovernanceSmcUtil) Finalize(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) error {
+	payload, err := g.Abi.Pack("finalize")
+	if err != nil {
+		return err
+	}
+	res, err := g.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload)
+	if err != nil {
+		return err
+	}
+	var changes struct {
+		Keys   []*big.Int
+		Values []*big.Int
+	}
+	if err := g.Abi.UnpackIntoInterface(&changes, "finalize", res); err != nil {
+		return err
+	}
+	if len(changes.Keys) == 0 {
+		return nil
+	}
+	var params common.Address
+	if err := g.call(statedb, header, bc, cfg, &params, "params"); err != nil {
+		return err
+	}
+	for i, key := range changes.Keys {
+		var bounds struct {
+			Allowed bool
+			Min     *big.Int
+			Max     *big.Int
+		}
+		if err := g.call(statedb, header, bc, cfg, &bounds, "paramBounds", key); err != nil {
+			return err
+		}
+		value := changes.Values[i]
+		if !bounds.Allowed || value.Cmp(bounds.Min) < 0 || value.Cmp(bounds.Max) > 0 {
+			log.Error("Skip invalid governance param change", "key", key, "value", value)
+			continue
+		}
+		statedb.SetState(params, crypto.Keccak256Hash(common.BigToHash(key).Bytes(), paramsSlot.Bytes()), common.BigToHash(value))
+	}
+	// Update the state with pending changes
+	statedb.Finalise(true)
+	return nil
+}
+
+// GetAllProposalsLength returns number of proposals
+func (g *GovernanceSmcUtil) GetAllProposalsLength(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) (*big.Int, error) {
+	var length *big.Int
+	if err := g.call(statedb, header, bc, cfg, &length, "allProposals"); err != nil {
+		return nil, err
+	}
+	return length, nil
+}
+
+// GetPendingProposals returns ids of proposals which are not executed yet
+func (g *GovernanceSmcUtil) GetPendingProposals(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) ([]*big.Int, error) {
+	var ids []*big.Int
+	if err := g.call(statedb, header, bc, cfg, &ids, "getPendingProposals"); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetProposal returns a proposal and its results
+func (g *GovernanceSmcUtil) GetProposal(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, id *big.Int) (*Proposal, error) {
+	var proposal struct {
+		Proposer     common.Address
+		Keys         []*big.Int
+		Values       []*big.Int
+		Deposit      *big.Int
+		StartHeight  *big.Int
+		TargetHeight *big.Int
+		Status       uint8
+	}
+	if err := g.call(statedb, header, bc, cfg, &proposal, "getProposal", id); err != nil {
+		return nil, err
+	}
+	var results struct {
+		Yes     *big.Int
+		No      *big.Int
+		Abstain *big.Int
+	}
+	if err := g.call(statedb, header, bc, cfg, &results, "getProposalResults", id); err != nil {
+		return nil, err
+	}
+	return &Proposal{
+		ID:           id,
+		Proposer:     proposal.Proposer,
+		Keys:         proposal.Keys,
+		Values:       proposal.Values,
+		Deposit:      proposal.Deposit,
+		StartHeight:  proposal.StartHeight,
+		TargetHeight: proposal.TargetHeight,
+		Status:       proposal.Status,
+		Yes:          results.Yes,
+		No:           results.No,
+		Abstain:      results.Abstain,
+	}, nil
+}
+
+// GetVote returns vote of an address in a proposal and its weight, the stake of the voter when voting
+func (g *GovernanceSmcUtil) GetVote(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, id *big.Int, voter common.Address) (bool, uint8, *big.Int, error) {
+	var vote struct {
+		Voted  bool
+		Option uint8
+		Weight *big.Int
+	}
+	if err := g.call(statedb, header, bc, cfg, &vote, "getVote", id, voter); err != nil {
+		return false, 0, nil, err
+	}
+	return vote.Voted, vote.Option, vote.Weight, nil
+}
+
+// GetStake returns voting power of an address
+func (g *GovernanceSmcUtil) GetStake(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, voter common.Address) (*big.Int, error) {
+	var stake *big.Int
+	if err := g.call(statedb, header, bc, cfg, &stake, "stakeOf", voter); err != nil {
+		return nil, err
+	}
+	return stake, nil
+}
+
+// call calls a view method of governance contract and unpacks its result into v
+func (g *GovernanceSmcUtil) call(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, v interface{}, method string, args ...interface{}) error {
+	if !g.IsDeployed(statedb) {
+		return ErrGovernanceNotDeployed
+	}
+	payload, err := g.Abi.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	res, err := g.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload)
+	if err != nil {
+		return err
+	}
+	if err := g.Abi.UnpackIntoInterface(v, method, res); err != nil {
+		log.Error("Error unpacking governance result", "method", method, "err", err)
+		return err
+	}
+	return nil
+}
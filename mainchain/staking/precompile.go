@@ -0,0 +1,187 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package staking
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// PrecompileAddress is the reserved address of the native staking contract.
+var PrecompileAddress = common.BytesToAddress([]byte{0x08, 0x00})
+
+// PrecompileABI is the interface of the native staking contract. Validators
+// are designated by their address, the contract looks up their staking
+// contract. The calls act on behalf of the calling account.
+const PrecompileABI = `[
+	{"type":"function","name":"getValidators","stateMutability":"view","inputs":[],
+	 "outputs":[{"name":"validators","type":"address[]"},{"name":"tokens","type":"uint256[]"},{"name":"jailed","type":"bool[]"}]},
+	{"type":"function","name":"delegate","stateMutability":"payable","inputs":[{"name":"validator","type":"address"}],"outputs":[]},
+	{"type":"function","name":"undelegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"withdraw","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"}],"outputs":[]},
+	{"type":"function","name":"withdrawRewards","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"}],"outputs":[]},
+	{"type":"function","name":"pendingRewards","stateMutability":"view","inputs":[{"name":"delegator","type":"address"},{"name":"validator","type":"address"}],
+	 "outputs":[{"name":"rewards","type":"uint256"}]}
+]`
+
+// Precompile is the native staking contract. It lets contracts delegate to
+// and query validators without knowing their staking contracts, by calling
+// the staking and validator contracts from the running KVM.
+type Precompile struct {
+	abi       abi.ABI
+	staking   *StakingSmcUtil
+	validator *ValidatorSmcUtil
+}
+
+// NewPrecompile creates the native staking contract on top of the staking and
+// validator contracts.
+func NewPrecompile(staking *StakingSmcUtil, validator *ValidatorSmcUtil) (*Precompile, error) {
+	precompileAbi, err := abi.JSON(strings.NewReader(PrecompileABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Precompile{abi: precompileAbi, staking: staking, validator: validator}, nil
+}
+
+// RequiredGas returns the base gas of a call, the calls to the staking and
+// validator contracts are paid from the remaining gas.
+func (p *Precompile) RequiredGas(input []byte) uint64 {
+	return configs.StakingPrecompileGas
+}
+
+// Run is never used, the contract runs through RunStateful.
+func (p *Precompile) Run(input []byte) ([]byte, error) {
+	return nil, kvm.ErrExecutionReverted
+}
+
+// RunStateful runs a call of the native staking contract.
+func (p *Precompile) RunStateful(vm *kvm.KVM, caller common.Address, input []byte, value *big.Int, gas uint64, readOnly bool) ([]byte, uint64, error) {
+	if len(input) < 4 {
+		return nil, gas, kvm.ErrExecutionReverted
+	}
+	method, err := p.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, gas, kvm.ErrExecutionReverted
+	}
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, gas, kvm.ErrExecutionReverted
+	}
+	if value.Sign() != 0 && !method.IsPayable() {
+		return nil, gas, kvm.ErrExecutionReverted
+	}
+	if readOnly && !method.IsConstant() {
+		return nil, gas, kvm.ErrWriteProtection
+	}
+
+	switch method.Name {
+	case "getValidators":
+		return p.getValidators(vm, method, gas)
+	case "pendingRewards":
+		valSmcAddr, gas, err := p.valSmcAddr(vm, args[1].(common.Address), gas)
+		if err != nil {
+			return nil, gas, err
+		}
+		return p.call(vm, valSmcAddr, gas, p.validator.Abi, "getDelegationRewards", args[0].(common.Address))
+	}
+
+	valSmcAddr, gas, err := p.valSmcAddr(vm, args[0].(common.Address), gas)
+	if err != nil {
+		return nil, gas, err
+	}
+	var payload []byte
+	switch method.Name {
+	case "delegate":
+		// The value is delegated by the caller itself, give it back first
+		vm.Transfer(vm.StateDB, PrecompileAddress, caller, value)
+		payload, err = p.validator.Abi.Pack("delegate")
+	case "undelegate":
+		payload, err = p.validator.Abi.Pack("undelegateWithAmount", args[1].(*big.Int))
+	case "withdraw":
+		payload, err = p.validator.Abi.Pack("withdraw")
+	case "withdrawRewards":
+		payload, err = p.validator.Abi.Pack("withdrawRewards")
+	}
+	if err != nil {
+		return nil, gas, err
+	}
+	ret, gas, err := vm.Call(kvm.AccountRef(caller), valSmcAddr, payload, gas, value)
+	if err != nil {
+		return ret, gas, err
+	}
+	return nil, gas, nil
+}
+
+// getValidators returns the address, tokens and jail status of all validators.
+func (p *Precompile) getValidators(vm *kvm.KVM, method *abi.Method, gas uint64) ([]byte, uint64, error) {
+	ret, gas, err := p.call(vm, p.staking.ContractAddress, gas, p.staking.Abi, "allValsLength")
+	if err != nil {
+		return nil, gas, err
+	}
+	length := new(big.Int).SetBytes(ret)
+	var (
+		validators []common.Address
+		tokens     []*big.Int
+		jailed     []bool
+	)
+	for i := int64(0); i < length.Int64(); i++ {
+		if ret, gas, err = p.call(vm, p.staking.ContractAddress, gas, p.staking.Abi, "allVals", big.NewInt(i)); err != nil {
+			return nil, gas, err
+		}
+		if ret, gas, err = p.call(vm, common.BytesToAddress(ret), gas, p.validator.Abi, "inforValidator"); err != nil {
+			return nil, gas, err
+		}
+		var info Validator
+		if err := p.validator.Abi.UnpackIntoInterface(&info, "inforValidator", ret); err != nil {
+			return nil, gas, kvm.ErrExecutionReverted
+		}
+		validators = append(validators, info.ValAddr)
+		tokens = append(tokens, info.Tokens)
+		jailed = append(jailed, info.Jailed)
+	}
+	ret, err = method.Outputs.Pack(validators, tokens, jailed)
+	return ret, gas, err
+}
+
+// valSmcAddr returns the staking contract of a validator.
+func (p *Precompile) valSmcAddr(vm *kvm.KVM, valAddr common.Address, gas uint64) (common.Address, uint64, error) {
+	ret, gas, err := p.call(vm, p.staking.ContractAddress, gas, p.staking.Abi, "ownerOf", valAddr)
+	if err != nil {
+		return common.Address{}, gas, err
+	}
+	valSmcAddr := common.BytesToAddress(ret)
+	if valSmcAddr == (common.Address{}) {
+		return common.Address{}, gas, kvm.ErrExecutionReverted
+	}
+	return valSmcAddr, gas, nil
+}
+
+// call runs a read-only method of a staking or validator contract.
+func (p *Precompile) call(vm *kvm.KVM, addr common.Address, gas uint64, contractAbi *abi.ABI, method string, args ...interface{}) ([]byte, uint64, error) {
+	payload, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		return nil, gas, err
+	}
+	return vm.StaticCall(kvm.AccountRef(PrecompileAddress), addr, payload, gas)
+}
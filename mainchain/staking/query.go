@@ -0,0 +1,329 @@
+package staking
+
+import (
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// queryGas is the gas given to every view call of a query
+const queryGas = 100000000
+
+// Pagination selects a window of a list. Page starts from 0, a zero Limit
+// returns the whole list.
+type Pagination struct {
+	Page  uint64 `json:"page"`
+	Limit uint64 `json:"limit"`
+}
+
+// bounds returns the [start, end) range of a list with total items selected by p
+func (p *Pagination) bounds(total int) (int, int) {
+	if p == nil || p.Limit == 0 {
+		return 0, total
+	}
+	start := p.Page * p.Limit
+	if start >= uint64(total) {
+		return total, total
+	}
+	end := start + p.Limit
+	if end > uint64(total) {
+		end = uint64(total)
+	}
+	return int(start), int(end)
+}
+
+// UBDEntry is a pending unbonding of a delegator
+type UBDEntry struct {
+	Amount         *big.Int `json:"amount"`
+	CompletionTime *big.Int `json:"completionTime"`
+}
+
+// Delegation is the stake of a delegator in one validator
+type Delegation struct {
+	ValAddr       common.Address `json:"validatorAddress"`
+	ValStakingSmc common.Address `json:"valStakingSmc"`
+	StakedAmount  *big.Int       `json:"stakedAmount"`
+	Reward        *big.Int       `json:"reward"`
+	UBDEntries    []*UBDEntry    `json:"ubdEntries"`
+}
+
+// DelegatorReward is the part of a reward allocation to a validator earned by one of its delegators
+type DelegatorReward struct {
+	Height        uint64
+	Type          types.StakingEventType
+	ValStakingSmc common.Address
+	Reward        *big.Int
+}
+
+// StakingQuery reads staking and validator contracts at one state. Its reads
+// are batched: the calls of each step of a request, such as the info of every
+// validator or the stake and reward of every delegator of a page, run together
+// in a single read-only KVM instead of one state transition per call.
+type StakingQuery struct {
+	staking   *StakingSmcUtil
+	validator *ValidatorSmcUtil
+
+	statedb *state.StateDB
+	header  *types.Header
+	bc      vm.ChainContext
+	cfg     kvm.Config
+}
+
+// smcCall is a view call of a staking or validator contract in a batch. Its
+// result is unpacked into v, or into out if v is nil.
+type smcCall struct {
+	to     common.Address
+	abi    *abi.ABI
+	method string
+	args   []interface{}
+	v      interface{}
+	out    []interface{}
+}
+
+// NewStakingQuery returns a query over staking contracts at the given state and header
+func NewStakingQuery(stakingUtil *StakingSmcUtil, validatorUtil *ValidatorSmcUtil, statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) *StakingQuery {
+	return &StakingQuery{
+		staking:   stakingUtil,
+		validator: validatorUtil,
+		statedb:   statedb,
+		header:    header,
+		bc:        bc,
+		cfg:       cfg,
+	}
+}
+
+// Header returns the header of the block this query reads at
+func (q *StakingQuery) Header() *types.Header {
+	return q.header
+}
+
+// stakingCall returns a call of the staking contract
+func (q *StakingQuery) stakingCall(v interface{}, method string, args ...interface{}) *smcCall {
+	return &smcCall{to: q.staking.ContractAddress, abi: q.staking.Abi, method: method, args: args, v: v}
+}
+
+// validatorCall returns a call of the validator contract at valSmcAddr
+func (q *StakingQuery) validatorCall(valSmcAddr common.Address, v interface{}, method string, args ...interface{}) *smcCall {
+	return &smcCall{to: valSmcAddr, abi: q.validator.Abi, method: method, args: args, v: v}
+}
+
+// batch runs calls in one read-only KVM at the query state and unpacks their
+// results. It stops at the first failing call.
+func (q *StakingQuery) batch(calls ...*smcCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	msg := types.NewMessage(q.staking.ContractAddress, &q.staking.ContractAddress, 0, big.NewInt(0), queryGas, big.NewInt(0), nil, false)
+	vmenv := kvm.NewKVM(vm.NewKVMContext(msg, q.header, q.bc), q.statedb, q.cfg)
+	for _, c := range calls {
+		input, err := c.abi.Pack(c.method, c.args...)
+		if err != nil {
+			return err
+		}
+		ret, _, err := vmenv.StaticCall(kvm.AccountRef(c.to), c.to, input, queryGas)
+		if err != nil {
+			return err
+		}
+		if c.v != nil {
+			err = c.abi.UnpackIntoInterface(c.v, c.method, ret)
+		} else {
+			c.out, err = c.abi.Unpack(c.method, ret)
+		}
+		if err != nil {
+			log.Error("Error unpacking staking query result", "method", c.method, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Validators returns all validators. Delegators of each validator are only
+// read if withDelegators is true, and are paginated by page.
+func (q *StakingQuery) Validators(withDelegators bool, page *Pagination) ([]*Validator, error) {
+	allValsLen, err := q.staking.GetAllValsLength(q.statedb, q.header, q.bc, q.cfg)
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]*smcCall, allValsLen.Int64())
+	for i := range calls {
+		calls[i] = q.stakingCall(nil, "allVals", big.NewInt(int64(i)))
+	}
+	if err := q.batch(calls...); err != nil {
+		return nil, err
+	}
+	valSmcAddrs := make([]common.Address, 0, len(calls))
+	for _, c := range calls {
+		valSmcAddrs = append(valSmcAddrs, c.out[0].(common.Address))
+	}
+	return q.validators(valSmcAddrs, withDelegators, page)
+}
+
+// Validator returns info of the validator owned by valAddr
+func (q *StakingQuery) Validator(valAddr common.Address, withDelegators bool, page *Pagination) (*Validator, error) {
+	valSmcAddr, err := q.ValidatorSmcAddr(valAddr)
+	if err != nil {
+		return nil, err
+	}
+	return q.ValidatorBySmcAddr(valSmcAddr, withDelegators, page)
+}
+
+// ValidatorSmcAddr returns staking contract of the validator owned by valAddr,
+// empty address if valAddr owns no validator
+func (q *StakingQuery) ValidatorSmcAddr(valAddr common.Address) (common.Address, error) {
+	return q.staking.GetValFromOwner(q.statedb, q.header, q.bc, q.cfg, valAddr)
+}
+
+// ValidatorBySmcAddr returns info of the validator at valSmcAddr
+func (q *StakingQuery) ValidatorBySmcAddr(valSmcAddr common.Address, withDelegators bool, page *Pagination) (*Validator, error) {
+	vals, err := q.validators([]common.Address{valSmcAddr}, withDelegators, page)
+	if err != nil {
+		return nil, err
+	}
+	return vals[0], nil
+}
+
+// validators reads the validators at valSmcAddrs in two batches, one for
+// their info and one for the delegators of the page.
+func (q *StakingQuery) validators(valSmcAddrs []common.Address, withDelegators bool, page *Pagination) ([]*Validator, error) {
+	type commission struct {
+		Rate          *big.Int
+		MaxRate       *big.Int
+		MaxChangeRate *big.Int
+	}
+	type delegations struct {
+		DelAddrs []common.Address
+		Shares   []*big.Int
+	}
+	var (
+		vals        = make([]*Validator, len(valSmcAddrs))
+		commissions = make([]*commission, len(valSmcAddrs))
+		dels        = make([]*delegations, len(valSmcAddrs))
+		calls       = make([]*smcCall, 0, 4*len(valSmcAddrs))
+	)
+	for i, valSmcAddr := range valSmcAddrs {
+		vals[i] = &Validator{ValStakingSmc: valSmcAddr, SigningInfo: &SigningInfo{}}
+		commissions[i], dels[i] = &commission{}, &delegations{}
+		calls = append(calls,
+			q.validatorCall(valSmcAddr, vals[i], "inforValidator"),
+			q.validatorCall(valSmcAddr, commissions[i], "commission"),
+			q.validatorCall(valSmcAddr, vals[i].SigningInfo, "signingInfo"),
+			q.validatorCall(valSmcAddr, dels[i], "getDelegations"),
+		)
+	}
+	if err := q.batch(calls...); err != nil {
+		return nil, err
+	}
+
+	calls = calls[:0]
+	for i, val := range vals {
+		val.ValStakingSmc = valSmcAddrs[i]
+		val.CommissionRate, val.MaxRate, val.MaxChangeRate = commissions[i].Rate, commissions[i].MaxRate, commissions[i].MaxChangeRate
+		// a validator without tokens has no delegations to read rewards from
+		if val.Tokens.Sign() <= 0 {
+			continue
+		}
+		val.TotalDelegators = len(dels[i].DelAddrs)
+		if !withDelegators {
+			continue
+		}
+		start, end := page.bounds(len(dels[i].DelAddrs))
+		val.Delegators = make([]*Delegator, 0, end-start)
+		for _, delAddr := range dels[i].DelAddrs[start:end] {
+			val.Delegators = append(val.Delegators, &Delegator{Address: delAddr})
+			calls = append(calls, q.delegatorCalls(val.ValStakingSmc, delAddr)...)
+		}
+	}
+	if err := q.batch(calls...); err != nil {
+		return nil, err
+	}
+	for _, val := range vals {
+		for _, delegator := range val.Delegators {
+			delegator.Reward, delegator.StakedAmount = calls[0].out[0].(*big.Int), calls[1].out[0].(*big.Int)
+			calls = calls[2:]
+		}
+	}
+	return vals, nil
+}
+
+// delegatorCalls returns the calls reading the reward and the stake of
+// delAddr in the validator at valSmcAddr
+func (q *StakingQuery) delegatorCalls(valSmcAddr common.Address, delAddr common.Address) []*smcCall {
+	return []*smcCall{
+		q.validatorCall(valSmcAddr, nil, "getDelegationRewards", delAddr),
+		q.validatorCall(valSmcAddr, nil, "getDelegatorStake", delAddr),
+	}
+}
+
+// Delegators returns delegators of the validator at valSmcAddr, paginated by page
+func (q *StakingQuery) Delegators(valSmcAddr common.Address, page *Pagination) ([]*Delegator, error) {
+	val, err := q.ValidatorBySmcAddr(valSmcAddr, true, page)
+	if err != nil {
+		return nil, err
+	}
+	return val.Delegators, nil
+}
+
+// ValidatorsByDelegator returns staking contracts of the validators delAddr delegated to
+func (q *StakingQuery) ValidatorsByDelegator(delAddr common.Address) ([]common.Address, error) {
+	return q.staking.GetValidatorsByDelegator(q.statedb, q.header, q.bc, q.cfg, delAddr)
+}
+
+// DelegatorInfo returns every delegation of delAddr, with its pending reward
+// and unbonding entries
+func (q *StakingQuery) DelegatorInfo(delAddr common.Address) ([]*Delegation, error) {
+	valSmcAddrs, err := q.ValidatorsByDelegator(delAddr)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		vals  = make([]*Validator, len(valSmcAddrs))
+		calls = make([]*smcCall, 0, 2*len(valSmcAddrs))
+	)
+	for i, valSmcAddr := range valSmcAddrs {
+		vals[i] = &Validator{}
+		calls = append(calls,
+			q.validatorCall(valSmcAddr, vals[i], "inforValidator"),
+			q.validatorCall(valSmcAddr, nil, "getUBDEntries", delAddr),
+		)
+	}
+	if err := q.batch(calls...); err != nil {
+		return nil, err
+	}
+
+	delegations := make([]*Delegation, 0, len(valSmcAddrs))
+	stakeCalls := make([]*smcCall, 0, 2*len(valSmcAddrs))
+	for i, valSmcAddr := range valSmcAddrs {
+		delegation := &Delegation{
+			ValAddr:       vals[i].ValAddr,
+			ValStakingSmc: valSmcAddr,
+			StakedAmount:  new(big.Int),
+			Reward:        new(big.Int),
+		}
+		balances, completionTimes := calls[2*i+1].out[0].([]*big.Int), calls[2*i+1].out[1].([]*big.Int)
+		delegation.UBDEntries = make([]*UBDEntry, 0, len(balances))
+		for j := range balances {
+			delegation.UBDEntries = append(delegation.UBDEntries, &UBDEntry{Amount: balances[j], CompletionTime: completionTimes[j]})
+		}
+		// fully undelegated validators only keep unbonding entries
+		if vals[i].Tokens.Sign() > 0 {
+			stakeCalls = append(stakeCalls, q.delegatorCalls(valSmcAddr, delAddr)...)
+		}
+		delegations = append(delegations, delegation)
+	}
+	if err := q.batch(stakeCalls...); err != nil {
+		return nil, err
+	}
+	for i, delegation := range delegations {
+		if vals[i].Tokens.Sign() > 0 {
+			delegation.Reward, delegation.StakedAmount = stakeCalls[0].out[0].(*big.Int), stakeCalls[1].out[0].(*big.Int)
+			stakeCalls = stakeCalls[2:]
+		}
+	}
+	return delegations, nil
+}
@@ -24,6 +24,7 @@ type StakingSmcUtil struct {
 	Abi             *abi.ABI
 	ContractAddress common.Address
 	Bytecode        string
+	Governance      *GovernanceSmcUtil // nil if governance contract is not configured
 	logger          log.Logger
 }
 
@@ -44,6 +45,7 @@ type Validator struct {
 	MaxRate               *big.Int       `json:"maxRate,omitempty"`
 	MaxChangeRate         *big.Int       `json:"maxChangeRate,omitempty"`
 	SigningInfo           *SigningInfo   `json:"signingInfo"`
+	TotalDelegators       int            `json:"totalDelegators"`
 	Delegators            []*Delegator   `json:"delegators,omitempty"`
 }
 
@@ -71,7 +73,13 @@ func NewSmcStakingUtil() (*StakingSmcUtil, error) {
 		return nil, err
 	}
 
-	return &StakingSmcUtil{Abi: &abi, ContractAddress: common.HexToAddress(configs.DefaultStakingContractAddress), Bytecode: bytecodeStaking}, nil
+	util := &StakingSmcUtil{Abi: &abi, ContractAddress: common.HexToAddress(configs.DefaultStakingContractAddress), Bytecode: bytecodeStaking}
+	if contract, ok := configs.GetContracts()[configs.GovernanceContractKey]; ok && contract.ABI != "" {
+		if util.Governance, err = NewSmcGovernanceUtil(); err != nil {
+			return nil, err
+		}
+	}
+	return util, nil
 }
 
 //SetParams set params
@@ -120,7 +128,7 @@ func (s *StakingSmcUtil) CreateGenesisValidator(statedb *state.StateDB, header *
 
 	name := []byte(_name)
 	var arrName [32]byte
-	copy(arrName[:], name[:32])
+	copy(arrName[:], name)
 
 	if !k1 || !k2 || !k3 || !k4 {
 		panic("Error while parsing genesis validator params")
@@ -352,6 +360,46 @@ func (s *StakingSmcUtil) GetValidatorsByDelegator(statedb *state.StateDB, header
 	return valsAddr.ValAddrs, nil
 }
 
+// GetParamsSmcAddr returns address of params contract
+func (s *StakingSmcUtil) GetParamsSmcAddr(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) (common.Address, error) {
+	payload, err := s.Abi.Pack("params")
+	if err != nil {
+		return common.Address{}, err
+	}
+	res, err := s.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var paramsSmc struct {
+		AddrParamsSmc common.Address
+	}
+	if err := s.Abi.UnpackIntoInterface(&paramsSmc, "params", res); err != nil {
+		log.Error("Error unpacking params address", "err", err)
+		return common.Address{}, err
+	}
+	return paramsSmc.AddrParamsSmc, nil
+}
+
+// GetTreasury returns address of treasury contract, which receives the deposits of rejected proposals
+func (s *StakingSmcUtil) GetTreasury(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) (common.Address, error) {
+	payload, err := s.Abi.Pack("treasury")
+	if err != nil {
+		return common.Address{}, err
+	}
+	res, err := s.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var treasury struct {
+		AddrTreasury common.Address
+	}
+	if err := s.Abi.UnpackIntoInterface(&treasury, "treasury", res); err != nil {
+		log.Error("Error unpacking treasury address", "err", err)
+		return common.Address{}, err
+	}
+	return treasury.AddrTreasury, nil
+}
+
 // SetRoot set address root
 func (s *StakingSmcUtil) SetRoot(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config) error {
 	payload, err := s.Abi.Pack("transferOwnership", s.ContractAddress)
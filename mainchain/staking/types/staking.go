@@ -26,4 +26,5 @@ type Evidence struct {
 	Height           uint64
 	Time             time.Time
 	TotalVotingPower uint64
+	Hash             common.Hash
 }
@@ -77,34 +77,44 @@ func (s *ValidatorSmcUtil) Delegate(statedb *state.StateDB, header *types.Header
 
 // GetValidator show info of a validator based on address
 func (s *ValidatorSmcUtil) GetInforValidator(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address) (*Validator, error) {
-	payload, err := s.Abi.Pack("inforValidator")
+	validator, err := s.getInforValidator(statedb, header, bc, cfg, valSmcAddr)
 	if err != nil {
 		return nil, err
 	}
-	res, err := s.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload, valSmcAddr, valSmcAddr)
+	rate, maxRate, maxChangeRate, err := s.GetCommissionValidator(statedb, header, bc, cfg, valSmcAddr)
+	if err != nil {
+		return nil, err
+	}
+	validator.CommissionRate = rate
+	validator.MaxRate = maxRate
+	validator.MaxChangeRate = maxChangeRate
+	signingInfo, err := s.GetSigningInfo(statedb, header, bc, cfg, valSmcAddr)
 	if err != nil {
 		return nil, err
 	}
+	validator.SigningInfo = signingInfo
+	return validator, nil
+}
 
-	var validator Validator
-	// unpack result
-	err = s.Abi.UnpackIntoInterface(&validator, "inforValidator", res)
+// getInforValidator returns the base info of a validator, without its commission and signing info
+func (s *ValidatorSmcUtil) getInforValidator(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address) (*Validator, error) {
+	payload, err := s.Abi.Pack("inforValidator")
 	if err != nil {
-		log.Error("Error unpacking validator info", "err", err)
 		return nil, err
 	}
-	rate, maxRate, maxChangeRate, err := s.GetCommissionValidator(statedb, header, bc, cfg, valSmcAddr)
+	res, err := s.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload, valSmcAddr, valSmcAddr)
 	if err != nil {
 		return nil, err
 	}
-	validator.CommissionRate = rate
-	validator.MaxRate = maxRate
-	validator.MaxChangeRate = maxChangeRate
-	signingInfo, err := s.GetSigningInfo(statedb, header, bc, cfg, valSmcAddr)
+
+	var validator Validator
+	// unpack result
+	err = s.Abi.UnpackIntoInterface(&validator, "inforValidator", res)
 	if err != nil {
+		log.Error("Error unpacking validator info", "err", err)
 		return nil, err
 	}
-	validator.SigningInfo = signingInfo
+	validator.ValStakingSmc = valSmcAddr
 	return &validator, nil
 }
 
@@ -135,6 +145,23 @@ func (s *ValidatorSmcUtil) GetCommissionValidator(statedb *state.StateDB, header
 
 // GetDelegators returns all delegators of a validator
 func (s *ValidatorSmcUtil) GetDelegators(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address) ([]*Delegator, error) {
+	delAddrs, err := s.GetDelegatorAddrs(statedb, header, bc, cfg, valSmcAddr)
+	if err != nil {
+		return nil, err
+	}
+	var delegators []*Delegator
+	for _, delAddr := range delAddrs {
+		delegator, err := s.GetDelegator(statedb, header, bc, cfg, valSmcAddr, delAddr)
+		if err != nil {
+			return nil, err
+		}
+		delegators = append(delegators, delegator)
+	}
+	return delegators, nil
+}
+
+// GetDelegatorAddrs returns addresses of all delegators of a validator
+func (s *ValidatorSmcUtil) GetDelegatorAddrs(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address) ([]common.Address, error) {
 	payload, err := s.Abi.Pack("getDelegations")
 	if err != nil {
 		return nil, err
@@ -154,23 +181,24 @@ func (s *ValidatorSmcUtil) GetDelegators(statedb *state.StateDB, header *types.H
 		log.Error("Error unpacking delegation details", "err", err)
 		return nil, err
 	}
-	var delegators []*Delegator
-	for _, delAddr := range delegations.DelAddrs {
-		reward, err := s.GetDelegationRewards(statedb, header, bc, cfg, valSmcAddr, delAddr)
-		if err != nil {
-			return nil, err
-		}
-		stakedAmount, err := s.GetDelegatorStakedAmount(statedb, header, bc, cfg, valSmcAddr, delAddr)
-		if err != nil {
-			return nil, err
-		}
-		delegators = append(delegators, &Delegator{
-			Address:      delAddr,
-			StakedAmount: stakedAmount,
-			Reward:       reward,
-		})
+	return delegations.DelAddrs, nil
+}
+
+// GetDelegator returns staked amount and pending reward of a delegator to current validator
+func (s *ValidatorSmcUtil) GetDelegator(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address, delAddr common.Address) (*Delegator, error) {
+	reward, err := s.GetDelegationRewards(statedb, header, bc, cfg, valSmcAddr, delAddr)
+	if err != nil {
+		return nil, err
 	}
-	return delegators, nil
+	stakedAmount, err := s.GetDelegatorStakedAmount(statedb, header, bc, cfg, valSmcAddr, delAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &Delegator{
+		Address:      delAddr,
+		StakedAmount: stakedAmount,
+		Reward:       reward,
+	}, nil
 }
 
 // GetDelegationRewards returns reward of a delegation
@@ -219,6 +247,34 @@ func (s *ValidatorSmcUtil) GetDelegatorStakedAmount(statedb *state.StateDB, head
 	return delegation.DelStake, nil
 }
 
+// GetUBDEntries returns unbonding entries of a delegator to current validator
+func (s *ValidatorSmcUtil) GetUBDEntries(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address, delAddr common.Address) ([]*UBDEntry, error) {
+	payload, err := s.Abi.Pack("getUBDEntries", delAddr)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.ConstructAndApplySmcCallMsg(statedb, header, bc, cfg, payload, valSmcAddr, valSmcAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack result, outputs of getUBDEntries are unnamed
+	out, err := s.Abi.Unpack("getUBDEntries", res)
+	if err != nil {
+		log.Error("Error unpacking unbonding entries", "err", err)
+		return nil, err
+	}
+	balances, completionTimes := out[0].([]*big.Int), out[1].([]*big.Int)
+	ubdEntries := make([]*UBDEntry, 0, len(balances))
+	for i := range balances {
+		ubdEntries = append(ubdEntries, &UBDEntry{
+			Amount:         balances[i],
+			CompletionTime: completionTimes[i],
+		})
+	}
+	return ubdEntries, nil
+}
+
 // GetSigningInfo returns signing info of this validator
 func (s *ValidatorSmcUtil) GetSigningInfo(statedb *state.StateDB, header *types.Header, bc vm.ChainContext, cfg kvm.Config, valSmcAddr common.Address) (*SigningInfo, error) {
 	payload, err := s.Abi.Pack("signingInfo")
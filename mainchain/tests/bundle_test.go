@@ -0,0 +1,132 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// noEvidence is an evidence pool without pending evidence
+type noEvidence struct{}
+
+func (noEvidence) PendingEvidence(int64) ([]types.Evidence, int64) { return nil, 0 }
+
+func TestCreateProposalBlock_Bundles(t *testing.T) {
+	c := newGovernanceChain(t)
+	poolConfig := tx_pool.DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	txPool := tx_pool.NewTxPool(poolConfig, c.bc.Config(), c.bc)
+	bundlePool := tx_pool.NewBundlePool(c.bc)
+	bo := blockchain.NewBlockOperations(log.New(), c.bc, txPool, noEvidence{}, c.util)
+	bo.SetBundlePool(bundlePool)
+
+	sign := func(i int, nonce uint64, data []byte) *types.Transaction {
+		to := common.HexToAddress("0x000000000000000000000000000000000000b0b0")
+		if data != nil {
+			to = c.util.Governance.ContractAddress
+		}
+		tx, err := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(nonce, to, big.NewInt(1), 100000, c.gasFee, data), c.keys[i])
+		require.NoError(t, err)
+		return tx
+	}
+	// an unknown method of the governance contract reverts
+	reverting := []byte{0xde, 0xad, 0xbe, 0xef}
+	revertTx := sign(1, 1, reverting)
+	var (
+		accepted = &types.Bundle{Height: 1, Txs: types.Transactions{sign(0, 0, nil), sign(0, 1, nil)}}
+		reverted = &types.Bundle{Height: 1, Txs: types.Transactions{sign(1, 0, nil), revertTx}}
+		// the valid first tx isn't included without the failing second one
+		failed = &types.Bundle{Height: 1, Txs: types.Transactions{sign(0, 2, nil), sign(0, 5, nil)}}
+		// the state rolled back from the rejected bundles lets these nonces through
+		mayRevert = &types.Bundle{Height: 1, Txs: types.Transactions{sign(1, 0, nil), revertTx}, RevertingTxHashes: []common.Hash{revertTx.Hash()}}
+	)
+	for _, bundle := range []*types.Bundle{accepted, reverted, failed, mayRevert} {
+		require.NoError(t, bundlePool.Add(bundle))
+	}
+
+	vals := make([]*types.Validator, len(c.vals))
+	for i, val := range c.vals {
+		vals[i] = types.NewValidator(val, 1)
+	}
+	lastState := cstate.LastestBlockState{
+		ConsensusParams: *configs.DefaultConsensusParams(),
+		Validators:      types.NewValidatorSet(vals),
+		NextValidators:  types.NewValidatorSet(vals),
+		LastBlockTime:   time.Now(),
+	}
+	block, _ := bo.CreateProposalBlock(1, lastState, c.vals[0], &types.Commit{})
+
+	// accepted bundles go first, each contiguously and in arrival order
+	var want []common.Hash
+	for _, tx := range append(accepted.Txs, mayRevert.Txs...) {
+		want = append(want, tx.Hash())
+	}
+	var got []common.Hash
+	for _, tx := range block.Transactions() {
+		got = append(got, tx.Hash())
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, uint64(len(want)), block.Header().NumTxs)
+
+	// rejected bundles are dropped from the pool
+	kept := bundlePool.Bundles(1)
+	if assert.Len(t, kept, 2) {
+		assert.Equal(t, accepted.Hash(), kept[0].Hash())
+		assert.Equal(t, mayRevert.Hash(), kept[1].Hash())
+	}
+
+	// the proposal is valid, the reverting tx allowed in its bundle fails. The
+	// tx pool doesn't follow blocks committed without their parents.
+	txPool.Stop()
+	receipts := c.commit(1, block.Transactions()...)
+	for i, receipt := range receipts {
+		status := types.ReceiptStatusSuccessful
+		if block.Transactions()[i].Hash() == revertTx.Hash() {
+			status = types.ReceiptStatusFailed
+		}
+		assert.Equal(t, status, receipt.Status, "tx %d", i)
+	}
+
+	// bundles are simulated after the staking system calls of the block
+	next := &types.Bundle{Height: 2, Txs: types.Transactions{sign(0, 2, nil)}}
+	require.NoError(t, bundlePool.Add(next))
+	lastState.LastValidators = lastState.Validators
+	sigs := make([]types.CommitSig, lastState.LastValidators.Size())
+	for i, val := range lastState.LastValidators.Validators {
+		sigs[i] = types.NewCommitSigForBlock([]byte{1}, val.Address, time.Now())
+	}
+	commit := types.NewCommit(1, 0, types.BlockID{Hash: block.Hash()}, sigs)
+	block, _ = bo.CreateProposalBlock(2, lastState, c.vals[0], commit)
+	if assert.Len(t, block.Transactions(), 1) {
+		assert.Equal(t, next.Txs[0].Hash(), block.Transactions()[0].Hash())
+	}
+}
@@ -0,0 +1,301 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tests
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	g "github.com/kardiachain/go-kardia/mainchain/genesis"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/mainchain/staking"
+	stypes "github.com/kardiachain/go-kardia/mainchain/staking/types"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// paramsABI is the part of the Params contract read by the tests
+const paramsABI = `[{"constant":true,"inputs":[{"name":"key","type":"uint8"}],"name":"getParam","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"}]`
+
+// Params.ParamKey values used by the tests
+const (
+	paramMaxProposers = 2
+	paramMinStake     = 9
+)
+
+// governanceChain is a chain with two genesis validators committing blocks
+// through the block operations of a node.
+type governanceChain struct {
+	t      *testing.T
+	bc     *blockchain.BlockChain
+	bo     *blockchain.BlockOperations
+	util   *staking.StakingSmcUtil
+	keys   []*ecdsa.PrivateKey
+	vals   []common.Address
+	nonces []uint64
+	gasFee *big.Int
+}
+
+func newGovernanceChain(t *testing.T) *governanceChain {
+	c := &governanceChain{t: t, gasFee: big.NewInt(1)}
+	balance, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	accounts := make(map[string]*big.Int)
+	for _, pk := range privKeys[:2] {
+		key, err := crypto.HexToECDSA(pk)
+		require.NoError(t, err)
+		c.keys = append(c.keys, key)
+		c.vals = append(c.vals, crypto.PubkeyToAddress(key.PublicKey))
+		c.nonces = append(c.nonces, 0)
+		accounts[crypto.PubkeyToAddress(key.PublicKey).Hex()] = balance
+	}
+
+	configs.AddDefaultContract()
+	contracts := make(map[string]string)
+	for key, contract := range configs.GetContracts() {
+		configs.LoadGenesisContract(key, contract.Address, contract.ByteCode, contract.ABI)
+		if key != configs.StakingContractKey && key != configs.GovernanceContractKey {
+			contracts[contract.Address] = contract.ByteCode
+		}
+	}
+	genesis := g.DefaulTestnetFullGenesisBlock(accounts, contracts)
+	for i, val := range c.vals {
+		genesis.Validators = append(genesis.Validators, &g.GenesisValidator{
+			Name:             "Val" + string(rune('1'+i)),
+			Address:          val.Hex(),
+			CommissionRate:   commissionRate,
+			MaxRate:          maxRate,
+			MaxChangeRate:    maxChangeRate,
+			SelfDelegate:     selfDelegate,
+			StartWithGenesis: true,
+		})
+	}
+
+	var err error
+	c.util, err = staking.NewSmcStakingUtil()
+	require.NoError(t, err)
+	require.NotNil(t, c.util.Governance)
+	kaiDb := kvstore.NewStoreDB(memorydb.New())
+	chainConfig, _, err := g.SetupGenesisBlock(log.New(), kaiDb, genesis, c.util)
+	require.NoError(t, err)
+	c.bc, err = blockchain.NewBlockChain(log.New(), kaiDb, chainConfig)
+	require.NoError(t, err)
+	// blocks are committed directly, without a tx pool following the head
+	c.bo = blockchain.NewBlockOperations(log.New(), c.bc, nil, nil, c.util)
+	return c
+}
+
+// tx signs a call of a governance method by the validator i, all signed txs
+// must be committed.
+func (c *governanceChain) tx(i int, value *big.Int, method string, args ...interface{}) *types.Transaction {
+	input, err := c.util.Governance.Abi.Pack(method, args...)
+	require.NoError(c.t, err)
+	nonce := c.nonces[i]
+	c.nonces[i]++
+	tx, err := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(nonce, c.util.Governance.ContractAddress, value, 5000000, c.gasFee, input), c.keys[i])
+	require.NoError(c.t, err)
+	return tx
+}
+
+// commit executes and commits a block with the given txs at height and
+// returns the receipts of the txs.
+func (c *governanceChain) commit(height uint64, txs ...*types.Transaction) types.Receipts {
+	block := types.NewBlock(&types.Header{
+		Height:   height,
+		Time:     time.Now(),
+		GasLimit: configs.BlockGasLimit,
+		NumTxs:   uint64(len(txs)),
+	}, txs, &types.Commit{}, nil)
+	// both validators signed the last block
+	lastCommit := stypes.LastCommitInfo{}
+	for _, val := range c.vals {
+		lastCommit.Votes = append(lastCommit.Votes, stypes.VoteInfo{Address: val, VotingPower: big.NewInt(votingPower), SignedLastBlock: true})
+	}
+	_, _, err := c.bo.CommitAndValidateBlockTxs(block, lastCommit, nil)
+	require.NoError(c.t, err)
+	info := c.bc.DB().ReadBlockInfo(block.Hash(), height)
+	require.NotNil(c.t, info)
+	require.Len(c.t, info.Receipts, len(txs))
+	return info.Receipts
+}
+
+// proposal reads a proposal at head state.
+func (c *governanceChain) proposal(id int64) *staking.Proposal {
+	state, err := c.bc.State()
+	require.NoError(c.t, err)
+	p, err := c.util.Governance.GetProposal(state, c.bc.CurrentBlock().Header(), c.bc, kvm.Config{}, big.NewInt(id))
+	require.NoError(c.t, err)
+	return p
+}
+
+// param reads a param of the Params contract at head state.
+func (c *governanceChain) param(key uint8) *big.Int {
+	state, err := c.bc.State()
+	require.NoError(c.t, err)
+	header := c.bc.CurrentBlock().Header()
+	paramsAddr, err := c.util.GetParamsSmcAddr(state, header, c.bc, kvm.Config{})
+	require.NoError(c.t, err)
+	paramsAbi, err := abi.JSON(strings.NewReader(paramsABI))
+	require.NoError(c.t, err)
+	input, err := paramsAbi.Pack("getParam", key)
+	require.NoError(c.t, err)
+	msg := types.NewMessage(c.vals[0], &paramsAddr, 0, big.NewInt(0), 1000000, big.NewInt(0), input, false)
+	ret, _, err := kvm.NewKVM(vm.NewKVMContext(msg, header, c.bc), state, kvm.Config{}).StaticCall(kvm.AccountRef(c.vals[0]), paramsAddr, input, 1000000)
+	require.NoError(c.t, err)
+	return new(big.Int).SetBytes(ret)
+}
+
+// balance returns the balance of addr at head state.
+func (c *governanceChain) balance(addr common.Address) *big.Int {
+	state, err := c.bc.State()
+	require.NoError(c.t, err)
+	return state.GetBalance(addr)
+}
+
+func TestGovernance_ProposeVoteFinalize(t *testing.T) {
+	c := newGovernanceChain(t)
+	gov := c.util.Governance
+	deposit := new(big.Int).Mul(big.NewInt(500000), big.NewInt(1e18))
+	target := big.NewInt(2 + 17280)
+	newMinStake := new(big.Int).Mul(big.NewInt(50000), big.NewInt(1e18))
+	oldMinStake := c.param(paramMinStake)
+	oldMaxProposers := c.param(paramMaxProposers)
+	require.NotEqual(t, newMinStake, oldMinStake)
+
+	// the first validator proposes to double minStake, the second one to change
+	// maxProposers, a deposit too low and a voting period too short are rejected
+	receipts := c.commit(1,
+		c.tx(0, deposit, "propose", []*big.Int{big.NewInt(paramMinStake)}, []*big.Int{newMinStake}, target),
+		c.tx(1, deposit, "propose", []*big.Int{big.NewInt(paramMaxProposers)}, []*big.Int{big.NewInt(1)}, target),
+		c.tx(1, new(big.Int).Sub(deposit, big.NewInt(1)), "propose", []*big.Int{big.NewInt(paramMaxProposers)}, []*big.Int{big.NewInt(1)}, target),
+		c.tx(1, deposit, "propose", []*big.Int{big.NewInt(paramMaxProposers)}, []*big.Int{big.NewInt(1)}, big.NewInt(17280)),
+	)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipts[0].Status)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipts[1].Status)
+	assert.Equal(t, types.ReceiptStatusFailed, receipts[2].Status)
+	assert.Equal(t, types.ReceiptStatusFailed, receipts[3].Status)
+	assert.Equal(t, new(big.Int).Mul(deposit, big.NewInt(2)), c.balance(c.util.Governance.ContractAddress))
+	p := c.proposal(0)
+	assert.Equal(t, c.vals[0], p.Proposer)
+	assert.Equal(t, target, p.TargetHeight)
+	assert.Equal(t, staking.ProposalPending, p.Status)
+
+	// both validators vote yes on the first proposal, the second one is only
+	// voted no by the first validator
+	receipts = c.commit(2,
+		c.tx(0, big.NewInt(0), "vote", big.NewInt(0), staking.VoteYes),
+		c.tx(1, big.NewInt(0), "vote", big.NewInt(0), staking.VoteYes),
+		c.tx(0, big.NewInt(0), "vote", big.NewInt(1), staking.VoteNo),
+	)
+	for _, receipt := range receipts {
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	}
+	stake, _ := new(big.Int).SetString(selfDelegate, 10)
+	p = c.proposal(0)
+	assert.Equal(t, new(big.Int).Mul(stake, big.NewInt(2)), p.Yes)
+	assert.Equal(t, 0, p.No.Sign())
+
+	// nothing is executed before the target height
+	c.commit(target.Uint64() - 1)
+	assert.Equal(t, staking.ProposalPending, c.proposal(0).Status)
+	assert.Equal(t, oldMinStake, c.param(paramMinStake))
+
+	state, err := c.bc.State()
+	require.NoError(t, err)
+	treasury, err := c.util.GetTreasury(state, c.bc.CurrentBlock().Header(), c.bc, kvm.Config{})
+	require.NoError(t, err)
+	proposer, treasuryBalance := c.balance(c.vals[0]), c.balance(treasury)
+
+	// the chain finalizes both proposals at the target height
+	receipts = c.commit(target.Uint64(), c.tx(1, big.NewInt(0), "vote", big.NewInt(1), staking.VoteYes))
+	assert.Equal(t, types.ReceiptStatusFailed, receipts[0].Status)
+
+	p = c.proposal(0)
+	assert.Equal(t, staking.ProposalPassed, p.Status)
+	assert.Equal(t, newMinStake, c.param(paramMinStake))
+	assert.Equal(t, new(big.Int).Add(proposer, deposit), c.balance(c.vals[0]))
+
+	p = c.proposal(1)
+	assert.Equal(t, staking.ProposalRejected, p.Status)
+	assert.Equal(t, stake, p.No)
+	assert.Equal(t, oldMaxProposers, c.param(paramMaxProposers))
+	assert.True(t, c.balance(treasury).Cmp(new(big.Int).Add(treasuryBalance, deposit)) >= 0)
+	assert.Equal(t, 0, c.balance(c.util.Governance.ContractAddress).Sign())
+
+	state, err = c.bc.State()
+	require.NoError(t, err)
+	pending, err := gov.GetPendingProposals(state, c.bc.CurrentBlock().Header(), c.bc, kvm.Config{})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// finalize can only be called by the chain
+	receipts = c.commit(target.Uint64()+1, c.tx(0, big.NewInt(0), "finalize"))
+	assert.Equal(t, types.ReceiptStatusFailed, receipts[0].Status)
+}
+
+func TestGovernance_ParamBoundsAndRevote(t *testing.T) {
+	c := newGovernanceChain(t)
+	deposit := new(big.Int).Mul(big.NewInt(500000), big.NewInt(1e18))
+	target := big.NewInt(2 + 17280)
+	propose := func(key, value int64) *types.Transaction {
+		return c.tx(0, deposit, "propose", []*big.Int{big.NewInt(key)}, []*big.Int{big.NewInt(value)}, target)
+	}
+
+	// signedBlockWindow (7), Deposit (18) and unknown keys cannot be changed,
+	// maxProposers must stay in [1, 100]
+	receipts := c.commit(1, propose(7, 100), propose(18, 1), propose(20, 1), propose(paramMaxProposers, 0), propose(paramMaxProposers, 101), propose(paramMaxProposers, 100))
+	for _, receipt := range receipts[:5] {
+		assert.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	}
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipts[5].Status)
+
+	// voting again moves the weight of the vote to the new option
+	receipts = c.commit(2,
+		c.tx(0, big.NewInt(0), "vote", big.NewInt(0), staking.VoteYes),
+		c.tx(0, big.NewInt(0), "vote", big.NewInt(0), staking.VoteNo),
+	)
+	for _, receipt := range receipts {
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	}
+	stake, _ := new(big.Int).SetString(selfDelegate, 10)
+	p := c.proposal(0)
+	assert.Equal(t, 0, p.Yes.Sign())
+	assert.Equal(t, stake, p.No)
+
+	state, err := c.bc.State()
+	require.NoError(t, err)
+	voted, option, weight, err := c.util.Governance.GetVote(state, c.bc.CurrentBlock().Header(), c.bc, kvm.Config{}, big.NewInt(0), c.vals[0])
+	require.NoError(t, err)
+	assert.True(t, voted)
+	assert.Equal(t, staking.VoteNo, option)
+	assert.Equal(t, stake, weight)
+}
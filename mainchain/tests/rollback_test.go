@@ -0,0 +1,67 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/lib/common"
+	stypes "github.com/kardiachain/go-kardia/mainchain/staking/types"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestBlockChain_RollbackAboveHead(t *testing.T) {
+	c := newGovernanceChain(t)
+	lastCommit := stypes.LastCommitInfo{}
+	for _, val := range c.vals {
+		lastCommit.Votes = append(lastCommit.Votes, stypes.VoteInfo{Address: val, VotingPower: big.NewInt(votingPower), SignedLastBlock: true})
+	}
+	var blocks []*types.Block
+	for height := uint64(1); height <= 3; height++ {
+		block := types.NewBlock(&types.Header{
+			Height:   height,
+			Time:     time.Now(),
+			GasLimit: configs.BlockGasLimit,
+		}, nil, &types.Commit{}, nil)
+		c.bc.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), &types.Commit{})
+		_, _, err := c.bo.CommitAndValidateBlockTxs(block, lastCommit, nil)
+		require.NoError(t, err)
+		blocks = append(blocks, block)
+	}
+	// the node stopped after executing block 3 without saving its state
+	require.NoError(t, c.bc.RewindHead(2))
+	assert.Equal(t, uint64(3), c.bc.StoreHeight())
+
+	require.NoError(t, c.bc.Rollback(1))
+	assert.Equal(t, uint64(1), c.bc.CurrentBlock().Height())
+	assert.NotEqual(t, common.Hash{}, c.bc.DB().ReadAppHash(1))
+	assert.NotNil(t, c.bc.DB().ReadBlockInfo(blocks[0].Hash(), 1))
+	for _, block := range blocks[1:] {
+		assert.Equal(t, common.Hash{}, c.bc.DB().ReadAppHash(block.Height()), "height %d", block.Height())
+		assert.Nil(t, c.bc.DB().ReadBlockInfo(block.Hash(), block.Height()), "height %d", block.Height())
+	}
+	// the blocks are kept to be executed again
+	assert.Equal(t, uint64(3), c.bc.StoreHeight())
+}
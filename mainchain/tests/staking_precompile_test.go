@@ -0,0 +1,120 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tests
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/mainchain/staking"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestStakingPrecompile(t *testing.T) {
+	_, stateDB, stakingUtil, valUtil, block, err := setup()
+	require.NoError(t, err)
+	valAddr := common.HexToAddress("0x7cefC13B6E2aedEeDFB7Cb6c32457240746BAEe5")
+	require.NoError(t, stakingUtil.CreateGenesisValidator(stateDB, block.Header(), nil, kvm.Config{}, valAddr, "Val1", "10", "20", "1", selfDelegate))
+	valSmcAddr, err := stakingUtil.GetValSmcAddr(stateDB, block.Header(), nil, kvm.Config{}, big.NewInt(0))
+	require.NoError(t, err)
+
+	precompile, err := staking.NewPrecompile(stakingUtil, valUtil)
+	require.NoError(t, err)
+	kvm.RegisterPrecompiledContract(staking.PrecompileAddress, precompile, (*configs.ChainConfig).IsStakingPrecompile)
+	t.Cleanup(func() { kvm.UnregisterPrecompiledContract(staking.PrecompileAddress) })
+	activation := uint64(0)
+	chainConfig := &configs.ChainConfig{StakingPrecompileHeight: &activation}
+
+	precompileAbi, err := abi.JSON(strings.NewReader(staking.PrecompileABI))
+	require.NoError(t, err)
+	delAddr := common.HexToAddress("0x0000000000000000000000000000000000de1e9a")
+	amount := new(big.Int).Mul(big.NewInt(30000), big.NewInt(1e18))
+	stateDB.AddBalance(delAddr, new(big.Int).Mul(amount, big.NewInt(2)))
+
+	newKVM := func() *kvm.KVM {
+		msg := types.NewMessage(delAddr, &staking.PrecompileAddress, 0, big.NewInt(0), 10000000, big.NewInt(0), nil, false)
+		ctx := vm.NewKVMContext(msg, block.Header(), nil)
+		ctx.ChainConfig = chainConfig
+		return kvm.NewKVM(ctx, stateDB, kvm.Config{})
+	}
+	pack := func(method string, args ...interface{}) []byte {
+		input, err := precompileAbi.Pack(method, args...)
+		require.NoError(t, err)
+		return input
+	}
+
+	// the precompile has its own activation height
+	v1Only := newKVM()
+	v1Only.ChainConfig = &configs.ChainConfig{PrecompilesV1Height: &activation}
+	ret, _, err := v1Only.StaticCall(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("getValidators"), 10000000)
+	require.NoError(t, err)
+	assert.Empty(t, ret)
+
+	// query the validators
+	ret, _, err = newKVM().StaticCall(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("getValidators"), 10000000)
+	require.NoError(t, err)
+	out, err := precompileAbi.Unpack("getValidators", ret)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{valAddr}, out[0])
+	assert.Equal(t, selfDelegate, out[1].([]*big.Int)[0].String())
+	assert.Equal(t, []bool{false}, out[2])
+
+	rewards, err := valUtil.GetDelegationRewards(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, valAddr)
+	require.NoError(t, err)
+	ret, _, err = newKVM().StaticCall(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("pendingRewards", valAddr, valAddr), 10000000)
+	require.NoError(t, err)
+	assert.Equal(t, common.LeftPadBytes(rewards.Bytes(), 32), ret)
+
+	// delegate on behalf of the caller
+	_, _, err = newKVM().Call(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("delegate", valAddr), 10000000, amount)
+	require.NoError(t, err)
+	stake, err := valUtil.GetDelegatorStakedAmount(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, delAddr)
+	require.NoError(t, err)
+	assert.Equal(t, amount, stake)
+	assert.Equal(t, amount, stateDB.GetBalance(delAddr))
+	assert.Equal(t, 0, stateDB.GetBalance(staking.PrecompileAddress).Sign())
+
+	// undelegate the stake
+	_, _, err = newKVM().Call(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("undelegate", valAddr, amount), 10000000, big.NewInt(0))
+	require.NoError(t, err)
+	entries, err := valUtil.GetUBDEntries(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, delAddr)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, amount, entries[0].Amount)
+
+	// invalid calls
+	_, _, err = newKVM().StaticCall(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("delegate", valAddr), 10000000)
+	assert.Equal(t, kvm.ErrWriteProtection, err)
+	_, _, err = newKVM().Call(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("delegate", delAddr), 10000000, amount)
+	assert.Equal(t, kvm.ErrExecutionReverted, err)
+	_, _, err = newKVM().Call(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("withdrawRewards", valAddr), 10000000, amount)
+	assert.Equal(t, kvm.ErrExecutionReverted, err)
+	_, _, err = newKVM().DelegateCall(kvm.AccountRef(delAddr), staking.PrecompileAddress, pack("withdrawRewards", valAddr), 10000000)
+	assert.Equal(t, kvm.ErrStatefulPrecompileCode, err)
+	assert.Equal(t, amount, stateDB.GetBalance(delAddr))
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/mainchain/blockchain"
 	g "github.com/kardiachain/go-kardia/mainchain/genesis"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
 
 	"github.com/kardiachain/go-kardia/mainchain/staking"
 	"github.com/kardiachain/go-kardia/types"
@@ -78,7 +79,7 @@ func GetBlockchainStaking() (*blockchain.BlockChain, error, *state.StateDB) {
 	genesisContracts := make(map[string]string)
 	for key, contract := range configs.GetContracts() {
 		configs.LoadGenesisContract(key, contract.Address, contract.ByteCode, contract.ABI)
-		if key != configs.StakingContractKey {
+		if key != configs.StakingContractKey && key != configs.GovernanceContractKey {
 			genesisContracts[contract.Address] = contract.ByteCode
 		}
 	}
@@ -296,6 +297,81 @@ func TestGetValidatorsByDelegator(t *testing.T) {
 	assert.EqualValues(t, []common.Address{valSmcAddr}, valsAddrs)
 }
 
+func TestStakingQuery(t *testing.T) {
+	_, stateDB, util, valUtil, block, err := setup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := common.HexToAddress("0x7cefC13B6E2aedEeDFB7Cb6c32457240746BAEe5")
+	err = util.CreateGenesisValidator(stateDB, block.Header(), nil, kvm.Config{}, address, "Val1", "10", "20", "1", selfDelegate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valSmcAddr, err := util.GetValSmcAddr(stateDB, block.Header(), nil, kvm.Config{}, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegatorAddr := common.HexToAddress("0xfF3dac4f04dDbD24dE5D6039F90596F0a8bb08fd")
+	delAmount, _ := new(big.Int).SetString(selfDelegate, 10)
+	err = valUtil.Delegate(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, delegatorAddr, delAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := staking.NewStakingQuery(util, valUtil, stateDB, block.Header(), nil, kvm.Config{})
+	vals, err := query.Validators(false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, vals, 1)
+	assert.Equal(t, 2, vals[0].TotalDelegators)
+	assert.Empty(t, vals[0].Delegators)
+
+	// delegators of every validator are read in one batch
+	address1 := common.HexToAddress("0xc1fe56E3F58D3244F606306611a5d10c8333f1f6")
+	err = util.CreateGenesisValidator(stateDB, block.Header(), nil, kvm.Config{}, address1, "Val2", "10", "20", "1", minSelfDelegate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals, err = query.Validators(true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, vals, 2)
+	assert.Len(t, vals[0].Delegators, 2)
+	assert.Equal(t, delAmount.String(), vals[0].Delegators[1].StakedAmount.String())
+	assert.Equal(t, address1, vals[1].ValAddr)
+	assert.NotNil(t, vals[1].CommissionRate)
+	assert.Len(t, vals[1].Delegators, 1)
+	assert.Equal(t, address1, vals[1].Delegators[0].Address)
+	assert.Equal(t, minSelfDelegate, vals[1].Delegators[0].StakedAmount.String())
+
+	val, err := query.Validator(address, true, &staking.Pagination{Page: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, valSmcAddr, val.ValStakingSmc)
+	assert.Len(t, val.Delegators, 1)
+	assert.Equal(t, delegatorAddr, val.Delegators[0].Address)
+
+	delegators, err := query.Delegators(valSmcAddr, &staking.Pagination{Page: 2, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, delegators)
+
+	delegations, err := query.DelegatorInfo(delegatorAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, delegations, 1)
+	assert.Equal(t, address, delegations[0].ValAddr)
+	assert.Equal(t, valSmcAddr, delegations[0].ValStakingSmc)
+	assert.Equal(t, delAmount.String(), delegations[0].StakedAmount.String())
+	assert.Empty(t, delegations[0].UBDEntries)
+}
+
 func TestDoubleSign(t *testing.T) {
 	_, stateDB, util, valUtil, block, err := setup()
 	if err != nil {
@@ -327,3 +403,174 @@ func TestDoubleSign(t *testing.T) {
 	val, err = valUtil.GetInforValidator(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr)
 	assert.EqualValuesf(t, true, val.Jailed, "Double signed validator must be jailed")
 }
+
+func TestStakingEventRecorder(t *testing.T) {
+	_, stateDB, util, valUtil, block, err := setup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := common.HexToAddress("0x7cefC13B6E2aedEeDFB7Cb6c32457240746BAEe5")
+	err = util.CreateGenesisValidator(stateDB, block.Header(), nil, kvm.Config{}, address, "Val1", "10", "20", "1", selfDelegate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valSmcAddr, err := util.GetValFromOwner(stateDB, block.Header(), nil, kvm.Config{}, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := valUtil.StartValidator(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, address); err != nil {
+		t.Fatal(err)
+	}
+
+	header := block.Header()
+	header.Height = 2
+	header.ProposerAddress = address
+	evidence := stypes.Evidence{
+		Address:     address,
+		VotingPower: big.NewInt(1),
+		Height:      1,
+		Hash:        common.BytesToHash([]byte("evidence")),
+	}
+	recorder := staking.NewEventRecorder(util.ContractAddress, header.Height, []stypes.Evidence{evidence})
+	fee, err := util.Mint(stateDB, header, nil, kvm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Minted(fee)
+	lastCommit := stypes.LastCommitInfo{Votes: []stypes.VoteInfo{{Address: address, VotingPower: big.NewInt(1), SignedLastBlock: true}}}
+	if err := util.FinalizeCommit(stateDB, header, nil, recorder.Config(kvm.Config{}), lastCommit); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.DoubleSign(stateDB, header, nil, recorder.Config(kvm.Config{}), []stypes.Evidence{evidence}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := recorder.Events()
+	var kinds []string
+	for _, ev := range events {
+		kinds = append(kinds, ev.Type.String())
+		assert.Equal(t, header.Height, ev.Height)
+	}
+	assert.Equal(t, []string{"mint", "proposerReward", "reward", "slash", "jail"}, kinds)
+	assert.Equal(t, fee, events[0].Amount)
+	// there is no previous proposer at the first finalize
+	for _, ev := range events[2:] {
+		assert.Equal(t, valSmcAddr, ev.Validator)
+	}
+	assert.Equal(t, evidence.Hash, events[3].EvidenceHash)
+	assert.Equal(t, evidence.Hash, events[4].EvidenceHash)
+	assert.Equal(t, common.Hash{}, events[2].EvidenceHash)
+}
+
+// forwarderCode returns the code of a contract forwarding its calls and value
+// to target, then reverting if revert is set. Empty calls are accepted.
+func forwarderCode(target common.Address, revert bool) []byte {
+	code := common.FromHex("0x3615602e57" + // jump to the end on empty calldata
+		"3660006000376000600036600034") // copy calldata, push call args
+	code = append(append(append(code, 0x73), target.Bytes()...), 0x5a, 0xf1) // CALL target
+	if revert {
+		code = append(code, 0x60, 0x00, 0x80, 0xfd) // REVERT
+	} else {
+		code = append(code, 0x50, 0x00, 0x00, 0x00) // POP STOP
+	}
+	return append(code, 0x5b, 0x00)
+}
+
+func TestStakingEventRecorder_RevertedCall(t *testing.T) {
+	_, stateDB, util, valUtil, block, err := setup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the validator is owned by a contract forwarding calls of delAddr
+	owner := common.HexToAddress("0x00000000000000000000000000000000000f0a7d")
+	delAddr := common.HexToAddress("0x0000000000000000000000000000000000de1e9a")
+	selfStake, _ := new(big.Int).SetString(selfDelegate, 10)
+	stateDB.AddBalance(owner, selfStake)
+	if err := util.CreateGenesisValidator(stateDB, block.Header(), nil, kvm.Config{}, owner, "Val1", "10", "20", "1", selfDelegate); err != nil {
+		t.Fatal(err)
+	}
+	valSmcAddr, err := util.GetValFromOwner(stateDB, block.Header(), nil, kvm.Config{}, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := valUtil.StartValidator(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, owner); err != nil {
+		t.Fatal(err)
+	}
+	stateDB.SetCode(owner, forwarderCode(valSmcAddr, true))
+	amount := new(big.Int).Mul(big.NewInt(30000), big.NewInt(1e18))
+	stateDB.AddBalance(delAddr, amount)
+
+	recorder := staking.NewEventRecorder(util.ContractAddress, block.Height(), nil)
+	call := func(method string, value *big.Int) error {
+		input := append([]byte{}, valUtil.Abi.Methods[method].ID...)
+		msg := types.NewMessage(delAddr, &owner, 0, value, 10000000, big.NewInt(0), input, false)
+		vmenv := kvm.NewKVM(vm.NewKVMContext(msg, block.Header(), nil), stateDB, recorder.Config(kvm.Config{}))
+		_, _, err := vmenv.Call(kvm.AccountRef(delAddr), owner, input, 10000000, value)
+		return err
+	}
+	stake := func() string {
+		staked, err := valUtil.GetDelegatorStakedAmount(stateDB, block.Header(), nil, kvm.Config{}, valSmcAddr, owner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return staked.String()
+	}
+
+	// a reverted delegate and a reverted undelegate stopping the validator leave no event
+	assert.Equal(t, kvm.ErrExecutionReverted, call("delegate", amount))
+	assert.Equal(t, kvm.ErrExecutionReverted, call("undelegate", big.NewInt(0)))
+	assert.Empty(t, recorder.Events())
+	assert.Equal(t, selfDelegate, stake())
+	assert.Equal(t, amount, stateDB.GetBalance(delAddr))
+
+	// a successful undelegate taking the stake below the minimum stops the validator
+	stateDB.SetCode(owner, forwarderCode(valSmcAddr, false))
+	if err := call("undelegate", big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	// the event is only kept if the forwarded undelegate succeeded
+	events := recorder.Events()
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, types.StakingEventStop, events[0].Type)
+		assert.Equal(t, valSmcAddr, events[0].Validator)
+	}
+}
+
+func TestGovernanceUtil(t *testing.T) {
+	_, stateDB, util, _, block, err := setup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gov := util.Governance
+	if gov == nil {
+		t.Fatal("governance util is not created")
+	}
+	assert.Equal(t, common.HexToAddress(configs.DefaultGovernanceContractAddress), gov.ContractAddress)
+
+	// all methods used by helpers must exist in abi
+	for _, method := range []string{"propose", "vote", "finalize", "allProposals", "getPendingProposals", "getProposal", "getProposalResults", "getVote", "stakeOf"} {
+		if _, ok := gov.Abi.Methods[method]; !ok {
+			t.Fatalf("method %v is not found in governance abi", method)
+		}
+	}
+	if _, err := gov.Abi.Pack("propose", []*big.Int{big.NewInt(5)}, []*big.Int{big.NewInt(1000)}, big.NewInt(100000)); err != nil {
+		t.Fatal(err)
+	}
+
+	// governance is deployed at genesis without any proposal
+	assert.True(t, gov.IsDeployed(stateDB))
+	length, err := gov.GetAllProposalsLength(stateDB, block.Header(), nil, kvm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, length.Sign())
+
+	// governance is not deployed on chains without its code
+	stateDB.SetCode(gov.ContractAddress, nil)
+	assert.False(t, gov.IsDeployed(stateDB))
+	if _, err := gov.GetAllProposalsLength(stateDB, block.Header(), nil, kvm.Config{}); err != staking.ErrGovernanceNotDeployed {
+		t.Fatalf("expect %v got %v", staking.ErrGovernanceNotDeployed, err)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx_pool
+
+import (
+	"sync"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	// MaxBundleTxs is the maximum number of transactions of a bundle.
+	MaxBundleTxs = 16
+
+	// MaxBundleBlocks is how many blocks ahead of the chain head a bundle may
+	// target.
+	MaxBundleBlocks = 25
+
+	// maxBundlesPerHeight is the maximum number of bundles targeting the same
+	// height.
+	maxBundlesPerHeight = 64
+)
+
+// BundlePool keeps the bundles submitted to the node until the proposal of
+// the block they target. Bundles are never gossiped.
+type BundlePool struct {
+	chain  blockChain
+	signer types.Signer
+
+	mu      sync.Mutex
+	bundles map[uint64][]*types.Bundle // Bundles by target height, in arrival order
+	all     map[common.Hash]uint64     // Target height of every known bundle
+}
+
+// NewBundlePool creates a bundle pool accepting bundles for the blocks
+// following the head of the given chain.
+func NewBundlePool(chain blockChain) *BundlePool {
+	return &BundlePool{
+		chain:   chain,
+		signer:  types.HomesteadSigner{},
+		bundles: make(map[uint64][]*types.Bundle),
+		all:     make(map[common.Hash]uint64),
+	}
+}
+
+// Add validates a bundle and adds it to the pool. The transactions are only
+// checked statelessly, they are executed when the bundle is proposed.
+func (bp *BundlePool) Add(bundle *types.Bundle) error {
+	if len(bundle.Txs) == 0 {
+		return ErrBundleEmpty
+	}
+	if len(bundle.Txs) > MaxBundleTxs {
+		return ErrBundleTooLarge
+	}
+	head := bp.chain.CurrentBlock().Header()
+	if bundle.Height <= head.Height {
+		return ErrBundleExpired
+	}
+	if bundle.Height > head.Height+MaxBundleBlocks {
+		return ErrBundleTooFar
+	}
+	if bundle.Gas() > head.GasLimit {
+		return ErrGasLimit
+	}
+	for _, tx := range bundle.Txs {
+		if tx.Size() > txMaxSize {
+			return ErrOversizedData
+		}
+		if tx.Value().Sign() < 0 {
+			return ErrNegativeValue
+		}
+		if _, err := types.Sender(bp.signer, tx); err != nil {
+			return ErrInvalidSender
+		}
+	}
+
+	hash := bundle.Hash()
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.prune(head.Height)
+	if _, ok := bp.all[hash]; ok {
+		return ErrAlreadyKnown
+	}
+	if len(bp.bundles[bundle.Height]) >= maxBundlesPerHeight {
+		return ErrBundlePoolFull
+	}
+	bp.bundles[bundle.Height] = append(bp.bundles[bundle.Height], bundle)
+	bp.all[hash] = bundle.Height
+	return nil
+}
+
+// Bundles returns the bundles targeting the given height, in arrival order.
+// Bundles targeting lower heights are dropped.
+func (bp *BundlePool) Bundles(height uint64) []*types.Bundle {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if height > 0 {
+		bp.prune(height - 1)
+	}
+	return append([]*types.Bundle(nil), bp.bundles[height]...)
+}
+
+// Remove drops a bundle from the pool.
+func (bp *BundlePool) Remove(hash common.Hash) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	height, ok := bp.all[hash]
+	if !ok {
+		return
+	}
+	delete(bp.all, hash)
+	bundles := bp.bundles[height]
+	for i, bundle := range bundles {
+		if bundle.Hash() == hash {
+			bp.bundles[height] = append(bundles[:i:i], bundles[i+1:]...)
+			break
+		}
+	}
+	if len(bp.bundles[height]) == 0 {
+		delete(bp.bundles, height)
+	}
+}
+
+// Len returns the number of bundles in the pool.
+func (bp *BundlePool) Len() int {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	return len(bp.all)
+}
+
+// prune drops the bundles targeting the given height or lower.
+func (bp *BundlePool) prune(height uint64) {
+	for h, bundles := range bp.bundles {
+		if h > height {
+			continue
+		}
+		for _, bundle := range bundles {
+			delete(bp.all, bundle.Hash())
+		}
+		delete(bp.bundles, h)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx_pool
+
+import (
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestBundlePoolAdd(t *testing.T) {
+	pool := NewBundlePool(&testBlockChain{nil, 1000000, new(event.Feed)})
+	key, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		bundle *types.Bundle
+		err    error
+	}{
+		{&types.Bundle{Height: 1}, ErrBundleEmpty},
+		{&types.Bundle{Txs: make(types.Transactions, MaxBundleTxs+1), Height: 1}, ErrBundleTooLarge},
+		{&types.Bundle{Txs: types.Transactions{transaction(0, 100000, key)}, Height: 0}, ErrBundleExpired},
+		{&types.Bundle{Txs: types.Transactions{transaction(0, 100000, key)}, Height: MaxBundleBlocks + 1}, ErrBundleTooFar},
+		{&types.Bundle{Txs: types.Transactions{transaction(0, 600000, key), transaction(1, 600000, key)}, Height: 1}, ErrGasLimit},
+		{&types.Bundle{Txs: types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}, Height: 1}, nil},
+		{&types.Bundle{Txs: types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}, Height: 1}, ErrAlreadyKnown},
+	}
+	for i, tt := range tests {
+		if err := pool.Add(tt.bundle); err != tt.err {
+			t.Fatalf("test %d: add error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	if pool.Len() != 1 {
+		t.Fatalf("bundle count mismatch: have %d, want %d", pool.Len(), 1)
+	}
+}
+
+func TestBundlePoolOrdering(t *testing.T) {
+	pool := NewBundlePool(&testBlockChain{nil, 1000000, new(event.Feed)})
+	key, _ := crypto.GenerateKey()
+
+	var bundles []*types.Bundle
+	for i := 0; i < 3; i++ {
+		bundle := &types.Bundle{Txs: types.Transactions{transaction(uint64(i), 100000, key)}, Height: 2}
+		if err := pool.Add(bundle); err != nil {
+			t.Fatalf("failed to add bundle %d: %v", i, err)
+		}
+		bundles = append(bundles, bundle)
+	}
+	if err := pool.Add(&types.Bundle{Txs: types.Transactions{transaction(3, 100000, key)}, Height: 1}); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	// Bundles are returned in arrival order
+	have := pool.Bundles(2)
+	if len(have) != len(bundles) {
+		t.Fatalf("bundle count mismatch: have %d, want %d", len(have), len(bundles))
+	}
+	for i, bundle := range have {
+		if bundle.Hash() != bundles[i].Hash() {
+			t.Fatalf("bundle %d mismatch", i)
+		}
+	}
+	// Bundles targeting the previous heights are pruned
+	if pool.Len() != len(bundles) {
+		t.Fatalf("bundle count mismatch: have %d, want %d", pool.Len(), len(bundles))
+	}
+	// Removing keeps the order of the others
+	pool.Remove(bundles[1].Hash())
+	have = pool.Bundles(2)
+	if len(have) != 2 || have[0].Hash() != bundles[0].Hash() || have[1].Hash() != bundles[2].Hash() {
+		t.Fatalf("unexpected bundles after removal: %v", have)
+	}
+	if len(pool.Bundles(3)) != 0 || pool.Len() != 0 {
+		t.Fatalf("bundles not pruned: %d left", pool.Len())
+	}
+}
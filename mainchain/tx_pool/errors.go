@@ -76,6 +76,37 @@ var (
 	// making the transaction invalid, rather a DOS protection.
 	ErrOversizedData = errors.New("oversized data")
 
+	// ErrPrivateTxExpired is returned if the max block height of a private
+	// transaction is already reached.
+	ErrPrivateTxExpired = errors.New("private transaction max block height reached")
+
+	// ErrPrivateTxTooFar is returned if the max block height of a private
+	// transaction is more than MaxPrivateTxBlocks ahead of the chain head.
+	ErrPrivateTxTooFar = errors.New("private transaction max block height too far")
+
+	// ErrBundleEmpty is returned if a bundle has no transaction.
+	ErrBundleEmpty = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle has more than MaxBundleTxs
+	// transactions.
+	ErrBundleTooLarge = errors.New("too many transactions in bundle")
+
+	// ErrBundleExpired is returned if the target height of a bundle is already
+	// reached.
+	ErrBundleExpired = errors.New("bundle target height reached")
+
+	// ErrBundleTooFar is returned if the target height of a bundle is more
+	// than MaxBundleBlocks ahead of the chain head.
+	ErrBundleTooFar = errors.New("bundle target height too far")
+
+	// ErrBundlePoolFull is returned if the bundle pool can't take any more
+	// bundles for the target height.
+	ErrBundlePoolFull = errors.New("bundle pool full")
+
+	// ErrBundleNotValidator is returned if a bundle is sent to a node which
+	// isn't a validator, bundles aren't forwarded to the proposers.
+	ErrBundleNotValidator = errors.New("bundles are only accepted by validators, send it to the proposer of the target height")
+
 	// errNoActiveJournal is returned if a transaction is attempted to be inserted
 	// into the journal, but no such file is currently open.
 	errNoActiveJournal = errors.New("no active journal")
@@ -84,4 +115,5 @@ var (
 	errClosed            = errors.New("peer set is closed")
 	errAlreadyRegistered = errors.New("peer is already registered")
 	errNotRegistered     = errors.New("peer is not registered")
+	errSendFailed        = errors.New("failed to send message to peer")
 )
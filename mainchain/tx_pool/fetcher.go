@@ -0,0 +1,276 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx_pool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	// maxTxAnnounces is the maximum number of unknown transaction announcements
+	// tracked per peer, announcements above it are dropped (prevent DOS).
+	maxTxAnnounces = 4096
+
+	// maxTxRetrievals is the maximum number of transactions requested from a
+	// peer in a single request.
+	maxTxRetrievals = 256
+
+	// txArriveTimeout is the time allowance for an announced transaction to
+	// arrive through a direct broadcast before it's explicitly requested.
+	txArriveTimeout = 500 * time.Millisecond
+
+	// txFetchTimeout is the maximum time allowed for a requested transaction
+	// to arrive before it's requested from another announcer.
+	txFetchTimeout = 5 * time.Second
+
+	// txFetchInterval is how often the fetcher schedules requests and checks
+	// for timed out ones.
+	txFetchInterval = 100 * time.Millisecond
+)
+
+// txRequest is a transaction retrieval request in flight to a peer.
+type txRequest struct {
+	hashes map[common.Hash]struct{}
+	time   time.Time
+}
+
+// TxFetcher retrieves transactions announced by peers. Every announced hash
+// is requested from a single peer at a time, first waiting txArriveTimeout for
+// it to be broadcast anyway. Requests not answered within txFetchTimeout are
+// retried from another peer which announced the same hash.
+type TxFetcher struct {
+	logger log.Logger
+
+	mu        sync.Mutex
+	waiting   map[common.Hash]time.Time           // Announced hashes not requested yet, with their first announce time
+	announces map[common.Hash]map[p2p.ID]struct{} // Peers which announced each hash still unknown to us
+	announced map[p2p.ID]map[common.Hash]struct{} // Hashes announced by each peer, the reverse of announces
+	fetching  map[common.Hash]p2p.ID              // Hashes requested and the peer they're requested from
+	requests  map[p2p.ID]*txRequest               // In-flight request of each peer
+	hasTx     func(common.Hash) bool              // Callback checking whether a transaction is in the pool
+	addTxs    func([]*types.Transaction) []error  // Callback injecting retrieved transactions into the pool
+	fetchTxs  func(p2p.ID, []common.Hash) error   // Callback requesting transactions from a peer
+
+	quit chan struct{}
+}
+
+// NewTxFetcher creates a transaction fetcher retrieving announced transactions
+// through fetchTxs and handing them over to the pool through addTxs.
+func NewTxFetcher(logger log.Logger, hasTx func(common.Hash) bool, addTxs func([]*types.Transaction) []error,
+	fetchTxs func(p2p.ID, []common.Hash) error) *TxFetcher {
+	return &TxFetcher{
+		logger:    logger,
+		waiting:   make(map[common.Hash]time.Time),
+		announces: make(map[common.Hash]map[p2p.ID]struct{}),
+		announced: make(map[p2p.ID]map[common.Hash]struct{}),
+		fetching:  make(map[common.Hash]p2p.ID),
+		requests:  make(map[p2p.ID]*txRequest),
+		hasTx:     hasTx,
+		addTxs:    addTxs,
+		fetchTxs:  fetchTxs,
+		quit:      make(chan struct{}),
+	}
+}
+
+// Start starts the scheduling loop of the fetcher.
+func (f *TxFetcher) Start() {
+	go f.loop()
+}
+
+// Stop terminates the scheduling loop of the fetcher.
+func (f *TxFetcher) Stop() {
+	close(f.quit)
+}
+
+func (f *TxFetcher) loop() {
+	ticker := time.NewTicker(txFetchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			f.schedule(now)
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// Notify records the transaction hashes announced by a peer. Hashes already
+// in the pool are ignored.
+func (f *TxFetcher) Notify(peer p2p.ID, hashes []common.Hash) {
+	now := time.Now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, hash := range hashes {
+		if f.hasTx(hash) {
+			continue
+		}
+		if _, ok := f.announces[hash][peer]; ok {
+			continue
+		}
+		if len(f.announced[peer]) >= maxTxAnnounces {
+			f.logger.Debug("Dropping transaction announcements", "peer", peer, "count", len(hashes))
+			return
+		}
+		if f.announces[hash] == nil {
+			f.announces[hash] = make(map[p2p.ID]struct{})
+		}
+		f.announces[hash][peer] = struct{}{}
+		if f.announced[peer] == nil {
+			f.announced[peer] = make(map[common.Hash]struct{})
+		}
+		f.announced[peer][hash] = struct{}{}
+
+		if _, ok := f.fetching[hash]; ok {
+			continue
+		}
+		if _, ok := f.waiting[hash]; !ok {
+			f.waiting[hash] = now
+		}
+	}
+}
+
+// Enqueue injects the transactions received from a peer, either broadcast or
+// requested, into the pool and stops tracking them. It returns the results of
+// the pool insertion.
+func (f *TxFetcher) Enqueue(peer p2p.ID, txs []*types.Transaction) []error {
+	errs := f.addTxs(txs)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, tx := range txs {
+		f.forget(tx.Hash())
+	}
+	return errs
+}
+
+// Drop stops tracking the announcements of a peer, its in-flight hashes are
+// rescheduled to the other announcers.
+func (f *TxFetcher) Drop(peer p2p.ID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req := f.requests[peer]; req != nil {
+		delete(f.requests, peer)
+		for hash := range req.hashes {
+			delete(f.fetching, hash)
+			f.waiting[hash] = time.Time{}
+		}
+	}
+	for hash := range f.announced[peer] {
+		f.unannounce(peer, hash)
+	}
+	delete(f.announced, peer)
+}
+
+// schedule retries the requests timed out at now and requests the hashes
+// which waited long enough for a broadcast.
+func (f *TxFetcher) schedule(now time.Time) {
+	f.mu.Lock()
+
+	// Give the hashes of timed out requests to the other announcers
+	for peer, req := range f.requests {
+		if now.Sub(req.time) < txFetchTimeout {
+			continue
+		}
+		f.logger.Debug("Transaction request timed out", "peer", peer, "count", len(req.hashes))
+		delete(f.requests, peer)
+		for hash := range req.hashes {
+			delete(f.fetching, hash)
+			f.waiting[hash] = time.Time{}
+			f.unannounce(peer, hash)
+			delete(f.announced[peer], hash)
+		}
+	}
+	// Assign every hash due to an idle announcer
+	batches := make(map[p2p.ID][]common.Hash)
+	for hash, announced := range f.waiting {
+		if now.Sub(announced) < txArriveTimeout {
+			continue
+		}
+		if f.hasTx(hash) {
+			f.forget(hash)
+			continue
+		}
+		for peer := range f.announces[hash] {
+			if _, busy := f.requests[peer]; busy || len(batches[peer]) >= maxTxRetrievals {
+				continue
+			}
+			batches[peer] = append(batches[peer], hash)
+			delete(f.waiting, hash)
+			f.fetching[hash] = peer
+			break
+		}
+	}
+	for peer, hashes := range batches {
+		req := &txRequest{hashes: make(map[common.Hash]struct{}, len(hashes)), time: now}
+		for _, hash := range hashes {
+			req.hashes[hash] = struct{}{}
+		}
+		f.requests[peer] = req
+	}
+	f.mu.Unlock()
+
+	for peer, hashes := range batches {
+		if err := f.fetchTxs(peer, hashes); err != nil {
+			f.logger.Debug("Failed to request transactions", "peer", peer, "count", len(hashes), "err", err)
+		}
+	}
+}
+
+// forget stops tracking a hash which is known to the pool now. The request it
+// was part of is done once all its hashes arrived.
+func (f *TxFetcher) forget(hash common.Hash) {
+	delete(f.waiting, hash)
+	if peer, ok := f.fetching[hash]; ok {
+		delete(f.fetching, hash)
+		if req := f.requests[peer]; req != nil {
+			delete(req.hashes, hash)
+			if len(req.hashes) == 0 {
+				delete(f.requests, peer)
+			}
+		}
+	}
+	for peer := range f.announces[hash] {
+		delete(f.announced[peer], hash)
+	}
+	delete(f.announces, hash)
+}
+
+// unannounce removes a peer from the announcers of a hash, the hash is
+// forgotten once no announcer is left. The caller updates announced.
+func (f *TxFetcher) unannounce(peer p2p.ID, hash common.Hash) {
+	delete(f.announces[hash], peer)
+	if len(f.announces[hash]) > 0 {
+		return
+	}
+	delete(f.announces, hash)
+	if _, ok := f.fetching[hash]; !ok {
+		delete(f.waiting, hash)
+	}
+}
@@ -23,6 +23,10 @@ const (
 	// This is the target size for the packs of transactions sent while broadcasting transactions.
 	// A pack can get larger than this if a single transactions exceeds this size.
 	txsyncPackSize = 100 * 1024
+
+	// maxTxAnnounceBatch is the maximum number of transaction hashes sent in
+	// a single announcement.
+	maxTxAnnounceBatch = 4096
 )
 
 // max is a helper function which returns the larger of the two given integers.
@@ -50,6 +54,7 @@ type peer struct {
 
 	knownTxs    mapset.Set                           // Set of transaction hashes known to be known by this peer
 	txBroadcast chan []common.Hash                   // Channel used to queue transaction propagation requests
+	txAnnounce  chan []common.Hash                   // Channel used to queue transaction announcement requests
 	getPooledTx func(common.Hash) *types.Transaction // Callback used to retrieve transaction from txpool
 
 	terminated chan struct{} // Termination channel, close when peer close to stop the broadcast loop routine.
@@ -63,13 +68,15 @@ func newPeer(logger log.Logger, p p2p.Peer, getPooledTx func(hash common.Hash) *
 		peer:        p,
 		knownTxs:    mapset.NewSet(),
 		txBroadcast: make(chan []common.Hash),
+		txAnnounce:  make(chan []common.Hash),
 		getPooledTx: getPooledTx,
 		terminated:  make(chan struct{}),
 	}
 }
 
-// close signals the broadcast goroutine to terminate.
+// close signals the broadcast and announce goroutines to terminate.
 func (p *peer) close() {
+	close(p.terminated)
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
@@ -118,6 +125,7 @@ func (ps *peerSet) Register(p *peer) error {
 	}
 	ps.peers[p.id] = p
 	go p.broadcastTransactions()
+	go p.announceTransactions()
 
 	return nil
 }
@@ -239,22 +247,15 @@ func (ps *peerSet) PeersWithoutTx(hash common.Hash) []*peer {
 
 // SendTransactions sends transactions to the peer, adds the txn hashes to known txn set.
 func (p *peer) sendTransactions(txs types.Transactions) error {
-	// If we reached the memory allowance, drop a previously known transaction hash
-	for p.knownTxs.Cardinality() > max(0, maxKnownTxs-len(txs)) {
-		p.knownTxs.Pop()
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
 	}
+	p.markTransactions(hashes)
 
-	encoded := make([][]byte, len(txs))
-	for idx, tx := range txs {
-		txBytes, err := rlp.EncodeToBytes(tx)
-		if err != nil {
-			panic(err)
-		}
-		encoded[idx] = txBytes
-	}
 	msg := prototx.Message{
 		Sum: &prototx.Message_Txs{
-			Txs: &prototx.Txs{Txs: encoded},
+			Txs: &prototx.Txs{Txs: encodeTxs(txs)},
 		},
 	}
 	bz, err := msg.Marshal()
@@ -272,14 +273,149 @@ func (p *peer) AsyncSendTransactions(hashes []common.Hash) {
 	// Tx will be actually sent in SendTransactions() trigger by broadcast() routine
 	select {
 	case p.txBroadcast <- hashes:
-		// Mark all the transactions as known, but ensure we don't overflow our limits
-		for p.knownTxs.Cardinality() > max(0, maxKnownTxs-len(hashes)) {
-			p.knownTxs.Pop()
+		p.markTransactions(hashes)
+	case <-p.terminated:
+		p.logger.Debug("Dropping transaction propagation", "count", len(hashes))
+	}
+}
+
+// announceTransactions is a async write loop that announces tx hashes to
+// remote peers.
+func (p *peer) announceTransactions() {
+	var (
+		queue []common.Hash         // Queue of hashes to announce
+		done  chan struct{}         // Non-nil if background announcer is running
+		fail  = make(chan error, 1) // Channel used to receive network error
+	)
+	for {
+		// If there's no in-flight announce running, check if a new one is needed
+		if done == nil && len(queue) > 0 {
+			// Pile hashes of transactions still in the pool up to the batch limit
+			var (
+				count   int
+				pending []common.Hash
+			)
+			for ; count < len(queue) && len(pending) < maxTxAnnounceBatch; count++ {
+				if p.getPooledTx(queue[count]) != nil {
+					pending = append(pending, queue[count])
+				}
+			}
+			queue = queue[:copy(queue, queue[count:])]
+
+			if len(pending) > 0 {
+				done = make(chan struct{})
+				go func() {
+					if err := p.sendPooledTransactionHashes(pending); err != nil {
+						p.logger.Error("Announce txs failed", "err", err, "count", len(pending), "peer", p.id)
+						fail <- err
+						return
+					}
+					close(done)
+					p.logger.Trace("Announced transactions", "count", len(pending))
+				}()
+			}
 		}
-		for _, hash := range hashes {
-			p.knownTxs.Add(hash)
+		select {
+		case hashes := <-p.txAnnounce:
+			queue = append(queue, hashes...)
+			if len(queue) > maxQueuedTxs {
+				queue = queue[:copy(queue, queue[len(queue)-maxQueuedTxs:])]
+			}
+		case <-done:
+			done = nil
+		case <-fail:
+			// The announcements are lost, the peer may still request them
+			// once they reach it through another peer.
+			done = nil
+		case <-p.terminated:
+			return
 		}
+	}
+}
+
+// sendPooledTransactionHashes announces transaction hashes to the peer, adds
+// them to the known txn set.
+func (p *peer) sendPooledTransactionHashes(hashes []common.Hash) error {
+	p.markTransactions(hashes)
+	msg := prototx.Message{
+		Sum: &prototx.Message_AnnounceTxs{
+			AnnounceTxs: &prototx.AnnounceTxs{Hashes: hashesToBytes(hashes)},
+		},
+	}
+	return p.send(msg)
+}
+
+// AsyncSendPooledTransactionHashes queues a list of transaction hashes to be
+// announced to a remote peer. If the peer's announce queue is full, the event
+// is silently dropped.
+func (p *peer) AsyncSendPooledTransactionHashes(hashes []common.Hash) {
+	select {
+	case p.txAnnounce <- hashes:
+		p.markTransactions(hashes)
 	case <-p.terminated:
-		p.logger.Debug("Dropping transaction propagation", "count", len(hashes))
+		p.logger.Debug("Dropping transaction announcement", "count", len(hashes))
+	}
+}
+
+// requestTransactions asks the peer for the transactions with the given hashes.
+func (p *peer) requestTransactions(hashes []common.Hash) error {
+	msg := prototx.Message{
+		Sum: &prototx.Message_RequestTxs{
+			RequestTxs: &prototx.RequestTxs{Hashes: hashesToBytes(hashes)},
+		},
+	}
+	return p.send(msg)
+}
+
+// sendPrivateTransactions forwards private transactions to the peer. They are
+// not marked as known, a private transaction is never broadcast anyway.
+func (p *peer) sendPrivateTransactions(txs types.Transactions, maxHeight uint64) error {
+	msg := prototx.Message{
+		Sum: &prototx.Message_PrivateTxs{
+			PrivateTxs: &prototx.PrivateTxs{Txs: encodeTxs(txs), MaxHeight: maxHeight},
+		},
+	}
+	return p.send(msg)
+}
+
+// markTransactions marks transactions as known by the peer, ensuring the set
+// doesn't overflow its limit.
+func (p *peer) markTransactions(hashes []common.Hash) {
+	for p.knownTxs.Cardinality() > max(0, maxKnownTxs-len(hashes)) {
+		p.knownTxs.Pop()
+	}
+	for _, hash := range hashes {
+		p.knownTxs.Add(hash)
+	}
+}
+
+func (p *peer) send(msg prototx.Message) error {
+	bz, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	if !p.peer.Send(TxpoolChannel, bz) {
+		return errSendFailed
+	}
+	return nil
+}
+
+func encodeTxs(txs types.Transactions) [][]byte {
+	encoded := make([][]byte, len(txs))
+	for idx, tx := range txs {
+		txBytes, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			panic(err)
+		}
+		encoded[idx] = txBytes
+	}
+	return encoded
+}
+
+func hashesToBytes(hashes []common.Hash) [][]byte {
+	encoded := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		encoded[i] = hash.Bytes()
 	}
+	return encoded
 }
@@ -1,13 +1,17 @@
 package tx_pool
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 
 	"github.com/kardiachain/go-kardia/kai/events"
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/lib/behaviour"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/lib/rlp"
 	prototx "github.com/kardiachain/go-kardia/proto/kardiachain/txpool"
@@ -34,7 +38,13 @@ type Reactor struct {
 	txsCh  chan events.NewTxsEvent
 	txsSub event.Subscription
 
-	peers *peerSet
+	peers    *peerSet
+	fetcher  *TxFetcher
+	reporter behaviour.Reporter
+
+	// consensus state and validator key used to route private txs
+	stateDB cstate.Store
+	privVal types.PrivValidator
 }
 
 // NewReactor returns a new Reactor with the given config and txpool.
@@ -45,11 +55,27 @@ func NewReactor(config TxPoolConfig, txpool *TxPool) *Reactor {
 		peers:  newPeerSet(),
 	}
 	txR.BaseReactor = *p2p.NewBaseReactor("txpool", txR)
+	txR.fetcher = NewTxFetcher(txR.Logger, txpool.Has, txpool.AddRemotes, txR.requestTransactions)
 	return txR
 }
 
+// SetLogger implements service.Service.
+func (txR *Reactor) SetLogger(l log.Logger) {
+	txR.BaseService.SetLogger(l)
+	txR.fetcher.logger = l
+}
+
+// SetValidatorState sets the consensus state store and the private validator
+// used to route private txs to the proposers.
+func (txR *Reactor) SetValidatorState(stateDB cstate.Store, privVal types.PrivValidator) {
+	txR.stateDB = stateDB
+	txR.privVal = privVal
+}
+
 // OnStart implements p2p.BaseReactor.
 func (txR *Reactor) OnStart() error {
+	txR.reporter = behaviour.NewSwitchReporter(txR.Switch)
+	txR.fetcher.Start()
 	if !txR.config.Broadcast {
 		txR.Logger.Info("Tx broadcasting is disabled")
 		return nil
@@ -74,7 +100,7 @@ func (txR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 // AddPeer implements Reactor.
 // It starts a broadcast routine ensuring all txs are forwarded to the given peer.
 func (txR *Reactor) AddPeer(peer p2p.Peer) error {
-	if err := txR.peers.Register(newPeer(txR.Logger, peer, txR.txpool.Get)); err != nil {
+	if err := txR.peers.Register(newPeer(txR.Logger, peer, txR.getPublicTx)); err != nil {
 		txR.Logger.Error("register peer err: %s", err)
 		return err
 	}
@@ -83,6 +109,7 @@ func (txR *Reactor) AddPeer(peer p2p.Peer) error {
 
 // RemovePeer implements Reactor.
 func (txR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) error {
+	txR.fetcher.Drop(peer.ID())
 	if err := txR.peers.Unregister(peer.ID()); err != nil {
 		txR.Logger.Error("unregister peer err: %s", err)
 		return err
@@ -91,17 +118,153 @@ func (txR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) error {
 	return nil
 }
 
+// requestTransactions asks a peer for announced transactions on behalf of
+// the fetcher.
+func (txR *Reactor) requestTransactions(id p2p.ID, hashes []common.Hash) error {
+	p := txR.peers.Peer(id)
+	if p == nil {
+		return errNotRegistered
+	}
+	return p.requestTransactions(hashes)
+}
+
 // Receive implements Reactor.
-// It adds any received transactions to the txpool.
+// It adds any received transactions to the txpool, fetches the announced ones
+// and answers transaction requests from the txpool.
 func (txR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		txR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		txR.Switch.StopPeerForError(src, err)
+		_ = txR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 	txR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
-	txR.txpool.AddRemotes(msg.Txs)
+
+	switch msg := msg.(type) {
+	case *TxsMessage:
+		if p := txR.peers.Peer(src.ID()); p != nil {
+			hashes := make([]common.Hash, len(msg.Txs))
+			for i, tx := range msg.Txs {
+				hashes[i] = tx.Hash()
+			}
+			p.markTransactions(hashes)
+		}
+		txR.reportTxs(src, txR.fetcher.Enqueue(src.ID(), msg.Txs))
+	case *AnnounceTxsMessage:
+		if p := txR.peers.Peer(src.ID()); p != nil {
+			p.markTransactions(msg.Hashes)
+		}
+		txR.fetcher.Notify(src.ID(), msg.Hashes)
+	case *RequestTxsMessage:
+		p := txR.peers.Peer(src.ID())
+		if p == nil {
+			return
+		}
+		var (
+			txs  types.Transactions
+			size common.StorageSize
+		)
+		for _, hash := range msg.Hashes {
+			if size >= txsyncPackSize {
+				break
+			}
+			if tx := txR.getPublicTx(hash); tx != nil {
+				txs = append(txs, tx)
+				size += tx.Size()
+			}
+		}
+		if len(txs) > 0 {
+			_ = p.sendTransactions(txs)
+		}
+	case *PrivateTxsMessage:
+		// the peer may not know yet that this node left the validator set
+		if !txR.IsValidator() {
+			return
+		}
+		errs := make([]error, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			errs[i] = txR.txpool.AddPrivate(tx, msg.MaxHeight)
+		}
+		txR.reportTxs(src, errs)
+	}
+}
+
+// getPublicTx returns a tx of the txpool which may be gossiped, nil for
+// unknown and private txs.
+func (txR *Reactor) getPublicTx(hash common.Hash) *types.Transaction {
+	if txR.txpool.IsPrivate(hash) {
+		return nil
+	}
+	return txR.txpool.Get(hash)
+}
+
+// SendPrivateTxs forwards private txs to the proposers of the current and next
+// heights, which must be connected peers unless it's this node. It returns the
+// number of proposers reached.
+func (txR *Reactor) SendPrivateTxs(txs types.Transactions, maxHeight uint64) int {
+	if txR.stateDB == nil {
+		return 0
+	}
+	var reached int
+	for _, proposer := range proposers(txR.stateDB.Load()) {
+		if txR.privVal != nil && proposer.Equal(txR.privVal.GetAddress()) {
+			reached++
+			continue
+		}
+		p := txR.peers.Peer(p2p.ID(hex.EncodeToString(proposer.Bytes())))
+		if p == nil {
+			txR.Logger.Debug("Proposer not connected, private txs not forwarded", "proposer", proposer)
+			continue
+		}
+		if err := p.sendPrivateTransactions(txs, maxHeight); err != nil {
+			txR.Logger.Debug("Failed to forward private txs", "proposer", proposer, "err", err)
+			continue
+		}
+		reached++
+	}
+	return reached
+}
+
+// IsValidator returns whether this node is a validator of the current or next
+// heights, which private txs may be forwarded to.
+func (txR *Reactor) IsValidator() bool {
+	if txR.stateDB == nil || txR.privVal == nil {
+		return false
+	}
+	state := txR.stateDB.Load()
+	addr := txR.privVal.GetAddress()
+	return (state.Validators != nil && state.Validators.HasAddress(addr)) ||
+		(state.NextValidators != nil && state.NextValidators.HasAddress(addr))
+}
+
+// proposers returns the distinct proposers of the current and next heights.
+func proposers(state cstate.LastestBlockState) []common.Address {
+	var addrs []common.Address
+	for _, vals := range []*types.ValidatorSet{state.Validators, state.NextValidators} {
+		if vals == nil || vals.Size() == 0 {
+			continue
+		}
+		addr := vals.GetProposer().Address
+		if len(addrs) == 0 || !addrs[0].Equal(addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// reportTxs raises the trust of a peer sending new txs. Rejected txs are not
+// reported, the pool rules and the account state of the peer may differ from
+// ours (known, underpriced, bad nonce, gas limit...) without it misbehaving.
+func (txR *Reactor) reportTxs(src p2p.Peer, errs []error) {
+	added := 0
+	for _, err := range errs {
+		if err == nil {
+			added++
+		}
+	}
+	if added > 0 {
+		_ = txR.reporter.Report(behaviour.UsefulMessage(src.ID(), fmt.Sprintf("%d new txs", added)))
+	}
 }
 
 // PeerState describes the state of a peer.
@@ -111,7 +274,6 @@ type PeerState interface {
 
 // Send new txpool txs to peer.
 func (txR *Reactor) broadcastTxRoutine() {
-	txset := make(map[*peer][]common.Hash)
 	txR.txsCh = make(chan events.NewTxsEvent, txChanSize)
 	txR.txsSub = txR.txpool.SubscribeNewTxsEvent(txR.txsCh)
 	for {
@@ -122,20 +284,7 @@ func (txR *Reactor) broadcastTxRoutine() {
 
 		select {
 		case txEvent := <-txR.txsCh:
-			for _, tx := range txEvent.Txs {
-				peers := txR.peers.PeersWithoutTx(tx.Hash())
-
-				// Send the txset to a subset of our peers
-				subset := peers[:int(math.Sqrt(float64(len(peers))))]
-				for _, peer := range subset {
-					txset[peer] = append(txset[peer], tx.Hash())
-				}
-				txR.Logger.Trace("Broadcast transaction", "hash", tx.Hash(), "recipients", len(peers))
-			}
-			for peer, hashes := range txset {
-				// only send to validators
-				peer.AsyncSendTransactions(hashes)
-			}
+			txR.broadcastTxs(txEvent.Txs)
 		case <-txR.txsSub.Err():
 			return
 		}
@@ -143,7 +292,39 @@ func (txR *Reactor) broadcastTxRoutine() {
 
 }
 
+// broadcastTxs sends the given txs to a square root subset of the peers not
+// knowing them yet and announces their hashes to the rest.
+func (txR *Reactor) broadcastTxs(txs types.Transactions) {
+	var (
+		txset   = make(map[*peer][]common.Hash)
+		annoset = make(map[*peer][]common.Hash)
+	)
+	for _, tx := range txs {
+		if txR.txpool.IsPrivate(tx.Hash()) {
+			continue
+		}
+		peers := txR.peers.PeersWithoutTx(tx.Hash())
+
+		// Send the txset to a subset of our peers
+		subset := int(math.Sqrt(float64(len(peers))))
+		for _, peer := range peers[:subset] {
+			txset[peer] = append(txset[peer], tx.Hash())
+		}
+		for _, peer := range peers[subset:] {
+			annoset[peer] = append(annoset[peer], tx.Hash())
+		}
+		txR.Logger.Trace("Broadcast transaction", "hash", tx.Hash(), "recipients", len(peers))
+	}
+	for peer, hashes := range txset {
+		peer.AsyncSendTransactions(hashes)
+	}
+	for peer, hashes := range annoset {
+		peer.AsyncSendPooledTransactionHashes(hashes)
+	}
+}
+
 func (txR *Reactor) OnStop() {
+	txR.fetcher.Stop()
 	if txR.txsSub != nil {
 		txR.txsSub.Unsubscribe()
 	}
@@ -152,38 +333,72 @@ func (txR *Reactor) OnStop() {
 //-----------------------------------------------------------------------------
 // Messages
 
-func decodeMsg(bz []byte) (TxsMessage, error) {
+// Message is a message sent or received by the Reactor.
+type Message interface {
+	ValidateBasic() error
+}
+
+func decodeMsg(bz []byte) (Message, error) {
 	msg := prototx.Message{}
 	err := msg.Unmarshal(bz)
 	if err != nil {
-		return TxsMessage{}, err
+		return nil, err
 	}
 
-	var message TxsMessage
-
-	if i, ok := msg.Sum.(*prototx.Message_Txs); ok {
-		txs := i.Txs.GetTxs()
-
-		if len(txs) == 0 {
-			return message, errors.New("empty TxsMessage")
+	var message Message
+	switch m := msg.Sum.(type) {
+	case *prototx.Message_Txs:
+		txs, err := decodeTxs(m.Txs.GetTxs())
+		if err != nil {
+			return nil, err
 		}
+		message = &TxsMessage{Txs: txs}
+	case *prototx.Message_AnnounceTxs:
+		hashes, err := bytesToHashes(m.AnnounceTxs.GetHashes())
+		if err != nil {
+			return nil, err
+		}
+		message = &AnnounceTxsMessage{Hashes: hashes}
+	case *prototx.Message_RequestTxs:
+		hashes, err := bytesToHashes(m.RequestTxs.GetHashes())
+		if err != nil {
+			return nil, err
+		}
+		message = &RequestTxsMessage{Hashes: hashes}
+	case *prototx.Message_PrivateTxs:
+		txs, err := decodeTxs(m.PrivateTxs.GetTxs())
+		if err != nil {
+			return nil, err
+		}
+		message = &PrivateTxsMessage{Txs: txs, MaxHeight: m.PrivateTxs.GetMaxHeight()}
+	default:
+		return nil, fmt.Errorf("msg type: %T is not supported", msg.Sum)
+	}
+	return message, message.ValidateBasic()
+}
 
-		decoded := make([]*types.Transaction, len(txs))
-		for j, txBytes := range txs {
-			tx := &types.Transaction{}
-			if err := rlp.DecodeBytes(txBytes, tx); err != nil {
-				return message, err
-			}
-
-			decoded[j] = tx
+func decodeTxs(txs [][]byte) ([]*types.Transaction, error) {
+	decoded := make([]*types.Transaction, len(txs))
+	for j, txBytes := range txs {
+		tx := &types.Transaction{}
+		if err := rlp.DecodeBytes(txBytes, tx); err != nil {
+			return nil, err
 		}
 
-		message = TxsMessage{
-			Txs: decoded,
+		decoded[j] = tx
+	}
+	return decoded, nil
+}
+
+func bytesToHashes(bzs [][]byte) ([]common.Hash, error) {
+	hashes := make([]common.Hash, len(bzs))
+	for i, bz := range bzs {
+		if len(bz) != common.HashLength {
+			return nil, fmt.Errorf("invalid hash length: %d", len(bz))
 		}
-		return message, nil
+		hashes[i] = common.BytesToHash(bz)
 	}
-	return message, fmt.Errorf("msg type: %T is not supported", msg)
+	return hashes, nil
 }
 
 //-------------------------------------
@@ -193,7 +408,81 @@ type TxsMessage struct {
 	Txs []*types.Transaction
 }
 
+// ValidateBasic implements Message.
+func (m *TxsMessage) ValidateBasic() error {
+	if len(m.Txs) == 0 {
+		return errors.New("empty TxsMessage")
+	}
+	return nil
+}
+
 // String returns a string representation of the TxsMessage.
 func (m *TxsMessage) String() string {
 	return fmt.Sprintf("[TxsMessage %v]", m.Txs)
 }
+
+// AnnounceTxsMessage is a Message announcing the hashes of transactions
+// available from the sender.
+type AnnounceTxsMessage struct {
+	Hashes []common.Hash
+}
+
+// ValidateBasic implements Message.
+func (m *AnnounceTxsMessage) ValidateBasic() error {
+	if len(m.Hashes) == 0 {
+		return errors.New("empty AnnounceTxsMessage")
+	}
+	if len(m.Hashes) > maxTxAnnounceBatch {
+		return fmt.Errorf("too many hashes announced: %d > %d", len(m.Hashes), maxTxAnnounceBatch)
+	}
+	return nil
+}
+
+// String returns a string representation of the AnnounceTxsMessage.
+func (m *AnnounceTxsMessage) String() string {
+	return fmt.Sprintf("[AnnounceTxsMessage %d hashes]", len(m.Hashes))
+}
+
+// RequestTxsMessage is a Message requesting transactions by hash.
+type RequestTxsMessage struct {
+	Hashes []common.Hash
+}
+
+// ValidateBasic implements Message.
+func (m *RequestTxsMessage) ValidateBasic() error {
+	if len(m.Hashes) == 0 {
+		return errors.New("empty RequestTxsMessage")
+	}
+	if len(m.Hashes) > maxTxRetrievals {
+		return fmt.Errorf("too many hashes requested: %d > %d", len(m.Hashes), maxTxRetrievals)
+	}
+	return nil
+}
+
+// String returns a string representation of the RequestTxsMessage.
+func (m *RequestTxsMessage) String() string {
+	return fmt.Sprintf("[RequestTxsMessage %d hashes]", len(m.Hashes))
+}
+
+// PrivateTxsMessage is a Message forwarding private transactions to a
+// proposer.
+type PrivateTxsMessage struct {
+	Txs       []*types.Transaction
+	MaxHeight uint64
+}
+
+// ValidateBasic implements Message.
+func (m *PrivateTxsMessage) ValidateBasic() error {
+	if len(m.Txs) == 0 {
+		return errors.New("empty PrivateTxsMessage")
+	}
+	if m.MaxHeight == 0 {
+		return errors.New("PrivateTxsMessage without max height")
+	}
+	return nil
+}
+
+// String returns a string representation of the PrivateTxsMessage.
+func (m *PrivateTxsMessage) String() string {
+	return fmt.Sprintf("[PrivateTxsMessage %v max height %d]", m.Txs, m.MaxHeight)
+}
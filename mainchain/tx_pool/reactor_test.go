@@ -0,0 +1,375 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx_pool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/kai/state/cstate"
+	"github.com/kardiachain/go-kardia/kai/state/cstate/mocks"
+	"github.com/kardiachain/go-kardia/lib/behaviour"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/p2p/mock"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	prototx "github.com/kardiachain/go-kardia/proto/kardiachain/txpool"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// testPeer is a mock peer recording the txpool messages sent to it.
+type testPeer struct {
+	*mock.Peer
+	sent chan Message
+}
+
+func newTestPeer() *testPeer {
+	return &testPeer{Peer: mock.NewPeer(nil), sent: make(chan Message, 16)}
+}
+
+func (tp *testPeer) Send(chID byte, msgBytes []byte) bool {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	tp.sent <- msg
+	return true
+}
+
+// expect waits for the next message sent to the peer.
+func (tp *testPeer) expect(t *testing.T) Message {
+	t.Helper()
+	select {
+	case msg := <-tp.sent:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("no message sent to %s", tp.ID())
+		return nil
+	}
+}
+
+// expectNone checks no message is sent to the peer.
+func (tp *testPeer) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case msg := <-tp.sent:
+		t.Fatalf("unexpected message sent to %s: %v", tp.ID(), msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func setupReactor(t *testing.T, peers int) (*Reactor, *ecdsa.PrivateKey, []*testPeer) {
+	pool, key := setupTxPool()
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	txR := NewReactor(testTxPoolConfig, pool)
+	txR.reporter = behaviour.NewMockReporter()
+	tps := make([]*testPeer, peers)
+	for i := range tps {
+		tps[i] = newTestPeer()
+		if err := txR.AddPeer(tps[i]); err != nil {
+			t.Fatalf("failed to add peer: %v", err)
+		}
+	}
+	return txR, key, tps
+}
+
+func encodeMsg(t *testing.T, msg prototx.Message) []byte {
+	bz, err := msg.Marshal()
+	if err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+	return bz
+}
+
+func announceMsg(t *testing.T, hashes ...common.Hash) []byte {
+	return encodeMsg(t, prototx.Message{Sum: &prototx.Message_AnnounceTxs{
+		AnnounceTxs: &prototx.AnnounceTxs{Hashes: hashesToBytes(hashes)},
+	}})
+}
+
+func requestMsg(t *testing.T, hashes ...common.Hash) []byte {
+	return encodeMsg(t, prototx.Message{Sum: &prototx.Message_RequestTxs{
+		RequestTxs: &prototx.RequestTxs{Hashes: hashesToBytes(hashes)},
+	}})
+}
+
+func txsMsg(t *testing.T, txs ...*types.Transaction) []byte {
+	msg := prototx.Message{Sum: &prototx.Message_Txs{Txs: &prototx.Txs{}}}
+	for _, tx := range txs {
+		enc, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			t.Fatalf("failed to encode tx: %v", err)
+		}
+		msg.GetTxs().Txs = append(msg.GetTxs().Txs, enc)
+	}
+	return encodeMsg(t, msg)
+}
+
+func TestReactorBroadcastAndAnnounce(t *testing.T) {
+	txR, key, peers := setupReactor(t, 4)
+	defer txR.txpool.Stop()
+
+	tx := transaction(0, 100000, key)
+	if err := txR.txpool.AddLocal(tx); err != nil {
+		t.Fatalf("failed to add tx: %v", err)
+	}
+	txR.broadcastTxs(types.Transactions{tx})
+
+	// Two peers get the full tx, the two others its hash
+	var full, announced int
+	for _, p := range peers {
+		switch msg := p.expect(t).(type) {
+		case *TxsMessage:
+			if len(msg.Txs) != 1 || msg.Txs[0].Hash() != tx.Hash() {
+				t.Fatalf("unexpected txs: %v", msg)
+			}
+			full++
+		case *AnnounceTxsMessage:
+			if len(msg.Hashes) != 1 || msg.Hashes[0] != tx.Hash() {
+				t.Fatalf("unexpected announcement: %v", msg)
+			}
+			announced++
+		}
+		if !txR.peers.Peer(p.ID()).knownTxs.Contains(tx.Hash()) {
+			t.Fatalf("tx not marked known for %s", p.ID())
+		}
+	}
+	if full != 2 || announced != 2 {
+		t.Fatalf("full/announced mismatch: have %d/%d, want 2/2", full, announced)
+	}
+
+	// Every peer knows the tx now
+	txR.broadcastTxs(types.Transactions{tx})
+	for _, p := range peers {
+		p.expectNone(t)
+	}
+}
+
+func TestReactorFetchAnnounced(t *testing.T) {
+	txR, key, peers := setupReactor(t, 2)
+	defer txR.txpool.Stop()
+
+	tx := transaction(0, 100000, key)
+	for _, p := range peers {
+		txR.Receive(TxpoolChannel, p, announceMsg(t, tx.Hash()))
+		if !txR.peers.Peer(p.ID()).knownTxs.Contains(tx.Hash()) {
+			t.Fatalf("tx not marked known for %s", p.ID())
+		}
+	}
+	now := time.Now()
+
+	// Nothing is requested before the tx had a chance to be broadcast
+	txR.fetcher.schedule(now)
+	for _, p := range peers {
+		p.expectNone(t)
+	}
+
+	// The tx is requested from a single announcer
+	now = now.Add(txArriveTimeout)
+	txR.fetcher.schedule(now)
+	first, second := peers[0], peers[1]
+	if _, ok := txR.fetcher.requests[second.ID()]; ok {
+		first, second = second, first
+	}
+	if msg, ok := first.expect(t).(*RequestTxsMessage); !ok || len(msg.Hashes) != 1 || msg.Hashes[0] != tx.Hash() {
+		t.Fatalf("unexpected request: %v", msg)
+	}
+	second.expectNone(t)
+
+	// Once timed out it's requested from the other announcer
+	now = now.Add(txFetchTimeout)
+	txR.fetcher.schedule(now)
+	if msg, ok := second.expect(t).(*RequestTxsMessage); !ok || len(msg.Hashes) != 1 || msg.Hashes[0] != tx.Hash() {
+		t.Fatalf("unexpected request: %v", msg)
+	}
+	first.expectNone(t)
+
+	txR.Receive(TxpoolChannel, second, txsMsg(t, tx))
+	if !txR.txpool.Has(tx.Hash()) {
+		t.Fatalf("fetched tx not added to the pool")
+	}
+	if len(txR.fetcher.waiting) != 0 || len(txR.fetcher.fetching) != 0 || len(txR.fetcher.requests) != 0 || len(txR.fetcher.announces) != 0 {
+		t.Fatalf("fetcher still tracking the tx")
+	}
+
+	// Announcing a pooled tx doesn't trigger a request
+	txR.Receive(TxpoolChannel, first, announceMsg(t, tx.Hash()))
+	txR.fetcher.schedule(now.Add(txArriveTimeout))
+	first.expectNone(t)
+}
+
+func TestReactorFetchBroadcastArrives(t *testing.T) {
+	txR, key, peers := setupReactor(t, 2)
+	defer txR.txpool.Stop()
+
+	tx := transaction(0, 100000, key)
+	txR.Receive(TxpoolChannel, peers[0], announceMsg(t, tx.Hash()))
+	txR.Receive(TxpoolChannel, peers[1], txsMsg(t, tx))
+	now := time.Now()
+	if !txR.peers.Peer(peers[1].ID()).knownTxs.Contains(tx.Hash()) {
+		t.Fatalf("tx not marked known for the sender")
+	}
+
+	// The broadcast tx is no longer fetched
+	txR.fetcher.schedule(now.Add(txArriveTimeout))
+	peers[0].expectNone(t)
+	if len(txR.fetcher.waiting) != 0 || len(txR.fetcher.announced[peers[0].ID()]) != 0 {
+		t.Fatalf("fetcher still tracking the tx")
+	}
+}
+
+func TestReactorFetchPeerDropped(t *testing.T) {
+	txR, key, peers := setupReactor(t, 2)
+	defer txR.txpool.Stop()
+
+	tx := transaction(0, 100000, key)
+	txR.Receive(TxpoolChannel, peers[0], announceMsg(t, tx.Hash()))
+	now := time.Now()
+	txR.fetcher.schedule(now.Add(txArriveTimeout))
+	peers[0].expect(t)
+
+	// The request is moved to the remaining announcer as soon as the peer drops
+	txR.Receive(TxpoolChannel, peers[1], announceMsg(t, tx.Hash()))
+	if err := txR.RemovePeer(peers[0], nil); err != nil {
+		t.Fatalf("failed to remove peer: %v", err)
+	}
+	txR.fetcher.schedule(now.Add(txArriveTimeout))
+	if _, ok := peers[1].expect(t).(*RequestTxsMessage); !ok {
+		t.Fatalf("tx not requested from the remaining announcer")
+	}
+
+	// Without any announcer left the tx is forgotten
+	if err := txR.RemovePeer(peers[1], nil); err != nil {
+		t.Fatalf("failed to remove peer: %v", err)
+	}
+	if len(txR.fetcher.waiting) != 0 || len(txR.fetcher.fetching) != 0 || len(txR.fetcher.announces) != 0 {
+		t.Fatalf("fetcher still tracking the tx")
+	}
+}
+
+func TestReactorServeRequest(t *testing.T) {
+	txR, key, peers := setupReactor(t, 1)
+	defer txR.txpool.Stop()
+
+	tx := transaction(0, 100000, key)
+	if err := txR.txpool.AddLocal(tx); err != nil {
+		t.Fatalf("failed to add tx: %v", err)
+	}
+	unknown := common.HexToHash("0x01")
+	txR.Receive(TxpoolChannel, peers[0], requestMsg(t, unknown, tx.Hash()))
+	if msg, ok := peers[0].expect(t).(*TxsMessage); !ok || len(msg.Txs) != 1 || msg.Txs[0].Hash() != tx.Hash() {
+		t.Fatalf("unexpected response: %v", msg)
+	}
+
+	// Nothing is sent back when no requested tx is known
+	txR.Receive(TxpoolChannel, peers[0], requestMsg(t, unknown))
+	peers[0].expectNone(t)
+}
+
+func TestDecodeMsgInvalid(t *testing.T) {
+	tests := []prototx.Message{
+		{Sum: &prototx.Message_Txs{Txs: &prototx.Txs{}}},
+		{Sum: &prototx.Message_AnnounceTxs{AnnounceTxs: &prototx.AnnounceTxs{}}},
+		{Sum: &prototx.Message_AnnounceTxs{AnnounceTxs: &prototx.AnnounceTxs{Hashes: [][]byte{{0x01}}}}},
+		{Sum: &prototx.Message_RequestTxs{RequestTxs: &prototx.RequestTxs{Hashes: make([][]byte, maxTxRetrievals+1)}}},
+	}
+	for i, msg := range tests {
+		if _, err := decodeMsg(encodeMsg(t, msg)); err == nil {
+			t.Errorf("test %d: expected decoding error", i)
+		}
+	}
+}
+
+func privateTxsMsg(t *testing.T, maxHeight uint64, txs ...*types.Transaction) []byte {
+	return encodeMsg(t, prototx.Message{Sum: &prototx.Message_PrivateTxs{
+		PrivateTxs: &prototx.PrivateTxs{Txs: encodeTxs(txs), MaxHeight: maxHeight},
+	}})
+}
+
+// validatorState returns a state store whose current and next proposers are
+// the given addresses.
+func validatorState(current, next common.Address) *mocks.Store {
+	store := &mocks.Store{}
+	store.On("Load").Return(cstate.LastestBlockState{
+		Validators:     types.NewValidatorSet([]*types.Validator{types.NewValidator(current, 10)}),
+		NextValidators: types.NewValidatorSet([]*types.Validator{types.NewValidator(next, 10)}),
+	})
+	return store
+}
+
+func TestReactorPrivateTxsForwarded(t *testing.T) {
+	txR, key, peers := setupReactor(t, 3)
+	defer txR.txpool.Stop()
+
+	current, next := common.HexToAddress(string(peers[0].ID())), common.HexToAddress(string(peers[1].ID()))
+	txR.SetValidatorState(validatorState(current, next), types.NewMockPV())
+
+	tx := transaction(0, 100000, key)
+	if err := txR.txpool.AddPrivate(tx, 5); err != nil {
+		t.Fatalf("failed to add private tx: %v", err)
+	}
+	// The tx is neither broadcast, announced nor served
+	txR.broadcastTxs(types.Transactions{tx})
+	txR.Receive(TxpoolChannel, peers[2], requestMsg(t, tx.Hash()))
+	for _, p := range peers {
+		p.expectNone(t)
+	}
+
+	// but forwarded to the current and next proposers only
+	if reached := txR.SendPrivateTxs(types.Transactions{tx}, 5); reached != 2 {
+		t.Fatalf("proposers reached mismatch: have %d, want 2", reached)
+	}
+	for _, p := range peers[:2] {
+		msg, ok := p.expect(t).(*PrivateTxsMessage)
+		if !ok || len(msg.Txs) != 1 || msg.Txs[0].Hash() != tx.Hash() || msg.MaxHeight != 5 {
+			t.Fatalf("unexpected private txs: %v", msg)
+		}
+	}
+	peers[2].expectNone(t)
+}
+
+func TestReactorPrivateTxsReceived(t *testing.T) {
+	txR, key, peers := setupReactor(t, 1)
+	defer txR.txpool.Stop()
+
+	self := types.NewMockPV()
+	tx := transaction(0, 100000, key)
+
+	// A node out of the validator set ignores private txs
+	txR.SetValidatorState(validatorState(common.HexToAddress("0x01"), common.HexToAddress("0x02")), self)
+	txR.Receive(TxpoolChannel, peers[0], privateTxsMsg(t, 5, tx))
+	if txR.txpool.Has(tx.Hash()) {
+		t.Fatalf("private tx accepted by a non validator")
+	}
+
+	// The next proposer adds them to its pool as private txs
+	txR.SetValidatorState(validatorState(common.HexToAddress("0x01"), self.GetAddress()), self)
+	txR.Receive(TxpoolChannel, peers[0], privateTxsMsg(t, 5, tx))
+	if !txR.txpool.Has(tx.Hash()) || !txR.txpool.IsPrivate(tx.Hash()) {
+		t.Fatalf("private tx not added to the pool")
+	}
+	// and doesn't forward them to itself
+	if reached := txR.SendPrivateTxs(types.Transactions{tx}, 5); reached != 1 {
+		t.Fatalf("proposers reached mismatch: have %d, want 1", reached)
+	}
+	peers[0].expectNone(t)
+}
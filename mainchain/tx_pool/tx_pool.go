@@ -51,6 +51,14 @@ const (
 	// more expensive to propagate; larger transactions also take more resources
 	// to validate whether they fit into the pool or not.
 	txMaxSize = 4 * txSlotSize // 128KB
+
+	// DefaultPrivateTxBlocks is the number of blocks a private transaction
+	// submitted without a max block height may wait to be included.
+	DefaultPrivateTxBlocks = 25
+
+	// MaxPrivateTxBlocks is the maximum number of blocks a private transaction
+	// may wait to be included.
+	MaxPrivateTxBlocks = 1000
 )
 
 var (
@@ -193,6 +201,7 @@ type TxPool struct {
 	beats   map[common.Address]time.Time // Last heartbeat from each known account
 	all     *txLookup                    // All transactions to allow lookups
 	priced  *txPricedList                // All transactions sorted by price
+	private map[common.Hash]uint64       // Private transactions and the last height they may be included at
 
 	chainHeadCh     chan events.ChainHeadEvent
 	chainHeadSub    event.Subscription
@@ -228,6 +237,7 @@ func NewTxPool(config TxPoolConfig, chainCfg *configs.ChainConfig, chain blockCh
 		queue:           make(map[common.Address]*txList),
 		beats:           make(map[common.Address]time.Time),
 		all:             newTxLookup(),
+		private:         make(map[common.Hash]uint64),
 		chainHeadCh:     make(chan events.ChainHeadEvent, chainHeadChanSize),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
@@ -486,6 +496,23 @@ func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common
 	return pending, queued
 }
 
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, grouped by nonce.
+func (pool *TxPool) ContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var pending types.Transactions
+	if list, ok := pool.pending[addr]; ok {
+		pending = list.Flatten()
+	}
+	var queued types.Transactions
+	if list, ok := pool.queue[addr]; ok {
+		queued = list.Flatten()
+	}
+	return pending, queued
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -763,6 +790,48 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 	return errs[0]
 }
 
+// AddPrivate enqueues a private transaction into the pool if it is valid. A
+// private transaction is never gossiped to peers and is dropped from the pool
+// if it's not included by maxHeight.
+func (pool *TxPool) AddPrivate(tx *types.Transaction, maxHeight uint64) error {
+	head := pool.chain.CurrentBlock().Height()
+	if maxHeight <= head {
+		return ErrPrivateTxExpired
+	}
+	if maxHeight > head+MaxPrivateTxBlocks {
+		return ErrPrivateTxTooFar
+	}
+	// Mark the transaction before it's added, so it's never announced
+	hash := tx.Hash()
+	pool.mu.Lock()
+	if pool.all.Get(hash) != nil {
+		pool.mu.Unlock()
+		return ErrAlreadyKnown
+	}
+	pool.private[hash] = maxHeight
+	pool.mu.Unlock()
+
+	if err := pool.addTxs([]*types.Transaction{tx}, false, true)[0]; err != nil {
+		pool.mu.Lock()
+		if pool.all.Get(hash) == nil {
+			delete(pool.private, hash)
+		}
+		pool.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// IsPrivate returns whether the transaction with the given hash is a private
+// transaction of the pool.
+func (pool *TxPool) IsPrivate(hash common.Hash) bool {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	_, ok := pool.private[hash]
+	return ok
+}
+
 // AddRemotes enqueues a batch of transactions into the pool if they are valid. If the
 // senders are not among the locally tracked ones, full pricing constraints will apply.
 //
@@ -898,6 +967,20 @@ func (pool *TxPool) Has(hash common.Hash) bool {
 	return pool.all.Get(hash) != nil
 }
 
+// RemoveTx drops a transaction from the pool, moving all subsequent pending
+// transactions of its sender back to the future queue. It returns false if the
+// transaction isn't in the pool.
+func (pool *TxPool) RemoveTx(hash common.Hash) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.all.Get(hash) == nil {
+		return false
+	}
+	pool.removeTx(hash, true)
+	return true
+}
+
 // removeTx removes a single transaction from the queue, moving all subsequent
 // transactions back to the future queue.
 func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
@@ -1084,6 +1167,9 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
 		pool.demoteUnexecutables()
+		if reset.newHead != nil {
+			pool.expirePrivate(reset.newHead.Height)
+		}
 	}
 	// Ensure pool.queue and pool.pending sizes stay within the configured limits.
 	pool.truncatePending()
@@ -1202,6 +1288,22 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.addTxsLocked(reinject, false)
 }
 
+// expirePrivate drops the private transactions not included by the given
+// height although it was their max height, and forgets the included ones.
+func (pool *TxPool) expirePrivate(height uint64) {
+	for hash, maxHeight := range pool.private {
+		if pool.all.Get(hash) == nil {
+			delete(pool.private, hash)
+			continue
+		}
+		if height >= maxHeight {
+			log.Debug("Dropping expired private transaction", "hash", hash, "maxHeight", maxHeight)
+			pool.removeTx(hash, true)
+			delete(pool.private, hash)
+		}
+	}
+}
+
 // promoteExecutables moves transactions that have become processable from the
 // future queue to the set of pending transactions. During this process, all
 // invalidated transactions (low nonce, low balance) are deleted.
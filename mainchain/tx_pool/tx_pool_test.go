@@ -1879,6 +1879,52 @@ func TestTransactionStatusCheck(t *testing.T) {
 	}
 }
 
+// Tests that the content of an account can be retrieved and that dropping a
+// pending transaction moves the following ones back to the queue.
+func TestTransactionContentFromAndRemove(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(account, big.NewInt(1000000))
+
+	txs := types.Transactions{
+		transaction(0, 100000, key),
+		transaction(1, 100000, key),
+		transaction(3, 100000, key),
+	}
+	pool.AddRemotesSync(txs)
+
+	pending, queued := pool.ContentFrom(account)
+	if len(pending) != 2 || len(queued) != 1 {
+		t.Fatalf("content mismatched: have %d/%d, want %d/%d", len(pending), len(queued), 2, 1)
+	}
+	if pending[0].Hash() != txs[0].Hash() || pending[1].Hash() != txs[1].Hash() || queued[0].Hash() != txs[2].Hash() {
+		t.Fatalf("content not sorted by nonce")
+	}
+	if pending, queued := pool.ContentFrom(common.Address{}); pending != nil || queued != nil {
+		t.Fatalf("unknown account has content: %v %v", pending, queued)
+	}
+
+	if !pool.RemoveTx(txs[0].Hash()) {
+		t.Fatalf("failed to remove pending transaction")
+	}
+	if pool.RemoveTx(txs[0].Hash()) {
+		t.Fatalf("removed transaction twice")
+	}
+	if status := pool.Status([]common.Hash{txs[1].Hash()}); status[0] != TxStatusQueued {
+		t.Fatalf("following transaction status mismatch: have %v, want %v", status[0], TxStatusQueued)
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 2 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 0, 2)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Test the transaction slots consumption is computed correctly
 func TestTransactionSlotCount(t *testing.T) {
 	t.Parallel()
@@ -1981,3 +2027,66 @@ func benchmarkPoolBatchInsert(b *testing.B, size int, local bool) {
 		}
 	}
 }
+
+func TestPrivateTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	tx := transaction(0, 100000, key)
+	if err := pool.AddPrivate(tx, 0); err != ErrPrivateTxExpired {
+		t.Fatalf("expected %v, got %v", ErrPrivateTxExpired, err)
+	}
+	if err := pool.AddPrivate(tx, MaxPrivateTxBlocks+1); err != ErrPrivateTxTooFar {
+		t.Fatalf("expected %v, got %v", ErrPrivateTxTooFar, err)
+	}
+	// An invalid transaction isn't left marked private
+	pool.currentState.AddBalance(account, big.NewInt(1))
+	if err := pool.AddPrivate(tx, 2); err != ErrInsufficientFunds {
+		t.Fatalf("expected %v, got %v", ErrInsufficientFunds, err)
+	}
+	if pool.IsPrivate(tx.Hash()) {
+		t.Fatalf("rejected transaction marked private")
+	}
+
+	pool.currentState.AddBalance(account, big.NewInt(1000000))
+	if err := pool.AddPrivate(tx, 2); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if !pool.IsPrivate(tx.Hash()) || !pool.Has(tx.Hash()) {
+		t.Fatalf("private transaction not tracked")
+	}
+	if err := pool.AddPrivate(tx, 2); err != ErrAlreadyKnown {
+		t.Fatalf("expected %v, got %v", ErrAlreadyKnown, err)
+	}
+	// A public transaction can't be turned private
+	public := transaction(1, 100000, key)
+	if err := pool.addRemoteSync(public); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := pool.AddPrivate(public, 2); err != ErrAlreadyKnown || pool.IsPrivate(public.Hash()) {
+		t.Fatalf("public transaction turned private: %v", err)
+	}
+
+	// The transaction is kept until its max height is reached
+	pool.mu.Lock()
+	pool.expirePrivate(1)
+	pool.mu.Unlock()
+	if !pool.Has(tx.Hash()) {
+		t.Fatalf("private transaction expired early")
+	}
+	pool.mu.Lock()
+	pool.expirePrivate(2)
+	pool.mu.Unlock()
+	if pool.Has(tx.Hash()) || pool.IsPrivate(tx.Hash()) {
+		t.Fatalf("private transaction not expired")
+	}
+	if !pool.Has(public.Hash()) {
+		t.Fatalf("public transaction expired")
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
@@ -20,8 +20,11 @@ package node
 
 import (
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/pex"
 	"github.com/kardiachain/go-kardia/rpc"
 )
 
@@ -32,10 +35,219 @@ func (n *Node) apis() []rpc.API {
 			Namespace: "node",
 			Version:   "1.0",
 			Service:   &publicAdminAPI{n},
+		}, {
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   &privateAdminAPI{n},
+			Public:    false,
 		},
 	}
 }
 
+// privateAdminAPI is the collection of administrative API methods exposed
+// only over IPC, or over HTTP and WebSocket on localhost.
+type privateAdminAPI struct {
+	node *Node // Node interfaced by this API
+}
+
+// AddPeer requests connecting to a remote node given as id@host:port.
+func (api *privateAdminAPI) AddPeer(addr string) (bool, error) {
+	if _, err := p2p.NewNetAddressString(addr); err != nil {
+		return false, fmt.Errorf("invalid peer address: %w", err)
+	}
+	if err := api.node.sw.DialPeersAsync([]string{addr}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddPersistentPeer adds a remote node given as id@host:port to the
+// persistent peers, the node keeps reconnecting to it when disconnected.
+func (api *privateAdminAPI) AddPersistentPeer(addr string) (bool, error) {
+	if _, err := p2p.NewNetAddressString(addr); err != nil {
+		return false, fmt.Errorf("invalid peer address: %w", err)
+	}
+	if err := api.node.sw.AddPersistentPeers([]string{addr}); err != nil {
+		return false, err
+	}
+	if err := api.node.sw.DialPeersAsync([]string{addr}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer with the given id and removes it from
+// the persistent peers. It returns false if the peer is neither connected nor
+// persistent.
+func (api *privateAdminAPI) RemovePeer(id string) (bool, error) {
+	peerID := p2p.ID(id)
+	removed := api.node.sw.RemovePersistentPeer(peerID)
+	if peer := api.node.sw.Peers().Get(peerID); peer != nil {
+		api.node.sw.StopPeerGracefully(peer)
+		removed = true
+	}
+	return removed, nil
+}
+
+// BanPeer disconnects from the peer with the given id and rejects it for the
+// given number of seconds, 0 banning it for ever. The ban is kept across
+// restarts.
+func (api *privateAdminAPI) BanPeer(id string, seconds uint64) (*p2p.BanEntry, error) {
+	entry, err := api.node.sw.BanPeer(id, time.Duration(seconds)*time.Second, "admin")
+	if err != nil {
+		return nil, err
+	}
+	api.node.sw.RemovePersistentPeer(p2p.ID(id))
+	return entry, nil
+}
+
+// BanIP disconnects from the peers connected from ip, which may also be a CIDR
+// range, and rejects them for the given number of seconds, 0 banning them for
+// ever. The ban is kept across restarts.
+func (api *privateAdminAPI) BanIP(ip string, seconds uint64) (*p2p.BanEntry, error) {
+	if net.ParseIP(ip) == nil {
+		if _, _, err := net.ParseCIDR(ip); err != nil {
+			return nil, fmt.Errorf("invalid ip %q", ip)
+		}
+	}
+	return api.node.sw.BanPeer(ip, time.Duration(seconds)*time.Second, "admin")
+}
+
+// Unban lifts the ban of a node id, ip or CIDR range. It returns false if it
+// isn't banned.
+func (api *privateAdminAPI) Unban(target string) (bool, error) {
+	banList := api.node.sw.BanList()
+	if banList == nil {
+		return false, fmt.Errorf("ban list is not available")
+	}
+	return banList.Unban(target)
+}
+
+// BanList returns the active bans.
+func (api *privateAdminAPI) BanList() ([]*p2p.BanEntry, error) {
+	banList := api.node.sw.BanList()
+	if banList == nil {
+		return nil, fmt.Errorf("ban list is not available")
+	}
+	return banList.List(), nil
+}
+
+// AddrBook returns every address known by the address book, banned ones
+// included.
+func (api *privateAdminAPI) AddrBook() ([]*pex.AddressInfo, error) {
+	if api.node.addrBook == nil {
+		return nil, fmt.Errorf("address book is not available")
+	}
+	return api.node.addrBook.ListAddresses(), nil
+}
+
+// StartHTTP starts the HTTP RPC API server. Missing parameters fall back to
+// the node configuration.
+func (api *privateAdminAPI) StartHTTP(host *string, port *int, cors *string, apis *string, vhosts *string) (bool, error) {
+	api.node.lock.Lock()
+	defer api.node.lock.Unlock()
+
+	// Determine host and port.
+	if host == nil {
+		h := DefaultHTTPHost
+		if api.node.config.HTTPHost != "" {
+			h = api.node.config.HTTPHost
+		}
+		host = &h
+	}
+	if port == nil {
+		port = &api.node.config.HTTPPort
+	}
+
+	// Determine config.
+	config := httpConfig{
+		CorsAllowedOrigins: api.node.config.HTTPCors,
+		Vhosts:             api.node.config.HTTPVirtualHosts,
+		Modules:            api.node.config.HTTPModules,
+		Auth:               api.node.config.RPCAuth,
+		Limits:             api.node.config.RPCLimits,
+	}
+	if cors != nil {
+		config.CorsAllowedOrigins = splitAndTrimEmpty(*cors, ",", " ")
+	}
+	if vhosts != nil {
+		config.Vhosts = splitAndTrimEmpty(*vhosts, ",", " ")
+	}
+	if apis != nil {
+		config.Modules = splitAndTrimEmpty(*apis, ",", " ")
+	}
+
+	if err := api.node.http.setListenAddr(*host, *port); err != nil {
+		return false, err
+	}
+	if err := api.node.http.enableRPC(api.node.rpcAPIs, config); err != nil {
+		return false, err
+	}
+	if err := api.node.http.start(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StopHTTP shuts down the HTTP server.
+func (api *privateAdminAPI) StopHTTP() (bool, error) {
+	api.node.http.stop()
+	return true, nil
+}
+
+// StartWS starts the websocket RPC API server. Missing parameters fall back
+// to the node configuration.
+func (api *privateAdminAPI) StartWS(host *string, port *int, allowedOrigins *string, apis *string) (bool, error) {
+	api.node.lock.Lock()
+	defer api.node.lock.Unlock()
+
+	// Determine host and port.
+	if host == nil {
+		h := DefaultWSHost
+		if api.node.config.WSHost != "" {
+			h = api.node.config.WSHost
+		}
+		host = &h
+	}
+	if port == nil {
+		port = &api.node.config.WSPort
+	}
+
+	// Determine config.
+	config := wsConfig{
+		Modules: api.node.config.WSModules,
+		Origins: api.node.config.WSOrigins,
+		Auth:    api.node.config.RPCAuth,
+		Limits:  api.node.config.RPCLimits,
+	}
+	if apis != nil {
+		config.Modules = splitAndTrimEmpty(*apis, ",", " ")
+	}
+	if allowedOrigins != nil {
+		config.Origins = splitAndTrimEmpty(*allowedOrigins, ",", " ")
+	}
+
+	server := api.node.wsServerForPort(*port)
+	if err := server.setListenAddr(*host, *port); err != nil {
+		return false, err
+	}
+	if err := server.enableWS(api.node.rpcAPIs, config); err != nil {
+		return false, err
+	}
+	if err := server.start(); err != nil {
+		return false, err
+	}
+	api.node.log.Info("WebSocket endpoint opened", "url", api.node.WSEndpoint())
+	return true, nil
+}
+
+// StopWS terminates all WebSocket servers.
+func (api *privateAdminAPI) StopWS() (bool, error) {
+	api.node.http.stopWS()
+	api.node.ws.stop()
+	return true, nil
+}
+
 // PublicAdminAPI is the collection of administrative API methods exposed over
 // both secure and unsecure RPC channels.
 type publicAdminAPI struct {
@@ -54,6 +266,7 @@ type Peer struct {
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	TrustScore       *int                 `json:"trust_score,omitempty"`
 }
 
 // Peers retrieves all the information we know about each individual peer at the
@@ -66,12 +279,16 @@ func (api *publicAdminAPI) Peers() ([]Peer, error) {
 		if !ok {
 			return nil, fmt.Errorf("peer.NodeInfo() is not DefaultNodeInfo")
 		}
-		peers = append(peers, Peer{
+		p := Peer{
 			NodeInfo:         nodeInfo,
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
-		})
+		}
+		if score, ok := api.node.sw.PeerTrustScore(peer.ID()); ok {
+			p.TrustScore = &score
+		}
+		peers = append(peers, p)
 	}
 	return peers, nil
 }
@@ -136,6 +136,52 @@ func TestStartRPC(t *testing.T) {
 	}
 }
 
+// TestAdminStartStopHTTP starts and stops the HTTP server at runtime through
+// the admin API.
+func TestAdminStartStopHTTP(t *testing.T) {
+	config := testNodeConfig()
+	config.Logger = testlog.Logger(t, log.LvlDebug)
+	stack, err := New(config)
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	defer stack.Stop()
+	if err := stack.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+
+	api := &privateAdminAPI{stack}
+	host, port := "127.0.0.1", 0
+	if _, err := api.StartHTTP(&host, &port, nil, nil, nil); err != nil {
+		t.Fatal("can't start HTTP:", err)
+	}
+	if !checkRPC(stack.HTTPEndpoint()) {
+		t.Fatal("HTTP RPC not available after admin_startHTTP")
+	}
+	if _, err := api.StopHTTP(); err != nil {
+		t.Fatal("can't stop HTTP:", err)
+	}
+	if checkReachable(stack.HTTPEndpoint()) {
+		t.Fatal("HTTP server still reachable after admin_stopHTTP")
+	}
+}
+
+// TestFilterLocalModules checks the admin modules are only served on loopback hosts.
+func TestFilterLocalModules(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	modules := []string{"kai", "admin", "dualadmin"}
+	for _, host := range []string{"localhost", "127.0.0.1", "::1"} {
+		if got := filterLocalModules(host, modules, logger); len(got) != 3 {
+			t.Errorf("host %s: got modules %v, want %v", host, got, modules)
+		}
+	}
+	for _, host := range []string{"", "0.0.0.0", "10.0.0.1"} {
+		if got := filterLocalModules(host, modules, logger); len(got) != 1 || got[0] != "kai" {
+			t.Errorf("host %s: got modules %v, want [kai]", host, got)
+		}
+	}
+}
+
 // checkReachable checks if the TCP endpoint in rawurl is open.
 func checkReachable(rawurl string) bool {
 	u, err := url.Parse(rawurl)
@@ -37,6 +37,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/gasprice"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
 	"github.com/kardiachain/go-kardia/rpc"
@@ -60,6 +61,9 @@ type MainChainConfig struct {
 	// Transaction pool options
 	TxPool tx_pool.TxPoolConfig
 
+	// Gas price oracle options
+	GPO gasprice.Config
+
 	// AcceptTxs accept tx sync process or not (1 is yes and 0 is no)
 	AcceptTxs uint32
 
@@ -125,6 +129,60 @@ type EnvironmentConfig struct {
 	VotingStrategy map[consensus.VoteTurn]int
 }
 
+// RPCAuthConfig configures the authentication of JSON-RPC requests over HTTP
+// and websocket. Callers authenticate either with a HS256 JWT signed with the
+// shared secret in JWTSecretFile or with one of the APIKeys. IPC is never
+// authenticated.
+type RPCAuthConfig struct {
+	// JWTSecretFile is the file holding the hex encoded JWT secret of at least
+	// 32 bytes. JWT authentication is disabled if it's empty.
+	JWTSecretFile string `toml:",omitempty"`
+
+	// JWTNamespaces and JWTMethods are the namespaces and methods callers
+	// authenticated by JWT are allowed to call. Everything served is allowed
+	// if both are empty.
+	JWTNamespaces []string `toml:",omitempty"`
+	JWTMethods    []string `toml:",omitempty"`
+
+	// APIKeys are the keys accepted in the X-API-Key header.
+	APIKeys []RPCAPIKey `toml:",omitempty"`
+}
+
+// RPCAPIKey is an API key and the namespaces and methods it's allowed to call.
+// Everything served is allowed if both Namespaces and Methods are empty.
+type RPCAPIKey struct {
+	Name       string // Name of the key in the audit log
+	Key        string
+	Namespaces []string `toml:",omitempty"`
+	Methods    []string `toml:",omitempty"` // Full method names, e.g. kai_getBalance
+}
+
+// RPCLimitsConfig bounds the load clients can put on the RPC servers. Zero
+// disables a limit.
+type RPCLimitsConfig struct {
+	// IPRate is the number of calls per second allowed to every client IP over
+	// HTTP and websocket, with bursts of up to IPBurst calls.
+	IPRate  float64 `toml:",omitempty"`
+	IPBurst int     `toml:",omitempty"`
+
+	// KeyRate and KeyBurst limit the calls of every authenticated caller, API
+	// key or JWT, instead of IPRate and IPBurst.
+	KeyRate  float64 `toml:",omitempty"`
+	KeyBurst int     `toml:",omitempty"`
+
+	// BatchLimit is the maximum number of calls in a batch request over HTTP
+	// and websocket.
+	BatchLimit int `toml:",omitempty"`
+
+	// ResponseSizeLimit is the maximum size in bytes of the results of a
+	// response over HTTP and websocket, batches included.
+	ResponseSizeLimit int `toml:",omitempty"`
+
+	// LogsBlockRange is the maximum number of blocks a kai_getLogs query may
+	// span, on every transport.
+	LogsBlockRange uint64 `toml:",omitempty"`
+}
+
 // Config represents a small collection of configuration values to fine tune the
 // P2P network layer of a protocol stack. These values can be further extended by
 // all registered services.
@@ -212,6 +270,15 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// RPCAuth enables the authentication of the HTTP and websocket RPC servers.
+	// Modules only served on localhost are served on any interface when it's
+	// set, since every call is authenticated.
+	RPCAuth *RPCAuthConfig `toml:",omitempty"`
+
+	// RPCLimits configures the rate limits and the batch and response size caps
+	// of the RPC servers.
+	RPCLimits RPCLimitsConfig
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
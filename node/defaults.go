@@ -21,6 +21,7 @@ package node
 import (
 	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/mainchain/gasprice"
 	"github.com/kardiachain/go-kardia/rpc"
 )
 
@@ -43,22 +44,30 @@ const (
 	KardiaServiceName = "KARDIA"
 )
 
+// DefaultRPCLimits are the default RPC limits, calls aren't rate limited.
+var DefaultRPCLimits = RPCLimitsConfig{
+	BatchLimit:        1000,
+	ResponseSizeLimit: 25 * 1024 * 1024,
+}
+
 // DefaultConfig contains reasonable default settings.
 var DefaultConfig = Config{
 	DataDir:          configs.DefaultDataDir(),
 	HTTPPort:         DefaultHTTPPort,
-	HTTPModules:      []string{"node", "kai", "tx", "account"},
+	HTTPModules:      []string{"node", "kai", "tx", "account", "txpool"},
 	HTTPVirtualHosts: []string{"0.0.0.0", "localhost"},
 	HTTPCors:         []string{"*"},
 	HTTPTimeouts:     rpc.DefaultHTTPTimeouts,
 	WSPort:           DefaultWSPort,
-	WSModules:        []string{"node", "kai", "tx", "account"},
+	WSModules:        []string{"node", "kai", "tx", "account", "txpool"},
+	RPCLimits:        DefaultRPCLimits,
 	P2P:              configs.DefaultP2PConfig(),
 	MainChainConfig: MainChainConfig{
 		ServiceName: KardiaServiceName,
 		ChainId:     MainChainID,
 		NetworkId:   DefaultNetworkID,
 		AcceptTxs:   1, // 1 is to allow new transactions, 0 is not
+		GPO:         gasprice.DefaultConfig,
 	},
 	DualChainConfig: DualChainConfig{
 		DBInfo: storage.NewLevelDbInfo(DualChainDataDir, DefaultDbCache, DefaultDbHandles),
@@ -40,6 +40,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/metrics"
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/lib/p2p/pex"
+	"github.com/kardiachain/go-kardia/lib/p2p/trust"
 	"github.com/kardiachain/go-kardia/lib/service"
 	bs "github.com/kardiachain/go-kardia/lib/service"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
@@ -154,9 +155,16 @@ func New(conf *Config) (*Node, error) {
 	// Setup Transport.
 	transport, peerFilters := createTransport(conf, nodeInfo, nodeKey)
 
-	// Setup Switch.
+	banList, err := createBanList(conf, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create ban list: %w", err)
+	}
+
+	// Setup Switch, keeping the trust history of peers along the chain data.
+	trustStore := trust.NewTrustMetricStore(db.DB(), trust.DefaultConfig())
+	trustStore.SetLogger(logger)
 	sw := createSwitch(
-		conf, transport, peerFilters, nodeInfo, nodeKey, logger,
+		conf, transport, peerFilters, trustStore, banList, nodeInfo, nodeKey, logger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(conf.P2P.PersistentPeers, ",", " "))
@@ -349,6 +357,8 @@ func (n *Node) startRPC() error {
 			CorsAllowedOrigins: n.config.HTTPCors,
 			Vhosts:             n.config.HTTPVirtualHosts,
 			Modules:            n.config.HTTPModules,
+			Auth:               n.config.RPCAuth,
+			Limits:             n.config.RPCLimits,
 		}
 		if err := n.http.setListenAddr(n.config.HTTPHost, n.config.HTTPPort); err != nil {
 			return err
@@ -364,6 +374,8 @@ func (n *Node) startRPC() error {
 		config := wsConfig{
 			Modules: n.config.WSModules,
 			Origins: n.config.WSOrigins,
+			Auth:    n.config.RPCAuth,
+			Limits:  n.config.RPCLimits,
 		}
 		if err := server.setListenAddr(n.config.WSHost, n.config.WSPort); err != nil {
 			return err
@@ -649,6 +661,8 @@ func makeNodeInfo(
 func createSwitch(config *Config,
 	transport p2p.Transport,
 	peerFilters []p2p.PeerFilterFunc,
+	trustStore *trust.MetricStore,
+	banList *p2p.BanList,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	p2pLogger log.Logger) *p2p.Switch {
@@ -656,6 +670,9 @@ func createSwitch(config *Config,
 	sw := p2p.NewSwitch(
 		config.P2P,
 		transport,
+		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchTrustMetricStore(trustStore),
+		p2p.SwitchBanList(banList),
 	)
 	sw.SetLogger(p2pLogger)
 
@@ -665,6 +682,24 @@ func createSwitch(config *Config,
 	return sw
 }
 
+// createBanList loads the ban list and bans the peers listed in the
+// banned_peers field for ever. These bans aren't saved, so removing a peer
+// from the field lifts its ban at the next start.
+func createBanList(config *Config, p2pLogger log.Logger) (*p2p.BanList, error) {
+	banList, err := p2p.NewBanList(config.P2P.BanListFile())
+	if err != nil {
+		return nil, err
+	}
+	banList.SetLogger(p2pLogger)
+	banList.SetAutoBan(config.P2P.AutoBanThreshold, config.P2P.AutoBanDuration)
+	for _, target := range splitAndTrimEmpty(config.P2P.BannedPeers, ",", " ") {
+		if err := banList.AddStaticBan(target, "banned_peers"); err != nil {
+			return nil, fmt.Errorf("could not ban %q from banned_peers field: %w", target, err)
+		}
+	}
+	return banList, nil
+}
+
 func createAddrBookAndSetOnSwitch(config *Config, sw *p2p.Switch,
 	p2pLogger log.Logger, nodeKey *p2p.NodeKey) (pex.AddrBook, error) {
 
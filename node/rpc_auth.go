@@ -0,0 +1,287 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+const (
+	// jwtMinSecretLength is the minimum length in bytes of the JWT secret.
+	jwtMinSecretLength = 32
+
+	// jwtIssuedAtWindow is the maximum difference between the issued-at claim
+	// of a JWT and the local time, it limits the replay of leaked tokens.
+	jwtIssuedAtWindow = 60 * time.Second
+
+	// apiKeyHeader is the request header carrying the API key.
+	apiKeyHeader = "X-API-Key"
+
+	// accessDeniedErrorCode is the JSON-RPC error code of rejected calls.
+	accessDeniedErrorCode = -32001
+
+	// rpcMethodSeparator separates the namespace and the name of a method,
+	// namespaces never contain it.
+	rpcMethodSeparator = "_"
+)
+
+var (
+	errMissingCredentials = errors.New("missing credentials")
+	errInvalidAPIKey      = errors.New("invalid API key")
+	errJWTDisabled        = errors.New("JWT authentication is disabled")
+	errInvalidJWT         = errors.New("invalid JWT")
+	errStaleJWT           = errors.New("stale JWT, issued-at too far from the current time")
+	errExpiredJWT         = errors.New("expired JWT")
+)
+
+// accessDeniedError is returned to callers of methods they aren't allowed to call.
+type accessDeniedError struct{ method string }
+
+func (e *accessDeniedError) ErrorCode() int { return accessDeniedErrorCode }
+
+func (e *accessDeniedError) Error() string {
+	return fmt.Sprintf("access to method %s denied", e.method)
+}
+
+// rpcAccess is the allow list of an authenticated caller.
+type rpcAccess struct {
+	name       string
+	namespaces map[string]bool
+	methods    map[string]bool
+}
+
+func newRPCAccess(name string, namespaces, methods []string) (*rpcAccess, error) {
+	a := &rpcAccess{
+		name:       name,
+		namespaces: make(map[string]bool, len(namespaces)),
+		methods:    make(map[string]bool, len(methods)),
+	}
+	for _, namespace := range namespaces {
+		if namespace == "" || strings.Contains(namespace, rpcMethodSeparator) {
+			return nil, fmt.Errorf("invalid RPC namespace %q allowed to %s", namespace, name)
+		}
+		a.namespaces[namespace] = true
+	}
+	for _, method := range methods {
+		a.methods[method] = true
+	}
+	return a, nil
+}
+
+// allow returns true if the caller may call method, that is if its namespace
+// or the method itself is allowed, or if nothing is restricted. The namespace
+// is split off at the first separator, the same way the server resolves the
+// service of a method.
+func (a *rpcAccess) allow(method string) bool {
+	if len(a.namespaces) == 0 && len(a.methods) == 0 {
+		return true
+	}
+	if a.methods[method] {
+		return true
+	}
+	elem := strings.SplitN(method, rpcMethodSeparator, 2)
+	if len(elem) != 2 {
+		return false
+	}
+	return a.namespaces[elem[0]]
+}
+
+// rpcCallerKey is the context key of the name of an authenticated caller.
+type rpcCallerKey struct{}
+
+// apiKey is an accepted API key and its allow list.
+type apiKey struct {
+	key    []byte
+	access *rpcAccess
+}
+
+// rpcAuth authenticates JSON-RPC requests over HTTP and websocket, and checks
+// every call against the allow list of the caller. Rejected requests and calls
+// are recorded in the audit log.
+type rpcAuth struct {
+	jwtSecret []byte // nil if JWT authentication is disabled
+	jwtAccess *rpcAccess
+	keys      []apiKey
+	audit     log.Logger
+	now       func() time.Time
+}
+
+// newRPCAuth creates the authenticator described by config, it returns nil if
+// no authentication is configured.
+func newRPCAuth(config *RPCAuthConfig, logger log.Logger) (*rpcAuth, error) {
+	if config == nil || (config.JWTSecretFile == "" && len(config.APIKeys) == 0) {
+		return nil, nil
+	}
+	auth := &rpcAuth{
+		audit: logger.New("module", "rpc-audit"),
+		now:   time.Now,
+	}
+	if config.JWTSecretFile != "" {
+		secret, err := readJWTSecret(config.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		auth.jwtSecret = secret
+		if auth.jwtAccess, err = newRPCAccess("jwt", config.JWTNamespaces, config.JWTMethods); err != nil {
+			return nil, err
+		}
+	}
+	for i, key := range config.APIKeys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("empty RPC API key %d", i)
+		}
+		name := key.Name
+		if name == "" {
+			name = fmt.Sprintf("key-%d", i)
+		}
+		access, err := newRPCAccess(name, key.Namespaces, key.Methods)
+		if err != nil {
+			return nil, err
+		}
+		auth.keys = append(auth.keys, apiKey{key: []byte(key.Key), access: access})
+	}
+	return auth, nil
+}
+
+// readJWTSecret reads the hex encoded JWT secret from file.
+func readJWTSecret(file string) ([]byte, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %v", err)
+	}
+	hex := strings.TrimSpace(string(data))
+	secret := common.FromHex(hex)
+	if len(secret) < jwtMinSecretLength || len(secret)*2 != len(strings.TrimPrefix(hex, "0x")) {
+		return nil, fmt.Errorf("invalid JWT secret in %s, want at least %d hex encoded bytes", file, jwtMinSecretLength)
+	}
+	return secret, nil
+}
+
+// handler wraps next, rejecting the requests which fail authentication and
+// installing the name and the allow list of the caller in the context of the
+// others.
+func (a *rpcAuth) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access, err := a.authenticate(r)
+		if err != nil {
+			a.audit.Warn("Rejected RPC request", "remote", r.RemoteAddr, "err", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		ctx := context.WithValue(r.Context(), rpcCallerKey{}, access.name)
+		ctx = rpc.WithAccess(ctx, func(method string) error {
+			if access.allow(method) {
+				return nil
+			}
+			a.audit.Warn("Rejected RPC call", "remote", r.RemoteAddr, "caller", access.name, "method", method)
+			return &accessDeniedError{method}
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticate returns the allow list of the caller of r, identified by the
+// bearer JWT or the API key of the request.
+func (a *rpcAuth) authenticate(r *http.Request) (*rpcAccess, error) {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if a.jwtSecret == nil {
+			return nil, errJWTDisabled
+		}
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return nil, errInvalidJWT
+		}
+		if err := a.verifyJWT(strings.TrimPrefix(auth, "Bearer ")); err != nil {
+			return nil, err
+		}
+		return a.jwtAccess, nil
+	}
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		for _, k := range a.keys {
+			if subtle.ConstantTimeCompare(k.key, []byte(key)) == 1 {
+				return k.access, nil
+			}
+		}
+		return nil, errInvalidAPIKey
+	}
+	return nil, errMissingCredentials
+}
+
+// verifyJWT checks the HS256 signature of token and its issued-at and expiry
+// claims.
+func (a *rpcAuth) verifyJWT(token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errInvalidJWT
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return errInvalidJWT
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errInvalidJWT
+	}
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errInvalidJWT
+	}
+	var claims struct {
+		IssuedAt  *int64 `json:"iat"`
+		ExpiresAt *int64 `json:"exp"`
+	}
+	if err := decodeJWTSegment(parts[1], &claims); err != nil || claims.IssuedAt == nil {
+		return errInvalidJWT
+	}
+	now := a.now()
+	issued := time.Unix(*claims.IssuedAt, 0)
+	if issued.Before(now.Add(-jwtIssuedAtWindow)) || issued.After(now.Add(jwtIssuedAtWindow)) {
+		return errStaleJWT
+	}
+	if claims.ExpiresAt != nil && !now.Before(time.Unix(*claims.ExpiresAt, 0)) {
+		return errExpiredJWT
+	}
+	return nil
+}
+
+// decodeJWTSegment decodes a base64url encoded JSON segment of a JWT into v.
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,220 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/log/testlog"
+)
+
+type authTestResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+// authRequest posts body with the given headers, it returns the status code and
+// the raw response.
+func authRequest(t *testing.T, url, body string, headers ...string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("content-type", "application/json")
+	for i := 0; i < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, data
+}
+
+func signJWT(secret []byte, claims string) string {
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestRPCAuthAPIKey(t *testing.T) {
+	auth := &RPCAuthConfig{APIKeys: []RPCAPIKey{
+		{Name: "meta", Key: "meta-key", Methods: []string{"rpc_modules"}},
+		{Name: "kai", Key: "kai-key", Namespaces: []string{"kai"}},
+	}}
+	srv := createAndStartServer(t, &httpConfig{Auth: auth}, false, &wsConfig{})
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+	call := `{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]}`
+
+	status, _ := authRequest(t, url, call)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = authRequest(t, url, call, apiKeyHeader, "bad-key")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, data := authRequest(t, url, call, apiKeyHeader, "meta-key")
+	require.Equal(t, http.StatusOK, status)
+	var resp authTestResponse
+	require.NoError(t, json.Unmarshal(data, &resp))
+	assert.Nil(t, resp.Error)
+	assert.NotEmpty(t, resp.Result)
+
+	status, data = authRequest(t, url, call, apiKeyHeader, "kai-key")
+	require.Equal(t, http.StatusOK, status)
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, accessDeniedErrorCode, resp.Error.Code)
+
+	// every call of a batch is checked
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]},{"jsonrpc":"2.0","id":2,"method":"kai_blockNumber","params":[]}]`
+	status, data = authRequest(t, url, batch, apiKeyHeader, "meta-key")
+	require.Equal(t, http.StatusOK, status)
+	var batchResp []authTestResponse
+	require.NoError(t, json.Unmarshal(data, &batchResp))
+	require.Len(t, batchResp, 2)
+	assert.Nil(t, batchResp[0].Error)
+	require.NotNil(t, batchResp[1].Error)
+	assert.Equal(t, accessDeniedErrorCode, batchResp[1].Error.Code)
+}
+
+func TestRPCAuthJWT(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpcauth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+	secretFile := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("0x"+hex.EncodeToString(secret)+"\n"), 0600))
+
+	srv := createAndStartServer(t, &httpConfig{Auth: &RPCAuthConfig{JWTSecretFile: secretFile}}, false, &wsConfig{})
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+	call := `{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]}`
+	now := time.Now().Unix()
+
+	tests := []struct {
+		token  string
+		status int
+	}{
+		{signJWT(secret, fmt.Sprintf(`{"iat":%d}`, now)), http.StatusOK},
+		{signJWT(secret, fmt.Sprintf(`{"iat":%d,"exp":%d}`, now, now+10)), http.StatusOK},
+		{signJWT(secret, fmt.Sprintf(`{"iat":%d}`, now-600)), http.StatusUnauthorized},
+		{signJWT(secret, fmt.Sprintf(`{"iat":%d,"exp":%d}`, now, now-1)), http.StatusUnauthorized},
+		{signJWT(secret, `{}`), http.StatusUnauthorized},
+		{signJWT(make([]byte, 32), fmt.Sprintf(`{"iat":%d}`, now)), http.StatusUnauthorized},
+		{"garbage", http.StatusUnauthorized},
+	}
+	for i, test := range tests {
+		status, _ := authRequest(t, url, call, "Authorization", "Bearer "+test.token)
+		assert.Equal(t, test.status, status, "test %d", i)
+	}
+	// API keys aren't configured
+	status, _ := authRequest(t, url, call, apiKeyHeader, "key")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestRPCAuthInvalidSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpcauth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("0x1234"), 0600))
+	logger := testlog.Logger(t, log.LvlDebug)
+
+	_, err = newRPCAuth(&RPCAuthConfig{JWTSecretFile: secretFile}, logger)
+	assert.Error(t, err)
+	_, err = newRPCAuth(&RPCAuthConfig{JWTSecretFile: filepath.Join(dir, "missing")}, logger)
+	assert.Error(t, err)
+	_, err = newRPCAuth(&RPCAuthConfig{APIKeys: []RPCAPIKey{{Name: "empty"}}}, logger)
+	assert.Error(t, err)
+	_, err = newRPCAuth(&RPCAuthConfig{APIKeys: []RPCAPIKey{{Key: "key", Namespaces: []string{"dual_admin"}}}}, logger)
+	assert.Error(t, err)
+}
+
+func TestRPCAccessAllow(t *testing.T) {
+	access, err := newRPCAccess("test", []string{"dual"}, []string{"kai_blockNumber"})
+	require.NoError(t, err)
+	assert.True(t, access.allow("dual_getBlock"))
+	assert.True(t, access.allow("kai_blockNumber"))
+	assert.False(t, access.allow("kai_getBlock"))
+	assert.False(t, access.allow("dualadmin_addPeer"))
+	assert.False(t, access.allow("dual"))
+
+	access, err = newRPCAccess("test", []string{"dualadmin"}, nil)
+	require.NoError(t, err)
+	assert.True(t, access.allow("dualadmin_addPeer"))
+	assert.False(t, access.allow("dual_getBlock"))
+
+	access, err = newRPCAccess("test", nil, nil)
+	require.NoError(t, err)
+	assert.True(t, access.allow("dualadmin_addPeer"))
+}
+
+func TestRPCAuthWebsocket(t *testing.T) {
+	auth := &RPCAuthConfig{APIKeys: []RPCAPIKey{
+		{Key: "meta-key", Namespaces: []string{"rpc"}},
+		{Key: "kai-key", Namespaces: []string{"kai"}},
+	}}
+	srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: []string{"*"}, Auth: auth})
+	defer srv.stop()
+	url := "ws://" + srv.listenAddr()
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	call := func(key string) authTestResponse {
+		conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{apiKeyHeader: {key}})
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]}`)))
+		var resp authTestResponse
+		require.NoError(t, conn.ReadJSON(&resp))
+		return resp
+	}
+	assert.Nil(t, call("meta-key").Error)
+	resp2 := call("kai-key")
+	require.NotNil(t, resp2.Error)
+	assert.Equal(t, accessDeniedErrorCode, resp2.Error.Code)
+}
@@ -0,0 +1,162 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/kardiachain/go-kardia/lib/metrics"
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+const (
+	// rateLimitedErrorCode is the JSON-RPC error code of throttled calls.
+	rateLimitedErrorCode = -32005
+
+	// maxRateLimitBuckets is the maximum number of clients tracked by a rate
+	// limiter, the least recently seen ones are forgotten above it.
+	maxRateLimitBuckets = 65536
+)
+
+var (
+	throttledIPMeter  = metrics.NewRegisteredMeter("rpc/throttled/ip", nil)
+	throttledKeyMeter = metrics.NewRegisteredMeter("rpc/throttled/key", nil)
+)
+
+// rateLimitedError is returned to callers exceeding their rate limit.
+type rateLimitedError struct{}
+
+func (e *rateLimitedError) ErrorCode() int { return rateLimitedErrorCode }
+
+func (e *rateLimitedError) Error() string { return "rate limit exceeded" }
+
+// tokenBucket allows rate calls per second on average, with bursts of up to
+// burst calls.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	b := float64(burst)
+	if b < 1 {
+		b = math.Max(1, math.Ceil(rate))
+	}
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: now}
+}
+
+// take refills the bucket up to now and takes a token from it, it returns false
+// if the bucket is empty.
+func (b *tokenBucket) take(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rpcLimiter rate limits the calls of every client IP, or of every caller if
+// the request is authenticated, with a token bucket each.
+type rpcLimiter struct {
+	config RPCLimitsConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets *lru.Cache // Token buckets by client IP or caller name
+}
+
+// newRPCLimiter creates the rate limiter described by config, it returns nil if
+// calls aren't rate limited.
+func newRPCLimiter(config RPCLimitsConfig) *rpcLimiter {
+	if config.IPRate <= 0 && config.KeyRate <= 0 {
+		return nil
+	}
+	buckets, _ := lru.New(maxRateLimitBuckets)
+	return &rpcLimiter{config: config, now: time.Now, buckets: buckets}
+}
+
+// handler wraps next, checking every call of a request against the bucket of
+// its client. It must be wrapped by the authentication handler, if any.
+func (l *rpcLimiter) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket, meter := l.bucket(r)
+		if bucket == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := rpc.WithAccess(r.Context(), func(method string) error {
+			if bucket.take(l.now()) {
+				return nil
+			}
+			meter.Mark(1)
+			return &rateLimitedError{}
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// bucket returns the token bucket of the client of r and the meter of its
+// throttled calls. Authenticated callers are limited by KeyRate instead of
+// IPRate, it returns nil if the client isn't rate limited.
+func (l *rpcLimiter) bucket(r *http.Request) (*tokenBucket, metrics.Meter) {
+	var (
+		id    string
+		rate  float64
+		burst int
+		meter metrics.Meter
+	)
+	if caller, ok := r.Context().Value(rpcCallerKey{}).(string); ok {
+		id, rate, burst, meter = "key/"+caller, l.config.KeyRate, l.config.KeyBurst, throttledKeyMeter
+	} else {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		id, rate, burst, meter = "ip/"+ip, l.config.IPRate, l.config.IPBurst, throttledIPMeter
+	}
+	if rate <= 0 {
+		return nil, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if bucket, ok := l.buckets.Get(id); ok {
+		return bucket.(*tokenBucket), meter
+	}
+	bucket := newTokenBucket(rate, burst, l.now())
+	l.buckets.Add(id, bucket)
+	return bucket, meter
+}
@@ -0,0 +1,121 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	bucket := newTokenBucket(2, 3, now)
+
+	// the bucket starts full
+	for i := 0; i < 3; i++ {
+		assert.True(t, bucket.take(now), "take %d", i)
+	}
+	assert.False(t, bucket.take(now))
+
+	// refilled at rate tokens per second
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, bucket.take(now))
+	assert.False(t, bucket.take(now))
+
+	// up to burst tokens
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, bucket.take(now), "take %d", i)
+	}
+	assert.False(t, bucket.take(now))
+
+	// burst defaults to the rate, at least 1
+	assert.Equal(t, float64(1), newTokenBucket(0.5, 0, now).burst)
+	assert.Equal(t, float64(3), newTokenBucket(2.5, 0, now).burst)
+}
+
+// rateLimitedCalls sends a batch of n calls and returns how many were throttled.
+func rateLimitedCalls(t *testing.T, url string, n int, headers ...string) int {
+	t.Helper()
+
+	batch := make([]json.RawMessage, n)
+	for i := range batch {
+		batch[i] = json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]}`)
+	}
+	body, err := json.Marshal(batch)
+	require.NoError(t, err)
+	status, data := authRequest(t, url, string(body), headers...)
+	require.Equal(t, http.StatusOK, status)
+	var resp []authTestResponse
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.Len(t, resp, n)
+
+	var throttled int
+	for _, r := range resp {
+		if r.Error != nil {
+			require.Equal(t, rateLimitedErrorCode, r.Error.Code)
+			throttled++
+		}
+	}
+	return throttled
+}
+
+func TestRPCRateLimit(t *testing.T) {
+	auth := &RPCAuthConfig{APIKeys: []RPCAPIKey{
+		{Name: "limited", Key: "limited-key"},
+		{Name: "other", Key: "other-key"},
+	}}
+	limits := RPCLimitsConfig{IPRate: 0.001, IPBurst: 2, KeyRate: 0.001, KeyBurst: 3}
+
+	// unauthenticated clients are limited by IP
+	srv := createAndStartServer(t, &httpConfig{Limits: limits}, false, &wsConfig{})
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+	assert.Equal(t, 3, rateLimitedCalls(t, url, 5))
+	assert.Equal(t, 2, rateLimitedCalls(t, url, 2))
+
+	// authenticated callers have a bucket each
+	authSrv := createAndStartServer(t, &httpConfig{Auth: auth, Limits: limits}, false, &wsConfig{})
+	defer authSrv.stop()
+	url = "http://" + authSrv.listenAddr()
+	assert.Equal(t, 2, rateLimitedCalls(t, url, 5, apiKeyHeader, "limited-key"))
+	assert.Equal(t, 1, rateLimitedCalls(t, url, 1, apiKeyHeader, "limited-key"))
+	assert.Equal(t, 0, rateLimitedCalls(t, url, 3, apiKeyHeader, "other-key"))
+}
+
+func TestRPCBatchLimit(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{Limits: RPCLimitsConfig{BatchLimit: 2}}, false, &wsConfig{})
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	assert.Equal(t, 0, rateLimitedCalls(t, url, 2))
+
+	call := `{"jsonrpc":"2.0","id":1,"method":"rpc_modules","params":[]}`
+	status, data := authRequest(t, url, "["+call+","+call+","+call+"]")
+	require.Equal(t, http.StatusOK, status)
+	var resp authTestResponse
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32600, resp.Error.Code)
+}
@@ -41,12 +41,16 @@ type httpConfig struct {
 	Modules            []string
 	CorsAllowedOrigins []string
 	Vhosts             []string
+	Auth               *RPCAuthConfig
+	Limits             RPCLimitsConfig
 }
 
 // wsConfig is the JSON-RPC/Websocket configuration
 type wsConfig struct {
 	Origins []string
 	Modules []string
+	Auth    *RPCAuthConfig
+	Limits  RPCLimitsConfig
 }
 
 type rpcHandler struct {
@@ -157,6 +161,7 @@ func (h *httpServer) start() error {
 		"endpoint", listener.Addr(),
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ","),
 		"vhosts", strings.Join(h.httpConfig.Vhosts, ","),
+		"auth", h.httpConfig.Auth != nil,
 	)
 
 	// Log all handlers mounted on server.
@@ -215,7 +220,7 @@ func (h *httpServer) doStop() {
 
 	// Shut down the server.
 	httpHandler := h.httpHandler.Load().(*rpcHandler)
-	wsHandler := h.httpHandler.Load().(*rpcHandler)
+	wsHandler := h.wsHandler.Load().(*rpcHandler)
 	if httpHandler != nil {
 		h.httpHandler.Store((*rpcHandler)(nil))
 		httpHandler.server.Stop()
@@ -243,13 +248,20 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	}
 
 	// Create RPC server and handler.
+	auth, err := newRPCAuth(config.Auth, h.log)
+	if err != nil {
+		return err
+	}
+	config.Modules = filterLocalModules(h.host, config.Modules, h.log)
 	srv := rpc.NewServer()
+	srv.SetLimits(config.Limits.BatchLimit, config.Limits.ResponseSizeLimit)
 	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
 		return err
 	}
+	handler := newRPCAccessHandler(srv, auth, newRPCLimiter(config.Limits))
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts),
+		Handler: NewHTTPHandlerStack(handler, config.CorsAllowedOrigins, config.Vhosts),
 		server:  srv,
 	})
 	return nil
@@ -275,13 +287,19 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	}
 
 	// Create RPC server and handler.
+	auth, err := newRPCAuth(config.Auth, h.log)
+	if err != nil {
+		return err
+	}
+	config.Modules = filterLocalModules(h.host, config.Modules, h.log)
 	srv := rpc.NewServer()
+	srv.SetLimits(config.Limits.BatchLimit, config.Limits.ResponseSizeLimit)
 	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: srv.WebsocketHandler(config.Origins),
+		Handler: newRPCAccessHandler(srv.WebsocketHandler(config.Origins), auth, newRPCLimiter(config.Limits)),
 		server:  srv,
 	})
 	return nil
@@ -319,12 +337,54 @@ func (h *httpServer) wsAllowed() bool {
 	return h.wsHandler.Load().(*rpcHandler) != nil
 }
 
+// localModules are the modules only served over HTTP and WebSocket when
+// listening on a loopback interface, even with authentication enabled.
+var localModules = map[string]bool{"admin": true, "dualadmin": true}
+
+// filterLocalModules removes localModules from modules unless host is a
+// loopback interface.
+func filterLocalModules(host string, modules []string, log log.Logger) []string {
+	if isLoopbackHost(host) {
+		return modules
+	}
+	filtered := make([]string, 0, len(modules))
+	for _, module := range modules {
+		if localModules[module] {
+			log.Warn("Module is only served on localhost, ignoring it", "module", module, "host", host)
+			continue
+		}
+		filtered = append(filtered, module)
+	}
+	return filtered
+}
+
+// isLoopbackHost returns true if host only accepts local connections.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // isWebsocket checks the header of an http request for a websocket upgrade request.
 func isWebsocket(r *http.Request) bool {
 	return strings.ToLower(r.Header.Get("Upgrade")) == "websocket" &&
 		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
 }
 
+// newRPCAccessHandler wraps next with the authentication and the rate limiter,
+// if any. Requests are authenticated first so callers are limited by identity.
+func newRPCAccessHandler(next http.Handler, auth *rpcAuth, limiter *rpcLimiter) http.Handler {
+	if limiter != nil {
+		next = limiter.handler(next)
+	}
+	if auth != nil {
+		next = auth.handler(next)
+	}
+	return next
+}
+
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string) http.Handler {
 	// Wrap the CORS-handler within a host-handler
@@ -66,9 +66,156 @@ func (m *Txs) GetTxs() [][]byte {
 	return nil
 }
 
+// AnnounceTxs announces the hashes of transactions available from the sender.
+type AnnounceTxs struct {
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (m *AnnounceTxs) Reset()         { *m = AnnounceTxs{} }
+func (m *AnnounceTxs) String() string { return proto.CompactTextString(m) }
+func (*AnnounceTxs) ProtoMessage()    {}
+func (*AnnounceTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb40ebb23df31a9b, []int{1}
+}
+func (m *AnnounceTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AnnounceTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AnnounceTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AnnounceTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnounceTxs.Merge(m, src)
+}
+func (m *AnnounceTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *AnnounceTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnounceTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnounceTxs proto.InternalMessageInfo
+
+func (m *AnnounceTxs) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+// RequestTxs requests the transactions with the given hashes.
+type RequestTxs struct {
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (m *RequestTxs) Reset()         { *m = RequestTxs{} }
+func (m *RequestTxs) String() string { return proto.CompactTextString(m) }
+func (*RequestTxs) ProtoMessage()    {}
+func (*RequestTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb40ebb23df31a9b, []int{2}
+}
+func (m *RequestTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestTxs.Merge(m, src)
+}
+func (m *RequestTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestTxs proto.InternalMessageInfo
+
+func (m *RequestTxs) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+// PrivateTxs forwards private transactions to a proposer, they must not be
+// included after max_height.
+type PrivateTxs struct {
+	Txs       [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	MaxHeight uint64   `protobuf:"varint,2,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+}
+
+func (m *PrivateTxs) Reset()         { *m = PrivateTxs{} }
+func (m *PrivateTxs) String() string { return proto.CompactTextString(m) }
+func (*PrivateTxs) ProtoMessage()    {}
+func (*PrivateTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb40ebb23df31a9b, []int{3}
+}
+func (m *PrivateTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrivateTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrivateTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PrivateTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrivateTxs.Merge(m, src)
+}
+func (m *PrivateTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrivateTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrivateTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrivateTxs proto.InternalMessageInfo
+
+func (m *PrivateTxs) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *PrivateTxs) GetMaxHeight() uint64 {
+	if m != nil {
+		return m.MaxHeight
+	}
+	return 0
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_Txs
+	//	*Message_AnnounceTxs
+	//	*Message_RequestTxs
+	//	*Message_PrivateTxs
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -76,7 +223,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb40ebb23df31a9b, []int{1}
+	return fileDescriptor_cb40ebb23df31a9b, []int{4}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -114,8 +261,20 @@ type isMessage_Sum interface {
 type Message_Txs struct {
 	Txs *Txs `protobuf:"bytes,1,opt,name=txs,proto3,oneof" json:"txs,omitempty"`
 }
+type Message_AnnounceTxs struct {
+	AnnounceTxs *AnnounceTxs `protobuf:"bytes,2,opt,name=announce_txs,json=announceTxs,proto3,oneof" json:"announce_txs,omitempty"`
+}
+type Message_RequestTxs struct {
+	RequestTxs *RequestTxs `protobuf:"bytes,3,opt,name=request_txs,json=requestTxs,proto3,oneof" json:"request_txs,omitempty"`
+}
+type Message_PrivateTxs struct {
+	PrivateTxs *PrivateTxs `protobuf:"bytes,4,opt,name=private_txs,json=privateTxs,proto3,oneof" json:"private_txs,omitempty"`
+}
 
-func (*Message_Txs) isMessage_Sum() {}
+func (*Message_Txs) isMessage_Sum()         {}
+func (*Message_AnnounceTxs) isMessage_Sum() {}
+func (*Message_RequestTxs) isMessage_Sum()  {}
+func (*Message_PrivateTxs) isMessage_Sum()  {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -131,34 +290,70 @@ func (m *Message) GetTxs() *Txs {
 	return nil
 }
 
+func (m *Message) GetAnnounceTxs() *AnnounceTxs {
+	if x, ok := m.GetSum().(*Message_AnnounceTxs); ok {
+		return x.AnnounceTxs
+	}
+	return nil
+}
+
+func (m *Message) GetRequestTxs() *RequestTxs {
+	if x, ok := m.GetSum().(*Message_RequestTxs); ok {
+		return x.RequestTxs
+	}
+	return nil
+}
+
+func (m *Message) GetPrivateTxs() *PrivateTxs {
+	if x, ok := m.GetSum().(*Message_PrivateTxs); ok {
+		return x.PrivateTxs
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Message_Txs)(nil),
+		(*Message_AnnounceTxs)(nil),
+		(*Message_RequestTxs)(nil),
+		(*Message_PrivateTxs)(nil),
 	}
 }
 
 func init() {
 	proto.RegisterType((*Txs)(nil), "kardiachain.state.Txs")
+	proto.RegisterType((*AnnounceTxs)(nil), "kardiachain.state.AnnounceTxs")
+	proto.RegisterType((*RequestTxs)(nil), "kardiachain.state.RequestTxs")
+	proto.RegisterType((*PrivateTxs)(nil), "kardiachain.state.PrivateTxs")
 	proto.RegisterType((*Message)(nil), "kardiachain.state.Message")
 }
 
 func init() { proto.RegisterFile("kardiachain/txpool/types.proto", fileDescriptor_cb40ebb23df31a9b) }
 
 var fileDescriptor_cb40ebb23df31a9b = []byte{
-	// 187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcb, 0x4e, 0x2c, 0x4a,
-	0xc9, 0x4c, 0x4c, 0xce, 0x48, 0xcc, 0xcc, 0xd3, 0x2f, 0xa9, 0x28, 0xc8, 0xcf, 0xcf, 0xd1, 0x2f,
-	0xa9, 0x2c, 0x48, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x44, 0x92, 0xd7, 0x2b,
-	0x2e, 0x49, 0x2c, 0x49, 0x55, 0x12, 0xe7, 0x62, 0x0e, 0xa9, 0x28, 0x16, 0x12, 0xe0, 0x62, 0x2e,
-	0xa9, 0x28, 0x96, 0x60, 0x54, 0x60, 0xd6, 0xe0, 0x09, 0x02, 0x31, 0x95, 0x6c, 0xb8, 0xd8, 0x7d,
-	0x53, 0x8b, 0x8b, 0x13, 0xd3, 0x53, 0x85, 0xb4, 0x60, 0x92, 0x8c, 0x1a, 0xdc, 0x46, 0x62, 0x7a,
-	0x18, 0x86, 0xe8, 0x85, 0x54, 0x14, 0x7b, 0x30, 0x80, 0xb5, 0x39, 0xb1, 0x72, 0x31, 0x17, 0x97,
-	0xe6, 0x3a, 0x05, 0x9d, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c,
-	0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x45, 0x7a,
-	0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0xb2, 0x73, 0xd3, 0xf3, 0x75, 0x21,
-	0x5c, 0x7d, 0xb0, 0x5b, 0x51, 0xe4, 0xc0, 0xb6, 0x24, 0xb1, 0x81, 0x25, 0x8c, 0x01, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x6a, 0xfd, 0x6d, 0x25, 0xe6, 0x00, 0x00, 0x00,
+	// 329 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x31, 0x4b, 0xc3, 0x40,
+	0x14, 0xc7, 0x73, 0x4d, 0xad, 0xf8, 0xd2, 0x41, 0x33, 0xd4, 0x2e, 0x3d, 0x4a, 0x50, 0x28, 0x82,
+	0x09, 0xe8, 0xe2, 0x22, 0xd4, 0xba, 0x64, 0x11, 0xe4, 0xe8, 0xe4, 0x52, 0xae, 0xf5, 0xc8, 0x05,
+	0x4d, 0x2e, 0xe6, 0x2e, 0x72, 0x7e, 0x00, 0x77, 0x3f, 0x96, 0x63, 0x47, 0x47, 0x69, 0xbf, 0x88,
+	0xe4, 0xd2, 0xda, 0x88, 0xd5, 0xed, 0xde, 0xbd, 0xf7, 0xff, 0x3d, 0xfe, 0x7f, 0x1e, 0xe0, 0x07,
+	0x9a, 0xdf, 0xc7, 0x74, 0xc6, 0x69, 0x9c, 0x06, 0x4a, 0x67, 0x42, 0x3c, 0x06, 0xea, 0x25, 0x63,
+	0xd2, 0xcf, 0x72, 0xa1, 0x84, 0x7b, 0x50, 0xeb, 0xfb, 0x52, 0x51, 0xc5, 0xbc, 0x43, 0xb0, 0xc7,
+	0x5a, 0xba, 0xfb, 0x60, 0x2b, 0x2d, 0xbb, 0xa8, 0x6f, 0x0f, 0xda, 0xa4, 0x7c, 0x7a, 0xc7, 0xe0,
+	0x5c, 0xa5, 0xa9, 0x28, 0xd2, 0x19, 0x2b, 0x07, 0x3a, 0xd0, 0xe2, 0x54, 0x72, 0xb6, 0x9e, 0x59,
+	0x55, 0xde, 0x11, 0x00, 0x61, 0x4f, 0x05, 0x93, 0xea, 0xbf, 0xa9, 0x4b, 0x80, 0xdb, 0x3c, 0x7e,
+	0xa6, 0x8a, 0x6d, 0x5d, 0xe6, 0xf6, 0x00, 0x12, 0xaa, 0x27, 0x9c, 0xc5, 0x11, 0x57, 0xdd, 0x46,
+	0x1f, 0x0d, 0x9a, 0x64, 0x2f, 0xa1, 0x3a, 0x34, 0x1f, 0xde, 0x6b, 0x03, 0x76, 0x6f, 0x98, 0x94,
+	0x34, 0x62, 0xee, 0xc9, 0x5a, 0x8c, 0x06, 0xce, 0x59, 0xc7, 0xff, 0xe5, 0xc8, 0x1f, 0x6b, 0x19,
+	0x5a, 0x15, 0xf6, 0x1a, 0xda, 0x74, 0xe5, 0x61, 0x52, 0x8a, 0x1a, 0x46, 0x84, 0xb7, 0x88, 0x6a,
+	0x56, 0x43, 0x8b, 0x38, 0xb4, 0xe6, 0x7c, 0x08, 0x4e, 0x5e, 0x39, 0x34, 0x0c, 0xdb, 0x30, 0x7a,
+	0x5b, 0x18, 0x9b, 0x1c, 0x42, 0x8b, 0x40, 0xbe, 0x49, 0x65, 0x08, 0x4e, 0x56, 0xb9, 0x37, 0x84,
+	0xe6, 0x9f, 0x84, 0x4d, 0x46, 0x25, 0x21, 0xfb, 0xae, 0x46, 0x3b, 0x60, 0xcb, 0x22, 0x19, 0x91,
+	0xf7, 0x05, 0x46, 0xf3, 0x05, 0x46, 0x9f, 0x0b, 0x8c, 0xde, 0x96, 0xd8, 0x9a, 0x2f, 0xb1, 0xf5,
+	0xb1, 0xc4, 0xd6, 0xdd, 0x45, 0x14, 0x2b, 0x5e, 0x4c, 0xfd, 0x99, 0x48, 0x82, 0xfa, 0x11, 0x44,
+	0xe2, 0xb4, 0x2a, 0x03, 0x73, 0x01, 0x3f, 0x7a, 0x66, 0xe7, 0xb4, 0x65, 0x1a, 0xe7, 0x5f, 0x03,
+	0x00, 0x4b, 0xe4, 0x46, 0xee, 0x3c, 0x02, 0x00, 0x00,
 }
 
 func (m *Txs) Marshal() (dAtA []byte, err error) {
@@ -193,6 +388,107 @@ func (m *Txs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AnnounceTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AnnounceTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AnnounceTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hashes) > 0 {
+		for iNdEx := len(m.Hashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Hashes[iNdEx])
+			copy(dAtA[i:], m.Hashes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Hashes[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hashes) > 0 {
+		for iNdEx := len(m.Hashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Hashes[iNdEx])
+			copy(dAtA[i:], m.Hashes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Hashes[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrivateTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrivateTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrivateTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -246,6 +542,69 @@ func (m *Message_Txs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_AnnounceTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_AnnounceTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AnnounceTxs != nil {
+		{
+			size, err := m.AnnounceTxs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_RequestTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_RequestTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestTxs != nil {
+		{
+			size, err := m.RequestTxs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PrivateTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PrivateTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PrivateTxs != nil {
+		{
+			size, err := m.PrivateTxs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -272,35 +631,119 @@ func (m *Txs) Size() (n int) {
 	return n
 }
 
-func (m *Message) Size() (n int) {
+func (m *AnnounceTxs) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Sum != nil {
-		n += m.Sum.Size()
+	if len(m.Hashes) > 0 {
+		for _, b := range m.Hashes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
 	}
 	return n
 }
 
-func (m *Message_Txs) Size() (n int) {
+func (m *RequestTxs) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Txs != nil {
-		l = m.Txs.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.Hashes) > 0 {
+		for _, b := range m.Hashes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
 	}
 	return n
 }
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *PrivateTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxHeight != 0 {
+		n += 1 + sovTypes(uint64(m.MaxHeight))
+	}
+	return n
 }
-func sozTypes(x uint64) (n int) {
+
+func (m *Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sum != nil {
+		n += m.Sum.Size()
+	}
+	return n
+}
+
+func (m *Message_Txs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Txs != nil {
+		l = m.Txs.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_AnnounceTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AnnounceTxs != nil {
+		l = m.AnnounceTxs.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_RequestTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestTxs != nil {
+		l = m.RequestTxs.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_PrivateTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PrivateTxs != nil {
+		l = m.PrivateTxs.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
 	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *Txs) Unmarshal(dAtA []byte) error {
@@ -388,6 +831,271 @@ func (m *Txs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AnnounceTxs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AnnounceTxs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AnnounceTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hashes = append(m.Hashes, make([]byte, postIndex-iNdEx))
+			copy(m.Hashes[len(m.Hashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestTxs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestTxs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hashes = append(m.Hashes, make([]byte, postIndex-iNdEx))
+			copy(m.Hashes[len(m.Hashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrivateTxs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrivateTxs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrivateTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxHeight", wireType)
+			}
+			m.MaxHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -452,6 +1160,111 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_Txs{v}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AnnounceTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AnnounceTxs{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_AnnounceTxs{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestTxs{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_RequestTxs{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PrivateTxs{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_PrivateTxs{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -105,9 +105,88 @@ func (m *DuplicateVoteEvidence) GetTimestamp() time.Time {
 	return time.Time{}
 }
 
+// LightClientAttackEvidence contains evidence of a set of validators attempting
+// to mislead a light client.
+type LightClientAttackEvidence struct {
+	ConflictingBlock    *LightBlock  `protobuf:"bytes,1,opt,name=conflicting_block,json=conflictingBlock,proto3" json:"conflicting_block,omitempty"`
+	CommonHeight        uint64       `protobuf:"varint,2,opt,name=common_height,json=commonHeight,proto3" json:"common_height,omitempty"`
+	ByzantineValidators []*Validator `protobuf:"bytes,3,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators,omitempty"`
+	TotalVotingPower    int64        `protobuf:"varint,4,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
+	Timestamp           time.Time    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+}
+
+func (m *LightClientAttackEvidence) Reset()         { *m = LightClientAttackEvidence{} }
+func (m *LightClientAttackEvidence) String() string { return proto.CompactTextString(m) }
+func (*LightClientAttackEvidence) ProtoMessage()    {}
+func (*LightClientAttackEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9916f59e043142ef, []int{1}
+}
+func (m *LightClientAttackEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LightClientAttackEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LightClientAttackEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LightClientAttackEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LightClientAttackEvidence.Merge(m, src)
+}
+func (m *LightClientAttackEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *LightClientAttackEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_LightClientAttackEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LightClientAttackEvidence proto.InternalMessageInfo
+
+func (m *LightClientAttackEvidence) GetConflictingBlock() *LightBlock {
+	if m != nil {
+		return m.ConflictingBlock
+	}
+	return nil
+}
+
+func (m *LightClientAttackEvidence) GetCommonHeight() uint64 {
+	if m != nil {
+		return m.CommonHeight
+	}
+	return 0
+}
+
+func (m *LightClientAttackEvidence) GetByzantineValidators() []*Validator {
+	if m != nil {
+		return m.ByzantineValidators
+	}
+	return nil
+}
+
+func (m *LightClientAttackEvidence) GetTotalVotingPower() int64 {
+	if m != nil {
+		return m.TotalVotingPower
+	}
+	return 0
+}
+
+func (m *LightClientAttackEvidence) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
 type Evidence struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Evidence_DuplicateVoteEvidence
+	//	*Evidence_LightClientAttackEvidence
 	Sum isEvidence_Sum `protobuf_oneof:"sum"`
 }
 
@@ -115,7 +194,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9916f59e043142ef, []int{1}
+	return fileDescriptor_9916f59e043142ef, []int{2}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -153,8 +232,12 @@ type isEvidence_Sum interface {
 type Evidence_DuplicateVoteEvidence struct {
 	DuplicateVoteEvidence *DuplicateVoteEvidence `protobuf:"bytes,1,opt,name=duplicate_vote_evidence,json=duplicateVoteEvidence,proto3,oneof" json:"duplicate_vote_evidence,omitempty"`
 }
+type Evidence_LightClientAttackEvidence struct {
+	LightClientAttackEvidence *LightClientAttackEvidence `protobuf:"bytes,2,opt,name=light_client_attack_evidence,json=lightClientAttackEvidence,proto3,oneof" json:"light_client_attack_evidence,omitempty"`
+}
 
-func (*Evidence_DuplicateVoteEvidence) isEvidence_Sum() {}
+func (*Evidence_DuplicateVoteEvidence) isEvidence_Sum()     {}
+func (*Evidence_LightClientAttackEvidence) isEvidence_Sum() {}
 
 func (m *Evidence) GetSum() isEvidence_Sum {
 	if m != nil {
@@ -170,10 +253,18 @@ func (m *Evidence) GetDuplicateVoteEvidence() *DuplicateVoteEvidence {
 	return nil
 }
 
+func (m *Evidence) GetLightClientAttackEvidence() *LightClientAttackEvidence {
+	if x, ok := m.GetSum().(*Evidence_LightClientAttackEvidence); ok {
+		return x.LightClientAttackEvidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Evidence) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Evidence_DuplicateVoteEvidence)(nil),
+		(*Evidence_LightClientAttackEvidence)(nil),
 	}
 }
 
@@ -186,7 +277,7 @@ func (m *EvidenceData) Reset()         { *m = EvidenceData{} }
 func (m *EvidenceData) String() string { return proto.CompactTextString(m) }
 func (*EvidenceData) ProtoMessage()    {}
 func (*EvidenceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9916f59e043142ef, []int{2}
+	return fileDescriptor_9916f59e043142ef, []int{3}
 }
 func (m *EvidenceData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -224,6 +315,7 @@ func (m *EvidenceData) GetEvidence() []Evidence {
 
 func init() {
 	proto.RegisterType((*DuplicateVoteEvidence)(nil), "kardiachain.types.DuplicateVoteEvidence")
+	proto.RegisterType((*LightClientAttackEvidence)(nil), "kardiachain.types.LightClientAttackEvidence")
 	proto.RegisterType((*Evidence)(nil), "kardiachain.types.Evidence")
 	proto.RegisterType((*EvidenceData)(nil), "kardiachain.types.EvidenceData")
 }
@@ -231,32 +323,41 @@ func init() {
 func init() { proto.RegisterFile("kardiachain/types/evidence.proto", fileDescriptor_9916f59e043142ef) }
 
 var fileDescriptor_9916f59e043142ef = []byte{
-	// 388 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcd, 0x4a, 0xc3, 0x40,
-	0x10, 0xc7, 0x93, 0xa6, 0x2d, 0x75, 0x2b, 0x7e, 0x04, 0x4b, 0x43, 0xc5, 0xb4, 0xf4, 0x62, 0x0e,
-	0xba, 0x81, 0x7a, 0xf1, 0xe2, 0xc1, 0x50, 0xc1, 0x8b, 0x20, 0x41, 0x7a, 0xf0, 0x12, 0x36, 0xc9,
-	0x9a, 0x2e, 0x26, 0xdd, 0x90, 0x6e, 0x22, 0xbe, 0x45, 0xf1, 0xa9, 0x7a, 0xec, 0xd1, 0x93, 0x4a,
-	0xfb, 0x22, 0x92, 0xcd, 0x87, 0x85, 0xe6, 0xe0, 0x25, 0x64, 0xe7, 0xff, 0x9b, 0x9d, 0x99, 0xff,
-	0x0e, 0x18, 0xbc, 0xa2, 0xc8, 0x25, 0xc8, 0x99, 0x22, 0x32, 0xd3, 0xd9, 0x7b, 0x88, 0xe7, 0x3a,
-	0x4e, 0x88, 0x8b, 0x67, 0x0e, 0x86, 0x61, 0x44, 0x19, 0x95, 0x8f, 0xb7, 0x08, 0xc8, 0x89, 0xde,
-	0x89, 0x47, 0x3d, 0xca, 0x55, 0x3d, 0xfd, 0xcb, 0xc0, 0xde, 0xd9, 0xee, 0x55, 0xfc, 0x9b, 0xcb,
-	0x7d, 0x8f, 0x52, 0xcf, 0xc7, 0x3a, 0x3f, 0xd9, 0xf1, 0x8b, 0xce, 0x48, 0x80, 0xe7, 0x0c, 0x05,
-	0x61, 0x06, 0x0c, 0x3f, 0x6a, 0xa0, 0x33, 0x8e, 0x43, 0x9f, 0x38, 0x88, 0xe1, 0x09, 0x65, 0xf8,
-	0x2e, 0x6f, 0x44, 0x86, 0xa0, 0x99, 0x50, 0x86, 0x2d, 0xa4, 0x88, 0x03, 0x51, 0x6b, 0x8f, 0xba,
-	0x70, 0xa7, 0x27, 0x98, 0x26, 0x98, 0x8d, 0x14, 0xbb, 0x2d, 0x79, 0x5b, 0xa9, 0xfd, 0x83, 0x37,
-	0xe4, 0x0b, 0x20, 0x33, 0xca, 0x90, 0x6f, 0x25, 0x94, 0x91, 0x99, 0x67, 0x85, 0xf4, 0x0d, 0x47,
-	0x8a, 0x34, 0x10, 0x35, 0xc9, 0x3c, 0xe2, 0xca, 0x84, 0x0b, 0x8f, 0x69, 0x5c, 0x3e, 0x07, 0x87,
-	0x09, 0xf2, 0x89, 0x8b, 0x18, 0x8d, 0x72, 0xb4, 0xce, 0xd1, 0x83, 0x32, 0x9c, 0x81, 0x06, 0xd8,
-	0x2b, 0x67, 0x54, 0x1a, 0xbc, 0x93, 0x1e, 0xcc, 0x5c, 0x80, 0x85, 0x0b, 0xf0, 0xa9, 0x20, 0x8c,
-	0xd6, 0xf2, 0xab, 0x2f, 0x2c, 0xbe, 0xfb, 0xa2, 0xf9, 0x97, 0x36, 0x8c, 0x41, 0xab, 0xb4, 0xc1,
-	0x06, 0x5d, 0xb7, 0xf0, 0xc7, 0xe2, 0x03, 0x16, 0x4f, 0x95, 0xfb, 0xa2, 0x55, 0xcc, 0x59, 0xe9,
-	0xe8, 0xbd, 0x60, 0x76, 0xdc, 0x2a, 0xc1, 0x68, 0x00, 0x69, 0x1e, 0x07, 0xc3, 0x07, 0xb0, 0x5f,
-	0x84, 0xc6, 0x88, 0x21, 0xf9, 0x06, 0xb4, 0xb6, 0x6a, 0x49, 0x5a, 0x7b, 0x74, 0x5a, 0x51, 0xab,
-	0xbc, 0xa5, 0x9e, 0x8e, 0x62, 0x96, 0x29, 0x86, 0xb9, 0x5c, 0xab, 0xe2, 0x6a, 0xad, 0x8a, 0x3f,
-	0x6b, 0x55, 0x5c, 0x6c, 0x54, 0x61, 0xb5, 0x51, 0x85, 0xcf, 0x8d, 0x2a, 0x3c, 0x5f, 0x7b, 0x84,
-	0x4d, 0x63, 0x1b, 0x3a, 0x34, 0xd0, 0xb7, 0xf7, 0xc7, 0xa3, 0x97, 0xd9, 0x31, 0xdb, 0x17, 0x7d,
-	0x67, 0xb7, 0xec, 0x26, 0x17, 0xae, 0x7e, 0x03, 0x00, 0x00, 0xff, 0xff, 0xeb, 0x0a, 0x1a, 0xc5,
-	0xc2, 0x02, 0x00, 0x00,
+	// 539 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0x93, 0xb5, 0x9d, 0x8a, 0x37, 0x60, 0x0b, 0x9b, 0x96, 0x95, 0x2d, 0x2d, 0xe5, 0x40,
+	0x0f, 0x23, 0x91, 0xc6, 0x85, 0x0b, 0x87, 0x85, 0x21, 0x55, 0x08, 0x04, 0xb2, 0xd0, 0x0e, 0x5c,
+	0x22, 0xc7, 0xf1, 0x52, 0xab, 0x49, 0x5e, 0xd4, 0xb8, 0x45, 0xe3, 0x53, 0x4c, 0x7c, 0xaa, 0xdd,
+	0xd8, 0x91, 0x13, 0xa0, 0x56, 0xe2, 0x73, 0xa0, 0x38, 0x89, 0x57, 0xa9, 0xa9, 0xc4, 0x81, 0x4b,
+	0x14, 0xbf, 0xf7, 0x7b, 0xf6, 0x7b, 0xff, 0xf7, 0x6c, 0xd4, 0x1b, 0x93, 0x49, 0xc0, 0x09, 0x1d,
+	0x11, 0x9e, 0x38, 0xe2, 0x2a, 0x65, 0x99, 0xc3, 0x66, 0x3c, 0x60, 0x09, 0x65, 0x76, 0x3a, 0x01,
+	0x01, 0xc6, 0xee, 0x12, 0x61, 0x4b, 0xa2, 0xb3, 0x17, 0x42, 0x08, 0xd2, 0xeb, 0xe4, 0x7f, 0x05,
+	0xd8, 0x39, 0x5e, 0xdd, 0x4a, 0x7e, 0x4b, 0x77, 0x37, 0x04, 0x08, 0x23, 0xe6, 0xc8, 0x95, 0x3f,
+	0xbd, 0x74, 0x04, 0x8f, 0x59, 0x26, 0x48, 0x9c, 0x96, 0xc0, 0x93, 0xd5, 0xf8, 0x19, 0x89, 0x78,
+	0x40, 0x04, 0x4c, 0x0a, 0xa4, 0xff, 0x6d, 0x03, 0xed, 0x9f, 0x4f, 0xd3, 0x88, 0x53, 0x22, 0xd8,
+	0x05, 0x08, 0xf6, 0xa6, 0xcc, 0xd5, 0xb0, 0xd1, 0xe6, 0x0c, 0x04, 0xf3, 0x88, 0xa9, 0xf7, 0xf4,
+	0xc1, 0xd6, 0xe9, 0x81, 0xbd, 0x92, 0xb6, 0x9d, 0x07, 0xe0, 0x56, 0x8e, 0x9d, 0x29, 0xde, 0x37,
+	0x37, 0xfe, 0x81, 0x77, 0x8d, 0x13, 0x64, 0x08, 0x10, 0x24, 0xf2, 0x66, 0x20, 0x78, 0x12, 0x7a,
+	0x29, 0x7c, 0x61, 0x13, 0xb3, 0xd1, 0xd3, 0x07, 0x0d, 0xbc, 0x23, 0x3d, 0x17, 0xd2, 0xf1, 0x31,
+	0xb7, 0x1b, 0xcf, 0xd0, 0x43, 0x95, 0x7a, 0x89, 0x36, 0x25, 0xfa, 0x40, 0x99, 0x0b, 0xd0, 0x45,
+	0xf7, 0x94, 0x0c, 0x66, 0x4b, 0x66, 0xd2, 0xb1, 0x0b, 0xa1, 0xec, 0x4a, 0x28, 0xfb, 0x53, 0x45,
+	0xb8, 0xed, 0x9b, 0x9f, 0x5d, 0xed, 0xfa, 0x57, 0x57, 0xc7, 0x77, 0x61, 0xfd, 0xef, 0x1b, 0xe8,
+	0xf0, 0x1d, 0x0f, 0x47, 0xe2, 0x75, 0xc4, 0x59, 0x22, 0xce, 0x84, 0x20, 0x74, 0xac, 0x84, 0x79,
+	0x8b, 0x76, 0x29, 0x24, 0x97, 0x11, 0xa7, 0x32, 0x6f, 0x3f, 0x02, 0x3a, 0x2e, 0x35, 0x3a, 0xae,
+	0xa9, 0x59, 0x6e, 0xe4, 0xe6, 0x10, 0xde, 0x59, 0x8a, 0x93, 0x16, 0xe3, 0x29, 0xba, 0x4f, 0x21,
+	0x8e, 0x21, 0xf1, 0x46, 0x2c, 0xe7, 0xa4, 0x76, 0x4d, 0xbc, 0x5d, 0x18, 0x87, 0xd2, 0x66, 0x7c,
+	0x40, 0x7b, 0xfe, 0xd5, 0x57, 0x92, 0x08, 0x9e, 0x30, 0x4f, 0x95, 0x9b, 0x99, 0x8d, 0x5e, 0x63,
+	0xb0, 0x75, 0x7a, 0x54, 0xa7, 0x73, 0x05, 0xe1, 0x47, 0x2a, 0x52, 0xd9, 0xb2, 0x35, 0xd2, 0x37,
+	0xd7, 0x48, 0xff, 0x3f, 0x14, 0xfd, 0xa3, 0xa3, 0xb6, 0x12, 0xd0, 0x47, 0x07, 0x41, 0x35, 0x72,
+	0x9e, 0x9c, 0x99, 0xea, 0x82, 0x94, 0x32, 0x0e, 0x6a, 0x4a, 0xaa, 0x1d, 0xd2, 0xa1, 0x86, 0xf7,
+	0x83, 0xda, 0xe9, 0x05, 0x74, 0x14, 0xe5, 0xe2, 0x79, 0x54, 0xb6, 0xd0, 0x23, 0xb2, 0x87, 0x77,
+	0x07, 0x15, 0x33, 0x7a, 0xb2, 0xae, 0x5f, 0x75, 0x8d, 0x1f, 0x6a, 0xf8, 0x30, 0x5a, 0xe7, 0x74,
+	0x5b, 0xa8, 0x91, 0x4d, 0xe3, 0xfe, 0x7b, 0xb4, 0x5d, 0x99, 0xce, 0x89, 0x20, 0xc6, 0x2b, 0xd4,
+	0x5e, 0x2a, 0x2e, 0xef, 0xd7, 0xe3, 0x9a, 0x33, 0xd5, 0x2e, 0xcd, 0x5c, 0x3c, 0xac, 0x42, 0x5c,
+	0x7c, 0x33, 0xb7, 0xf4, 0xdb, 0xb9, 0xa5, 0xff, 0x9e, 0x5b, 0xfa, 0xf5, 0xc2, 0xd2, 0x6e, 0x17,
+	0x96, 0xf6, 0x63, 0x61, 0x69, 0x9f, 0x5f, 0x86, 0x5c, 0x8c, 0xa6, 0xbe, 0x4d, 0x21, 0x76, 0x96,
+	0xaf, 0x79, 0x08, 0xcf, 0x8b, 0x65, 0xf1, 0x2c, 0x38, 0x2b, 0x4f, 0x80, 0xbf, 0x29, 0x1d, 0x2f,
+	0xfe, 0x0e, 0x00, 0x74, 0xc7, 0x13, 0xd9, 0xa9, 0x04, 0x00, 0x00,
 }
 
 func (m *DuplicateVoteEvidence) Marshal() (dAtA []byte, err error) {
@@ -324,6 +425,73 @@ func (m *DuplicateVoteEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *LightClientAttackEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LightClientAttackEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintEvidence(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x2a
+	if m.TotalVotingPower != 0 {
+		i = encodeVarintEvidence(dAtA, i, uint64(m.TotalVotingPower))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ByzantineValidators) > 0 {
+		for iNdEx := len(m.ByzantineValidators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ByzantineValidators[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEvidence(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.CommonHeight != 0 {
+		i = encodeVarintEvidence(dAtA, i, uint64(m.CommonHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ConflictingBlock != nil {
+		{
+			size, err := m.ConflictingBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Evidence) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -377,6 +545,27 @@ func (m *Evidence_DuplicateVoteEvidence) MarshalToSizedBuffer(dAtA []byte) (int,
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Evidence_LightClientAttackEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence_LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LightClientAttackEvidence != nil {
+		{
+			size, err := m.LightClientAttackEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
 func (m *EvidenceData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -450,6 +639,33 @@ func (m *DuplicateVoteEvidence) Size() (n int) {
 	return n
 }
 
+func (m *LightClientAttackEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConflictingBlock != nil {
+		l = m.ConflictingBlock.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	if m.CommonHeight != 0 {
+		n += 1 + sovEvidence(uint64(m.CommonHeight))
+	}
+	if len(m.ByzantineValidators) > 0 {
+		for _, e := range m.ByzantineValidators {
+			l = e.Size()
+			n += 1 + l + sovEvidence(uint64(l))
+		}
+	}
+	if m.TotalVotingPower != 0 {
+		n += 1 + sovEvidence(uint64(m.TotalVotingPower))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovEvidence(uint64(l))
+	return n
+}
+
 func (m *Evidence) Size() (n int) {
 	if m == nil {
 		return 0
@@ -474,6 +690,18 @@ func (m *Evidence_DuplicateVoteEvidence) Size() (n int) {
 	}
 	return n
 }
+func (m *Evidence_LightClientAttackEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LightClientAttackEvidence != nil {
+		l = m.LightClientAttackEvidence.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	return n
+}
 func (m *EvidenceData) Size() (n int) {
 	if m == nil {
 		return 0
@@ -691,6 +919,200 @@ func (m *DuplicateVoteEvidence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LightClientAttackEvidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvidence
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LightClientAttackEvidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LightClientAttackEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictingBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConflictingBlock == nil {
+				m.ConflictingBlock = &LightBlock{}
+			}
+			if err := m.ConflictingBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommonHeight", wireType)
+			}
+			m.CommonHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommonHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ByzantineValidators = append(m.ByzantineValidators, &Validator{})
+			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
+			}
+			m.TotalVotingPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalVotingPower |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvidence(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Evidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -755,6 +1177,41 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Evidence_DuplicateVoteEvidence{v}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LightClientAttackEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LightClientAttackEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Evidence_LightClientAttackEvidence{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvidence(dAtA[iNdEx:])
@@ -850,6 +850,59 @@ func (m *BlockMeta) GetHeader() Header {
 	return Header{}
 }
 
+// LightBlock is a signed header with the validator set which signed it.
+type LightBlock struct {
+	SignedHeader *SignedHeader `protobuf:"bytes,1,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
+	ValidatorSet *ValidatorSet `protobuf:"bytes,2,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
+}
+
+func (m *LightBlock) Reset()         { *m = LightBlock{} }
+func (m *LightBlock) String() string { return proto.CompactTextString(m) }
+func (*LightBlock) ProtoMessage()    {}
+func (*LightBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f03c926763cb388, []int{10}
+}
+func (m *LightBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LightBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LightBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LightBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LightBlock.Merge(m, src)
+}
+func (m *LightBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *LightBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_LightBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LightBlock proto.InternalMessageInfo
+
+func (m *LightBlock) GetSignedHeader() *SignedHeader {
+	if m != nil {
+		return m.SignedHeader
+	}
+	return nil
+}
+
+func (m *LightBlock) GetValidatorSet() *ValidatorSet {
+	if m != nil {
+		return m.ValidatorSet
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("kardiachain.types.BlockIDFlag", BlockIDFlag_name, BlockIDFlag_value)
 	proto.RegisterEnum("kardiachain.types.SignedMsgType", SignedMsgType_name, SignedMsgType_value)
@@ -863,85 +916,89 @@ func init() {
 	proto.RegisterType((*Proposal)(nil), "kardiachain.types.Proposal")
 	proto.RegisterType((*SignedHeader)(nil), "kardiachain.types.SignedHeader")
 	proto.RegisterType((*BlockMeta)(nil), "kardiachain.types.BlockMeta")
+	proto.RegisterType((*LightBlock)(nil), "kardiachain.types.LightBlock")
 }
 
 func init() { proto.RegisterFile("kardiachain/types/types.proto", fileDescriptor_6f03c926763cb388) }
 
 var fileDescriptor_6f03c926763cb388 = []byte{
-	// 1153 bytes of a gzipped FileDescriptorProto
+	// 1205 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x17, 0x15, 0x25, 0x5a, 0x3f, 0x57, 0x96, 0x25, 0x13, 0x4e, 0x22, 0x2b, 0xf9, 0x64, 0x42, 0x1f,
-	0xda, 0x3a, 0xfd, 0x91, 0x92, 0xb4, 0x45, 0xd3, 0xa5, 0x65, 0x3b, 0x89, 0x10, 0x5b, 0x12, 0x28,
-	0xc5, 0x45, 0xbb, 0x21, 0x46, 0xe2, 0x98, 0x22, 0x4c, 0x71, 0x08, 0x72, 0xe4, 0xda, 0x6f, 0x50,
-	0x08, 0x5d, 0xe4, 0x05, 0xb4, 0x6a, 0x17, 0x5d, 0x17, 0xe8, 0x0b, 0x74, 0x95, 0x65, 0x76, 0xed,
-	0xca, 0x2d, 0xec, 0x5d, 0x9f, 0xa2, 0x98, 0x19, 0x8a, 0xa2, 0x2c, 0x19, 0x29, 0x9a, 0xa0, 0x1b,
-	0x41, 0x73, 0xef, 0x39, 0xc3, 0x7b, 0xcf, 0x3d, 0x33, 0x24, 0xfc, 0xef, 0x04, 0x79, 0x86, 0x85,
-	0xfa, 0x03, 0x64, 0x39, 0x35, 0x7a, 0xee, 0x62, 0x5f, 0xfc, 0x56, 0x5d, 0x8f, 0x50, 0xa2, 0xac,
-	0x47, 0xd2, 0x55, 0x9e, 0x28, 0x6d, 0x98, 0xc4, 0x24, 0x3c, 0x5b, 0x63, 0xff, 0x04, 0xb0, 0x54,
-	0x8e, 0xee, 0xd3, 0xf7, 0xce, 0x5d, 0x4a, 0x6a, 0xae, 0x47, 0xc8, 0x71, 0x90, 0xdf, 0x32, 0x09,
-	0x31, 0x6d, 0x5c, 0xe3, 0xab, 0xde, 0xe8, 0xb8, 0x46, 0xad, 0x21, 0xf6, 0x29, 0x1a, 0xba, 0x02,
-	0x50, 0xf9, 0x12, 0x72, 0x6d, 0xe4, 0xd1, 0x0e, 0xa6, 0xcf, 0x30, 0x32, 0xb0, 0xa7, 0x6c, 0xc0,
-	0x0a, 0x25, 0x14, 0xd9, 0x45, 0x49, 0x95, 0xb6, 0x73, 0x9a, 0x58, 0x28, 0x0a, 0xc8, 0x03, 0xe4,
-	0x0f, 0x8a, 0x71, 0x55, 0xda, 0x5e, 0xd5, 0xf8, 0xff, 0x8a, 0x05, 0x32, 0xa3, 0x32, 0x86, 0xe5,
-	0x18, 0xf8, 0x6c, 0xca, 0xe0, 0x0b, 0x16, 0xed, 0x9d, 0x53, 0xec, 0x07, 0x14, 0xb1, 0x50, 0x3e,
-	0x87, 0x15, 0x5e, 0x5e, 0x31, 0xa1, 0x4a, 0xdb, 0xd9, 0x47, 0x9b, 0xd5, 0x68, 0xa3, 0xa2, 0xfe,
-	0x6a, 0x9b, 0x01, 0xea, 0xf2, 0xab, 0x8b, 0xad, 0x98, 0x26, 0xd0, 0x95, 0x21, 0xa4, 0xea, 0x36,
-	0xe9, 0x9f, 0x34, 0xf6, 0xc2, 0x4a, 0xa4, 0x59, 0x25, 0x4a, 0x13, 0xf2, 0x2e, 0xf2, 0xa8, 0xee,
-	0x63, 0xaa, 0x0f, 0x78, 0x1b, 0xfc, 0xa9, 0xd9, 0x47, 0x6a, 0x75, 0x41, 0xc8, 0xea, 0x5c, 0xbb,
-	0xc1, 0x63, 0x72, 0x6e, 0x34, 0x58, 0xf9, 0x59, 0x86, 0x64, 0x20, 0xc7, 0xfb, 0x90, 0xe6, 0x64,
-	0xdd, 0x32, 0xf8, 0x9e, 0x99, 0x7a, 0xf6, 0xf2, 0x62, 0x2b, 0xb5, 0xcb, 0x62, 0x8d, 0x3d, 0x2d,
-	0xc5, 0x93, 0x0d, 0x43, 0xb9, 0x0d, 0xc9, 0x01, 0xb6, 0xcc, 0x01, 0xe5, 0x9d, 0xc9, 0x5a, 0xb0,
-	0x52, 0xee, 0x42, 0xc6, 0x44, 0xbe, 0x6e, 0x5b, 0x43, 0x8b, 0x16, 0xf3, 0x3c, 0x95, 0x36, 0x91,
-	0x7f, 0xc0, 0xd6, 0xca, 0x63, 0x90, 0xd9, 0x3c, 0x8a, 0x32, 0x2f, 0xb6, 0x54, 0x15, 0xc3, 0xaa,
-	0x4e, 0x87, 0x55, 0xed, 0x4e, 0x87, 0x55, 0x4f, 0xb3, 0x32, 0x5f, 0xfe, 0xb1, 0x25, 0x69, 0x9c,
-	0xa1, 0xec, 0x41, 0xce, 0x46, 0x3e, 0xd5, 0x7b, 0x4c, 0x15, 0x56, 0xdb, 0x4a, 0xb0, 0xc5, 0x62,
-	0xbf, 0x81, 0x70, 0x41, 0xa7, 0x59, 0x46, 0x13, 0x21, 0x43, 0xd9, 0x86, 0x02, 0xdf, 0xa5, 0x4f,
-	0x86, 0x43, 0x8b, 0xea, 0x5c, 0xd7, 0x24, 0xd7, 0x75, 0x8d, 0xc5, 0x77, 0x79, 0xf8, 0x19, 0x53,
-	0xf8, 0x2e, 0x64, 0x0c, 0x44, 0x91, 0x80, 0xa4, 0x38, 0x24, 0xcd, 0x02, 0x3c, 0xf9, 0x01, 0xe4,
-	0x4f, 0x91, 0x6d, 0x19, 0x88, 0x12, 0xcf, 0x17, 0x90, 0xb4, 0xd8, 0x65, 0x16, 0xe6, 0xc0, 0x07,
-	0xb0, 0xe1, 0xe0, 0x33, 0xaa, 0x5f, 0x47, 0x67, 0x38, 0x5a, 0x61, 0xb9, 0xa3, 0x79, 0xc6, 0x7b,
-	0xb0, 0xd6, 0x27, 0x8e, 0x8f, 0x1d, 0x7f, 0x14, 0x60, 0x81, 0x63, 0x73, 0x61, 0x94, 0xc3, 0x36,
-	0x21, 0x8d, 0x5c, 0x57, 0x00, 0xb2, 0x1c, 0x90, 0x42, 0xae, 0xcb, 0x53, 0xff, 0x87, 0x1c, 0x3e,
-	0xb5, 0x0c, 0xec, 0xf4, 0xb1, 0xc8, 0xe7, 0x78, 0x7e, 0x75, 0x1a, 0xe4, 0xa0, 0xfb, 0x50, 0x70,
-	0x3d, 0xe2, 0x12, 0x1f, 0x7b, 0x3a, 0x32, 0x0c, 0x0f, 0xfb, 0x7e, 0x71, 0x8d, 0xe3, 0xf2, 0xd3,
-	0xf8, 0x8e, 0x08, 0x2b, 0x77, 0x20, 0xe5, 0x8c, 0x86, 0x3a, 0x3d, 0xf3, 0x8b, 0x05, 0x31, 0x69,
-	0x67, 0x34, 0xec, 0x9e, 0xf9, 0x95, 0xbf, 0xe2, 0x20, 0x1f, 0x11, 0x8a, 0x95, 0xcf, 0x40, 0x66,
-	0xca, 0x73, 0x87, 0xae, 0x2d, 0xb5, 0x60, 0xc7, 0x32, 0x1d, 0x6c, 0x1c, 0xfa, 0x66, 0xf7, 0xdc,
-	0xc5, 0x1a, 0x47, 0x47, 0x0c, 0x14, 0x9f, 0x33, 0xd0, 0x06, 0xac, 0x78, 0x64, 0xe4, 0x18, 0xdc,
-	0x57, 0x39, 0x4d, 0x2c, 0x94, 0x27, 0x90, 0x0e, 0x47, 0x2f, 0xbf, 0x71, 0xf4, 0x79, 0x36, 0x7a,
-	0x66, 0xdb, 0x20, 0xa0, 0xa5, 0x7a, 0x81, 0x03, 0xea, 0x90, 0x09, 0x6f, 0x84, 0xd0, 0x43, 0xff,
-	0xc4, 0x86, 0x33, 0x9a, 0xf2, 0x11, 0xac, 0x87, 0x03, 0x0d, 0xd5, 0x13, 0x36, 0x2a, 0x84, 0x89,
-	0xa9, 0x7c, 0x51, 0xaf, 0xe8, 0xe2, 0xda, 0x48, 0xf1, 0xc6, 0x66, 0x5e, 0x69, 0xf0, 0xfb, 0xe3,
-	0x1e, 0x64, 0x7c, 0xcb, 0x74, 0x10, 0x1d, 0x79, 0x38, 0xb0, 0xd3, 0x2c, 0x50, 0xf9, 0x55, 0x82,
-	0xa4, 0xb0, 0x67, 0x44, 0x38, 0x69, 0xb9, 0x70, 0xf1, 0x9b, 0x84, 0x4b, 0xbc, 0x95, 0x70, 0x10,
-	0x56, 0xe3, 0x17, 0x65, 0x35, 0xb1, 0x9d, 0x7d, 0x74, 0x6f, 0xc9, 0x4e, 0xa2, 0xc8, 0x8e, 0x65,
-	0x06, 0xe7, 0x2f, 0xc2, 0xaa, 0x5c, 0x48, 0x90, 0x09, 0xf3, 0x4a, 0x1d, 0x72, 0xd3, 0xca, 0xf4,
-	0x63, 0x1b, 0x99, 0x81, 0x7f, 0xca, 0x37, 0x97, 0xf7, 0xc4, 0x46, 0xa6, 0x96, 0x0d, 0x2a, 0x62,
-	0x8b, 0xe5, 0xa3, 0x88, 0xdf, 0x30, 0x8a, 0xb9, 0xd9, 0x27, 0xfe, 0xdd, 0xec, 0xe7, 0xa6, 0x24,
-	0x5f, 0x9f, 0xd2, 0x2f, 0x71, 0x48, 0xb7, 0xf9, 0xf9, 0x41, 0xf6, 0x7f, 0x72, 0x2c, 0xee, 0x42,
-	0xc6, 0x25, 0xb6, 0x2e, 0x32, 0x32, 0xcf, 0xa4, 0x5d, 0x62, 0x6b, 0x0b, 0xa3, 0x5f, 0x79, 0x57,
-	0x67, 0x26, 0xf9, 0x0e, 0x74, 0x4b, 0x5d, 0xd7, 0x8d, 0xc2, 0xaa, 0xd0, 0x22, 0x78, 0x09, 0x3d,
-	0x64, 0x22, 0xf0, 0xd7, 0x9a, 0xb4, 0xe4, 0xb5, 0x29, 0xea, 0x16, 0x50, 0x2d, 0x00, 0x32, 0x8a,
-	0xb8, 0xd5, 0x83, 0x37, 0xe1, 0xe6, 0x8d, 0xde, 0xd4, 0x02, 0x60, 0xe5, 0x7b, 0x09, 0x32, 0xbc,
-	0xd9, 0x43, 0x4c, 0xd1, 0x9c, 0x5a, 0xd2, 0x5b, 0xa8, 0xf5, 0x45, 0x58, 0x7b, 0xe2, 0x0d, 0xb5,
-	0x07, 0x27, 0x24, 0x80, 0x7f, 0xf8, 0x9b, 0x04, 0xd9, 0x88, 0xd1, 0x95, 0x87, 0x70, 0xab, 0x7e,
-	0xd0, 0xda, 0x7d, 0xae, 0x37, 0xf6, 0xf4, 0x27, 0x07, 0x3b, 0x4f, 0xf5, 0x17, 0xcd, 0xe7, 0xcd,
-	0xd6, 0x57, 0xcd, 0x42, 0xac, 0x74, 0x7b, 0x3c, 0x51, 0x95, 0x08, 0xf6, 0x85, 0x73, 0xe2, 0x90,
-	0x6f, 0x1d, 0xa5, 0x06, 0x1b, 0xf3, 0x94, 0x9d, 0x7a, 0x67, 0xbf, 0xd9, 0x2d, 0x48, 0xa5, 0x5b,
-	0xe3, 0x89, 0xba, 0x1e, 0x61, 0xec, 0xf4, 0x7c, 0xec, 0xd0, 0x45, 0xc2, 0x6e, 0xeb, 0xf0, 0xb0,
-	0xd1, 0x2d, 0xc4, 0x17, 0x08, 0xc1, 0xe5, 0x73, 0x1f, 0xd6, 0xe7, 0x09, 0xcd, 0xc6, 0x41, 0x21,
-	0x51, 0x52, 0xc6, 0x13, 0x75, 0x2d, 0x82, 0x6e, 0x5a, 0x76, 0x29, 0xfd, 0xdd, 0x0f, 0xe5, 0xd8,
-	0x4f, 0x3f, 0x96, 0x25, 0xd6, 0x59, 0x6e, 0xce, 0xeb, 0xca, 0xc7, 0x70, 0xa7, 0xd3, 0x78, 0xda,
-	0xdc, 0xdf, 0xd3, 0x0f, 0x3b, 0x4f, 0xf5, 0xee, 0xd7, 0xed, 0xfd, 0x48, 0x77, 0xf9, 0xf1, 0x44,
-	0xcd, 0x06, 0x2d, 0xdd, 0x84, 0x6e, 0x6b, 0xfb, 0x47, 0xad, 0xee, 0x7e, 0x41, 0x12, 0xe8, 0xb6,
-	0x87, 0x4f, 0x09, 0xc5, 0x1c, 0xfd, 0x00, 0x36, 0x97, 0xa0, 0xc3, 0xc6, 0xd6, 0xc7, 0x13, 0x35,
-	0xd7, 0xf6, 0xb0, 0x30, 0x01, 0x67, 0x54, 0xa1, 0xb8, 0xc8, 0x68, 0xb5, 0x5b, 0x9d, 0x9d, 0x83,
-	0x82, 0x5a, 0x2a, 0x8c, 0x27, 0xea, 0xea, 0xf4, 0x54, 0x33, 0xfc, 0xac, 0xb3, 0xba, 0xf6, 0xea,
-	0xb2, 0x2c, 0xbd, 0xbe, 0x2c, 0x4b, 0x7f, 0x5e, 0x96, 0xa5, 0x97, 0x57, 0xe5, 0xd8, 0xeb, 0xab,
-	0x72, 0xec, 0xf7, 0xab, 0x72, 0xec, 0x9b, 0xc7, 0xa6, 0x45, 0x07, 0xa3, 0x5e, 0xb5, 0x4f, 0x86,
-	0xb5, 0xe8, 0x37, 0xab, 0x49, 0x3e, 0x11, 0x4b, 0xf1, 0x89, 0x5a, 0x5b, 0xf8, 0x2e, 0xee, 0x25,
-	0x79, 0xe2, 0xd3, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0xce, 0x5f, 0xb4, 0xa6, 0x33, 0x0b, 0x00,
-	0x00,
+	0x17, 0x35, 0x65, 0x5a, 0x3f, 0x57, 0xa6, 0x2d, 0x13, 0x4e, 0xc2, 0x28, 0xf9, 0x64, 0x7e, 0x2a,
+	0xda, 0x3a, 0xfd, 0x91, 0x92, 0xb4, 0x45, 0xd3, 0xa5, 0x65, 0x3b, 0x89, 0x10, 0x59, 0x12, 0x28,
+	0x25, 0x45, 0xbb, 0x21, 0x46, 0xe2, 0x84, 0x22, 0x42, 0x71, 0x08, 0x72, 0xe4, 0xda, 0x6f, 0x50,
+	0x08, 0x5d, 0x64, 0xd9, 0x8d, 0x56, 0xed, 0xa2, 0xeb, 0x02, 0x7d, 0x81, 0xae, 0xb2, 0xcc, 0xae,
+	0x5d, 0xa5, 0x85, 0xb3, 0xeb, 0x53, 0x14, 0x33, 0x43, 0x51, 0x54, 0x24, 0x21, 0x68, 0x13, 0x74,
+	0x23, 0x70, 0xee, 0x3d, 0x67, 0x74, 0xef, 0xb9, 0x67, 0x86, 0x84, 0xff, 0x3d, 0x41, 0x81, 0xe5,
+	0xa0, 0xfe, 0x00, 0x39, 0x5e, 0x95, 0x9e, 0xfb, 0x38, 0x14, 0xbf, 0x15, 0x3f, 0x20, 0x94, 0xa8,
+	0x3b, 0x89, 0x74, 0x85, 0x27, 0x8a, 0xbb, 0x36, 0xb1, 0x09, 0xcf, 0x56, 0xd9, 0x93, 0x00, 0x16,
+	0x4b, 0xc9, 0x7d, 0xfa, 0xc1, 0xb9, 0x4f, 0x49, 0xd5, 0x0f, 0x08, 0x79, 0x1c, 0xe5, 0xf7, 0x6c,
+	0x42, 0x6c, 0x17, 0x57, 0xf9, 0xaa, 0x37, 0x7a, 0x5c, 0xa5, 0xce, 0x10, 0x87, 0x14, 0x0d, 0xfd,
+	0x08, 0xf0, 0xff, 0xc5, 0x42, 0x4e, 0x91, 0xeb, 0x58, 0x88, 0x92, 0x40, 0x40, 0xca, 0x5f, 0x80,
+	0xd2, 0x46, 0x01, 0xed, 0x60, 0x7a, 0x1f, 0x23, 0x0b, 0x07, 0xea, 0x2e, 0x6c, 0x50, 0x42, 0x91,
+	0xab, 0x49, 0xba, 0xb4, 0xaf, 0x18, 0x62, 0xa1, 0xaa, 0x20, 0x0f, 0x50, 0x38, 0xd0, 0x52, 0xba,
+	0xb4, 0xbf, 0x69, 0xf0, 0xe7, 0xb2, 0x03, 0x32, 0xa3, 0x32, 0x86, 0xe3, 0x59, 0xf8, 0x6c, 0xca,
+	0xe0, 0x0b, 0x16, 0xed, 0x9d, 0x53, 0x1c, 0x46, 0x14, 0xb1, 0x50, 0x3f, 0x83, 0x0d, 0xde, 0x81,
+	0xb6, 0xae, 0x4b, 0xfb, 0xf9, 0xdb, 0x57, 0x2b, 0x49, 0x2d, 0x44, 0x8b, 0x95, 0x36, 0x03, 0xd4,
+	0xe4, 0x67, 0x2f, 0xf6, 0xd6, 0x0c, 0x81, 0x2e, 0x0f, 0x21, 0x53, 0x73, 0x49, 0xff, 0x49, 0xfd,
+	0x28, 0xae, 0x44, 0x9a, 0x55, 0xa2, 0x36, 0x61, 0xdb, 0x47, 0x01, 0x35, 0x43, 0x4c, 0xcd, 0x01,
+	0x6f, 0x83, 0xff, 0x6b, 0xfe, 0xb6, 0x5e, 0x59, 0xd0, 0xba, 0x32, 0xd7, 0x6e, 0xf4, 0x37, 0x8a,
+	0x9f, 0x0c, 0x96, 0x7f, 0x96, 0x21, 0x1d, 0xc9, 0xf1, 0x1e, 0x64, 0x39, 0xd9, 0x74, 0x2c, 0xbe,
+	0x67, 0xae, 0x96, 0xbf, 0x78, 0xb1, 0x97, 0x39, 0x64, 0xb1, 0xfa, 0x91, 0x91, 0xe1, 0xc9, 0xba,
+	0xa5, 0x5e, 0x86, 0xf4, 0x00, 0x3b, 0xf6, 0x80, 0xf2, 0xce, 0x64, 0x23, 0x5a, 0xa9, 0xd7, 0x20,
+	0x67, 0xa3, 0xd0, 0x74, 0x9d, 0xa1, 0x43, 0xb5, 0x6d, 0x9e, 0xca, 0xda, 0x28, 0x6c, 0xb0, 0xb5,
+	0x7a, 0x07, 0x64, 0x36, 0x32, 0x4d, 0xe6, 0xc5, 0x16, 0x2b, 0x62, 0x9e, 0x95, 0xe9, 0x3c, 0x2b,
+	0xdd, 0xe9, 0x3c, 0x6b, 0x59, 0x56, 0xe6, 0xd3, 0x3f, 0xf6, 0x24, 0x83, 0x33, 0xd4, 0x23, 0x50,
+	0x5c, 0x14, 0x52, 0xb3, 0xc7, 0x54, 0x61, 0xb5, 0x6d, 0x44, 0x5b, 0x2c, 0xf6, 0x1b, 0x09, 0x17,
+	0x75, 0x9a, 0x67, 0x34, 0x11, 0xb2, 0xd4, 0x7d, 0x28, 0xf0, 0x5d, 0xfa, 0x64, 0x38, 0x74, 0xa8,
+	0xc9, 0x75, 0x4d, 0x73, 0x5d, 0xb7, 0x58, 0xfc, 0x90, 0x87, 0xef, 0x33, 0x85, 0xaf, 0x41, 0xce,
+	0x42, 0x14, 0x09, 0x48, 0x86, 0x43, 0xb2, 0x2c, 0xc0, 0x93, 0xef, 0xc3, 0x76, 0x6c, 0xab, 0x50,
+	0x40, 0xb2, 0x62, 0x97, 0x59, 0x98, 0x03, 0x6f, 0xc2, 0xae, 0x87, 0xcf, 0xa8, 0xf9, 0x2a, 0x3a,
+	0xc7, 0xd1, 0x2a, 0xcb, 0x3d, 0x9a, 0x67, 0xbc, 0x0b, 0x5b, 0x7d, 0xe2, 0x85, 0xd8, 0x0b, 0x47,
+	0x11, 0x16, 0x38, 0x56, 0x89, 0xa3, 0x1c, 0x76, 0x15, 0xb2, 0xc8, 0xf7, 0x05, 0x20, 0xcf, 0x01,
+	0x19, 0xe4, 0xfb, 0x3c, 0xf5, 0x0e, 0x28, 0xf8, 0xd4, 0xb1, 0xb0, 0xd7, 0xc7, 0x22, 0xaf, 0xf0,
+	0xfc, 0xe6, 0x34, 0xc8, 0x41, 0x37, 0xa0, 0xe0, 0x07, 0xc4, 0x27, 0x21, 0x0e, 0x4c, 0x64, 0x59,
+	0x01, 0x0e, 0x43, 0x6d, 0x8b, 0xe3, 0xb6, 0xa7, 0xf1, 0x03, 0x11, 0x56, 0xaf, 0x40, 0xc6, 0x1b,
+	0x0d, 0x4d, 0x7a, 0x16, 0x6a, 0x05, 0x31, 0x69, 0x6f, 0x34, 0xec, 0x9e, 0x85, 0xe5, 0xbf, 0x52,
+	0x20, 0x3f, 0x22, 0x14, 0xab, 0x9f, 0x82, 0xcc, 0x94, 0xe7, 0x0e, 0xdd, 0x5a, 0x6a, 0xc1, 0x8e,
+	0x63, 0x7b, 0xd8, 0x3a, 0x09, 0xed, 0xee, 0xb9, 0x8f, 0x0d, 0x8e, 0x4e, 0x18, 0x28, 0x35, 0x67,
+	0xa0, 0x5d, 0xd8, 0x08, 0xc8, 0xc8, 0xb3, 0xb8, 0xaf, 0x14, 0x43, 0x2c, 0xd4, 0xbb, 0x90, 0x8d,
+	0x47, 0x2f, 0xbf, 0x76, 0xf4, 0xdb, 0x6c, 0xf4, 0xcc, 0xb6, 0x51, 0xc0, 0xc8, 0xf4, 0x22, 0x07,
+	0xd4, 0x20, 0x17, 0x5f, 0x1a, 0xda, 0xc6, 0x3f, 0xb0, 0xe1, 0x8c, 0xa6, 0x7e, 0x08, 0x3b, 0xf1,
+	0x40, 0x63, 0xf5, 0x84, 0x8d, 0x0a, 0x71, 0x62, 0x2a, 0x5f, 0xd2, 0x2b, 0xa6, 0xb8, 0x36, 0x32,
+	0xbc, 0xb1, 0x99, 0x57, 0xea, 0x2c, 0xaa, 0x5e, 0x87, 0x5c, 0xe8, 0xd8, 0x1e, 0xa2, 0xa3, 0x00,
+	0x47, 0x76, 0x9a, 0x05, 0xca, 0xbf, 0x4a, 0x90, 0x16, 0xf6, 0x4c, 0x08, 0x27, 0x2d, 0x17, 0x2e,
+	0xb5, 0x4a, 0xb8, 0xf5, 0x37, 0x12, 0x0e, 0xe2, 0x6a, 0x42, 0x4d, 0xd6, 0xd7, 0xf7, 0xf3, 0xb7,
+	0xaf, 0x2f, 0xd9, 0x49, 0x14, 0xd9, 0x71, 0xec, 0xe8, 0xfc, 0x25, 0x58, 0xe5, 0x17, 0x12, 0xe4,
+	0xe2, 0xbc, 0x5a, 0x03, 0x65, 0x5a, 0x99, 0xf9, 0xd8, 0x45, 0x76, 0xe4, 0x9f, 0xd2, 0xea, 0xf2,
+	0xee, 0xba, 0xc8, 0x36, 0xf2, 0x51, 0x45, 0x6c, 0xb1, 0x7c, 0x14, 0xa9, 0x15, 0xa3, 0x98, 0x9b,
+	0xfd, 0xfa, 0xbf, 0x9b, 0xfd, 0xdc, 0x94, 0xe4, 0x57, 0xa7, 0xf4, 0x4b, 0x0a, 0xb2, 0x6d, 0x7e,
+	0x7e, 0x90, 0xfb, 0x9f, 0x1c, 0x8b, 0x6b, 0x90, 0xf3, 0x89, 0x6b, 0x8a, 0x8c, 0xcc, 0x33, 0x59,
+	0x9f, 0xb8, 0xc6, 0xc2, 0xe8, 0x37, 0xde, 0xd6, 0x99, 0x49, 0xbf, 0x05, 0xdd, 0x32, 0xaf, 0xea,
+	0x46, 0x61, 0x53, 0x68, 0x11, 0xbd, 0x84, 0x6e, 0x31, 0x11, 0xd8, 0x93, 0x26, 0x2d, 0x79, 0x6d,
+	0x8a, 0xba, 0x05, 0xd4, 0x48, 0x0f, 0x62, 0x8a, 0xb8, 0xd5, 0xb5, 0xd4, 0x4a, 0x8a, 0xf0, 0x9e,
+	0x11, 0x01, 0xcb, 0xdf, 0x49, 0x90, 0xe3, 0xcd, 0x9e, 0x60, 0x8a, 0xe6, 0xd4, 0x92, 0xde, 0x40,
+	0xad, 0xcf, 0xe3, 0xda, 0xd7, 0x5f, 0x53, 0x7b, 0x74, 0x42, 0x22, 0x78, 0xf9, 0x7b, 0x09, 0xa0,
+	0xc1, 0x66, 0xcd, 0xb7, 0x64, 0x6f, 0xbc, 0x90, 0x6b, 0x62, 0xce, 0x49, 0xb1, 0xb7, 0xd2, 0x47,
+	0x91, 0x20, 0x9b, 0x61, 0x52, 0xc9, 0x23, 0x50, 0x66, 0x07, 0x24, 0xc4, 0x53, 0x75, 0x96, 0xed,
+	0x12, 0xbf, 0x89, 0x3a, 0x98, 0x1a, 0x9b, 0xa7, 0x89, 0xd5, 0x07, 0xbf, 0x49, 0x90, 0x4f, 0x9c,
+	0x41, 0xf5, 0x16, 0x5c, 0xaa, 0x35, 0x5a, 0x87, 0x0f, 0xcc, 0xfa, 0x91, 0x79, 0xb7, 0x71, 0x70,
+	0xcf, 0x7c, 0xd8, 0x7c, 0xd0, 0x6c, 0x7d, 0xd9, 0x2c, 0xac, 0x15, 0x2f, 0x8f, 0x27, 0xba, 0x9a,
+	0xc0, 0x3e, 0xf4, 0x9e, 0x78, 0xe4, 0x1b, 0x4f, 0xad, 0xc2, 0xee, 0x3c, 0xe5, 0xa0, 0xd6, 0x39,
+	0x6e, 0x76, 0x0b, 0x52, 0xf1, 0xd2, 0x78, 0xa2, 0xef, 0x24, 0x18, 0x07, 0xbd, 0x10, 0x7b, 0x74,
+	0x91, 0x70, 0xd8, 0x3a, 0x39, 0xa9, 0x77, 0x0b, 0xa9, 0x05, 0x42, 0x74, 0x2f, 0xde, 0x80, 0x9d,
+	0x79, 0x42, 0xb3, 0xde, 0x28, 0xac, 0x17, 0xd5, 0xf1, 0x44, 0xdf, 0x4a, 0xa0, 0x9b, 0x8e, 0x5b,
+	0xcc, 0x7e, 0xfb, 0x43, 0x69, 0xed, 0xa7, 0x1f, 0x4b, 0x12, 0xeb, 0x4c, 0x99, 0x3b, 0x86, 0xea,
+	0x47, 0x70, 0xa5, 0x53, 0xbf, 0xd7, 0x3c, 0x3e, 0x32, 0x4f, 0x3a, 0xf7, 0xcc, 0xee, 0x57, 0xed,
+	0xe3, 0x44, 0x77, 0xdb, 0xe3, 0x89, 0x9e, 0x8f, 0x5a, 0x5a, 0x85, 0x6e, 0x1b, 0xc7, 0x8f, 0x5a,
+	0xdd, 0xe3, 0x82, 0x24, 0xd0, 0xed, 0x00, 0x9f, 0x12, 0x8a, 0x39, 0xfa, 0x26, 0x5c, 0x5d, 0x82,
+	0x8e, 0x1b, 0xdb, 0x19, 0x4f, 0x74, 0xa5, 0x1d, 0x60, 0xe1, 0x4f, 0xce, 0xa8, 0x80, 0xb6, 0xc8,
+	0x68, 0xb5, 0x5b, 0x9d, 0x83, 0x46, 0x41, 0x2f, 0x16, 0xc6, 0x13, 0x7d, 0x73, 0x7a, 0xe1, 0x30,
+	0xfc, 0xac, 0xb3, 0x9a, 0xf1, 0xec, 0xa2, 0x24, 0x3d, 0xbf, 0x28, 0x49, 0x7f, 0x5e, 0x94, 0xa4,
+	0xa7, 0x2f, 0x4b, 0x6b, 0xcf, 0x5f, 0x96, 0xd6, 0x7e, 0x7f, 0x59, 0x5a, 0xfb, 0xfa, 0x8e, 0xed,
+	0xd0, 0xc1, 0xa8, 0x57, 0xe9, 0x93, 0x61, 0x35, 0xf9, 0xc1, 0x6c, 0x93, 0x8f, 0xc5, 0x52, 0x7c,
+	0x60, 0x57, 0x17, 0x3e, 0xa6, 0x7b, 0x69, 0x9e, 0xf8, 0xe4, 0xef, 0x01, 0x00, 0x1c, 0x0a, 0xa5,
+	0x8b, 0xf1, 0x0b, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1525,6 +1582,53 @@ func (m *BlockMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *LightBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LightBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LightBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValidatorSet != nil {
+		{
+			size, err := m.ValidatorSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SignedHeader != nil {
+		{
+			size, err := m.SignedHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1780,6 +1884,23 @@ func (m *BlockMeta) Size() (n int) {
 	return n
 }
 
+func (m *LightBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedHeader != nil {
+		l = m.SignedHeader.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ValidatorSet != nil {
+		l = m.ValidatorSet.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -3698,6 +3819,131 @@ func (m *BlockMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LightBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LightBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LightBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignedHeader == nil {
+				m.SignedHeader = &SignedHeader{}
+			}
+			if err := m.SignedHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidatorSet == nil {
+				m.ValidatorSet = &ValidatorSet{}
+			}
+			if err := m.ValidatorSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,50 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import "context"
+
+// AccessFunc decides whether a connection may call the given method, the
+// call is rejected with the returned error if it's non-nil.
+type AccessFunc func(method string) error
+
+type accessContextKey struct{}
+
+// WithAccess returns a copy of ctx carrying fn. HTTP requests and websocket
+// connections served with such a context have every call, batch elements and
+// subscriptions included, checked by fn before it's executed. Checks already
+// carried by ctx run before fn.
+func WithAccess(ctx context.Context, fn AccessFunc) context.Context {
+	if prev := accessFromContext(ctx); prev != nil {
+		next := fn
+		fn = func(method string) error {
+			if err := prev(method); err != nil {
+				return err
+			}
+			return next(method)
+		}
+	}
+	return context.WithValue(ctx, accessContextKey{}, fn)
+}
+
+// accessFromContext returns the AccessFunc carried by ctx, if any.
+func accessFromContext(ctx context.Context) AccessFunc {
+	fn, _ := ctx.Value(accessContextKey{}).(AccessFunc)
+	return fn
+}
@@ -58,6 +58,15 @@ func (e *subscriptionNotFoundError) Error() string {
 	return fmt.Sprintf("no %q subscription in %s namespace", e.subscription, e.namespace)
 }
 
+const errMsgBatchTooLarge = "batch too large"
+
+// the response exceeds the response size limit of the server
+type responseTooLargeError struct{}
+
+func (e *responseTooLargeError) ErrorCode() int { return -32003 }
+
+func (e *responseTooLargeError) Error() string { return "response too large" }
+
 // Invalid JSON was received by the server.
 type parseError struct{ message string }
 
@@ -61,17 +61,25 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limits         handlerLimits
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
 }
 
+// handlerLimits bounds the batches and responses served by a handler, zero
+// disables a limit.
+type handlerLimits struct {
+	batchLimit        int // Maximum number of messages in a batch
+	responseSizeLimit int // Maximum size in bytes of the results of a response
+}
+
 type callProc struct {
 	ctx       context.Context
 	notifiers []*Notifier
 }
 
-func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry) *handler {
+func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, limits handlerLimits) *handler {
 	rootCtx, cancelRoot := context.WithCancel(connCtx)
 	h := &handler{
 		reg:            reg,
@@ -82,6 +90,7 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		rootCtx:        rootCtx,
 		cancelRoot:     cancelRoot,
 		allowSubscribe: true,
+		limits:         limits,
 		serverSubs:     make(map[ID]*Subscription),
 		log:            log.Root(),
 	}
@@ -101,6 +110,13 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		})
 		return
 	}
+	if h.limits.batchLimit > 0 && len(msgs) > h.limits.batchLimit {
+		batchTooLargeGauge.Inc(1)
+		h.startCallProc(func(cp *callProc) {
+			h.conn.writeJSON(cp.ctx, errorMessage(&invalidRequestError{errMsgBatchTooLarge}))
+		})
+		return
+	}
 
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
@@ -114,11 +130,31 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	}
 	// Process calls on a goroutine because they may block indefinitely:
 	h.startCallProc(func(cp *callProc) {
-		answers := make([]*jsonrpcMessage, 0, len(msgs))
+		var (
+			answers  = make([]*jsonrpcMessage, 0, len(msgs))
+			size     int
+			tooLarge bool
+		)
 		for _, msg := range calls {
-			if answer := h.handleCallMsg(cp, msg); answer != nil {
-				answers = append(answers, answer)
+			// Once the response size limit is exceeded the remaining calls
+			// aren't executed anymore
+			if tooLarge {
+				if msg.isCall() {
+					responseTooLargeGauge.Inc(1)
+					answers = append(answers, msg.errorResponse(&responseTooLargeError{}))
+				}
+				continue
 			}
+			answer := h.handleCallMsg(cp, msg)
+			if answer == nil {
+				continue
+			}
+			if h.limits.responseSizeLimit > 0 && size+len(answer.Result) > h.limits.responseSizeLimit {
+				responseTooLargeGauge.Inc(1)
+				answer, tooLarge = msg.errorResponse(&responseTooLargeError{}), true
+			}
+			answers = append(answers, answer)
+			size += len(answer.Result)
 		}
 		h.addSubscriptions(cp.notifiers)
 		if len(answers) > 0 {
@@ -317,6 +353,11 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if access := accessFromContext(cp.ctx); access != nil {
+		if err := access(msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -390,7 +431,12 @@ func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *cal
 	if err != nil {
 		return msg.errorResponse(err)
 	}
-	return msg.response(result)
+	answer := msg.response(result)
+	if h.limits.responseSizeLimit > 0 && len(answer.Result) > h.limits.responseSizeLimit {
+		responseTooLargeGauge.Inc(1)
+		return msg.errorResponse(&responseTooLargeError{})
+	}
+	return answer
 }
 
 // unsubscribe is the callback function for all *_unsubscribe calls.
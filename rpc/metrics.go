@@ -29,6 +29,8 @@ var (
 	successfulRequestGauge = metrics.NewRegisteredGauge("rpc/success", nil)
 	failedReqeustGauge     = metrics.NewRegisteredGauge("rpc/failure", nil)
 	rpcServingTimer        = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+	batchTooLargeGauge     = metrics.NewRegisteredGauge("rpc/batchtoolarge", nil)
+	responseTooLargeGauge  = metrics.NewRegisteredGauge("rpc/responsetoolarge", nil)
 )
 
 func newRPCServingTimer(method string, valid bool) metrics.Timer {
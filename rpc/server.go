@@ -46,6 +46,7 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+	limits   handlerLimits
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -66,12 +67,25 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 	return s.services.registerName(name, receiver)
 }
 
+// SetLimits sets the maximum number of calls in a batch and the maximum size in
+// bytes of the results of a response, batches included. Zero disables a limit.
+// It must be called before the server starts serving.
+func (s *Server) SetLimits(batchLimit, responseSizeLimit int) {
+	s.limits = handlerLimits{batchLimit: batchLimit, responseSizeLimit: responseSizeLimit}
+}
+
 // ServeCodec reads incoming requests from codec, calls the appropriate callback and writes
 // the response back using the given codec. It will block until the codec is closed or the
 // server is stopped. In either case the codec is closed.
 //
 // Note that codec options are no longer supported.
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(context.Background(), codec)
+}
+
+// serveCodec serves codec like ServeCodec, the handler of the connection is
+// rooted at ctx.
+func (s *Server) serveCodec(ctx context.Context, codec ServerCodec) {
 	defer codec.close()
 
 	// Don't serve if server is stopped.
@@ -83,7 +97,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(ctx, codec, s.idgen, &s.services, s.limits)
 	<-codec.closed()
 	c.Close()
 }
@@ -97,7 +111,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 		return
 	}
 
-	h := newHandler(ctx, codec, s.idgen, &s.services)
+	h := newHandler(ctx, codec, s.idgen, &s.services, s.limits)
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
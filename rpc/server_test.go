@@ -51,6 +51,13 @@ func TestServerRegisterName(t *testing.T) {
 	}
 }
 
+func TestServerRegisterNameWithSeparator(t *testing.T) {
+	server := NewServer()
+	if err := server.RegisterName("test_admin", new(testService)); err == nil {
+		t.Fatal("Expected an error registering a service name containing the method separator")
+	}
+}
+
 func TestServer(t *testing.T) {
 	files, _ := ioutil.ReadDir("testdata")
 	// if err != nil {
@@ -150,3 +157,48 @@ func TestServerShortLivedConn(t *testing.T) {
 		}
 	}
 }
+
+func TestServerLimits(t *testing.T) {
+	server := newTestServer()
+	server.SetLimits(3, 40)
+	defer server.Stop()
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	go server.ServeCodec(NewCodec(serverConn), 0)
+	readbuf := bufio.NewReader(clientConn)
+
+	tests := []struct {
+		request, response string
+	}{
+		{
+			`[{"jsonrpc":"2.0","id":1,"method":"test_rets"},{"jsonrpc":"2.0","id":2,"method":"test_rets"},{"jsonrpc":"2.0","id":3,"method":"test_rets"},{"jsonrpc":"2.0","id":4,"method":"test_rets"}]`,
+			`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch too large"}}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"String":"x","Int":1,"Args":null}}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a much longer string",1]}`,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32003,"message":"response too large"}}`,
+		},
+		{
+			`[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":3,"method":"test_rets"}]`,
+			`[{"jsonrpc":"2.0","id":1,"result":{"String":"x","Int":1,"Args":null}},{"jsonrpc":"2.0","id":2,"error":{"code":-32003,"message":"response too large"}},{"jsonrpc":"2.0","id":3,"error":{"code":-32003,"message":"response too large"}}]`,
+		},
+	}
+	for i, test := range tests {
+		clientConn.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, err := io.WriteString(clientConn, test.request+"\n"); err != nil {
+			t.Fatalf("test %d: write error: %v", i, err)
+		}
+		resp, err := readbuf.ReadString('\n')
+		if err != nil {
+			t.Fatalf("test %d: read error: %v", i, err)
+		}
+		if resp = strings.TrimRight(resp, "\r\n"); resp != test.response {
+			t.Errorf("test %d: wrong response\ngot:  %s\nwant: %s", i, resp, test.response)
+		}
+	}
+}
@@ -60,7 +60,13 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn)
-		s.ServeCodec(codec, 0)
+		// The connection outlives the upgrade request, only the access
+		// checks of the request are kept.
+		ctx := context.Background()
+		if access := accessFromContext(r.Context()); access != nil {
+			ctx = WithAccess(ctx, access)
+		}
+		s.serveCodec(ctx, codec)
 	})
 }
 
@@ -0,0 +1,58 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// Bundle is an ordered list of transactions a proposer includes all-or-nothing
+// and contiguously in the block at Height.
+type Bundle struct {
+	Txs    Transactions
+	Height uint64
+	// RevertingTxHashes lists the transactions of the bundle allowed to
+	// revert, any other reverting transaction rejects the whole bundle.
+	RevertingTxHashes []common.Hash
+}
+
+// Hash returns the hash identifying the bundle.
+func (b *Bundle) Hash() common.Hash {
+	return rlpHash(b)
+}
+
+// MayRevert returns whether the transaction with the given hash is allowed to
+// revert.
+func (b *Bundle) MayRevert(hash common.Hash) bool {
+	for _, h := range b.RevertingTxHashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Gas returns the gas limit of all the transactions of the bundle.
+func (b *Bundle) Gas() uint64 {
+	var gas uint64
+	for _, tx := range b.Txs {
+		gas += tx.Gas()
+	}
+	return gas
+}
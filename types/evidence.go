@@ -20,9 +20,11 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -40,6 +42,7 @@ type EvidenceType uint8
 const (
 	EvidenceDuplicateVote       = EvidenceType(0x01)
 	EvidenceMock                = EvidenceType(0x02)
+	EvidenceLightClientAttack   = EvidenceType(0x03)
 	MaxEvidenceBytesDenominator = 10
 	// MaxEvidenceBytes is a maximum size of any evidence
 	MaxEvidenceBytes int64 = 484
@@ -85,6 +88,17 @@ func EvidenceToProto(evidence Evidence) (*kproto.Evidence, error) {
 			},
 		}, nil
 
+	case *LightClientAttackEvidence:
+		pbev, err := evi.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		return &kproto.Evidence{
+			Sum: &kproto.Evidence_LightClientAttackEvidence{
+				LightClientAttackEvidence: pbev,
+			},
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("toproto: evidence is not recognized: %T", evi)
 	}
@@ -100,6 +114,8 @@ func EvidenceFromProto(evidence *kproto.Evidence) (Evidence, error) {
 	switch evi := evidence.Sum.(type) {
 	case *kproto.Evidence_DuplicateVoteEvidence:
 		return DuplicateVoteEvidenceFromProto(evi.DuplicateVoteEvidence)
+	case *kproto.Evidence_LightClientAttackEvidence:
+		return LightClientAttackEvidenceFromProto(evi.LightClientAttackEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
@@ -197,6 +213,7 @@ func (dve *DuplicateVoteEvidence) VM() []types.Evidence {
 		Time:             dve.Timestamp,
 		TotalVotingPower: uint64(dve.TotalVotingPower),
 		VotingPower:      big.NewInt(dve.ValidatorPower),
+		Hash:             dve.Hash(),
 	}}
 }
 
@@ -316,6 +333,259 @@ func DuplicateVoteEvidenceFromProto(pb *kproto.DuplicateVoteEvidence) (*Duplicat
 	return dve, dve.ValidateBasic()
 }
 
+//-------------------------------------------
+
+// LightClientAttackEvidence is a generalized evidence that captures all forms of known attacks on
+// a light client such that a full node can verify, propose and commit the evidence on-chain for
+// punishment of the malicious validators. There are three forms of attacks: Lunatic, Equivocation
+// and Amnesia. These attacks are exhaustive. You can find a more detailed overview of this at
+// https://github.com/tendermint/spec/blob/master/spec/consensus/light-client/accountability.md
+//
+// CommonHeight is used to indicate the type of attack. If the height is different to the conflicting block
+// height, then nodes will treat this as of the Lunatic form, else it is of the Equivocation form.
+type LightClientAttackEvidence struct {
+	ConflictingBlock *LightBlock
+	CommonHeight     uint64
+
+	// information about the byzantine validators, used for slashing
+	ByzantineValidators []*Validator // validators in the validator set that misbehaved in creating the conflicting block
+	TotalVotingPower    int64        // total voting power of the validator set at the common height
+	Timestamp           time.Time    // timestamp of the block at the common height
+}
+
+// GetByzantineValidators finds out what style of attack LightClientAttackEvidence was and then works out who
+// the malicious validators were and returns them. This is used both for forming the ByzantineValidators
+// field and for validating that it is correct. Validators are ordered based on validator power.
+func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *ValidatorSet,
+	trusted *SignedHeader) []*Validator {
+	var validators []*Validator
+	// First check if the header is invalid. This means that it is a lunatic attack and therefore we take the
+	// validators who are in the commonVals and voted for the lunatic header
+	if l.ConflictingHeaderIsInvalid(trusted.Header) {
+		for _, commitSig := range l.ConflictingBlock.Commit.Signatures {
+			if !commitSig.ForBlock() {
+				continue
+			}
+
+			_, val := commonVals.GetByAddress(commitSig.ValidatorAddress)
+			if val == nil {
+				// validator wasn't in the common validator set
+				continue
+			}
+			validators = append(validators, val)
+		}
+		sort.Sort(ValidatorsByVotingPower(validators))
+		return validators
+	} else if trusted.Commit.Round == l.ConflictingBlock.Commit.Round {
+		// This is an equivocation attack as both commits are in the same round. We then find the validators
+		// from the conflicting light block validator set that voted in both headers.
+		// Validator hashes are the same therefore the indexing order of validators are the same and thus we
+		// only need a single loop to find the validators that voted twice.
+		for i := 0; i < len(l.ConflictingBlock.Commit.Signatures) && i < len(trusted.Commit.Signatures); i++ {
+			sigA := l.ConflictingBlock.Commit.Signatures[i]
+			if sigA.Absent() {
+				continue
+			}
+
+			sigB := trusted.Commit.Signatures[i]
+			if sigB.Absent() {
+				continue
+			}
+
+			_, val := l.ConflictingBlock.ValidatorSet.GetByAddress(sigA.ValidatorAddress)
+			if val == nil {
+				continue
+			}
+			validators = append(validators, val)
+		}
+		sort.Sort(ValidatorsByVotingPower(validators))
+		return validators
+	}
+	// if the rounds are different then this is an amnesia attack. Unfortunately, given the nature of the attack,
+	// we aren't able yet to deduce which are malicious validators and which are not hence we return an
+	// empty validator set.
+	return validators
+}
+
+// ConflictingHeaderIsInvalid takes a trusted header and matches it againt a conflicting header
+// to determine whether the conflicting header was the product of a valid state transition
+// or not. If it is then all the deterministic fields of the header should be the same.
+// If not, it is an invalid header and constitutes a lunatic attack.
+func (l *LightClientAttackEvidence) ConflictingHeaderIsInvalid(trustedHeader *Header) bool {
+	return !trustedHeader.ValidatorsHash.Equal(l.ConflictingBlock.ValidatorsHash) ||
+		!trustedHeader.NextValidatorsHash.Equal(l.ConflictingBlock.NextValidatorsHash) ||
+		!trustedHeader.ConsensusHash.Equal(l.ConflictingBlock.ConsensusHash) ||
+		!trustedHeader.AppHash.Equal(l.ConflictingBlock.AppHash)
+}
+
+// Bytes returns the proto-encoded evidence as a byte array
+func (l *LightClientAttackEvidence) Bytes() []byte {
+	pbe, err := l.ToProto()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Hash returns the hash of the header and the commonHeight. This is designed to cause hash collisions
+// with evidence that have the same conflicting header and common height but different permutations
+// of validator commit signatures. The reason for this is that we don't want to allow several
+// permutations of the same evidence to be committed on chain. Ideally we commit the header with the
+// most commit signatures (captures the most byzantine validators) but anything greater than 1/3 is
+// sufficient.
+func (l *LightClientAttackEvidence) Hash() common.Hash {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, l.CommonHeight)
+	bz := make([]byte, common.HashLength+n)
+	copy(bz[:common.HashLength], l.ConflictingBlock.Hash().Bytes())
+	copy(bz[common.HashLength:], buf[:n])
+	return hash(bz)
+}
+
+// Height returns the last height at which the primary provider and witness provider had the same header.
+// We use this as the height of the infraction rather than the actual conflicting header because we know
+// that the malicious validators were bonded at this height which is important for evidence expiry
+func (l *LightClientAttackEvidence) Height() uint64 {
+	return l.CommonHeight
+}
+
+// String returns a string representation of LightClientAttackEvidence
+func (l *LightClientAttackEvidence) String() string {
+	return fmt.Sprintf(`LightClientAttackEvidence{
+		ConflictingBlock: %v,
+		CommonHeight: %d,
+		ByzatineValidators: %v,
+		TotalVotingPower: %d,
+		Timestamp: %v}#%X`,
+		l.ConflictingBlock.String(), l.CommonHeight, l.ByzantineValidators,
+		l.TotalVotingPower, l.Timestamp, l.Hash())
+}
+
+// Time returns the time of the common block where the infraction leveraged off.
+func (l *LightClientAttackEvidence) Time() time.Time {
+	return l.Timestamp
+}
+
+// VM returns one piece of staking evidence per byzantine validator so that
+// each of them is slashed by the staking contract.
+func (l *LightClientAttackEvidence) VM() []types.Evidence {
+	evidence := make([]types.Evidence, len(l.ByzantineValidators))
+	for idx, val := range l.ByzantineValidators {
+		evidence[idx] = types.Evidence{
+			Address:          val.Address,
+			Height:           l.CommonHeight,
+			Time:             l.Timestamp,
+			TotalVotingPower: uint64(l.TotalVotingPower),
+			VotingPower:      big.NewInt(val.VotingPower),
+			Hash:             l.Hash(),
+		}
+	}
+	return evidence
+}
+
+// ValidateBasic performs basic validation such that the evidence is consistent and can now be used for verification.
+func (l *LightClientAttackEvidence) ValidateBasic() error {
+	if l == nil {
+		return errors.New("empty light client attack evidence")
+	}
+	if l.ConflictingBlock == nil {
+		return errors.New("conflicting block is nil")
+	}
+
+	// this check needs to be done before we can run validate basic
+	if l.ConflictingBlock.SignedHeader == nil || l.ConflictingBlock.Header == nil {
+		return errors.New("conflicting block missing header")
+	}
+
+	if l.TotalVotingPower <= 0 {
+		return errors.New("negative or zero total voting power")
+	}
+
+	if err := l.ConflictingBlock.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid conflicting light block: %w", err)
+	}
+
+	if l.CommonHeight == 0 {
+		return errors.New("zero common height")
+	}
+
+	// check that common height isn't ahead of the height of the conflicting block. It
+	// is possible that they are the same height if the light node witnesses either an
+	// amnesia or a equivocation attack.
+	if l.CommonHeight > l.ConflictingBlock.Height {
+		return fmt.Errorf("common height is ahead of the conflicting block height (%d > %d)",
+			l.CommonHeight, l.ConflictingBlock.Height)
+	}
+
+	for idx, val := range l.ByzantineValidators {
+		if val == nil {
+			return fmt.Errorf("nil byzantine validator #%d", idx)
+		}
+	}
+
+	return nil
+}
+
+// ToProto encodes LightClientAttackEvidence to protobuf
+func (l *LightClientAttackEvidence) ToProto() (*kproto.LightClientAttackEvidence, error) {
+	conflictingBlock, err := l.ConflictingBlock.ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	byzVals := make([]*kproto.Validator, len(l.ByzantineValidators))
+	for idx, val := range l.ByzantineValidators {
+		valpb, err := val.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		byzVals[idx] = valpb
+	}
+
+	return &kproto.LightClientAttackEvidence{
+		ConflictingBlock:    conflictingBlock,
+		CommonHeight:        l.CommonHeight,
+		ByzantineValidators: byzVals,
+		TotalVotingPower:    l.TotalVotingPower,
+		Timestamp:           l.Timestamp,
+	}, nil
+}
+
+// LightClientAttackEvidenceFromProto decodes protobuf
+func LightClientAttackEvidenceFromProto(lpb *kproto.LightClientAttackEvidence) (*LightClientAttackEvidence, error) {
+	if lpb == nil {
+		return nil, errors.New("empty light client attack evidence")
+	}
+
+	conflictingBlock, err := LightBlockFromProto(lpb.ConflictingBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	byzVals := make([]*Validator, len(lpb.ByzantineValidators))
+	for idx, valpb := range lpb.ByzantineValidators {
+		val, err := ValidatorFromProto(valpb)
+		if err != nil {
+			return nil, err
+		}
+		byzVals[idx] = val
+	}
+
+	l := &LightClientAttackEvidence{
+		ConflictingBlock:    conflictingBlock,
+		CommonHeight:        lpb.CommonHeight,
+		ByzantineValidators: byzVals,
+		TotalVotingPower:    lpb.TotalVotingPower,
+		Timestamp:           lpb.Timestamp,
+	}
+
+	return l, l.ValidateBasic()
+}
+
 //-------------------------------------------- MOCKING --------------------------------------
 
 // unstable - use only for testing
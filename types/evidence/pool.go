@@ -21,6 +21,7 @@ package evidence
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -425,6 +426,44 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 // fastCheck leverages the fact that the evidence pool may have already verified the evidence to see if it can
 // quickly conclude that the evidence is already valid.
 func (evpool *Pool) fastCheck(ev types.Evidence) bool {
+	if lcae, ok := ev.(*types.LightClientAttackEvidence); ok {
+		// the hash of light client attack evidence doesn't cover the byzantine
+		// validators, so they must match the ones of the pending evidence
+		evBytes, err := evpool.evidenceDB.Get(keyPending(ev))
+		if evBytes == nil { // the evidence is not in the nodes pending list
+			return false
+		}
+		if err != nil {
+			evpool.logger.Error("Failed to load light client attack evidence", "err", err, "key(height/hash)", keyPending(ev))
+			return false
+		}
+		pending, err := bytesToEv(evBytes)
+		if err != nil {
+			evpool.logger.Error("Failed to convert light client attack evidence from proto", "err", err)
+			return false
+		}
+		trustedEv, ok := pending.(*types.LightClientAttackEvidence)
+		if !ok {
+			return false
+		}
+		if len(trustedEv.ByzantineValidators) != len(lcae.ByzantineValidators) {
+			return false
+		}
+
+		// ensure that both validator arrays are in the same order
+		byzValsCopy := make([]*types.Validator, len(lcae.ByzantineValidators))
+		for i, v := range lcae.ByzantineValidators {
+			byzValsCopy[i] = v.Copy()
+		}
+		sort.Sort(types.ValidatorsByVotingPower(byzValsCopy))
+		for idx, val := range trustedEv.ByzantineValidators {
+			if !byzValsCopy[idx].Address.Equal(val.Address) || byzValsCopy[idx].VotingPower != val.VotingPower {
+				return false
+			}
+		}
+		return true
+	}
+
 	// for all other evidence the evidence pool just checks if it is already in the pending db
 	return evpool.isPending(ev)
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kardiachain/go-kardia/lib/behaviour"
 	"github.com/kardiachain/go-kardia/lib/clist"
 	"github.com/kardiachain/go-kardia/lib/log"
 
@@ -44,7 +45,8 @@ const (
 // Reactor handles evpool evidence broadcasting amongst peers.
 type Reactor struct {
 	p2p.BaseReactor
-	evpool *Pool
+	evpool   *Pool
+	reporter behaviour.Reporter
 }
 
 // NewReactor returns a new Reactor with the given config and evpool.
@@ -62,6 +64,12 @@ func (evR *Reactor) SetLogger(l log.Logger) {
 	evR.evpool.SetLogger(l)
 }
 
+// OnStart implements p2p.BaseReactor.
+func (evR *Reactor) OnStart() error {
+	evR.reporter = behaviour.NewSwitchReporter(evR.Switch)
+	return nil
+}
+
 // GetChannels implements Reactor.
 // It returns the list of channels for this reactor.
 func (evR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
@@ -86,7 +94,7 @@ func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	evis, err := decodeMsg(msgBytes)
 	if err != nil {
 		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err, "bytes", msgBytes)
-		evR.Switch.StopPeerForError(src, err)
+		_ = evR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 	for _, ev := range evis {
@@ -95,9 +103,10 @@ func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		case *types.ErrEvidenceInvalid:
 			evR.Logger.Error(err.Error())
 			// punish peer
-			evR.Switch.StopPeerForError(src, err)
+			_ = evR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 			return
 		case nil:
+			_ = evR.reporter.Report(behaviour.UsefulMessage(src.ID(), "added evidence"))
 		default:
 			// continue to the next piece of evidence
 			evR.Logger.Error("Evidence has not been added", "evidence", evis, "err", err)
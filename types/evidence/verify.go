@@ -2,9 +2,11 @@ package evidence
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/light"
 	"github.com/kardiachain/go-kardia/types"
 )
 
@@ -55,11 +57,124 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 			return err
 		}
 		return VerifyDuplicateVote(ev, state.ChainID, valSet)
+
+	case *types.LightClientAttackEvidence:
+		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
+		if err != nil {
+			return err
+		}
+		commonVals, err := evpool.stateDB.LoadValidators(evidence.Height())
+		if err != nil {
+			return err
+		}
+		trustedHeader := commonHeader
+		// in the case of lunatic the trusted header is different to the common header
+		if evidence.Height() != ev.ConflictingBlock.Height {
+			trustedHeader, err = getSignedHeader(evpool.blockStore, ev.ConflictingBlock.Height)
+			if err != nil {
+				// FIXME: This multi step process is a bit unergonomic. We may want to consider a more efficient process
+				// that doesn't require as much io and is atomic.
+
+				// If the node doesn't have a block at the height of the conflicting block, then this could be
+				// a forward lunatic attack. Thus the node must get the latest height it has
+				latestHeight := state.LastBlockHeight
+				trustedHeader, err = getSignedHeader(evpool.blockStore, latestHeight-1)
+				if err != nil {
+					return err
+				}
+				if trustedHeader.Time.Before(ev.ConflictingBlock.Time) {
+					return fmt.Errorf("latest block time (%v) is before conflicting block time (%v)",
+						trustedHeader.Time, ev.ConflictingBlock.Time,
+					)
+				}
+			}
+		}
+
+		err = VerifyLightClientAttack(ev, state.ChainID, commonHeader, trustedHeader, commonVals)
+		if err != nil {
+			return err
+		}
+		// find out what type of attack this was and thus extract the malicious validators. Note in the case of an
+		// Amnesia attack we don't have any malicious validators.
+		validators := ev.GetByzantineValidators(commonVals, trustedHeader)
+		// ensure this matches the validators that are listed in the evidence. They should be ordered based on power.
+		if validators == nil && ev.ByzantineValidators != nil {
+			return fmt.Errorf("expected nil validators from an amnesia light client attack but got %d",
+				len(ev.ByzantineValidators))
+		}
+
+		if exp, got := len(validators), len(ev.ByzantineValidators); exp != got {
+			return fmt.Errorf("expected %d byzantine validators from evidence but got %d",
+				exp, got)
+		}
+
+		// ensure that both validator arrays are in the same order
+		for idx, val := range validators {
+			if !val.Address.Equal(ev.ByzantineValidators[idx].Address) {
+				return fmt.Errorf("evidence contained a different byzantine validator address to the one we were expecting."+
+					"Expected %v, got %v", val.Address, ev.ByzantineValidators[idx].Address)
+			}
+			if ev.ByzantineValidators[idx].VotingPower != val.VotingPower {
+				return fmt.Errorf("evidence contained a byzantine validator with a different power to the one we were expecting."+
+					"Expected %d, got %d", val.VotingPower, ev.ByzantineValidators[idx].VotingPower)
+			}
+		}
+
+		return nil
 	default:
 		return fmt.Errorf("unrecognized evidence type: %T", evidence)
 	}
 }
 
+// VerifyLightClientAttack verifies LightClientAttackEvidence against the state of the full node. This involves
+// the following checks:
+//   - the common header from the full node has at least 1/3 voting power which is also present in
+//     the conflicting header's commit
+//   - 2/3+ of the conflicting validator set correctly signed the conflicting block
+//   - the nodes trusted header at the same height as the conflicting header has a different hash
+func VerifyLightClientAttack(e *types.LightClientAttackEvidence, chainID string,
+	commonHeader, trustedHeader *types.SignedHeader, commonVals *types.ValidatorSet) error {
+	// In the case of lunatic attack there will be a different commonHeader height. Therefore the node perform a single
+	// verification jump between the common header and the conflicting one
+	if commonHeader.Height != e.ConflictingBlock.Height {
+		err := commonVals.VerifyCommitLightTrusting(chainID, e.ConflictingBlock.Commit, light.DefaultTrustLevel)
+		if err != nil {
+			return fmt.Errorf("skipping verification of conflicting block failed: %w", err)
+		}
+
+		// In the case of equivocation and amnesia we expect all header hashes to be correctly derived
+	} else if e.ConflictingHeaderIsInvalid(trustedHeader.Header) {
+		return errors.New("common height is the same as conflicting block height so expected the conflicting" +
+			" block to be correctly derived yet it wasn't")
+	}
+
+	// Verify that the 2/3+ commits from the conflicting validator set were for the conflicting header
+	if err := e.ConflictingBlock.ValidatorSet.VerifyCommitLight(chainID, e.ConflictingBlock.Commit.BlockID,
+		e.ConflictingBlock.Height, e.ConflictingBlock.Commit); err != nil {
+		return fmt.Errorf("invalid commit from conflicting block: %w", err)
+	}
+
+	// Assert the correct amount of voting power of the validator set
+	if evTotal, valsTotal := e.TotalVotingPower, commonVals.TotalVotingPower(); evTotal != valsTotal {
+		return fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
+			evTotal, valsTotal)
+	}
+
+	// check in the case of a forward lunatic attack that monotonically increasing time has been violated
+	if e.ConflictingBlock.Height > trustedHeader.Height && e.ConflictingBlock.Time.After(trustedHeader.Time) {
+		return fmt.Errorf("conflicting block doesn't violate monotonically increasing time (%v is after %v)",
+			e.ConflictingBlock.Time, trustedHeader.Time,
+		)
+
+		// In all other cases check that the hashes of the conflicting header and the trusted header are different
+	} else if trustedHeader.Hash().Equal(e.ConflictingBlock.Hash()) {
+		return fmt.Errorf("trusted header hash matches the evidence's conflicting header hash: %X",
+			trustedHeader.Hash())
+	}
+
+	return nil
+}
+
 // VerifyDuplicateVote verifies DuplicateVoteEvidence against the state of full node. This involves the
 // following checks:
 //      - the validator is in the validator set at the height of the evidence
@@ -119,3 +234,20 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 
 	return nil
 }
+
+// getSignedHeader loads the header and the commit of the block at the given
+// height from the block store.
+func getSignedHeader(blockStore BlockStore, height uint64) (*types.SignedHeader, error) {
+	blockMeta := blockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("don't have header at height #%d", height)
+	}
+	commit := blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		return nil, fmt.Errorf("don't have commit at height #%d", height)
+	}
+	return &types.SignedHeader{
+		Header: blockMeta.Header,
+		Commit: commit,
+	}, nil
+}
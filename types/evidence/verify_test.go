@@ -1,6 +1,7 @@
 package evidence
 
 import (
+	"sort"
 	"testing"
 	"time"
 
@@ -148,3 +149,140 @@ func makeBlockID(hash []byte, partSetSize uint32, partSetHash []byte) types.Bloc
 		},
 	}
 }
+
+func TestVerifyLightClientAttackLunatic(t *testing.T) {
+	const chainID = "mychain"
+	commonVals, commonPrivVals := types.RandValidatorSet(3, 10)
+
+	// two of the three common validators sign a block with a different
+	// validator set
+	conflictingVals, conflictingPrivVals := validatorSetFromPrivVals(append(commonPrivVals[:2:2], types.NewMockPV()), 10)
+
+	commonBlock := makeLightBlock(t, chainID, 4, 0, defaultEvidenceTime, common.Hash{}, commonVals, commonPrivVals)
+	trustedBlock := makeLightBlock(t, chainID, 10, 0, defaultEvidenceTime.Add(time.Minute),
+		common.BytesToHash([]byte("app_hash")), commonVals, commonPrivVals)
+	conflictingBlock := makeLightBlock(t, chainID, 10, 0, defaultEvidenceTime.Add(time.Minute),
+		common.BytesToHash([]byte("forged_app_hash")), conflictingVals, conflictingPrivVals)
+
+	ev := &types.LightClientAttackEvidence{
+		ConflictingBlock: conflictingBlock,
+		CommonHeight:     4,
+		TotalVotingPower: commonVals.TotalVotingPower(),
+		Timestamp:        defaultEvidenceTime,
+	}
+	ev.ByzantineValidators = ev.GetByzantineValidators(commonVals, trustedBlock.SignedHeader)
+	require.Len(t, ev.ByzantineValidators, 2)
+	require.NoError(t, ev.ValidateBasic())
+
+	assert.NoError(t, VerifyLightClientAttack(ev, chainID, commonBlock.SignedHeader, trustedBlock.SignedHeader, commonVals))
+	assert.Error(t, VerifyLightClientAttack(ev, "other_chain", commonBlock.SignedHeader, trustedBlock.SignedHeader, commonVals))
+
+	// the trusted block can't be the conflicting one
+	assert.Error(t, VerifyLightClientAttack(ev, chainID, commonBlock.SignedHeader, conflictingBlock.SignedHeader, commonVals))
+
+	// the total voting power must match
+	ev.TotalVotingPower = 1
+	assert.Error(t, VerifyLightClientAttack(ev, chainID, commonBlock.SignedHeader, trustedBlock.SignedHeader, commonVals))
+	ev.TotalVotingPower = commonVals.TotalVotingPower()
+
+	// a single common validator doesn't hold 1/3 of the voting power
+	lunaticVals, lunaticPrivVals := validatorSetFromPrivVals(
+		[]types.PrivValidator{commonPrivVals[0], types.NewMockPV(), types.NewMockPV()}, 10)
+	lunaticEv := &types.LightClientAttackEvidence{
+		ConflictingBlock: makeLightBlock(t, chainID, 10, 0, defaultEvidenceTime.Add(time.Minute),
+			common.BytesToHash([]byte("forged_app_hash")), lunaticVals, lunaticPrivVals),
+		CommonHeight:     4,
+		TotalVotingPower: commonVals.TotalVotingPower(),
+		Timestamp:        defaultEvidenceTime,
+	}
+	assert.Error(t, VerifyLightClientAttack(lunaticEv, chainID, commonBlock.SignedHeader, trustedBlock.SignedHeader, commonVals))
+
+	// the evidence pool verifies the evidence against its own chain
+	state := cstate.LastestBlockState{
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(2 * time.Minute),
+		LastBlockHeight: 11,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smocks.Store{}
+	stateStore.On("LoadValidators", uint64(4)).Return(commonVals, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", uint64(4)).Return(&types.BlockMeta{Header: commonBlock.Header})
+	blockStore.On("LoadBlockCommit", uint64(4)).Return(commonBlock.Commit)
+	blockStore.On("LoadBlockMeta", uint64(10)).Return(&types.BlockMeta{Header: trustedBlock.Header})
+	blockStore.On("LoadBlockCommit", uint64(10)).Return(trustedBlock.Commit)
+
+	pool, err := NewPool(stateStore, memorydb.New(), blockStore)
+	require.NoError(t, err)
+	assert.NoError(t, pool.CheckEvidence(types.EvidenceList{ev}))
+
+	// the byzantine validators must be the ones which signed the conflicting block
+	badEv := *ev
+	badEv.ByzantineValidators = ev.ByzantineValidators[:1]
+	assert.Error(t, pool.CheckEvidence(types.EvidenceList{&badEv}))
+}
+
+func TestVerifyLightClientAttackEquivocation(t *testing.T) {
+	const chainID = "mychain"
+	vals, privVals := types.RandValidatorSet(3, 10)
+
+	appHash := common.BytesToHash([]byte("app_hash"))
+	trustedBlock := makeLightBlock(t, chainID, 10, 0, defaultEvidenceTime, appHash, vals, privVals)
+	// the same validators sign a valid block with a different time in the same round
+	conflictingBlock := makeLightBlock(t, chainID, 10, 0, defaultEvidenceTime.Add(time.Second), appHash, vals, privVals)
+
+	ev := &types.LightClientAttackEvidence{
+		ConflictingBlock: conflictingBlock,
+		CommonHeight:     10,
+		TotalVotingPower: vals.TotalVotingPower(),
+		Timestamp:        defaultEvidenceTime,
+	}
+	ev.ByzantineValidators = ev.GetByzantineValidators(vals, trustedBlock.SignedHeader)
+	assert.Len(t, ev.ByzantineValidators, 3)
+	assert.NoError(t, VerifyLightClientAttack(ev, chainID, trustedBlock.SignedHeader, trustedBlock.SignedHeader, vals))
+
+	// same block
+	ev.ConflictingBlock = trustedBlock
+	assert.Error(t, VerifyLightClientAttack(ev, chainID, trustedBlock.SignedHeader, trustedBlock.SignedHeader, vals))
+
+	// at the common height the conflicting block must be correctly derived
+	ev.ConflictingBlock = makeLightBlock(t, chainID, 10, 0, defaultEvidenceTime,
+		common.BytesToHash([]byte("forged_app_hash")), vals, privVals)
+	assert.Error(t, VerifyLightClientAttack(ev, chainID, trustedBlock.SignedHeader, trustedBlock.SignedHeader, vals))
+
+	// an amnesia attack doesn't blame any validator
+	amnesiaBlock := makeLightBlock(t, chainID, 10, 1, defaultEvidenceTime.Add(time.Second), appHash, vals, privVals)
+	ev.ConflictingBlock = amnesiaBlock
+	assert.Empty(t, ev.GetByzantineValidators(vals, trustedBlock.SignedHeader))
+}
+
+// validatorSetFromPrivVals returns the validator set of the given private
+// validators, sorted in the same order as the set.
+func validatorSetFromPrivVals(privVals []types.PrivValidator, power int64) (*types.ValidatorSet, []types.PrivValidator) {
+	sort.Sort(types.PrivValidatorsByAddress(privVals))
+	vals := make([]*types.Validator, len(privVals))
+	for i, pv := range privVals {
+		vals[i] = types.NewValidator(pv.GetAddress(), power)
+	}
+	return types.NewValidatorSet(vals), privVals
+}
+
+func makeLightBlock(t *testing.T, chainID string, height uint64, round uint32, blockTime time.Time,
+	appHash common.Hash, vals *types.ValidatorSet, privVals []types.PrivValidator) *types.LightBlock {
+	header := &types.Header{
+		Height:             height,
+		Time:               blockTime,
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		AppHash:            appHash,
+	}
+	blockID := makeBlockID(header.Hash().Bytes(), 1, []byte("partshash"))
+	voteSet := types.NewVoteSet(chainID, height, round, kproto.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, height, round, voteSet, privVals, blockTime)
+	require.NoError(t, err)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
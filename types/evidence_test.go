@@ -196,3 +196,85 @@ func makeHeaderRandom() *Header {
 		ProposerAddress:    common.BytesToAddress(crypto.CRandBytes(20)),
 	}
 }
+
+func makeLightClientAttackEvidence(t *testing.T, chainID string) *LightClientAttackEvidence {
+	const height uint64 = 37
+	voteSet, valSet, privVals := randVoteSet(height, 1, kproto.PrecommitType, 3, 10)
+
+	header := makeHeaderRandom()
+	header.Height = height
+	header.Time = defaultVoteTime
+	header.ValidatorsHash = valSet.Hash()
+	blockID := createBlockID(header.Hash(), 1, common.BytesToHash(merkle.Sum([]byte("partshash"))))
+	commit, err := MakeCommit(blockID, height, 1, voteSet, privVals, defaultVoteTime)
+	require.NoError(t, err)
+
+	return &LightClientAttackEvidence{
+		ConflictingBlock: &LightBlock{
+			SignedHeader: &SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: valSet,
+		},
+		CommonHeight:        height - 1,
+		ByzantineValidators: valSet.Validators[:2],
+		TotalVotingPower:    valSet.TotalVotingPower(),
+		Timestamp:           defaultVoteTime,
+	}
+}
+
+func TestLightClientAttackEvidence(t *testing.T) {
+	const chainID = "mychain"
+	ev := makeLightClientAttackEvidence(t, chainID)
+	require.NoError(t, ev.ValidateBasic())
+	assert.Equal(t, uint64(36), ev.Height())
+	assert.Len(t, ev.VM(), 2)
+	for i, vmEv := range ev.VM() {
+		assert.Equal(t, ev.ByzantineValidators[i].Address, vmEv.Address)
+		assert.Equal(t, ev.Hash(), vmEv.Hash)
+	}
+
+	// the hash only depends on the conflicting header and the common height
+	other := *ev
+	other.ByzantineValidators = nil
+	assert.Equal(t, ev.Hash(), other.Hash())
+	other.CommonHeight--
+	assert.NotEqual(t, ev.Hash(), other.Hash())
+
+	pb, err := EvidenceToProto(ev)
+	require.NoError(t, err)
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+	var decodedPb kproto.Evidence
+	require.NoError(t, decodedPb.Unmarshal(bz))
+	decoded, err := EvidenceFromProto(&decodedPb)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Hash(), decoded.Hash())
+	assert.Equal(t, ev.Bytes(), decoded.Bytes())
+}
+
+func TestLightClientAttackEvidenceValidateBasic(t *testing.T) {
+	const chainID = "mychain"
+	testCases := []struct {
+		testName  string
+		malleate  func(ev *LightClientAttackEvidence)
+		expectErr bool
+	}{
+		{"Good evidence", func(ev *LightClientAttackEvidence) {}, false},
+		{"Nil conflicting block", func(ev *LightClientAttackEvidence) { ev.ConflictingBlock = nil }, true},
+		{"Nil header", func(ev *LightClientAttackEvidence) { ev.ConflictingBlock.Header = nil }, true},
+		{"Zero common height", func(ev *LightClientAttackEvidence) { ev.CommonHeight = 0 }, true},
+		{"Common height ahead", func(ev *LightClientAttackEvidence) { ev.CommonHeight = 38 }, true},
+		{"Zero total voting power", func(ev *LightClientAttackEvidence) { ev.TotalVotingPower = 0 }, true},
+		{"Nil validator set", func(ev *LightClientAttackEvidence) { ev.ConflictingBlock.ValidatorSet = nil }, true},
+		{"Nil byzantine validator", func(ev *LightClientAttackEvidence) {
+			ev.ByzantineValidators = []*Validator{nil}
+		}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			ev := makeLightClientAttackEvidence(t, chainID)
+			tc.malleate(ev)
+			assert.Equal(t, tc.expectErr, ev.ValidateBasic() != nil, tc.testName)
+		})
+	}
+}
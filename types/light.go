@@ -0,0 +1,262 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	kproto "github.com/kardiachain/go-kardia/proto/kardiachain/types"
+)
+
+// SignedHeader is a header along with the commit of the validators who signed
+// it.
+type SignedHeader struct {
+	*Header `json:"header"`
+
+	Commit *Commit `json:"commit"`
+}
+
+// ValidateBasic does basic consistency checks and makes sure the header and
+// commit are consistent.
+//
+// NOTE: This does not actually check the cryptographic signatures. Make sure
+// to use a Verifier to validate the signatures actually provide a
+// significantly strong proof for this header's validity.
+func (sh SignedHeader) ValidateBasic() error {
+	if sh.Header == nil {
+		return errors.New("missing header")
+	}
+	if sh.Commit == nil {
+		return errors.New("missing commit")
+	}
+
+	if err := sh.Header.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if err := sh.Commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+
+	// Make sure the header is consistent with the commit.
+	if sh.Commit.Height != sh.Height {
+		return fmt.Errorf("header and commit height mismatch: %d vs %d", sh.Height, sh.Commit.Height)
+	}
+	if hhash, chash := sh.Hash(), sh.Commit.BlockID.Hash; !hhash.Equal(chash) {
+		return fmt.Errorf("commit signs block %X, header is block %X", chash, hhash)
+	}
+	return nil
+}
+
+// String returns a string representation of SignedHeader.
+func (sh SignedHeader) String() string {
+	return fmt.Sprintf("SignedHeader{%v %v}", sh.Header, sh.Commit)
+}
+
+// ToProto converts SignedHeader to protobuf
+func (sh *SignedHeader) ToProto() *kproto.SignedHeader {
+	if sh == nil {
+		return nil
+	}
+
+	psh := new(kproto.SignedHeader)
+	if sh.Header != nil {
+		psh.Header = sh.Header.ToProto()
+	}
+	if sh.Commit != nil {
+		psh.Commit = sh.Commit.ToProto()
+	}
+	return psh
+}
+
+// SignedHeaderFromProto sets a protobuf SignedHeader to the given pointer.
+// It returns an error if the header or the commit is invalid.
+func SignedHeaderFromProto(shp *kproto.SignedHeader) (*SignedHeader, error) {
+	if shp == nil {
+		return nil, errors.New("nil SignedHeader")
+	}
+
+	sh := new(SignedHeader)
+	if shp.Header != nil {
+		h, err := HeaderFromProto(shp.Header)
+		if err != nil {
+			return nil, err
+		}
+		sh.Header = &h
+	}
+	if shp.Commit != nil {
+		c, err := CommitFromProto(shp.Commit)
+		if err != nil {
+			return nil, err
+		}
+		sh.Commit = c
+	}
+	return sh, nil
+}
+
+// LightBlock is a SignedHeader and a ValidatorSet. It is the basis of the
+// light client.
+type LightBlock struct {
+	*SignedHeader
+
+	ValidatorSet *ValidatorSet
+}
+
+// ValidateBasic checks that the data is correct and consistent.
+//
+// NOTE: This does not verify the signatures of the commit.
+func (lb LightBlock) ValidateBasic() error {
+	if lb.SignedHeader == nil {
+		return errors.New("missing signed header")
+	}
+	if lb.ValidatorSet == nil {
+		return errors.New("missing validator set")
+	}
+
+	if err := lb.SignedHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid signed header: %w", err)
+	}
+	if err := lb.ValidatorSet.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid validator set: %w", err)
+	}
+
+	// make sure the validator set is consistent with the header
+	if valSetHash := lb.ValidatorSet.Hash(); !lb.ValidatorsHash.Equal(valSetHash) {
+		return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)",
+			lb.ValidatorsHash, valSetHash,
+		)
+	}
+	return nil
+}
+
+// String returns a string representation of LightBlock.
+func (lb LightBlock) String() string {
+	return fmt.Sprintf("LightBlock{%v %v}", lb.SignedHeader, lb.ValidatorSet)
+}
+
+// ToProto converts LightBlock to protobuf.
+func (lb *LightBlock) ToProto() (*kproto.LightBlock, error) {
+	if lb == nil {
+		return nil, nil
+	}
+
+	lbp := new(kproto.LightBlock)
+	var err error
+	if lb.SignedHeader != nil {
+		lbp.SignedHeader = lb.SignedHeader.ToProto()
+	}
+	if lb.ValidatorSet != nil {
+		lbp.ValidatorSet, err = lb.ValidatorSet.ToProto()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lbp, nil
+}
+
+// LightBlockFromProto converts from protobuf back into the LightBlock.
+// An error is returned if either the validator set or signed header are invalid.
+func LightBlockFromProto(pb *kproto.LightBlock) (*LightBlock, error) {
+	if pb == nil {
+		return nil, errors.New("nil light block")
+	}
+
+	lb := new(LightBlock)
+
+	if pb.SignedHeader != nil {
+		sh, err := SignedHeaderFromProto(pb.SignedHeader)
+		if err != nil {
+			return nil, err
+		}
+		lb.SignedHeader = sh
+	}
+
+	if pb.ValidatorSet != nil {
+		vals, err := ValidatorSetFromProto(pb.ValidatorSet)
+		if err != nil {
+			return nil, err
+		}
+		lb.ValidatorSet = vals
+	}
+
+	return lb, nil
+}
+
+// lightBlockJSON is the JSON encoding of LightBlock. The signed header and the
+// validator set are protobuf encoded so that their hashes survive the round
+// trip.
+type lightBlockJSON struct {
+	SignedHeader common.Bytes `json:"signed_header"`
+	ValidatorSet common.Bytes `json:"validator_set"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (lb LightBlock) MarshalJSON() ([]byte, error) {
+	var (
+		enc lightBlockJSON
+		err error
+	)
+	if lb.SignedHeader != nil {
+		if enc.SignedHeader, err = lb.SignedHeader.ToProto().Marshal(); err != nil {
+			return nil, err
+		}
+	}
+	if lb.ValidatorSet != nil {
+		vsp, err := lb.ValidatorSet.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		if enc.ValidatorSet, err = vsp.Marshal(); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (lb *LightBlock) UnmarshalJSON(input []byte) error {
+	var dec lightBlockJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	var shp kproto.SignedHeader
+	if err := shp.Unmarshal(dec.SignedHeader); err != nil {
+		return fmt.Errorf("invalid signed header: %w", err)
+	}
+	sh, err := SignedHeaderFromProto(&shp)
+	if err != nil {
+		return fmt.Errorf("invalid signed header: %w", err)
+	}
+
+	var vsp kproto.ValidatorSet
+	if err := vsp.Unmarshal(dec.ValidatorSet); err != nil {
+		return fmt.Errorf("invalid validator set: %w", err)
+	}
+	vs, err := ValidatorSetFromProto(&vsp)
+	if err != nil {
+		return fmt.Errorf("invalid validator set: %w", err)
+	}
+
+	lb.SignedHeader, lb.ValidatorSet = sh, vs
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+ *  Copyright 2021 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// StakingEventType is the kind of a staking side effect applied by the chain at the beginning of a block
+type StakingEventType uint8
+
+const (
+	StakingEventMint           StakingEventType = iota // new tokens minted for the block
+	StakingEventProposerReward                         // bonus allocated to the previous block proposer
+	StakingEventReward                                 // rewards allocated to a validator for its voting power
+	StakingEventSlash                                  // tokens burned from a validator
+	StakingEventJail                                   // validator jailed for downtime or double sign
+	StakingEventUnjail                                 // validator unjailed by its owner
+	StakingEventStop                                   // validator stopped as its stake fell below the minimum
+)
+
+var stakingEventTypes = map[StakingEventType]string{
+	StakingEventMint:           "mint",
+	StakingEventProposerReward: "proposerReward",
+	StakingEventReward:         "reward",
+	StakingEventSlash:          "slash",
+	StakingEventJail:           "jail",
+	StakingEventUnjail:         "unjail",
+	StakingEventStop:           "stop",
+}
+
+func (t StakingEventType) String() string {
+	if name, ok := stakingEventTypes[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// StakingEvent is a staking side effect of a block, such as minting, reward
+// allocation, slashing or jailing, which produces no receipt on its own.
+type StakingEvent struct {
+	Type         StakingEventType
+	Height       uint64
+	Validator    common.Address // validator staking contract, empty for mint events
+	Amount       *big.Int
+	EvidenceHash common.Hash // evidence of a double sign slash or jail
+	TxHash       common.Hash // transaction of an unjail or a stop
+}
@@ -33,9 +33,13 @@ type StoreDB interface {
 	WriteBlockInfo(hash common.Hash, height uint64, blockInfo *BlockInfo)
 	WriteCanonicalHash(hash common.Hash, height uint64)
 	WriteEvent(smartcontract *KardiaSmartcontract)
+	WriteWatcher(address string, masterSmc string, watcher *Watcher)
+	WriteSmartContractAbi(address string, abi string)
+	WriteDualAction(action string, address string)
 	WriteTxLookupEntries(block *Block)
 	WriteHeadBlockHash(common.Hash)
 	WriteAppHash(uint64, common.Hash)
+	WriteStakingEvents(height uint64, events []*StakingEvent)
 
 	ReadCanonicalHash(height uint64) common.Hash
 	ReadChainConfig(hash common.Hash) *configs.ChainConfig
@@ -58,8 +62,22 @@ type StoreDB interface {
 	ReadSmartContractAbi(address string) *abi.ABI
 	ReadEvent(address string, method string) *Watcher
 	ReadEvents(address string) (string, []*Watcher)
+	ReadRawSmartContractAbi(address string) string
+	ReadDisabledWatchers(address string) []*Watcher
+	ReadDualActions() map[string]string
+	ReadEventFromDualAction(action string) (string, *abi.ABI)
+	ReadWatcherVersion(address string) uint64
+	ReadStakingEvents(height uint64) []*StakingEvent
+
+	DisableWatcher(address string, method string) bool
+	DeleteDualAction(action string)
+	IncreaseWatcherVersion(address string) uint64
 
 	DeleteBlockMeta(height uint64) error
 	DeleteBlockPart(height uint64) error
 	DeleteCanonicalHash(height uint64)
+	DeleteStakingEvents(height uint64)
+	DeleteBlockInfo(hash common.Hash, height uint64)
+	DeleteTxLookupEntries(block *Block)
+	DeleteAppHash(height uint64)
 }
@@ -30,6 +30,7 @@ import (
 
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/crypto"
+	kmath "github.com/kardiachain/go-kardia/lib/math"
 	"github.com/kardiachain/go-kardia/lib/merkle"
 	kproto "github.com/kardiachain/go-kardia/proto/kardiachain/types"
 )
@@ -698,6 +699,114 @@ func (vs *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height uin
 	return nil
 }
 
+// VerifyCommitLight verifies +2/3 of the set had signed the given commit.
+//
+// This method is primarily used by the light client and does not check all the
+// signatures: it stops as soon as +2/3 of the voting power signed the block.
+func (vs *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID, height uint64, commit *Commit) error {
+	if vs == nil {
+		return ErrNilValidatorSet
+	}
+	if commit == nil {
+		return ErrNilCommit
+	}
+	if vs.Size() != len(commit.Signatures) {
+		return NewErrInvalidCommitSignatures(uint64(vs.Size()), uint64(len(commit.Signatures)))
+	}
+	if height != commit.GetHeight() {
+		return NewErrInvalidCommitHeight(height, commit.GetHeight())
+	}
+	if !blockID.Equal(commit.BlockID) {
+		return fmt.Errorf("Invalid commit -- wrong block id: want %v got %v",
+			blockID, commit.BlockID)
+	}
+
+	talliedVotingPower := int64(0)
+	votingPowerNeeded := vs.TotalVotingPower() * 2 / 3
+	for idx, commitSig := range commit.Signatures {
+		// No need to verify absent or nil votes.
+		if !commitSig.ForBlock() {
+			continue
+		}
+		// The vals and commit have a 1-to-1 correspondance.
+		val := vs.Validators[idx]
+
+		// Validate signature.
+		signBytes := commit.VoteSignBytes(chainID, uint32(idx))
+		if !VerifySignature(val.Address, crypto.Keccak256(signBytes), commitSig.Signature) {
+			return errors.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		}
+
+		talliedVotingPower += val.VotingPower
+		// return as soon as +2/3 of the signatures are verified
+		if talliedVotingPower > votingPowerNeeded {
+			return nil
+		}
+	}
+	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+}
+
+// VerifyCommitLightTrusting verifies that trustLevel of the validator set
+// signed the given commit. The commit may come from a different validator set,
+// validators are matched by address.
+//
+// This method is primarily used by the light client to skip verification of
+// intermediate headers, the validator set being the last trusted one.
+func (vs *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Commit, trustLevel kmath.Fraction) error {
+	if vs == nil {
+		return ErrNilValidatorSet
+	}
+	if commit == nil {
+		return ErrNilCommit
+	}
+	if trustLevel.Denominator == 0 {
+		return errors.New("trustLevel has zero Denominator")
+	}
+
+	var (
+		talliedVotingPower int64
+		seenVals           = make(map[int]int, len(commit.Signatures)) // validator index -> commit index
+	)
+
+	// Safely calculate voting power needed.
+	totalVotingPowerMulByNumerator, overflow := safeMul(vs.TotalVotingPower(), trustLevel.Numerator)
+	if overflow {
+		return errors.New("int64 overflow while calculating voting power needed. please provide smaller trustLevel numerator")
+	}
+	votingPowerNeeded := totalVotingPowerMulByNumerator / trustLevel.Denominator
+
+	for idx, commitSig := range commit.Signatures {
+		// No need to verify absent or nil votes.
+		if !commitSig.ForBlock() {
+			continue
+		}
+
+		// We don't know the validators that committed this block, so we have to
+		// check for each vote if its validator is already known.
+		valIdx, val := vs.GetByAddress(commitSig.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+		// check for double vote of validator on the same commit
+		if firstIndex, ok := seenVals[valIdx]; ok {
+			return errors.Errorf("double vote from %v (%d and %d)", val, firstIndex, idx)
+		}
+		seenVals[valIdx] = idx
+
+		// Validate signature.
+		signBytes := commit.VoteSignBytes(chainID, uint32(idx))
+		if !VerifySignature(val.Address, crypto.Keccak256(signBytes), commitSig.Signature) {
+			return errors.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		}
+
+		talliedVotingPower += val.VotingPower
+		if talliedVotingPower > votingPowerNeeded {
+			return nil
+		}
+	}
+	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+}
+
 // IsErrTooMuchChange returns too much change error
 func IsErrTooMuchChange(err error) bool {
 	_, ok := errors.Cause(err).(errTooMuchChange)
@@ -889,6 +998,28 @@ func safeAdd(a, b int64) (int64, bool) {
 	return a + b, false
 }
 
+func safeMul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+
+	absOfB := b
+	if b < 0 {
+		absOfB = -b
+	}
+
+	absOfA := a
+	if a < 0 {
+		absOfA = -a
+	}
+
+	if absOfA > math.MaxInt64/absOfB {
+		return 0, true
+	}
+
+	return a * b, false
+}
+
 func safeSub(a, b int64) (int64, bool) {
 	if b > 0 && a < math.MinInt64+b {
 		return -1, true
@@ -28,6 +28,7 @@ import (
 	"strings"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -35,6 +36,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/crypto"
 	kmath "github.com/kardiachain/go-kardia/lib/math"
 	krand "github.com/kardiachain/go-kardia/lib/rand"
+	kproto "github.com/kardiachain/go-kardia/proto/kardiachain/types"
 )
 
 func TestValidatorSetBasic(t *testing.T) {
@@ -1206,3 +1208,29 @@ func (valz validatorsByPriority) Less(i, j int) bool {
 func (valz validatorsByPriority) Swap(i, j int) {
 	valz[i], valz[j] = valz[j], valz[i]
 }
+
+func TestValidatorSetVerifyCommitLightTrusting(t *testing.T) {
+	voteSet, valSet, privVals := randVoteSet(1, 0, kproto.PrecommitType, 4, 10)
+	blockID := makeBlockIDRandom()
+	commit, err := MakeCommit(blockID, 1, 0, voteSet, privVals, time.Now())
+	assert.NoError(t, err)
+
+	assert.NoError(t, valSet.VerifyCommitLight("test_chain_id", blockID, 1, commit))
+	assert.Error(t, valSet.VerifyCommitLight("test_chain_id", makeBlockIDRandom(), 1, commit))
+	assert.Error(t, valSet.VerifyCommitLight("test_chain_id", blockID, 2, commit))
+
+	// the signers of the commit are all trusted
+	assert.NoError(t, valSet.VerifyCommitLightTrusting("test_chain_id", commit, kmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// one signer out of four validators is not enough
+	others, _ := RandValidatorSet(3, 10)
+	trusted := NewValidatorSet(append(others.Copy().Validators, valSet.Validators[0].Copy()))
+	err = trusted.VerifyCommitLightTrusting("test_chain_id", commit, kmath.Fraction{Numerator: 1, Denominator: 3})
+	if assert.Error(t, err) {
+		assert.IsType(t, ErrNotEnoughVotingPowerSigned{}, err)
+	}
+
+	// two signers out of four validators are enough
+	trusted = NewValidatorSet(append(others.Copy().Validators[:2], valSet.Validators[0].Copy(), valSet.Validators[1].Copy()))
+	assert.NoError(t, trusted.VerifyCommitLightTrusting("test_chain_id", commit, kmath.Fraction{Numerator: 1, Denominator: 3}))
+}